@@ -0,0 +1,81 @@
+// cmd/bridgectl/bundle.go - Download Support Bundle
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"mqtt-bridge/internal/config"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// runSupportBundle 브릿지 관리자 API에서 지원 번들(tar.gz) 다운로드
+func runSupportBundle(args []string) int {
+	flags := flag.NewFlagSet("support-bundle", flag.ContinueOnError)
+	addr := flags.String("addr", "", "bridge HTTP base URL (default from HTTP_ADDR)")
+	hours := flags.Int("hours", 24, "include logs and order history from the last N hours")
+	output := flags.String("o", "", "output file (default support-bundle-<time>.tar.gz)")
+	timeout := flags.Duration("timeout", 2*time.Minute, "request timeout")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	baseURL := *addr
+	if baseURL == "" {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+			return exitError
+		}
+		baseURL = httpBaseURL(cfg.HTTPAddr)
+	}
+
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("support-bundle-%s.tar.gz", time.Now().Format("20060102-150405"))
+	}
+
+	client := &http.Client{Timeout: *timeout}
+	resp, err := client.Get(fmt.Sprintf("%s/api/support-bundle?hours=%d", strings.TrimSuffix(baseURL, "/"), *hours))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to request support bundle: %v\n", err)
+		return exitError
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		fmt.Fprintf(os.Stderr, "bridge returned %s: %s\n", resp.Status, strings.TrimSpace(string(body)))
+		return exitError
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create %s: %v\n", path, err)
+		return exitError
+	}
+	defer file.Close()
+
+	written, err := io.Copy(file, resp.Body)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", path, err)
+		return exitError
+	}
+
+	fmt.Printf("Support bundle written to %s (%d bytes)\n", path, written)
+	return exitOK
+}
+
+// httpBaseURL HTTP_ADDR(:8080, 0.0.0.0:8080 등)를 로컬 접속 URL로 변환
+func httpBaseURL(httpAddr string) string {
+	host := httpAddr
+	if strings.HasPrefix(host, ":") {
+		host = "localhost" + host
+	} else if strings.HasPrefix(host, "0.0.0.0:") {
+		host = "localhost" + strings.TrimPrefix(host, "0.0.0.0")
+	}
+	return "http://" + host
+}
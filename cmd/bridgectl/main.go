@@ -0,0 +1,64 @@
+// cmd/bridgectl/main.go - Bridge Control CLI
+// PLC 없이 커미셔닝/점검을 위한 명령줄 도구
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// 종료 코드
+const (
+	exitOK          = 0
+	exitFailed      = 1 // 명령이 F로 종료
+	exitError       = 2 // 사용법/연결 오류, 타임아웃
+	exitBusy        = 3 // 로봇 사용 중 (B, 동시 실행 한도)
+	exitSafety      = 4 // 로봇 비상 정지 중 (X)
+	exitRateLimited = 5 // 명령 수신 한도 초과 (L)
+	exitNotFound    = 6 // 상태 조회 결과 없음 (N)
+	exitNotAllowed  = 7 // 허용 목록에 없는 추론/궤적 이름 (U)
+	exitManualMode  = 8 // 로봇 수동/서비스 모드 (M)
+	exitPaused      = 9 // 로봇 일시 정지 중 (P)
+	exitUsage       = 64
+)
+
+// commandName 사용법 출력용 이름
+const commandName = "bridgectl"
+
+// subcommand 하위 명령 정의
+type subcommand struct {
+	name        string
+	description string
+	run         func(args []string) int
+}
+
+var subcommands = []subcommand{
+	{name: "send", description: "publish a PLC command and wait for its terminal status", run: runSend},
+	{name: "soak", description: "drive the bridge for hours with fault injection and report pass/fail reliability", run: runSoak},
+	{name: "support-bundle", description: "download a support bundle (logs, redacted config, state, history)", run: runSupportBundle},
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(exitUsage)
+	}
+
+	for _, sub := range subcommands {
+		if sub.name == os.Args[1] {
+			os.Exit(sub.run(os.Args[2:]))
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", os.Args[1])
+	usage()
+	os.Exit(exitUsage)
+}
+
+// usage 사용법 출력
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s <command> [flags]\n\nCommands:\n", commandName)
+	for _, sub := range subcommands {
+		fmt.Fprintf(os.Stderr, "  %-16s %s\n", sub.name, sub.description)
+	}
+}
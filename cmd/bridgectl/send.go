@@ -0,0 +1,130 @@
+// cmd/bridgectl/send.go - Send PLC Command and Await Response
+package main
+
+import (
+	"flag"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"os"
+	"strings"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// runSend bridge/command로 명령 발행 후 응답 토픽에서 최종 상태(S/F) 대기
+func runSend(args []string) int {
+	flags := flag.NewFlagSet("send", flag.ContinueOnError)
+	topic := flags.String("topic", "bridge/command", "PLC command topic")
+	timeout := flags.Duration("timeout", 60*time.Second, "time to wait for a terminal status")
+	quiet := flags.Bool("quiet", false, "only print the terminal status")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+	if flags.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s send [flags] COMMAND (e.g. PICK01:T:R)\n", commandName)
+		return exitUsage
+	}
+	command := strings.TrimSpace(flags.Arg(0))
+
+	client, cfg, err := connect("ctl")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+	defer client.Disconnect(250)
+
+	status, err := sendAndWait(client, cfg, *topic, command, *timeout, func(response string) {
+		if !*quiet {
+			fmt.Printf("%s %s\n", time.Now().Format("15:04:05.000"), response)
+		}
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	fmt.Printf("%s:%s\n", types.NewPLCResponse(command, status, "").Command, status)
+	switch status {
+	case types.PLCStatusFailed:
+		return exitFailed
+	case types.PLCStatusBusy:
+		return exitBusy
+	case types.PLCStatusSafetyStop:
+		return exitSafety
+	case types.PLCStatusRateLimited:
+		return exitRateLimited
+	case types.PLCStatusNotFound:
+		return exitNotFound
+	case types.PLCStatusNotAllowed:
+		return exitNotAllowed
+	case types.PLCStatusManualMode:
+		return exitManualMode
+	case types.PLCStatusPaused:
+		return exitPaused
+	}
+	return exitOK
+}
+
+// sendAndWait 응답 토픽을 먼저 구독한 뒤 명령을 발행하고 최종 상태 반환
+// onResponse는 해당 명령의 모든 응답(중간 상태 포함)마다 호출
+func sendAndWait(client *messaging.MQTTClient, cfg *config.Config, topic, command string, timeout time.Duration, onResponse func(string)) (string, error) {
+	baseCommand := types.NewPLCResponse(command, "", "").Command
+	responses := make(chan string, 16)
+
+	responseTopic := cfg.ExpandResponseTopic(cfg.PlcResponseTopic, cfg.RobotSerialNumber)
+	err := client.Subscribe(responseTopic, 0, func(c mqtt.Client, msg mqtt.Message) {
+		response := strings.TrimSpace(string(msg.Payload()))
+		if parsed, err := types.ParsePLCResponse(response); err == nil && parsed.Command == baseCommand {
+			select {
+			case responses <- response:
+			default:
+			}
+		}
+	})
+	if err != nil {
+		return "", err
+	}
+	defer client.GetNativeClient().Unsubscribe(responseTopic)
+
+	if err := client.Publish(topic, 0, false, command); err != nil {
+		return "", err
+	}
+
+	deadline := time.After(timeout)
+	for {
+		select {
+		case response := <-responses:
+			if onResponse != nil {
+				onResponse(response)
+			}
+			// 상태 조회(BASE:Q)는 진행 중 상태라도 첫 응답이 결과
+			parsed, _ := types.ParsePLCResponse(response)
+			if types.IsTerminalStatus(parsed.Status) || strings.HasSuffix(command, ":Q") {
+				return parsed.Status, nil
+			}
+		case <-deadline:
+			return "", fmt.Errorf("timed out after %s waiting for %s response", timeout, baseCommand)
+		}
+	}
+}
+
+// connect 환경 설정으로 브로커 연결 (브릿지와 충돌하지 않는 클라이언트 ID 사용)
+func connect(role string) (*messaging.MQTTClient, *config.Config, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load config: %v", err)
+	}
+	// 브릿지 로그 출력 억제 (CLI 출력만 표시)
+	utils.SetupLogger("warn")
+	cfg.MQTTClientID = fmt.Sprintf("%s-%s-%d", cfg.MQTTClientID, role, os.Getpid())
+
+	client, err := messaging.NewMQTTClient(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return client, cfg, nil
+}
@@ -0,0 +1,388 @@
+// cmd/bridgectl/soak.go - Long-running Soak Test (release qualification)
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/rand"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"os"
+	"os/exec"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// malformedPayloads 주입할 비정상 PLC 페이로드 (브릿지는 F로 거부하고 계속 동작해야 함)
+var malformedPayloads = []string{
+	"",
+	"   ",
+	":::",
+	"PICK01",
+	"PICK01:X:Q",
+	"PICK01:T:R:EXTRA:FIELDS",
+	"\x00\x01\x02\xff\xfe",
+	"{\"command\":\"PICK01:T:R\"}",
+	strings.Repeat("A", 4096) + ":T",
+}
+
+// soakReport 소크 테스트 결과 (pass/fail 판정 포함)
+type soakReport struct {
+	StartedAt          time.Time `json:"startedAt"`
+	FinishedAt         time.Time `json:"finishedAt"`
+	Commands           int       `json:"commands"`
+	Succeeded          int       `json:"succeeded"`
+	Failed             int       `json:"failed"`
+	TimedOut           int       `json:"timedOut"`
+	Errors             int       `json:"errors"`
+	SuccessRate        float64   `json:"successRate"`
+	LatencyP50         string    `json:"latencyP50"`
+	LatencyP95         string    `json:"latencyP95"`
+	LatencyP99         string    `json:"latencyP99"`
+	LatencyMax         string    `json:"latencyMax"`
+	ReconnectsInjected int       `json:"reconnectsInjected"`
+	MalformedInjected  int       `json:"malformedInjected"`
+	Unresponsive       int       `json:"unresponsive"` // 주입 직후 명령이 응답하지 않은 횟수
+	Passed             bool      `json:"passed"`
+	Reasons            []string  `json:"reasons,omitempty"`
+}
+
+// soakOptions 소크 테스트 설정
+type soakOptions struct {
+	duration       time.Duration
+	interval       time.Duration
+	commands       []string
+	topic          string
+	timeout        time.Duration
+	reconnectEvery time.Duration
+	malformedEvery time.Duration
+	simulator      string
+	simulatorArgs  []string
+	minSuccess     float64
+	maxP99         time.Duration
+}
+
+// runSoak 시뮬레이터와 실제 브릿지를 함께 장시간 구동하며 재연결/비정상 메시지를 주입하고 신뢰성 보고서 작성
+func runSoak(args []string) int {
+	flags := flag.NewFlagSet("soak", flag.ContinueOnError)
+	hours := flags.Float64("hours", 24, "soak duration in hours")
+	rate := flags.String("rate", "2/min", "command rate (N/s, N/min, N/h)")
+	commands := flags.String("commands", "SOAK01:T:R", "comma-separated PLC commands sent round-robin")
+	topic := flags.String("topic", "bridge/command", "PLC command topic")
+	timeout := flags.Duration("timeout", 2*time.Minute, "time to wait for each command's terminal status")
+	reconnectEvery := flags.Duration("reconnect-every", time.Hour, "restart the simulator and reconnect the CLI client at this interval (0 = never)")
+	malformedEvery := flags.Duration("malformed-every", 10*time.Minute, "inject a malformed command payload at this interval (0 = never)")
+	simulator := flags.String("simulator", "", "simulator binary to run and restart (empty = use an already running robot/simulator)")
+	simulatorArgs := flags.String("simulator-args", "", "space-separated simulator flags")
+	minSuccess := flags.Float64("min-success", 0.99, "minimum success rate (S / commands) to pass")
+	maxP99 := flags.Duration("max-p99", 0, "maximum p99 command latency to pass (0 = no limit)")
+	reportPath := flags.String("report", "", "also write the report as JSON to this file")
+	if err := flags.Parse(args); err != nil {
+		return exitUsage
+	}
+
+	interval, err := parseRate(*rate)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+
+	opts := soakOptions{
+		duration:       time.Duration(*hours * float64(time.Hour)),
+		interval:       interval,
+		commands:       splitList(*commands),
+		topic:          *topic,
+		timeout:        *timeout,
+		reconnectEvery: *reconnectEvery,
+		malformedEvery: *malformedEvery,
+		simulator:      *simulator,
+		simulatorArgs:  strings.Fields(*simulatorArgs),
+		minSuccess:     *minSuccess,
+		maxP99:         *maxP99,
+	}
+	if len(opts.commands) == 0 {
+		fmt.Fprintln(os.Stderr, "at least one command is required")
+		return exitUsage
+	}
+
+	report, err := soak(opts)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitError
+	}
+
+	printReport(report)
+	if *reportPath != "" {
+		data, _ := json.MarshalIndent(report, "", "  ")
+		if err := os.WriteFile(*reportPath, data, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write report: %v\n", err)
+			return exitError
+		}
+	}
+	if !report.Passed {
+		return exitFailed
+	}
+	return exitOK
+}
+
+// soak 소크 테스트 실행 (SIGINT 시 그때까지의 결과로 보고)
+func soak(opts soakOptions) (*soakReport, error) {
+	sim := &simulatorProcess{path: opts.simulator, args: opts.simulatorArgs}
+	if err := sim.start(); err != nil {
+		return nil, err
+	}
+	defer sim.stop()
+
+	client, cfg, err := connect("soak")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { client.Disconnect(250) }()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+
+	report := &soakReport{StartedAt: time.Now()}
+	var latencies []time.Duration
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+
+	end := time.Now().Add(opts.duration)
+	nextReconnect := nextInjection(opts.reconnectEvery)
+	nextMalformed := nextInjection(opts.malformedEvery)
+	checkAfterInjection := false
+
+	fmt.Printf("soak: %s at one command every %s until %s\n", opts.duration, opts.interval, end.Format(time.RFC3339))
+
+	ticker := time.NewTicker(opts.interval)
+	defer ticker.Stop()
+
+loop:
+	for i := 0; time.Now().Before(end); i++ {
+		now := time.Now()
+
+		if !nextReconnect.IsZero() && now.After(nextReconnect) {
+			fmt.Printf("%s inject: reconnect (simulator restart + client reconnect)\n", now.Format(time.RFC3339))
+			if err := sim.restart(); err != nil {
+				return nil, err
+			}
+			client.Disconnect(250)
+			if client, cfg, err = connect("soak"); err != nil {
+				return nil, fmt.Errorf("failed to reconnect after injection: %v", err)
+			}
+			report.ReconnectsInjected++
+			nextReconnect = nextInjection(opts.reconnectEvery)
+			checkAfterInjection = true
+		}
+
+		if !nextMalformed.IsZero() && now.After(nextMalformed) {
+			payload := malformedPayloads[rng.Intn(len(malformedPayloads))]
+			fmt.Printf("%s inject: malformed payload %q\n", now.Format(time.RFC3339), truncate(payload, 40))
+			if err := client.Publish(opts.topic, 0, false, payload); err != nil {
+				fmt.Printf("%s inject failed: %v\n", now.Format(time.RFC3339), err)
+			}
+			report.MalformedInjected++
+			nextMalformed = nextInjection(opts.malformedEvery)
+			checkAfterInjection = true
+		}
+
+		command := opts.commands[i%len(opts.commands)]
+		status, latency, err := timedSend(client, cfg, opts.topic, command, opts.timeout)
+		report.Commands++
+		switch {
+		case err != nil && strings.Contains(err.Error(), "timed out"):
+			report.TimedOut++
+			if checkAfterInjection {
+				report.Unresponsive++
+			}
+			fmt.Printf("%s %s TIMEOUT\n", time.Now().Format(time.RFC3339), command)
+		case err != nil:
+			report.Errors++
+			fmt.Printf("%s %s ERROR %v\n", time.Now().Format(time.RFC3339), command, err)
+		case status == types.PLCStatusSuccess:
+			report.Succeeded++
+			latencies = append(latencies, latency)
+		default:
+			report.Failed++
+			latencies = append(latencies, latency)
+			fmt.Printf("%s %s %s after %s\n", time.Now().Format(time.RFC3339), command, status, latency.Round(time.Millisecond))
+		}
+		checkAfterInjection = false
+
+		select {
+		case <-interrupt:
+			fmt.Println("soak: interrupted, reporting partial results")
+			break loop
+		case <-ticker.C:
+		}
+	}
+
+	report.FinishedAt = time.Now()
+	summarize(report, latencies, opts)
+	return report, nil
+}
+
+// timedSend 명령 발행 후 최종 상태와 소요 시간 반환
+func timedSend(client *messaging.MQTTClient, cfg *config.Config, topic, command string, timeout time.Duration) (string, time.Duration, error) {
+	start := time.Now()
+	status, err := sendAndWait(client, cfg, topic, command, timeout, nil)
+	return status, time.Since(start), err
+}
+
+// summarize 성공률/지연 시간 계산 및 pass/fail 판정
+func summarize(report *soakReport, latencies []time.Duration, opts soakOptions) {
+	if report.Commands > 0 {
+		report.SuccessRate = float64(report.Succeeded) / float64(report.Commands)
+	}
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	p99 := percentile(latencies, 0.99)
+	report.LatencyP50 = percentile(latencies, 0.50).String()
+	report.LatencyP95 = percentile(latencies, 0.95).String()
+	report.LatencyP99 = p99.String()
+	report.LatencyMax = percentile(latencies, 1).String()
+
+	if report.Commands == 0 {
+		report.Reasons = append(report.Reasons, "no commands were sent")
+	}
+	if report.SuccessRate < opts.minSuccess {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("success rate %.4f below %.4f", report.SuccessRate, opts.minSuccess))
+	}
+	if report.Unresponsive > 0 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("bridge unresponsive after %d injection(s)", report.Unresponsive))
+	}
+	if opts.maxP99 > 0 && p99 > opts.maxP99 {
+		report.Reasons = append(report.Reasons, fmt.Sprintf("p99 latency %s above %s", p99, opts.maxP99))
+	}
+	report.Passed = len(report.Reasons) == 0
+}
+
+// printReport 보고서 출력
+func printReport(report *soakReport) {
+	verdict := "PASS"
+	if !report.Passed {
+		verdict = "FAIL"
+	}
+
+	fmt.Printf("\n=== Soak report: %s ===\n", verdict)
+	fmt.Printf("duration     %s\n", report.FinishedAt.Sub(report.StartedAt).Round(time.Second))
+	fmt.Printf("commands     %d (S %d, F %d, timeout %d, error %d)\n", report.Commands, report.Succeeded, report.Failed, report.TimedOut, report.Errors)
+	fmt.Printf("success rate %.4f\n", report.SuccessRate)
+	fmt.Printf("latency      p50 %s, p95 %s, p99 %s, max %s\n", report.LatencyP50, report.LatencyP95, report.LatencyP99, report.LatencyMax)
+	fmt.Printf("injections   reconnect %d, malformed %d (unresponsive after injection: %d)\n", report.ReconnectsInjected, report.MalformedInjected, report.Unresponsive)
+	for _, reason := range report.Reasons {
+		fmt.Printf("  - %s\n", reason)
+	}
+}
+
+// simulatorProcess 소크 테스트가 관리하는 시뮬레이터 프로세스 (path가 비어있으면 아무것도 하지 않음)
+type simulatorProcess struct {
+	path string
+	args []string
+	cmd  *exec.Cmd
+}
+
+// start 시뮬레이터 시작 (출력은 소크 출력과 섞이지 않도록 버림)
+func (p *simulatorProcess) start() error {
+	if p.path == "" {
+		return nil
+	}
+	p.cmd = exec.Command(p.path, p.args...)
+	if err := p.cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start simulator: %v", err)
+	}
+	// 시뮬레이터 연결 및 ONLINE 발행 대기
+	time.Sleep(2 * time.Second)
+	return nil
+}
+
+// stop 시뮬레이터 정상 종료 (SIGTERM 후 대기, 10초 후 강제 종료)
+func (p *simulatorProcess) stop() {
+	if p.cmd == nil || p.cmd.Process == nil {
+		return
+	}
+	p.cmd.Process.Signal(syscall.SIGTERM)
+
+	done := make(chan struct{})
+	go func() {
+		p.cmd.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		p.cmd.Process.Kill()
+		<-done
+	}
+	p.cmd = nil
+}
+
+// restart 시뮬레이터 재시작 (로봇 재연결 주입)
+func (p *simulatorProcess) restart() error {
+	p.stop()
+	return p.start()
+}
+
+// parseRate 명령 빈도 해석 (예: 2/min -> 30s 간격)
+func parseRate(rate string) (time.Duration, error) {
+	count, unit, found := strings.Cut(rate, "/")
+	n, err := strconv.ParseFloat(count, 64)
+	if !found || err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid rate %q (expected N/s, N/min or N/h)", rate)
+	}
+
+	var per time.Duration
+	switch unit {
+	case "s", "sec":
+		per = time.Second
+	case "m", "min":
+		per = time.Minute
+	case "h", "hour":
+		per = time.Hour
+	default:
+		return 0, fmt.Errorf("invalid rate unit %q (expected s, min or h)", unit)
+	}
+	return time.Duration(float64(per) / n), nil
+}
+
+// nextInjection 다음 주입 시간 (간격이 0이면 zero time = 주입 안 함)
+func nextInjection(every time.Duration) time.Time {
+	if every <= 0 {
+		return time.Time{}
+	}
+	return time.Now().Add(every)
+}
+
+// percentile 정렬된 지연 시간의 백분위수 (비어있으면 0)
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	index := int(float64(len(sorted)-1) * p)
+	return sorted[index]
+}
+
+// splitList 쉼표 구분 목록 (빈 항목 제외)
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// truncate 출력용 문자열 자르기
+func truncate(value string, max int) string {
+	if len(value) <= max {
+		return value
+	}
+	return value[:max] + "..."
+}
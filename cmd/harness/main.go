@@ -0,0 +1,48 @@
+// cmd/harness/main.go - End-to-end Scenario Runner (embedded MQTT broker or in-memory broker, scripted robot)
+// 외부 브로커 없이 기본 시나리오를 실행하고 실패가 있으면 종료 코드 1 (CI는 go test ./internal/harness)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/harness"
+	"mqtt-bridge/internal/utils"
+	"os"
+	"time"
+)
+
+func main() {
+	stepDelay := flag.Duration("step-delay", 10*time.Millisecond, "delay between scripted robot state transitions")
+	timeout := flag.Duration("timeout", 5*time.Second, "maximum wait for each expected response")
+	broker := flag.String("broker", "embedded", "transport: embedded (mochi-mqtt over TCP) or memory (in-process fake)")
+	flag.Parse()
+
+	factories := map[string]harness.Factory{"embedded": harness.NewEmbedded, "memory": harness.New}
+	factory, ok := factories[*broker]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown -broker %q (embedded, memory)\n", *broker)
+		os.Exit(2)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		utils.Logger.Fatalf("Failed to load config: %v", err)
+	}
+	utils.SetupLogger(cfg.LogLevel)
+
+	failed := 0
+	for _, scenario := range harness.DefaultScenarios() {
+		if err := harness.Run(factory, cfg, scenario, *stepDelay, *timeout); err != nil {
+			failed++
+			fmt.Printf("FAIL  %s: %v\n", scenario.Name, err)
+			continue
+		}
+		fmt.Printf("PASS  %s\n", scenario.Name)
+	}
+
+	if failed > 0 {
+		fmt.Printf("%d scenario(s) failed\n", failed)
+		os.Exit(1)
+	}
+}
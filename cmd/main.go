@@ -17,9 +17,24 @@ func main() {
 	if err != nil {
 		utils.Logger.Fatalf("Failed to load config: %v", err)
 	}
+	if err := cfg.Validate(); err != nil {
+		utils.Logger.Fatalf("%v", err)
+	}
 
 	// 로거 설정
 	utils.SetupLogger(cfg.LogLevel)
+	if err := utils.ConfigureOutput(utils.LogOptions{
+		Format:     cfg.LogFormat,
+		File:       cfg.LogFile,
+		MaxSizeMB:  cfg.LogMaxSizeMB,
+		MaxAge:     cfg.LogMaxAge,
+		MaxBackups: cfg.LogMaxBackups,
+
+		PayloadLimit:   cfg.LogPayloadLimit,
+		SuppressTopics: cfg.LogSuppressTopicFilters(),
+	}); err != nil {
+		utils.Logger.Fatalf("Failed to configure logger: %v", err)
+	}
 	utils.Logger.Infof("🚀 Starting Direct Action MQTT Bridge")
 
 	// 브릿지 서비스 생성
@@ -35,13 +50,20 @@ func main() {
 		utils.Logger.Fatalf("Failed to start bridge service: %v", err)
 	}
 
-	// 우아한 종료 처리
+	// 우아한 종료 및 설정 재로드(SIGHUP) 처리
 	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
 
 	utils.Logger.Info("🎉 Direct Action Bridge started successfully")
 
-	<-sigChan
+	for sig := range sigChan {
+		if sig != syscall.SIGHUP {
+			break
+		}
+		if err := bridgeService.Reload(); err != nil {
+			utils.Logger.Errorf("❌ Configuration reload failed: %v", err)
+		}
+	}
 	utils.Logger.Info("🛑 Shutting down...")
 
 	// 컨텍스트 취소
@@ -0,0 +1,101 @@
+// cmd/replay/main.go - MQTT Audit Log Replay Tool
+// 감사 로그에 기록된 수신 메시지(로봇 상태 등)를 브로커에 재주입하여 디버깅
+package main
+
+import (
+	"flag"
+	"fmt"
+	"mqtt-bridge/internal/audit"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"strings"
+	"time"
+)
+
+func main() {
+	file := flag.String("file", "", "audit log file to replay (required)")
+	match := flag.String("match", "/state", "only replay topics containing this substring (empty = all)")
+	direction := flag.String("direction", audit.DirectionInbound, "record direction to replay (in, out)")
+	speed := flag.Float64("speed", 1.0, "replay speed factor (0 = no delay)")
+	from := flag.String("from", "", "skip records before this time (RFC3339)")
+	to := flag.String("to", "", "stop at records after this time (RFC3339)")
+	dryRun := flag.Bool("dry-run", false, "print records without publishing")
+	flag.Parse()
+
+	if *file == "" {
+		utils.Logger.Fatal("-file is required")
+	}
+
+	fromTime, err := parseOptionalTime(*from)
+	if err != nil {
+		utils.Logger.Fatalf("Invalid -from: %v", err)
+	}
+	toTime, err := parseOptionalTime(*to)
+	if err != nil {
+		utils.Logger.Fatalf("Invalid -to: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		utils.Logger.Fatalf("Failed to load config: %v", err)
+	}
+	utils.SetupLogger(cfg.LogLevel)
+
+	var client *messaging.MQTTClient
+	if !*dryRun {
+		// 브릿지와 클라이언트 ID 충돌 방지
+		cfg.MQTTClientID += "-replay"
+		client, err = messaging.NewMQTTClient(cfg)
+		if err != nil {
+			utils.Logger.Fatalf("Failed to create MQTT client: %v", err)
+		}
+		defer client.Disconnect(250)
+	}
+
+	var previous time.Time
+	replayed := 0
+
+	err = audit.ReadFile(*file, func(record audit.Record) error {
+		if record.Direction != *direction || !strings.Contains(record.Topic, *match) {
+			return nil
+		}
+		if !fromTime.IsZero() && record.Time.Before(fromTime) {
+			return nil
+		}
+		if !toTime.IsZero() && record.Time.After(toTime) {
+			return errStop
+		}
+
+		// 기록된 메시지 간격 재현
+		if *speed > 0 && !previous.IsZero() {
+			if gap := record.Time.Sub(previous); gap > 0 {
+				time.Sleep(time.Duration(float64(gap) / *speed))
+			}
+		}
+		previous = record.Time
+
+		replayed++
+		if *dryRun {
+			fmt.Printf("%s %s %s\n", record.Time.Format(time.RFC3339Nano), record.Topic, record.Payload)
+			return nil
+		}
+		return client.Publish(record.Topic, record.QoS, false, record.Payload)
+	})
+	if err != nil && err != errStop {
+		utils.Logger.Fatalf("Replay failed after %d records: %v", replayed, err)
+	}
+
+	utils.Logger.Infof("✅ Replayed %d records from %s", replayed, *file)
+}
+
+// errStop 종료 시간 도달 (정상 종료)
+var errStop = fmt.Errorf("replay window ended")
+
+// parseOptionalTime 비어있지 않으면 RFC3339 시간 파싱
+func parseOptionalTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
@@ -0,0 +1,62 @@
+// cmd/simulator/main.go - VDA5050 Robot Simulator
+// 브릿지 E2E 테스트용: order/instantActions를 구독하고 state/connection 메시지를 발행
+package main
+
+import (
+	"context"
+	"flag"
+	"math/rand"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+)
+
+func main() {
+	opts := simOptions{}
+	flag.DurationVar(&opts.StateInterval, "state-interval", time.Second, "periodic state publish interval")
+	flag.DurationVar(&opts.StepDelay, "step-delay", 500*time.Millisecond, "delay between WAITING/INITIALIZING/RUNNING transitions")
+	flag.DurationVar(&opts.ActionDuration, "action-duration", 3*time.Second, "time an action stays RUNNING")
+	flag.Float64Var(&opts.FailRate, "fail-rate", 0, "probability (0-1) that an action ends FAILED")
+	flag.BoolVar(&opts.FatalErrors, "fatal", false, "report injected failures with errorLevel FATAL")
+	flag.Float64Var(&opts.DropRate, "drop-rate", 0, "probability (0-1) that an order is ignored")
+	flag.Float64Var(&opts.StallRate, "stall-rate", 0, "probability (0-1) that an action never leaves RUNNING")
+	flag.DurationVar(&opts.OfflineAfter, "offline-after", 0, "publish OFFLINE after this duration (0 = never)")
+	flag.BoolVar(&opts.PositionInitialized, "position-initialized", false, "start with agvPosition.positionInitialized=true")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed for failure injection")
+	flag.Parse()
+
+	cfg, err := config.Load()
+	if err != nil {
+		utils.Logger.Fatalf("Failed to load config: %v", err)
+	}
+	utils.SetupLogger(cfg.LogLevel)
+
+	// 브릿지와 클라이언트 ID 충돌 방지
+	cfg.MQTTClientID += "-simulator"
+	client, err := messaging.NewMQTTClient(cfg)
+	if err != nil {
+		utils.Logger.Fatalf("Failed to create MQTT client: %v", err)
+	}
+
+	sim := newRobotSimulator(cfg, client, opts, rand.New(rand.NewSource(*seed)))
+	if err := sim.Start(); err != nil {
+		utils.Logger.Fatalf("Failed to start simulator: %v", err)
+	}
+	utils.Logger.Infof("🤖 Robot simulator running: %s/%s (%s)", cfg.RobotManufacturer, cfg.RobotSerialNumber, cfg.RobotTopicPrefix())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go sim.Run(ctx)
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+
+	cancel()
+	sim.Stop()
+	client.Disconnect(250)
+	utils.Logger.Info("✅ Simulator stopped")
+}
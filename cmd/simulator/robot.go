@@ -0,0 +1,346 @@
+// cmd/simulator/robot.go - Simulated Robot State Machine
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// simOptions 시뮬레이션 동작 및 오류 주입 설정
+type simOptions struct {
+	StateInterval       time.Duration
+	StepDelay           time.Duration
+	ActionDuration      time.Duration
+	FailRate            float64
+	FatalErrors         bool
+	DropRate            float64
+	StallRate           float64
+	OfflineAfter        time.Duration
+	PositionInitialized bool
+}
+
+// simActionState VDA5050 actionStates[] 항목
+type simActionState struct {
+	ActionID          string `json:"actionId"`
+	ActionType        string `json:"actionType"`
+	ActionStatus      string `json:"actionStatus"`
+	ResultDescription string `json:"resultDescription,omitempty"`
+}
+
+// simError VDA5050 errors[] 항목
+type simError struct {
+	ErrorType        string `json:"errorType"`
+	ErrorLevel       string `json:"errorLevel"`
+	ErrorDescription string `json:"errorDescription"`
+}
+
+// robotSimulator 단일 로봇 시뮬레이터
+type robotSimulator struct {
+	cfg    *config.Config
+	client *messaging.MQTTClient
+	opts   simOptions
+	rng    *rand.Rand
+
+	mu                  sync.Mutex
+	headerID            int64
+	orderID             string
+	orderUpdateID       int
+	actionStates        []*simActionState
+	errors              []simError
+	positionInitialized bool
+	cancelExecution     context.CancelFunc
+}
+
+// newRobotSimulator 새 시뮬레이터 생성
+func newRobotSimulator(cfg *config.Config, client *messaging.MQTTClient, opts simOptions, rng *rand.Rand) *robotSimulator {
+	return &robotSimulator{
+		cfg:                 cfg,
+		client:              client,
+		opts:                opts,
+		rng:                 rng,
+		actionStates:        make([]*simActionState, 0),
+		errors:              make([]simError, 0),
+		positionInitialized: opts.PositionInitialized,
+	}
+}
+
+// Start order/instantActions 구독 후 ONLINE 발행
+func (s *robotSimulator) Start() error {
+	if err := s.client.Subscribe(s.cfg.RobotTopic("order"), 0, s.handleOrder); err != nil {
+		return err
+	}
+	if err := s.client.Subscribe(s.cfg.RobotTopic("instantActions"), 0, s.handleInstantActions); err != nil {
+		return err
+	}
+	return s.publishConnection("ONLINE")
+}
+
+// Run 주기적 state 발행 및 OFFLINE 주입 (ctx 종료 시 반환)
+func (s *robotSimulator) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.opts.StateInterval)
+	defer ticker.Stop()
+
+	var offline <-chan time.Time
+	if s.opts.OfflineAfter > 0 {
+		offline = time.After(s.opts.OfflineAfter)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.publishState()
+		case <-offline:
+			utils.Logger.Warnf("💥 Injecting OFFLINE after %s", s.opts.OfflineAfter)
+			s.publishConnection("OFFLINE")
+		}
+	}
+}
+
+// Stop 실행 중인 액션 중단 후 OFFLINE 발행
+func (s *robotSimulator) Stop() {
+	s.mu.Lock()
+	if s.cancelExecution != nil {
+		s.cancelExecution()
+	}
+	s.mu.Unlock()
+
+	s.publishConnection("OFFLINE")
+}
+
+// handleOrder 오더 수신 - 액션을 WAITING으로 등록하고 순차 실행 시작
+func (s *robotSimulator) handleOrder(client mqtt.Client, msg mqtt.Message) {
+	var order types.OrderMessage
+	if err := json.Unmarshal(msg.Payload(), &order); err != nil {
+		utils.Logger.Errorf("❌ Invalid order: %v", err)
+		return
+	}
+
+	if s.roll(s.opts.DropRate) {
+		utils.Logger.Warnf("💥 Dropping order %s (failure injection)", order.OrderID)
+		return
+	}
+
+	s.mu.Lock()
+	if s.cancelExecution != nil {
+		s.cancelExecution()
+	}
+
+	s.orderID = order.OrderID
+	s.orderUpdateID = order.OrderUpdateID
+	s.actionStates = make([]*simActionState, 0)
+	s.errors = make([]simError, 0)
+	for _, node := range order.Nodes {
+		for _, action := range node.Actions {
+			s.actionStates = append(s.actionStates, &simActionState{
+				ActionID:     action.ActionID,
+				ActionType:   action.ActionType,
+				ActionStatus: "WAITING",
+			})
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancelExecution = cancel
+	actions := s.actionStates
+	s.mu.Unlock()
+
+	utils.Logger.Infof("🤖 Order accepted: %s (%d actions)", order.OrderID, len(actions))
+	s.publishState()
+
+	go s.execute(ctx, actions)
+}
+
+// execute 액션을 INITIALIZING → RUNNING → FINISHED/FAILED 순서로 진행
+func (s *robotSimulator) execute(ctx context.Context, actions []*simActionState) {
+	for _, action := range actions {
+		if !s.transition(ctx, action, "INITIALIZING", s.opts.StepDelay) {
+			return
+		}
+		if !s.transition(ctx, action, "RUNNING", s.opts.StepDelay) {
+			return
+		}
+
+		if s.roll(s.opts.StallRate) {
+			utils.Logger.Warnf("💥 Stalling action %s in RUNNING (failure injection)", action.ActionID)
+			return
+		}
+
+		if s.roll(s.opts.FailRate) {
+			s.failAction(ctx, action)
+			return
+		}
+		if !s.transition(ctx, action, "FINISHED", s.opts.ActionDuration) {
+			return
+		}
+	}
+}
+
+// transition 대기 후 액션 상태 변경 및 state 발행 (취소 시 false)
+func (s *robotSimulator) transition(ctx context.Context, action *simActionState, status string, delay time.Duration) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(delay):
+	}
+
+	s.mu.Lock()
+	action.ActionStatus = status
+	s.mu.Unlock()
+
+	utils.Logger.Infof("🤖 Action %s (%s) -> %s", action.ActionID, action.ActionType, status)
+	s.publishState()
+	return true
+}
+
+// failAction 오류 주입 - 액션 FAILED 및 errors[] 보고
+func (s *robotSimulator) failAction(ctx context.Context, action *simActionState) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(s.opts.ActionDuration):
+	}
+
+	level := types.ErrorLevelWarning
+	if s.opts.FatalErrors {
+		level = types.ErrorLevelFatal
+	}
+
+	s.mu.Lock()
+	action.ActionStatus = "FAILED"
+	action.ResultDescription = "simulated failure"
+	s.errors = append(s.errors, simError{
+		ErrorType:        "actionFailed",
+		ErrorLevel:       level,
+		ErrorDescription: fmt.Sprintf("simulated failure of %s", action.ActionType),
+	})
+	s.mu.Unlock()
+
+	utils.Logger.Warnf("💥 Action %s failed (failure injection, %s)", action.ActionID, level)
+	s.publishState()
+}
+
+// handleInstantActions cancelOrder, stateRequest, initPosition 처리 (VDA5050 1.1 instantActions 키도 허용)
+func (s *robotSimulator) handleInstantActions(client mqtt.Client, msg mqtt.Message) {
+	var instantActions types.InstantActionsMessageV1
+	if err := json.Unmarshal(msg.Payload(), &instantActions); err != nil {
+		utils.Logger.Errorf("❌ Invalid instantActions: %v", err)
+		return
+	}
+	var current types.InstantActionsMessage
+	if err := json.Unmarshal(msg.Payload(), &current); err == nil && len(current.Actions) > 0 {
+		instantActions.InstantActions = current.Actions
+	}
+
+	for _, action := range instantActions.InstantActions {
+		utils.Logger.Infof("🤖 InstantAction received: %s (%s)", action.ActionType, action.ActionID)
+
+		s.mu.Lock()
+		switch action.ActionType {
+		case "cancelOrder":
+			if s.cancelExecution != nil {
+				s.cancelExecution()
+				s.cancelExecution = nil
+			}
+			for _, state := range s.actionStates {
+				if state.ActionStatus != "FINISHED" && state.ActionStatus != "FAILED" {
+					state.ActionStatus = "FAILED"
+					state.ResultDescription = "canceled"
+				}
+			}
+		case "initPosition":
+			s.positionInitialized = true
+		}
+		s.actionStates = append(s.actionStates, &simActionState{
+			ActionID:     action.ActionID,
+			ActionType:   action.ActionType,
+			ActionStatus: "FINISHED",
+		})
+		s.mu.Unlock()
+	}
+
+	s.publishState()
+}
+
+// publishState 현재 상태를 state 토픽에 발행
+func (s *robotSimulator) publishState() {
+	s.mu.Lock()
+	s.headerID++
+	state := map[string]interface{}{
+		"headerId":      s.headerID,
+		"timestamp":     time.Now().UTC(),
+		"version":       "2.0.0",
+		"manufacturer":  s.cfg.RobotManufacturer,
+		"serialNumber":  s.cfg.RobotSerialNumber,
+		"orderId":       s.orderID,
+		"orderUpdateId": s.orderUpdateID,
+		"actionStates":  s.actionStates,
+		"errors":        s.errors,
+		"driving":       false,
+		"operatingMode": "AUTOMATIC",
+		"agvPosition": map[string]interface{}{
+			"x":                   0.0,
+			"y":                   0.0,
+			"theta":               0.0,
+			"mapId":               "",
+			"positionInitialized": s.positionInitialized,
+		},
+		"batteryState": map[string]interface{}{
+			"batteryCharge": 80.0,
+			"charging":      false,
+		},
+		"safetyState": map[string]interface{}{
+			"eStop":          "NONE",
+			"fieldViolation": false,
+		},
+	}
+	payload, err := json.Marshal(state)
+	s.mu.Unlock()
+
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal state: %v", err)
+		return
+	}
+	s.client.Publish(s.cfg.RobotTopic("state"), 0, false, payload)
+}
+
+// publishConnection 연결 상태 발행 (retained)
+func (s *robotSimulator) publishConnection(connectionState string) error {
+	s.mu.Lock()
+	s.headerID++
+	payload, err := json.Marshal(map[string]interface{}{
+		"headerId":        s.headerID,
+		"timestamp":       time.Now().UTC(),
+		"version":         "2.0.0",
+		"manufacturer":    s.cfg.RobotManufacturer,
+		"serialNumber":    s.cfg.RobotSerialNumber,
+		"connectionState": connectionState,
+	})
+	s.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+	return s.client.Publish(s.cfg.RobotTopic("connection"), 1, true, payload)
+}
+
+// roll 확률 p로 true
+func (s *robotSimulator) roll(p float64) bool {
+	if p <= 0 {
+		return false
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.rng.Float64() < p
+}
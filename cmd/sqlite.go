@@ -0,0 +1,9 @@
+//go:build sqlite
+
+// cmd/sqlite.go - SQLite driver for the order history store
+// Build with: go build -tags sqlite ./cmd
+package main
+
+import (
+	_ "modernc.org/sqlite"
+)
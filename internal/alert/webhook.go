@@ -0,0 +1,77 @@
+// internal/alert/webhook.go - Webhook Alert Notifier
+package alert
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Alert 웹훅으로 전송되는 알림
+type Alert struct {
+	Source   string    `json:"source"`   // 알림 발생 서브시스템 (예: canary)
+	Severity string    `json:"severity"` // info, warning, critical
+	Robot    string    `json:"robot,omitempty"`
+	Summary  string    `json:"summary"`
+	Detail   string    `json:"detail,omitempty"`
+	Time     time.Time `json:"time"`
+}
+
+// Severity 열거형
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// Webhook HTTP POST 웹훅 알림 전송기
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+// NewWebhook 새 웹훅 전송기 생성 (url이 비어있으면 nil 반환)
+func NewWebhook(url string) *Webhook {
+	if url == "" {
+		return nil
+	}
+	return &Webhook{
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send 알림 전송 (JSON POST, 2xx 이외 응답은 오류)
+func (w *Webhook) Send(ctx context.Context, alert Alert) error {
+	if w == nil {
+		return nil
+	}
+	if alert.Time.IsZero() {
+		alert.Time = time.Now()
+	}
+
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("failed to marshal alert: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+// internal/amqp/frame.go - AMQP 0-9-1 Frame and Field Encoding
+package amqp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// 프레임 타입
+const (
+	frameMethod    = 1
+	frameHeader    = 2
+	frameBody      = 3
+	frameHeartbeat = 8
+	frameEnd       = 0xCE
+)
+
+// 클래스/메서드 ID (사용하는 것만)
+const (
+	classConnection = 10
+	classChannel    = 20
+	classExchange   = 40
+	classQueue      = 50
+	classBasic      = 60
+	classConfirm    = 85
+
+	methodConnectionStart   = 10
+	methodConnectionStartOk = 11
+	methodConnectionTune    = 30
+	methodConnectionTuneOk  = 31
+	methodConnectionOpen    = 40
+	methodConnectionOpenOk  = 41
+	methodConnectionClose   = 50
+	methodConnectionCloseOk = 51
+
+	methodChannelOpen   = 10
+	methodChannelOpenOk = 11
+	methodChannelClose  = 40
+
+	methodExchangeDeclare   = 10
+	methodExchangeDeclareOk = 11
+
+	methodQueueDeclare   = 10
+	methodQueueDeclareOk = 11
+	methodQueueBind      = 20
+	methodQueueBindOk    = 21
+
+	methodBasicPublish = 40
+	methodBasicAck     = 80
+	methodBasicNack    = 120
+
+	methodConfirmSelect   = 10
+	methodConfirmSelectOk = 11
+)
+
+// protocolHeader AMQP 0-9-1 프로토콜 헤더
+var protocolHeader = []byte{'A', 'M', 'Q', 'P', 0, 0, 9, 1}
+
+// ErrMalformedFrame 프레임 해석 실패
+var ErrMalformedFrame = errors.New("malformed AMQP frame")
+
+// frame AMQP 프레임 하나
+type frame struct {
+	kind    byte
+	channel uint16
+	payload []byte
+}
+
+// readFrame 프레임 하나 수신
+func readFrame(r *bufio.Reader) (*frame, error) {
+	header := make([]byte, 7)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, err
+	}
+
+	size := binary.BigEndian.Uint32(header[3:7])
+	payload := make([]byte, size+1)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, err
+	}
+	if payload[size] != frameEnd {
+		return nil, ErrMalformedFrame
+	}
+
+	return &frame{
+		kind:    header[0],
+		channel: binary.BigEndian.Uint16(header[1:3]),
+		payload: payload[:size],
+	}, nil
+}
+
+// encodeFrame 프레임 인코딩
+func encodeFrame(kind byte, channel uint16, payload []byte) []byte {
+	data := []byte{kind}
+	data = binary.BigEndian.AppendUint16(data, channel)
+	data = binary.BigEndian.AppendUint32(data, uint32(len(payload)))
+	data = append(data, payload...)
+	return append(data, frameEnd)
+}
+
+// methodID 메서드 프레임의 클래스/메서드 ID
+func methodID(f *frame) (class, method uint16, err error) {
+	if f.kind != frameMethod || len(f.payload) < 4 {
+		return 0, 0, ErrMalformedFrame
+	}
+	return binary.BigEndian.Uint16(f.payload[0:2]), binary.BigEndian.Uint16(f.payload[2:4]), nil
+}
+
+// closeReason Connection.Close/Channel.Close의 응답 코드와 사유
+func closeReason(f *frame) error {
+	args := f.payload[4:]
+	if len(args) < 3 {
+		return fmt.Errorf("closed by broker")
+	}
+	code := binary.BigEndian.Uint16(args[0:2])
+	length := int(args[2])
+	if len(args) < 3+length {
+		return fmt.Errorf("closed by broker (%d)", code)
+	}
+	return fmt.Errorf("closed by broker: %d %s", code, string(args[3:3+length]))
+}
+
+// writer 메서드 인자 인코더
+type writer struct {
+	data []byte
+}
+
+// newMethod 메서드 인자 인코더 생성 (클래스/메서드 ID 포함)
+func newMethod(class, method uint16) *writer {
+	w := &writer{}
+	w.short(class)
+	w.short(method)
+	return w
+}
+
+func (w *writer) octet(value byte) {
+	w.data = append(w.data, value)
+}
+
+func (w *writer) short(value uint16) {
+	w.data = binary.BigEndian.AppendUint16(w.data, value)
+}
+
+func (w *writer) long(value uint32) {
+	w.data = binary.BigEndian.AppendUint32(w.data, value)
+}
+
+func (w *writer) longlong(value uint64) {
+	w.data = binary.BigEndian.AppendUint64(w.data, value)
+}
+
+// shortstr 길이 1바이트 문자열 (255바이트 초과는 잘림)
+func (w *writer) shortstr(value string) {
+	if len(value) > 255 {
+		value = value[:255]
+	}
+	w.octet(byte(len(value)))
+	w.data = append(w.data, value...)
+}
+
+func (w *writer) longstr(value string) {
+	w.long(uint32(len(value)))
+	w.data = append(w.data, value...)
+}
+
+// table 문자열 값만 가진 필드 테이블 (키 순서 유지를 위해 쌍 목록)
+func (w *writer) table(pairs ...string) {
+	field := &writer{}
+	for i := 0; i+1 < len(pairs); i += 2 {
+		field.shortstr(pairs[i])
+		field.octet('S')
+		field.longstr(pairs[i+1])
+	}
+	w.long(uint32(len(field.data)))
+	w.data = append(w.data, field.data...)
+}
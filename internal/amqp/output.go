@@ -0,0 +1,166 @@
+// internal/amqp/output.go - AMQP Output for PLC Responses and Order Events (RabbitMQ)
+package amqp
+
+import (
+	"context"
+	"encoding/json"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/utils"
+	"strings"
+	"time"
+)
+
+// maxPending 브로커 연결이 끊긴 동안 보관할 최대 메시지 수 (초과 시 오래된 것부터 폐기)
+const maxPending = 10000
+
+// maxReconnectDelay 재연결 대기 시간 상한
+const maxReconnectDelay = 30 * time.Second
+
+// Output PLC 응답과 오더 이벤트를 RabbitMQ 익스체인지로 미러링
+// 라우팅 키는 "<이벤트 타입>.<로봇>" (예: plc.response.AMR-001), 메시지는 영속(delivery-mode 2)이며
+// publisher confirm으로 브로커가 확인한 메시지만 대기열에서 제거
+type Output struct {
+	config   *config.Config
+	eventBus *events.Bus
+
+	conn        *conn
+	pending     []message
+	delay       time.Duration
+	nextAttempt time.Time
+}
+
+// NewOutput 새 AMQP 출력 생성
+func NewOutput(cfg *config.Config, eventBus *events.Bus) *Output {
+	return &Output{
+		config:   cfg,
+		eventBus: eventBus,
+		delay:    time.Second,
+	}
+}
+
+// Run 이벤트 수집 및 발행 (ctx 종료 시 연결 종료 후 반환)
+func (o *Output) Run(ctx context.Context) {
+	eventCh, unsubscribe := o.eventBus.Subscribe(1024)
+	defer unsubscribe()
+	defer o.disconnect()
+
+	retryTicker := time.NewTicker(time.Second)
+	defer retryTicker.Stop()
+
+	utils.Logger.Infof("📡 AMQP output started (exchange %s, queue %s)", o.config.AMQPExchange, o.config.AMQPQueue)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if isMirrored(event.Type) {
+				o.add(event)
+				o.publishPending()
+			}
+		case <-retryTicker.C:
+			o.publishPending()
+		}
+	}
+}
+
+// isMirrored 미러링 대상 이벤트인지 확인 (PLC 응답과 오더 수명 주기)
+func isMirrored(eventType string) bool {
+	return eventType == events.TypePLCResponse || strings.HasPrefix(eventType, "order.")
+}
+
+// add 발행 대기 메시지 추가 (최대 보관 수 초과 시 오래된 것 폐기)
+func (o *Output) add(event events.Event) {
+	body, err := json.Marshal(event)
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal event for AMQP: %v", err)
+		return
+	}
+
+	o.pending = append(o.pending, message{
+		exchange:    o.config.AMQPExchange,
+		routingKey:  routingKey(event),
+		contentType: "application/json",
+		body:        body,
+		timestamp:   event.Time,
+	})
+	if dropped := len(o.pending) - maxPending; dropped > 0 {
+		utils.Logger.Warnf("⚠️ AMQP output buffer full, dropping %d oldest message(s)", dropped)
+		o.pending = o.pending[dropped:]
+	}
+}
+
+// routingKey 이벤트 라우팅 키 (로봇이 없으면 이벤트 타입만)
+func routingKey(event events.Event) string {
+	if event.Robot == "" {
+		return event.Type
+	}
+	return event.Type + "." + event.Robot
+}
+
+// publishPending 대기 메시지를 순서대로 발행 (실패 시 연결을 닫고 백오프 후 재시도)
+func (o *Output) publishPending() {
+	if len(o.pending) == 0 {
+		return
+	}
+	if o.conn == nil {
+		if time.Now().Before(o.nextAttempt) {
+			return
+		}
+		if err := o.connect(); err != nil {
+			o.backoff("connect", err)
+			return
+		}
+	}
+
+	for len(o.pending) > 0 {
+		if err := o.conn.publish(o.pending[0]); err != nil {
+			o.backoff("publish", err)
+			return
+		}
+		o.pending = o.pending[1:]
+	}
+}
+
+// connect 브로커 연결 후 durable 익스체인지/큐 선언
+func (o *Output) connect() error {
+	c, err := dial(o.config.AMQPURL, o.config.MQTTClientID, 10*time.Second)
+	if err != nil {
+		return err
+	}
+	if err := c.declareExchange(o.config.AMQPExchange, "topic"); err != nil {
+		c.close()
+		return err
+	}
+	if o.config.AMQPQueue != "" {
+		if err := c.declareQueue(o.config.AMQPQueue, o.config.AMQPExchange, o.config.AMQPBindingKey); err != nil {
+			c.close()
+			return err
+		}
+	}
+
+	o.conn = c
+	o.delay = time.Second
+	utils.Logger.Infof("✅ AMQP output connected (exchange %s)", o.config.AMQPExchange)
+	return nil
+}
+
+// backoff 연결 종료 후 다음 재시도 시각 설정
+func (o *Output) backoff(stage string, err error) {
+	utils.Logger.Warnf("⚠️ AMQP %s failed: %v (%d message(s) kept, retrying in %s)", stage, err, len(o.pending), o.delay)
+	o.disconnect()
+	o.nextAttempt = time.Now().Add(o.delay)
+	o.delay = min(o.delay*2, maxReconnectDelay)
+}
+
+// disconnect 연결 종료
+func (o *Output) disconnect() {
+	if o.conn != nil {
+		o.conn.close()
+		o.conn = nil
+	}
+}
@@ -0,0 +1,40 @@
+// internal/api/auth.go - Mutating Route Protection
+package api
+
+import (
+	"crypto/subtle"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// requireToken 변경 요청 보호 (Authorization: Bearer <HTTP_AUTH_TOKEN>)
+// 브라우저는 교차 출처 요청에 Authorization 헤더를 preflight 없이 붙일 수 없으므로 CSRF도 함께 차단됨
+// HTTP_AUTH_TOKEN이 비어있으면 변경 요청은 모두 거부
+func (s *Server) requireToken(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.config.HTTPAuthToken == "" {
+			writeError(w, http.StatusForbidden, "mutating API is disabled (HTTP_AUTH_TOKEN is not set)")
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.HTTPAuthToken)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="bridge"`)
+			writeError(w, http.StatusUnauthorized, "missing or invalid bearer token")
+			return
+		}
+		next(w, r)
+	}
+}
+
+// requireJSON 본문이 있는 변경 요청은 application/json만 허용 (text/plain 등 단순 요청 차단)
+func requireJSON(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+		if err != nil || mediaType != "application/json" {
+			writeError(w, http.StatusUnsupportedMediaType, "Content-Type must be application/json")
+			return
+		}
+		next(w, r)
+	}
+}
@@ -0,0 +1,56 @@
+// internal/api/command.go - Inbound PLC Command Gateway (MES)
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"net/http"
+)
+
+// maxCommandBodySize 명령 요청 본문 최대 크기
+const maxCommandBodySize = 4 * 1024
+
+// plcCommandRequest 명령 요청 구조체
+type plcCommandRequest struct {
+	Command string `json:"command"`
+	Robot   string `json:"robot,omitempty"` // 대상 로봇 시리얼 (생략 시 첫 번째 명령 경로)
+}
+
+// handlePLCCommand MQTT bridge/command와 동일한 명령을 HTTP로 수신
+// 본문: {"command": "...", "robot": "..."} (application/json, Bearer 토큰 필요)
+func (s *Server) handlePLCCommand(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxCommandBodySize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "failed to read request body")
+		return
+	}
+
+	var request plcCommandRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body: "+err.Error())
+		return
+	}
+
+	command := request.Command
+	if command == "" {
+		writeError(w, http.StatusBadRequest, "command is required")
+		return
+	}
+
+	handler, err := messaging.HandlerForRobot(s.handlers, request.Robot)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.Logger.Infof("🌐 HTTP PLC Command received from %s for robot %s: '%s'", r.RemoteAddr, handler.RobotSerialNumber(), command)
+
+	result := handler.ProcessCommand(command)
+	if !result.Accepted {
+		writeJSON(w, http.StatusUnprocessableEntity, result)
+		return
+	}
+	writeJSON(w, http.StatusAccepted, result)
+}
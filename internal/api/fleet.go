@@ -0,0 +1,58 @@
+// internal/api/fleet.go - Fleet Status API and Dashboard
+package api
+
+import (
+	_ "embed"
+	"mqtt-bridge/internal/messaging"
+	"net/http"
+)
+
+//go:embed dashboard.html
+var dashboardHTML []byte
+
+// fleetSummary 셀 전체 집계 (대시보드 상단)
+type fleetSummary struct {
+	Robots        int      `json:"robots"`
+	Online        int      `json:"online"`
+	Unhealthy     int      `json:"unhealthy"`
+	ActiveOrders  int      `json:"activeOrders"`
+	PendingOrders int      `json:"pendingOrders"`
+	LatchedFaults int      `json:"latchedFaults"`
+	LowestBattery *float64 `json:"lowestBattery,omitempty"`
+}
+
+// handleFleet 로봇별 레인 상태와 셀 집계
+func (s *Server) handleFleet(w http.ResponseWriter, r *http.Request) {
+	lanes := make([]messaging.RobotStatus, 0, len(s.handlers))
+	var summary fleetSummary
+
+	for _, handler := range s.handlers {
+		lane := handler.GetRobotStatus()
+		lanes = append(lanes, lane)
+
+		summary.Robots++
+		if lane.Connection == "ONLINE" {
+			summary.Online++
+		}
+		if lane.Unhealthy != "" {
+			summary.Unhealthy++
+		}
+		summary.ActiveOrders += len(lane.ActiveOrders)
+		summary.PendingOrders += lane.PendingOrders
+		summary.LatchedFaults += lane.LatchedFaults
+		if lane.BatteryCharge != nil && (summary.LowestBattery == nil || *lane.BatteryCharge < *summary.LowestBattery) {
+			summary.LowestBattery = lane.BatteryCharge
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"summary": summary,
+		"robots":  lanes,
+	})
+}
+
+// handleDashboard 플릿 대시보드 페이지 (/api/fleet 주기 조회)
+func (s *Server) handleDashboard(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Write(dashboardHTML)
+}
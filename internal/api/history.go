@@ -0,0 +1,54 @@
+// internal/api/history.go - Order History API
+package api
+
+import (
+	"mqtt-bridge/internal/history"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// handleHistory 명령/오더/액션 상태/응답 이력 조회
+// 쿼리: from, to (RFC3339), orderId, limit
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	if s.history == nil {
+		writeError(w, http.StatusNotFound, "order history is disabled")
+		return
+	}
+
+	query := r.URL.Query()
+	var from, to time.Time
+	var err error
+	if value := query.Get("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid from: "+err.Error())
+			return
+		}
+	}
+	if value := query.Get("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid to: "+err.Error())
+			return
+		}
+	}
+
+	limit := 0
+	if value := query.Get("limit"); value != "" {
+		if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+			writeError(w, http.StatusBadRequest, "invalid limit")
+			return
+		}
+	}
+
+	result, err := s.history.Find(history.Query{
+		From:    from,
+		To:      to,
+		OrderID: query.Get("orderId"),
+		Limit:   limit,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,76 @@
+// internal/api/maps.go - Map Management Admin API
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"net/http"
+)
+
+// mapActions 경로 이름 -> VDA5050 InstantAction
+var mapActions = map[string]string{
+	"download": messaging.MapActionDownload,
+	"enable":   messaging.MapActionEnable,
+	"delete":   messaging.MapActionDelete,
+}
+
+// mapActionBody 지도 관리 요청 본문 (robot 생략 시 첫 번째 명령 경로의 로봇)
+type mapActionBody struct {
+	Robot string `json:"robot,omitempty"`
+	messaging.MapActionRequest
+}
+
+// handleListMaps 로봇별 기본 지도 ID 조회
+func (s *Server) handleListMaps(w http.ResponseWriter, r *http.Request) {
+	robots := make([]map[string]string, 0, len(s.handlers))
+	for _, handler := range s.handlers {
+		robots = append(robots, map[string]string{
+			"robot": handler.RobotSerialNumber(),
+			"mapId": handler.MapID(),
+		})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"robots": robots,
+	})
+}
+
+// handleMapAction 지도 다운로드/활성화/삭제 InstantAction 전송
+func (s *Server) handleMapAction(w http.ResponseWriter, r *http.Request) {
+	actionType, exists := mapActions[r.PathValue("action")]
+	if !exists {
+		writeError(w, http.StatusNotFound, "unknown map action (download, enable, delete)")
+		return
+	}
+
+	var body mapActionBody
+	data, err := io.ReadAll(io.LimitReader(r.Body, maxCommandBodySize))
+	if err == nil {
+		err = json.Unmarshal(data, &body)
+	}
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+
+	handler, err := messaging.HandlerForRobot(s.handlers, body.Robot)
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.Logger.Infof("🌐 HTTP %s request from %s for robot %s (map %s)", actionType, r.RemoteAddr, handler.RobotSerialNumber(), body.MapID)
+	actionID, err := handler.SendMapAction(actionType, body.MapActionRequest)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"robot":      handler.RobotSerialNumber(),
+		"actionType": actionType,
+		"actionId":   actionID,
+		"mapId":      body.MapID,
+		"mapVersion": body.MapVersion,
+	})
+}
@@ -0,0 +1,225 @@
+// internal/api/orders.go - Order Management Admin API
+package api
+
+import (
+	"errors"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"net/http"
+)
+
+// robotOrder 로봇 시리얼을 포함한 오더 (여러 명령 경로의 목록을 합칠 때)
+type robotOrder struct {
+	Robot string `json:"robot"`
+	messaging.OrderInfo
+}
+
+// robotCommand 로봇 시리얼을 포함한 수신 명령
+type robotCommand struct {
+	Robot string `json:"robot"`
+	messaging.CommandResult
+}
+
+// robotFault 로봇 시리얼을 포함한 래치 오류
+type robotFault struct {
+	Robot string `json:"robot"`
+	types.FaultEvent
+}
+
+// selectHandlers robot 쿼리로 대상 핸들러 선택 (비어있으면 전체 명령 경로, 없는 로봇이면 404 응답 후 nil)
+func (s *Server) selectHandlers(w http.ResponseWriter, r *http.Request) []*messaging.DirectActionHandler {
+	handlers, err := messaging.HandlersForRobot(s.handlers, r.URL.Query().Get("robot"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, err.Error())
+		return nil
+	}
+	return handlers
+}
+
+// orderHandler 오더를 추적 중인 핸들러 (모든 명령 경로에서 검색, 없으면 404 응답 후 nil)
+func (s *Server) orderHandler(w http.ResponseWriter, orderID, notFound string) *messaging.DirectActionHandler {
+	handler, err := messaging.HandlerForOrder(s.handlers, orderID)
+	if err != nil {
+		writeError(w, http.StatusNotFound, notFound)
+		return nil
+	}
+	return handler
+}
+
+// handleListOrders 활성/취소된 오더 목록 조회 (쿼리: robot)
+func (s *Server) handleListOrders(w http.ResponseWriter, r *http.Request) {
+	handlers := s.selectHandlers(w, r)
+	if handlers == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"orders": collectOrders(handlers, (*messaging.DirectActionHandler).GetOrders),
+	})
+}
+
+// handleGetOrder 오더 상세 조회
+func (s *Server) handleGetOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+	handler := s.orderHandler(w, orderID, "order not found")
+	if handler == nil {
+		return
+	}
+	order, _ := handler.GetOrder(orderID)
+	writeJSON(w, http.StatusOK, robotOrder{Robot: handler.RobotSerialNumber(), OrderInfo: order})
+}
+
+// handleCancelOrder 오더 수동 취소 (PLC 측이 멈춘 경우)
+func (s *Server) handleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+	utils.Logger.Infof("🌐 HTTP cancel request from %s for OrderID: %s", r.RemoteAddr, orderID)
+
+	handler := s.orderHandler(w, orderID, "order not found")
+	if handler == nil {
+		return
+	}
+	if err := handler.CancelOrder(orderID); err != nil {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+
+	order, _ := handler.GetOrder(orderID)
+	writeJSON(w, http.StatusAccepted, robotOrder{Robot: handler.RobotSerialNumber(), OrderInfo: order})
+}
+
+// handleListPendingOrders 운영자 확인 대기 오더 목록 조회 (커미셔닝 모드, 쿼리: robot)
+func (s *Server) handleListPendingOrders(w http.ResponseWriter, r *http.Request) {
+	handlers := s.selectHandlers(w, r)
+	if handlers == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"orders": collectOrders(handlers, (*messaging.DirectActionHandler).GetPendingOrders),
+	})
+}
+
+// handleConfirmOrder 확인 대기 오더를 로봇으로 발행
+func (s *Server) handleConfirmOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+	utils.Logger.Infof("🌐 HTTP order confirmation from %s for OrderID: %s", r.RemoteAddr, orderID)
+
+	handler := s.orderHandler(w, orderID, "no order awaiting confirmation")
+	if handler == nil {
+		return
+	}
+	if err := handler.ConfirmOrder(orderID); err != nil {
+		writePendingOrderError(w, err)
+		return
+	}
+
+	order, _ := handler.GetOrder(orderID)
+	writeJSON(w, http.StatusAccepted, robotOrder{Robot: handler.RobotSerialNumber(), OrderInfo: order})
+}
+
+// handleRejectOrder 확인 대기 오더를 발행하지 않고 실패 처리
+func (s *Server) handleRejectOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+	utils.Logger.Infof("🌐 HTTP order rejection from %s for OrderID: %s", r.RemoteAddr, orderID)
+
+	handler := s.orderHandler(w, orderID, "no order awaiting confirmation")
+	if handler == nil {
+		return
+	}
+	if err := handler.RejectOrder(orderID, "rejected by operator"); err != nil {
+		writePendingOrderError(w, err)
+		return
+	}
+
+	order, _ := handler.GetOrder(orderID)
+	writeJSON(w, http.StatusOK, robotOrder{Robot: handler.RobotSerialNumber(), OrderInfo: order})
+}
+
+// writePendingOrderError 확인/거부 실패 응답 (대기 오더 없음은 404)
+func writePendingOrderError(w http.ResponseWriter, err error) {
+	if errors.Is(err, messaging.ErrOrderNotFound) {
+		writeError(w, http.StatusNotFound, "no order awaiting confirmation")
+		return
+	}
+	writeError(w, http.StatusBadGateway, err.Error())
+}
+
+// handleListCommands 최근 수신 명령 목록 조회 (쿼리: robot)
+func (s *Server) handleListCommands(w http.ResponseWriter, r *http.Request) {
+	handlers := s.selectHandlers(w, r)
+	if handlers == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"commands": collectCommands(handlers),
+	})
+}
+
+// handleListFaults 운영자 확인 대기 중인 래치 오류 목록 조회 (쿼리: robot)
+func (s *Server) handleListFaults(w http.ResponseWriter, r *http.Request) {
+	handlers := s.selectHandlers(w, r)
+	if handlers == nil {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"faults": collectFaults(handlers),
+	})
+}
+
+// handleAckFault 래치 오류 확인 (해당 명령 재시도 허용, 쿼리: robot, 생략 시 모든 로봇)
+func (s *Server) handleAckFault(w http.ResponseWriter, r *http.Request) {
+	command := r.PathValue("command")
+	utils.Logger.Infof("🌐 HTTP fault acknowledgment from %s for command: %s", r.RemoteAddr, command)
+
+	handlers := s.selectHandlers(w, r)
+	if handlers == nil {
+		return
+	}
+	robots := make([]string, 0, len(handlers))
+	for _, handler := range handlers {
+		if handler.AcknowledgeFault(command) {
+			robots = append(robots, handler.RobotSerialNumber())
+		}
+	}
+	if len(robots) == 0 {
+		writeError(w, http.StatusNotFound, "no latched fault for command")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"command":      command,
+		"acknowledged": true,
+		"robots":       robots,
+	})
+}
+
+// collectOrders 핸들러별 오더 목록을 로봇 시리얼과 함께 합침
+func collectOrders(handlers []*messaging.DirectActionHandler, list func(*messaging.DirectActionHandler) []messaging.OrderInfo) []robotOrder {
+	orders := make([]robotOrder, 0)
+	for _, handler := range handlers {
+		for _, order := range list(handler) {
+			orders = append(orders, robotOrder{Robot: handler.RobotSerialNumber(), OrderInfo: order})
+		}
+	}
+	return orders
+}
+
+// collectCommands 핸들러별 최근 수신 명령을 로봇 시리얼과 함께 합침
+func collectCommands(handlers []*messaging.DirectActionHandler) []robotCommand {
+	commands := make([]robotCommand, 0)
+	for _, handler := range handlers {
+		for _, command := range handler.GetRecentCommands() {
+			commands = append(commands, robotCommand{Robot: handler.RobotSerialNumber(), CommandResult: command})
+		}
+	}
+	return commands
+}
+
+// collectFaults 핸들러별 래치 오류를 로봇 시리얼과 함께 합침
+func collectFaults(handlers []*messaging.DirectActionHandler) []robotFault {
+	faults := make([]robotFault, 0)
+	for _, handler := range handlers {
+		for _, fault := range handler.GetLatchedFaults() {
+			faults = append(faults, robotFault{Robot: handler.RobotSerialNumber(), FaultEvent: fault})
+		}
+	}
+	return faults
+}
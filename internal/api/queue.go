@@ -0,0 +1,50 @@
+// internal/api/queue.go - Command Queue Admin API
+package api
+
+import (
+	"errors"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"net/http"
+)
+
+// robotQueue 로봇 시리얼을 포함한 대기열 상태
+type robotQueue struct {
+	Robot string `json:"robot"`
+	messaging.QueueStatus
+}
+
+// handleGetQueue 로봇별 오더 처리 정책 및 대기 중인 명령 조회 (쿼리: robot)
+func (s *Server) handleGetQueue(w http.ResponseWriter, r *http.Request) {
+	handlers := s.selectHandlers(w, r)
+	if handlers == nil {
+		return
+	}
+	queues := make([]robotQueue, 0, len(handlers))
+	for _, handler := range handlers {
+		queues = append(queues, robotQueue{Robot: handler.RobotSerialNumber(), QueueStatus: handler.GetQueue()})
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"queues": queues,
+	})
+}
+
+// handleRemoveQueuedCommand 대기 중인 명령 제거 (모든 명령 경로에서 검색, PLC에는 실패 응답)
+func (s *Server) handleRemoveQueuedCommand(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	utils.Logger.Infof("🌐 HTTP queue removal from %s for command ID: %s", r.RemoteAddr, id)
+
+	for _, handler := range s.handlers {
+		queued, err := handler.RemoveQueuedCommand(id)
+		if errors.Is(err, messaging.ErrQueuedCommandNotFound) {
+			continue
+		}
+		if err != nil {
+			writeError(w, http.StatusConflict, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, queued)
+		return
+	}
+	writeError(w, http.StatusNotFound, messaging.ErrQueuedCommandNotFound.Error())
+}
@@ -0,0 +1,177 @@
+// internal/api/server.go - Bridge HTTP Server
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/history"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/metrics"
+	"mqtt-bridge/internal/utils"
+	"net/http"
+	"time"
+)
+
+// Server 브릿지 HTTP 서버 (Health/Readiness, 명령 게이트웨이, 관리자 API)
+type Server struct {
+	config     *config.Config
+	mqttClient *messaging.MQTTClient
+	subscriber *messaging.Subscriber
+	handlers   []*messaging.DirectActionHandler // 전체 명령 경로 (첫 번째가 robot 생략 시 명령 제출 대상)
+	eventBus   *events.Bus
+	history    *history.Store // nil이면 이력 API 비활성화
+	httpServer *http.Server
+}
+
+// NewServer 새 HTTP 서버 생성
+func NewServer(cfg *config.Config, mqttClient *messaging.MQTTClient, subscriber *messaging.Subscriber, handlers []*messaging.DirectActionHandler, eventBus *events.Bus, registry *metrics.Registry, historyStore *history.Store) *Server {
+	utils.Logger.Infof("🏗️ Creating HTTP Server")
+
+	server := &Server{
+		config:     cfg,
+		mqttClient: mqttClient,
+		subscriber: subscriber,
+		handlers:   handlers,
+		eventBus:   eventBus,
+		history:    historyStore,
+	}
+
+	utils.RegisterSecret(cfg.HTTPAuthToken)
+	if cfg.HTTPAuthToken == "" {
+		utils.Logger.Warnf("⚠️ HTTP_AUTH_TOKEN not set: command, cancel and other mutating API routes are disabled")
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", server.handleHealthz)
+	mux.HandleFunc("GET /readyz", server.handleReadyz)
+	mux.Handle("GET /metrics", registry.Handler())
+	mux.HandleFunc("POST /api/v1/plc-command", server.requireToken(requireJSON(server.handlePLCCommand)))
+	mux.HandleFunc("GET /api/v1/commands/{id}/events", server.handleCommandEvents)
+	mux.HandleFunc("GET /api/v1/events/ws", server.handleEventStream)
+	mux.HandleFunc("GET /api/orders", server.handleListOrders)
+	mux.HandleFunc("GET /api/orders/{id}", server.handleGetOrder)
+	mux.HandleFunc("POST /api/orders/{id}/cancel", server.requireToken(server.handleCancelOrder))
+	mux.HandleFunc("GET /api/orders/pending", server.handleListPendingOrders)
+	mux.HandleFunc("POST /api/orders/{id}/confirm", server.requireToken(server.handleConfirmOrder))
+	mux.HandleFunc("POST /api/orders/{id}/reject", server.requireToken(server.handleRejectOrder))
+	mux.HandleFunc("GET /api/commands", server.handleListCommands)
+	mux.HandleFunc("GET /api/queue", server.handleGetQueue)
+	mux.HandleFunc("DELETE /api/queue/{id}", server.requireToken(server.handleRemoveQueuedCommand))
+	mux.HandleFunc("GET /api/faults", server.handleListFaults)
+	mux.HandleFunc("POST /api/faults/{command}/ack", server.requireToken(server.handleAckFault))
+	mux.HandleFunc("GET /api/history", server.handleHistory)
+	mux.HandleFunc("GET /api/support-bundle", server.handleSupportBundle)
+	mux.HandleFunc("GET /api/fleet", server.handleFleet)
+	mux.HandleFunc("GET /api/config/deprecations", server.handleDeprecations)
+	mux.HandleFunc("GET /api/maps", server.handleListMaps)
+	mux.HandleFunc("POST /api/maps/{action}", server.requireToken(requireJSON(server.handleMapAction)))
+	mux.HandleFunc("GET /dashboard", server.handleDashboard)
+
+	server.httpServer = &http.Server{
+		Addr:              cfg.HTTPAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	utils.Logger.Infof("✅ HTTP Server Created")
+	return server
+}
+
+// Start HTTP 서버 시작 (백그라운드)
+func (s *Server) Start() {
+	utils.Logger.Infof("🌐 HTTP server listening on %s", s.config.HTTPAddr)
+
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			utils.Logger.Errorf("❌ HTTP server failed: %v", err)
+		}
+	}()
+}
+
+// Stop HTTP 서버 중지
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		utils.Logger.Errorf("❌ HTTP server shutdown failed: %v", err)
+		return
+	}
+	utils.Logger.Info("HTTP server stopped")
+}
+
+// handleHealthz 프로세스 생존 확인 (liveness)
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"status": "ok",
+	})
+}
+
+// robotReadiness 명령 경로별 준비 상태
+type robotReadiness struct {
+	Robot      string `json:"robot"`
+	Connection string `json:"connection"`
+	Unhealthy  string `json:"unhealthy,omitempty"`
+}
+
+// handleReadyz 요청 처리 가능 여부 확인 (readiness, 모든 명령 경로의 로봇 확인)
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	mqttConnected := s.mqttClient.IsConnected()
+	subscribed := s.subscriber.IsSubscribed()
+
+	ready := mqttConnected && subscribed
+	robots := make([]robotReadiness, 0, len(s.handlers))
+	for _, handler := range s.handlers {
+		robot := robotReadiness{
+			Robot:      handler.RobotSerialNumber(),
+			Connection: handler.GetRobotConnectionState(),
+			Unhealthy:  handler.UnhealthyReason(),
+		}
+		ready = ready && robot.Unhealthy == ""
+		if s.config.ReadyRequireRobotOnline {
+			ready = ready && robot.Connection == "ONLINE"
+		}
+		robots = append(robots, robot)
+	}
+
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, status, map[string]interface{}{
+		"ready": ready,
+		"checks": map[string]interface{}{
+			"mqttConnected": mqttConnected,
+			"subscribed":    subscribed,
+			"degraded":      s.subscriber.FailedSubscriptions(),
+			"robots":        robots,
+		},
+	})
+}
+
+// handleDeprecations 사용 중인 지원 중단 설정 키 (업그레이드 전 점검용)
+func (s *Server) handleDeprecations(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"deprecated": config.DeprecatedUsage(),
+	})
+}
+
+// writeJSON JSON 응답 작성
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		utils.Logger.Errorf("❌ Failed to write HTTP response: %v", err)
+	}
+}
+
+// writeError JSON 오류 응답 작성
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"error": message,
+	})
+}
@@ -0,0 +1,108 @@
+package api
+
+import (
+	"encoding/json"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/harness"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/metrics"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testToken = "api-test-token"
+
+// newFleetServer 로봇마다 하네스를 만들어 하나의 HTTP 서버에 연결
+func newFleetServer(t *testing.T, serials ...string) ([]*harness.Harness, *httptest.Server) {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config: %v", err)
+	}
+	cfg.HTTPAuthToken = testToken
+
+	harnesses := make([]*harness.Harness, 0, len(serials))
+	handlers := make([]*messaging.DirectActionHandler, 0, len(serials))
+	for _, serial := range serials {
+		robotCfg := *cfg
+		robotCfg.RobotSerialNumber = serial
+		h, err := harness.New(&robotCfg, time.Millisecond)
+		if err != nil {
+			t.Fatalf("harness: %v", err)
+		}
+		t.Cleanup(h.Close)
+		h.Robot.SetOutcome(harness.OutcomeHold)
+		harnesses = append(harnesses, h)
+		handlers = append(handlers, h.Handler)
+	}
+
+	server := NewServer(cfg, nil, nil, handlers, events.NewBus(), metrics.NewRegistry(), nil)
+	ts := httptest.NewServer(server.httpServer.Handler)
+	t.Cleanup(ts.Close)
+	return harnesses, ts
+}
+
+// do 요청 후 상태 코드와 JSON 본문
+func do(t *testing.T, ts *httptest.Server, method, path, body string) (int, map[string]interface{}) {
+	t.Helper()
+	req, _ := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp.StatusCode, decoded
+}
+
+func TestRoutesAcrossRobots(t *testing.T) {
+	harnesses, ts := newFleetServer(t, "ROBOT1", "ROBOT2")
+
+	status, result := do(t, ts, http.MethodPost, "/api/v1/plc-command", `{"command":"CAL:I","robot":"ROBOT2"}`)
+	if status != http.StatusAccepted {
+		t.Fatalf("submit: status %d (%v)", status, result)
+	}
+	orderID, _ := result["orderId"].(string)
+	if _, exists := harnesses[1].Handler.GetOrder(orderID); !exists {
+		t.Fatalf("order %s not created on ROBOT2", orderID)
+	}
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		robot  string // 응답의 robot 필드 (비어있으면 확인 안 함)
+	}{
+		{"get order on second route", http.MethodGet, "/api/orders/" + orderID, "", http.StatusOK, "ROBOT2"},
+		{"cancel order on second route", http.MethodPost, "/api/orders/" + orderID + "/cancel", "", http.StatusAccepted, "ROBOT2"},
+		{"unknown order", http.MethodGet, "/api/orders/missing", "", http.StatusNotFound, ""},
+		{"unknown robot filter", http.MethodGet, "/api/orders?robot=ROBOT9", "", http.StatusNotFound, ""},
+		{"submit to unknown robot", http.MethodPost, "/api/v1/plc-command", `{"command":"CAL:I","robot":"ROBOT9"}`, http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, body := do(t, ts, tt.method, tt.path, tt.body)
+			if status != tt.status {
+				t.Fatalf("status %d, want %d (%v)", status, tt.status, body)
+			}
+			if tt.robot != "" && body["robot"] != tt.robot {
+				t.Errorf("robot %v, want %s", body["robot"], tt.robot)
+			}
+		})
+	}
+
+	status, queues := do(t, ts, http.MethodGet, "/api/queue", "")
+	if list, _ := queues["queues"].([]interface{}); status != http.StatusOK || len(list) != 2 {
+		t.Errorf("queue: status %d, %v, want one entry per robot", status, queues)
+	}
+}
@@ -0,0 +1,82 @@
+// internal/api/sse.go - Command Status Server-Sent Events
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// sseKeepAliveInterval SSE 연결 유지 주석 전송 주기
+const sseKeepAliveInterval = 15 * time.Second
+
+// handleCommandEvents 제출된 명령(OrderID)의 상태 전이를 최종 상태까지 SSE로 스트리밍
+func (s *Server) handleCommandEvents(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("id")
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "streaming not supported")
+		return
+	}
+
+	handler := s.orderHandler(w, orderID, "command not found")
+	if handler == nil {
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	// keepalive와 이벤트 전송이 같은 writer를 사용하므로 직렬화
+	var writeMu sync.Mutex
+	go func() {
+		keepAlive := time.NewTicker(sseKeepAliveInterval)
+		defer keepAlive.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-keepAlive.C:
+				writeMu.Lock()
+				fmt.Fprint(w, ": keepalive\n\n")
+				flusher.Flush()
+				writeMu.Unlock()
+			}
+		}
+	}()
+
+	err := handler.WatchOrder(ctx, orderID, func(event events.Event) error {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		writeSSE(w, event)
+		flusher.Flush()
+		return nil
+	})
+	if err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, messaging.ErrOrderNotFound) {
+		writeMu.Lock()
+		fmt.Fprintf(w, "event: error\ndata: %q\n\n", err.Error())
+		writeMu.Unlock()
+	}
+}
+
+// writeSSE SSE 이벤트 작성
+func writeSSE(w http.ResponseWriter, event events.Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event.Type, data)
+}
@@ -0,0 +1,120 @@
+// internal/api/support.go - Support Bundle Admin API
+package api
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/history"
+	"mqtt-bridge/internal/support"
+	"mqtt-bridge/internal/utils"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// 지원 번들 제한
+const (
+	defaultBundleHours = 24
+	maxBundleLogBytes  = 20 * 1024 * 1024 // 로그 파일당 최대 포함 크기 (마지막 부분)
+)
+
+// handleSupportBundle 로그, 설정(비밀 제거), 상태 스냅샷, 오더 이력, 버전 정보를 tar.gz로 반환
+// 쿼리: hours (기본 24)
+func (s *Server) handleSupportBundle(w http.ResponseWriter, r *http.Request) {
+	hours := defaultBundleHours
+	if value := r.URL.Query().Get("hours"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			writeError(w, http.StatusBadRequest, "invalid hours")
+			return
+		}
+		hours = parsed
+	}
+	since := time.Now().Add(-time.Duration(hours) * time.Hour)
+
+	utils.Logger.Infof("🌐 Support bundle requested from %s (last %d hours)", r.RemoteAddr, hours)
+
+	filename := fmt.Sprintf("support-bundle-%s-%s.tar.gz", s.config.RobotSerialNumber, time.Now().Format("20060102-150405"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	bundle := support.NewWriter(w)
+	defer func() {
+		if err := bundle.Close(); err != nil {
+			utils.Logger.Errorf("❌ Failed to finish support bundle: %v", err)
+		}
+	}()
+
+	// 응답 헤더 전송 이후에는 오류를 번들 안에 기록
+	var problems []string
+	add := func(name string, err error) {
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %v", name, err))
+		}
+	}
+
+	add("version.json", bundle.AddJSON("version.json", support.CurrentVersion()))
+	add("config.json", bundle.AddJSON("config.json", support.RedactConfig(s.config)))
+	add("state.json", bundle.AddJSON("state.json", s.stateSnapshot()))
+
+	if s.history != nil {
+		result, err := s.history.Find(history.Query{From: since})
+		if err == nil {
+			err = bundle.AddJSON("history.json", result)
+		}
+		add("history.json", err)
+	}
+
+	for _, path := range s.recentLogFiles(since) {
+		add(path, bundle.AddFile(filepath.Join("logs", filepath.Base(path)), path, maxBundleLogBytes))
+	}
+
+	if len(problems) > 0 {
+		add("errors.json", bundle.AddJSON("errors.json", problems))
+	}
+}
+
+// stateSnapshot 현재 브릿지 상태 스냅샷 (명령 경로별 로봇 상태 포함)
+func (s *Server) stateSnapshot() map[string]interface{} {
+	robots := make([]map[string]interface{}, 0, len(s.handlers))
+	for _, handler := range s.handlers {
+		robots = append(robots, map[string]interface{}{
+			"robot":           handler.RobotSerialNumber(),
+			"robotConnection": handler.GetRobotConnectionState(),
+			"unhealthy":       handler.UnhealthyReason(),
+			"lastStateAt":     handler.LastStateAt(),
+			"orders":          handler.GetOrders(),
+			"pendingOrders":   handler.GetPendingOrders(),
+			"queue":           handler.GetQueue(),
+			"faults":          handler.GetLatchedFaults(),
+			"recentCommands":  handler.GetRecentCommands(),
+		})
+	}
+	return map[string]interface{}{
+		"mqttConnected":       s.mqttClient.IsConnected(),
+		"subscribed":          s.subscriber.IsSubscribed(),
+		"failedSubscriptions": s.subscriber.FailedSubscriptions(),
+		"robots":              robots,
+	}
+}
+
+// recentLogFiles since 이후 수정된 로그 파일과 회전 파일 목록
+func (s *Server) recentLogFiles(since time.Time) []string {
+	if s.config.LogFile == "" {
+		return nil
+	}
+
+	candidates, _ := filepath.Glob(s.config.LogFile + ".*")
+	candidates = append(candidates, s.config.LogFile)
+	sort.Strings(candidates)
+
+	var files []string
+	for _, path := range candidates {
+		if info, err := os.Stat(path); err == nil && info.ModTime().After(since) {
+			files = append(files, path)
+		}
+	}
+	return files
+}
@@ -0,0 +1,95 @@
+// internal/api/websocket.go - Live Bridge Event Stream (WebSocket)
+package api
+
+import (
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/utils"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	wsWriteTimeout = 5 * time.Second
+	wsPongTimeout  = 60 * time.Second
+	wsPingInterval = 30 * time.Second
+)
+
+// checkOrigin WebSocket origin 검사 (교차 출처 WebSocket 하이재킹 방지)
+// Origin 헤더가 없는 비브라우저 클라이언트, 같은 호스트, HTTP_ALLOWED_ORIGINS에 등록된 origin만 허용
+func (s *Server) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if config.ParseNameList(s.config.HTTPAllowedOrigins)[origin] {
+		return true
+	}
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(parsed.Host, r.Host)
+}
+
+// handleEventStream 브릿지 이벤트(명령 수신, 오더 발행, 상태 전이, PLC 응답)를 JSON으로 스트리밍
+func (s *Server) handleEventStream(w http.ResponseWriter, r *http.Request) {
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  1024,
+		WriteBufferSize: 4096,
+		CheckOrigin:     s.checkOrigin,
+	}
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		utils.Logger.Warnf("⚠️ WebSocket upgrade failed from %s (origin %q): %v", r.RemoteAddr, r.Header.Get("Origin"), err)
+		return
+	}
+	defer conn.Close()
+
+	utils.Logger.Infof("🔌 WebSocket event client connected: %s", r.RemoteAddr)
+	defer utils.Logger.Infof("🔌 WebSocket event client disconnected: %s", r.RemoteAddr)
+
+	eventCh, unsubscribe := s.eventBus.Subscribe(64)
+	defer unsubscribe()
+
+	// 클라이언트 종료 감지용 읽기 루프 (수신 메시지는 무시)
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadLimit(512)
+		conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(wsPongTimeout))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	ping := time.NewTicker(wsPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteJSON(event); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// internal/audit/log.go - MQTT Message Audit Log
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Direction 메시지 방향
+const (
+	DirectionInbound  = "in"
+	DirectionOutbound = "out"
+)
+
+// Record 감사 로그 레코드 (JSON Lines 한 줄)
+type Record struct {
+	Time      time.Time `json:"time"`
+	Direction string    `json:"direction"`
+	Topic     string    `json:"topic"`
+	QoS       byte      `json:"qos"`
+	Retained  bool      `json:"retained"`
+	Payload   string    `json:"payload"`
+}
+
+// Log append-only 감사 로그 파일 (동시 기록 안전)
+type Log struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// Open 감사 로그 파일 열기 (append 모드)
+func Open(path string) (*Log, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %v", err)
+	}
+
+	return &Log{
+		file: file,
+		enc:  json.NewEncoder(file),
+	}, nil
+}
+
+// Record 메시지 기록 (nil Log는 무시)
+func (l *Log) Record(direction, topic string, qos byte, retained bool, payload []byte) error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.enc.Encode(Record{
+		Time:      time.Now(),
+		Direction: direction,
+		Topic:     topic,
+		QoS:       qos,
+		Retained:  retained,
+		Payload:   string(payload),
+	})
+}
+
+// Close 감사 로그 파일 닫기
+func (l *Log) Close() error {
+	if l == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
+
+// ReadFile 감사 로그 파일을 순서대로 읽어 fn 호출 (fn 오류 시 중단)
+func ReadFile(path string, fn func(Record) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return fmt.Errorf("invalid audit record at line %d: %v", line, err)
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
@@ -0,0 +1,169 @@
+// internal/bridge/admin.go - Admin MQTT Topic for Runtime Control
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/support"
+	"mqtt-bridge/internal/utils"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// 관리 명령 (ADMIN_TOPIC JSON의 command)
+const (
+	adminListOrders  = "list_orders"   // 진행/취소 중인 오더 목록
+	adminCancelOrder = "cancel_order"  // orderId 오더 취소
+	adminSetLogLevel = "set_log_level" // level (debug, info, warn, error), 재로드 시 LOG_LEVEL로 복원
+	adminPause       = "pause"         // 명령 수신 일시 중지 (robot 지정 시 해당 로봇만)
+	adminResume      = "resume"        // 명령 수신 재개
+	adminDumpConfig  = "dump_config"   // 비밀 값을 가린 설정
+)
+
+// adminRequest 관리 명령 요청
+type adminRequest struct {
+	ID      string `json:"id,omitempty"` // 응답에 그대로 포함 (요청 구분용)
+	Command string `json:"command"`
+	Robot   string `json:"robot,omitempty"` // 비어있으면 모든 로봇
+	OrderID string `json:"orderId,omitempty"`
+	Level   string `json:"level,omitempty"`
+}
+
+// adminResponse <ADMIN_TOPIC>/response로 발행하는 결과
+type adminResponse struct {
+	ID        string      `json:"id,omitempty"`
+	Command   string      `json:"command"`
+	OK        bool        `json:"ok"`
+	Error     string      `json:"error,omitempty"`
+	Result    interface{} `json:"result,omitempty"`
+	Timestamp time.Time   `json:"timestamp"`
+}
+
+// adminOrders 로봇별 오더 목록
+type adminOrders struct {
+	Robot  string                `json:"robot"`
+	Paused bool                  `json:"paused"`
+	Orders []messaging.OrderInfo `json:"orders"`
+}
+
+// adminResponseTopic 관리 명령 응답 토픽
+func (s *Service) adminResponseTopic() string {
+	return s.config.AdminTopic + "/response"
+}
+
+// handleAdminRequest 관리 토픽 메시지 처리 (콜백 블로킹 방지를 위해 비동기 실행)
+func (s *Service) handleAdminRequest(client mqtt.Client, msg mqtt.Message) {
+	if msg.Retained() {
+		utils.Logger.Warnf("⚠️ Ignoring retained admin request on %s", msg.Topic())
+		return
+	}
+	payload := append([]byte(nil), msg.Payload()...)
+
+	go func() {
+		response := s.runAdmin(payload)
+		data, err := json.Marshal(response)
+		if err != nil {
+			utils.Logger.Errorf("❌ Failed to marshal admin response: %v", err)
+			return
+		}
+		if err := s.mqttClient.Publish(s.adminResponseTopic(), 1, false, data); err != nil {
+			utils.Logger.Errorf("❌ Failed to publish admin response: %v", err)
+		}
+	}()
+}
+
+// runAdmin 관리 명령 실행
+func (s *Service) runAdmin(payload []byte) adminResponse {
+	var request adminRequest
+	if err := json.Unmarshal(payload, &request); err != nil {
+		return newAdminResponse(request, nil, fmt.Errorf("invalid admin request: %v", err))
+	}
+	utils.Logger.Infof("🛠️ Admin command: %s (id: %s)", request.Command, request.ID)
+
+	handlers, err := s.adminHandlers(request.Robot)
+	if err != nil {
+		return newAdminResponse(request, nil, err)
+	}
+
+	switch request.Command {
+	case adminListOrders:
+		result := make([]adminOrders, 0, len(handlers))
+		for _, handler := range handlers {
+			result = append(result, adminOrders{
+				Robot:  handler.RobotSerialNumber(),
+				Paused: handler.IntakePaused(),
+				Orders: handler.GetOrders(),
+			})
+		}
+		return newAdminResponse(request, result, nil)
+	case adminCancelOrder:
+		if request.OrderID == "" {
+			return newAdminResponse(request, nil, fmt.Errorf("orderId is required"))
+		}
+		for _, handler := range handlers {
+			if hasOrder(handler, request.OrderID) {
+				return newAdminResponse(request, nil, handler.CancelOrder(request.OrderID))
+			}
+		}
+		return newAdminResponse(request, nil, fmt.Errorf("%w: %s", messaging.ErrOrderNotFound, request.OrderID))
+	case adminSetLogLevel:
+		switch request.Level {
+		case "debug", "info", "warn", "error":
+		default:
+			return newAdminResponse(request, nil, fmt.Errorf("unknown log level %q (debug, info, warn, error)", request.Level))
+		}
+		utils.SetupLogger(request.Level)
+		utils.Logger.Warnf("🛠️ Log level changed to %s via admin topic", request.Level)
+		return newAdminResponse(request, nil, nil)
+	case adminPause, adminResume:
+		for _, handler := range handlers {
+			handler.SetIntakePaused(request.Command == adminPause)
+		}
+		return newAdminResponse(request, nil, nil)
+	case adminDumpConfig:
+		return newAdminResponse(request, support.RedactConfig(s.config), nil)
+	default:
+		return newAdminResponse(request, nil, fmt.Errorf("unknown admin command %q", request.Command))
+	}
+}
+
+// adminHandlers 요청 대상 핸들러 (robot이 비어있으면 전체)
+func (s *Service) adminHandlers(robot string) ([]*messaging.DirectActionHandler, error) {
+	if robot == "" {
+		return s.handlers, nil
+	}
+	for _, handler := range s.handlers {
+		if handler.RobotSerialNumber() == robot {
+			return []*messaging.DirectActionHandler{handler}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown robot %q", robot)
+}
+
+// hasOrder 핸들러가 해당 오더를 진행 중인지 확인
+func hasOrder(handler *messaging.DirectActionHandler, orderID string) bool {
+	for _, order := range handler.GetOrders() {
+		if order.OrderID == orderID {
+			return true
+		}
+	}
+	return false
+}
+
+// newAdminResponse 관리 명령 결과 생성
+func newAdminResponse(request adminRequest, result interface{}, err error) adminResponse {
+	response := adminResponse{
+		ID:        request.ID,
+		Command:   request.Command,
+		OK:        err == nil,
+		Result:    result,
+		Timestamp: time.Now(),
+	}
+	if err != nil {
+		response.Error = err.Error()
+		utils.Logger.Warnf("⚠️ Admin command %s failed: %v", request.Command, err)
+	}
+	return response
+}
@@ -0,0 +1,120 @@
+// internal/bridge/errors.go - Bridge Error Event Topic
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// 오류 이벤트 심각도 (SCADA 알람 등급)
+const (
+	severityInfo     = "info"     // 이전 오류 해소 (알람 해제)
+	severityWarning  = "warning"  // 주의 필요, 동작은 계속
+	severityError    = "error"    // 개별 메시지/오더 실패
+	severityCritical = "critical" // 운영자 조치 필요
+)
+
+// errorEvent ERROR_TOPIC에 발행하는 구조화된 오류 이벤트
+type errorEvent struct {
+	Severity      string    `json:"severity"`
+	Source        string    `json:"source"` // 브릿지 이벤트 타입
+	Robot         string    `json:"robot,omitempty"`
+	Command       string    `json:"command,omitempty"`
+	OrderID       string    `json:"orderId,omitempty"`
+	CorrelationID string    `json:"correlationId,omitempty"`
+	Detail        string    `json:"detail,omitempty"` // 이벤트 상태 (토픽, 오류 레벨, 단계 등)
+	Message       string    `json:"message,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// errorPublisher 이벤트 버스의 오류성 이벤트를 ERROR_TOPIC으로 발행
+type errorPublisher struct {
+	client *messaging.MQTTClient
+	topic  string
+}
+
+// errorSeverity 오류 이벤트로 발행할 이벤트의 심각도 (발행 대상이 아니면 false)
+// 발행 실패, 스키마 위반, 로봇 오류, 오더 시간 초과, PLC 하트비트, 처리기 패닉
+func errorSeverity(event events.Event) (string, bool) {
+	switch event.Type {
+	case events.TypeMQTTPublishFailed:
+		return severityError, true
+	case events.TypeRobotMessageInvalid:
+		return severityWarning, true
+	case events.TypeRobotError:
+		if event.Status == types.ErrorLevelFatal {
+			return severityCritical, true
+		}
+		return severityWarning, true
+	case events.TypeOrderEscalated:
+		switch event.Status {
+		case "warn":
+			return severityWarning, true
+		case "cancel":
+			return severityError, true
+		default:
+			return severityCritical, true
+		}
+	case events.TypePLCHeartbeat:
+		if event.Status == "restored" {
+			return severityInfo, true
+		}
+		return severityCritical, true
+	case events.TypeHandlerPanic:
+		return severityCritical, true
+	}
+	return "", false
+}
+
+// Run 이벤트 버스를 구독하여 오류 이벤트 발행 (ctx 종료 시 반환)
+func (p *errorPublisher) Run(ctx context.Context, bus *events.Bus) {
+	eventCh, unsubscribe := bus.Subscribe(256)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			p.publish(event)
+		}
+	}
+}
+
+// publish 오류 이벤트 발행 (오류 토픽 자체의 발행 실패는 반복을 막기 위해 발행하지 않음)
+func (p *errorPublisher) publish(event events.Event) {
+	severity, ok := errorSeverity(event)
+	if !ok {
+		return
+	}
+	if event.Type == events.TypeMQTTPublishFailed && event.Status == p.topic {
+		return
+	}
+
+	payload, err := json.Marshal(errorEvent{
+		Severity:      severity,
+		Source:        event.Type,
+		Robot:         event.Robot,
+		Command:       event.Command,
+		OrderID:       event.OrderID,
+		CorrelationID: event.CorrelationID,
+		Detail:        event.Status,
+		Message:       event.Message,
+		Timestamp:     event.Time,
+	})
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal error event: %v", err)
+		return
+	}
+	if err := p.client.Publish(p.topic, 1, false, payload); err != nil {
+		utils.Logger.Warnf("⚠️ Failed to publish error event: %v", err)
+	}
+}
@@ -3,40 +3,275 @@ package bridge
 
 import (
 	"context"
+	"fmt"
+	"mqtt-bridge/internal/amqp"
+	"mqtt-bridge/internal/api"
+	"mqtt-bridge/internal/audit"
+	"mqtt-bridge/internal/canary"
 	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/enip"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/ha"
+	"mqtt-bridge/internal/history"
+	"mqtt-bridge/internal/hook"
+	"mqtt-bridge/internal/kafka"
 	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/metrics"
+	"mqtt-bridge/internal/modbus"
+	"mqtt-bridge/internal/opcua"
+	"mqtt-bridge/internal/rpc"
+	"mqtt-bridge/internal/s7"
+	"mqtt-bridge/internal/scheduler"
+	"mqtt-bridge/internal/sparkplug"
 	"mqtt-bridge/internal/utils"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/sirupsen/logrus"
 )
 
 // Service 간소화된 브릿지 서비스 (Direct Action 전용)
 type Service struct {
-	config     *config.Config
-	mqttClient *messaging.MQTTClient
-	subscriber *messaging.Subscriber
-	handler    *messaging.DirectActionHandler
+	config      *config.Config
+	mqttClient  *messaging.MQTTClient
+	subscriber  *messaging.Subscriber
+	routes      []config.CommandRoute
+	handlers    []*messaging.DirectActionHandler // 경로별 핸들러 (routes와 같은 순서)
+	eventBus    *events.Bus
+	metrics     *metrics.BridgeMetrics
+	exporters   []metrics.Exporter
+	history     *history.Store
+	auditLog    *audit.Log
+	apiServer   *api.Server
+	grpcServer  *rpc.Server
+	modbus      *modbus.Server
+	opcua       *opcua.Server
+	s7          *s7.Poller
+	enip        *enip.Server
+	sparkplug   *sparkplug.Node
+	kafka       *kafka.Sink
+	amqp        *amqp.Output
+	canary      *canary.Runner
+	scheduler   *scheduler.Runner
+	status      *statusPublisher
+	errorEvents *errorPublisher
+	elector     *ha.Elector
+
+	reloadMu sync.Mutex
 }
 
 // NewService 새 브릿지 서비스 생성
 func NewService(cfg *config.Config) (*Service, error) {
 	utils.Logger.Infof("🏗️ Creating Direct Action Bridge Service")
+	logDeprecatedKeys()
+
+	// MQTT 클라이언트 생성 (Sparkplug B 모드는 NDEATH 유언, 아니면 브릿지 상태 OFFLINE 유언)
+	// MQTT 연결당 유언은 하나이므로 Sparkplug B 모드에서는 상태 토픽 OFFLINE이 정상 종료 시에만 발행됨
+	var clientOptions []messaging.ClientOption
+	bdSeq := sparkplug.NewBdSeq()
+	startedAt := time.Now()
+	if cfg.SparkplugEnabled {
+		clientOptions = append(clientOptions, sparkplug.WillOption(cfg, bdSeq))
+		if cfg.BridgeStatusTopic != "" {
+			utils.Logger.Warnf("⚠️ Sparkplug B uses the MQTT last will - %s will not report OFFLINE on abnormal disconnect", cfg.BridgeStatusTopic)
+		}
+	} else if cfg.BridgeStatusTopic != "" {
+		clientOptions = append(clientOptions, statusWillOption(cfg, startedAt))
+	}
+	mqttClient, err := messaging.NewMQTTClient(cfg, clientOptions...)
+	if err != nil {
+		return nil, err
+	}
+
+	// 감사 로그 열기 (AUDIT_LOG_FILE 비어있으면 비활성화)
+	var auditLog *audit.Log
+	if cfg.AuditLogFile != "" {
+		auditLog, err = audit.Open(cfg.AuditLogFile)
+		if err != nil {
+			return nil, err
+		}
+		mqttClient.SetAuditLog(auditLog)
+		utils.Logger.Infof("📝 MQTT audit log enabled: %s", cfg.AuditLogFile)
+	}
 
-	// MQTT 클라이언트 생성
-	mqttClient, err := messaging.NewMQTTClient(cfg)
+	// 발행 메시지 보관 (OUTBOX_FILE 비어있으면 비활성화, 브로커 연결 끊김 동안 실패 대신 보관)
+	if cfg.OutboxFile != "" {
+		outbox, err := messaging.OpenOutbox(cfg.OutboxFile, cfg.OutboxMaxMessages, cfg.OutboxMaxAge)
+		if err != nil {
+			return nil, err
+		}
+		mqttClient.SetOutbox(outbox)
+		utils.Logger.Infof("📦 MQTT outbox enabled: %s (max %d messages)", cfg.OutboxFile, cfg.OutboxMaxMessages)
+	}
+
+	// 이벤트 버스 생성 (처리기 패닉 이벤트 포함)
+	eventBus := events.NewBus()
+	mqttClient.SetEventBus(eventBus)
+
+	// PLC 명령 경로별 Direct Action 핸들러 생성
+	routes, err := cfg.ParseCommandRoutes()
 	if err != nil {
 		return nil, err
 	}
+	handlers := make([]*messaging.DirectActionHandler, 0, len(routes))
+	commandRoutes := make([]messaging.CommandRoute, 0, len(routes))
+	for _, route := range routes {
+		handler, err := messaging.NewDirectActionHandler(mqttClient, routeConfig(cfg, route), eventBus)
+		if err != nil {
+			return nil, err
+		}
+		handlers = append(handlers, handler)
+		commandRoutes = append(commandRoutes, messaging.CommandRoute{CommandTopic: route.CommandTopic, Handler: handler})
+	}
+	for _, h := range handlers {
+		h.SetFleet(handlers)
+	}
 
-	// Direct Action 핸들러 생성
-	handler := messaging.NewDirectActionHandler(mqttClient, cfg)
+	// 명령/응답 훅 스크립트 (COMMAND_HOOK 비어있으면 비활성화, 모든 핸들러가 공유)
+	if cfg.CommandHook != "" {
+		commandHook, err := hook.NewScript(cfg.CommandHook, cfg.CommandHookTimeout)
+		if err != nil {
+			return nil, err
+		}
+		for _, h := range handlers {
+			h.SetHook(commandHook)
+		}
+	}
 
 	// 구독자 생성
-	subscriber := messaging.NewSubscriber(mqttClient, handler)
+	subscriber := messaging.NewSubscriber(mqttClient, commandRoutes)
 
 	service := &Service{
 		config:     cfg,
 		mqttClient: mqttClient,
 		subscriber: subscriber,
-		handler:    handler,
+		routes:     routes,
+		handlers:   handlers,
+		eventBus:   eventBus,
+		auditLog:   auditLog,
+		metrics:    metrics.NewBridgeMetrics(cfg.MetricsMaxCommandLabels),
+	}
+
+	// HA 리더 선출 (리더로 선출될 때까지 모든 핸들러 대기 모드)
+	if cfg.HAEnabled {
+		service.elector = ha.NewElector(mqttClient, cfg, handlers)
+	}
+
+	// 브릿지 상태 발행 (BRIDGE_STATUS_TOPIC 비어있으면 비활성화)
+	if cfg.BridgeStatusTopic != "" {
+		service.status = &statusPublisher{client: mqttClient, config: cfg, startedAt: startedAt}
+	}
+
+	// 오류 이벤트 발행 (ERROR_TOPIC 비어있으면 비활성화)
+	if cfg.ErrorTopic != "" {
+		service.errorEvents = &errorPublisher{client: mqttClient, topic: cfg.ErrorTopic}
+	}
+
+	// 로봇별 상태 대기열 길이 (수집 시점에 읽음)
+	service.metrics.Registry.NewGaugeFunc("bridge_robot_state_queue_depth",
+		"Robot state messages waiting for the state worker", "robot", func() map[string]float64 {
+			depths := make(map[string]float64, len(handlers))
+			for _, h := range handlers {
+				depths[h.RobotSerialNumber()] = float64(h.StateQueueDepth())
+			}
+			return depths
+		})
+
+	// Push 메트릭 exporter 생성 (선택)
+	exporters, err := newMetricExporters(cfg)
+	if err != nil {
+		return nil, err
+	}
+	service.exporters = exporters
+
+	// 오더 이력 저장소 열기 (HISTORY_DB_PATH 비어있으면 비활성화)
+	if cfg.HistoryDBPath != "" {
+		historyStore, err := history.Open(cfg.HistoryDBDriver, cfg.HistoryDBPath, cfg.HistoryRetention)
+		if err != nil {
+			return nil, err
+		}
+		service.history = historyStore
+		utils.Logger.Infof("🗄️ Order history enabled: %s (retention %s)", cfg.HistoryDBPath, cfg.HistoryRetention)
+
+		// 누적 카운터 복원 (재배포 후에도 장기 KPI 유지)
+		if cfg.MetricsPersistInterval > 0 {
+			if err := service.metrics.Registry.RestoreCounters(historyStore); err != nil {
+				utils.Logger.Warnf("⚠️ Failed to restore persisted counters, starting from zero: %v", err)
+			}
+		}
+	}
+
+	// Canary 실행기 생성 (선택)
+	if cfg.CanaryEnabled {
+		canaryRunner, err := canary.NewRunner(cfg, handlers)
+		if err != nil {
+			return nil, err
+		}
+		service.canary = canaryRunner
+	}
+
+	// 예약 명령 스케줄러 생성 (SCHEDULED_COMMANDS 비어있으면 비활성화)
+	if cfg.ScheduledCommands != "" {
+		schedulerRunner, err := scheduler.NewRunner(cfg, handlers)
+		if err != nil {
+			return nil, err
+		}
+		service.scheduler = schedulerRunner
+	}
+
+	// Modbus TCP 서버 생성 (MODBUS_ADDR 비어있으면 비활성화)
+	if cfg.ModbusAddr != "" {
+		service.modbus = modbus.NewServer(cfg.ModbusAddr, handlers, eventBus)
+	}
+
+	// OPC UA 서버 생성 (OPCUA_ADDR 비어있으면 비활성화, 로봇마다 노드 폴더 하나)
+	if cfg.OPCUAAddr != "" {
+		service.opcua = opcua.NewServer(cfg.OPCUAAddr, handlers, eventBus)
+	}
+
+	// Kafka 싱크 생성 (KAFKA_REST_URL 비어있으면 비활성화)
+	if cfg.KafkaRestURL != "" {
+		service.kafka = kafka.NewSink(cfg, handlers, eventBus)
+	}
+
+	// AMQP 출력 생성 (AMQP_URL 비어있으면 비활성화)
+	if cfg.AMQPURL != "" {
+		service.amqp = amqp.NewOutput(cfg, eventBus)
+	}
+
+	// Sparkplug B 노드 생성
+	if cfg.SparkplugEnabled {
+		service.sparkplug = sparkplug.NewNode(mqttClient, cfg, handlers, eventBus, bdSeq)
+	}
+
+	// S7 폴러 생성 (S7_ADDR 비어있으면 비활성화)
+	if cfg.S7Addr != "" {
+		s7Handler, err := selectHandler(handlers, "S7_ROBOT", cfg.S7Robot)
+		if err != nil {
+			return nil, err
+		}
+		service.s7 = s7.NewPoller(cfg, s7Handler, eventBus)
+	}
+
+	// EtherNet/IP 어댑터 생성 (ENIP_ADDR 비어있으면 비활성화)
+	if cfg.ENIPAddr != "" {
+		enipHandler, err := selectHandler(handlers, "ENIP_ROBOT", cfg.ENIPRobot)
+		if err != nil {
+			return nil, err
+		}
+		service.enip = enip.NewServer(cfg.ENIPAddr, enipHandler, eventBus)
+	}
+
+	// HTTP 서버 생성 (HTTP_ADDR 비어있으면 비활성화)
+	if cfg.HTTPAddr != "" {
+		service.apiServer = api.NewServer(cfg, mqttClient, subscriber, handlers, eventBus, service.metrics.Registry, service.history)
+	}
+
+	// gRPC 서버 생성 (GRPC_ADDR 비어있으면 비활성화, HTTP 명령 게이트웨이와 같은 첫 번째 경로)
+	if cfg.GRPCAddr != "" {
+		service.grpcServer = rpc.NewServer(cfg, handlers)
 	}
 
 	utils.Logger.Infof("✅ Direct Action Bridge Service Created")
@@ -47,10 +282,133 @@ func NewService(cfg *config.Config) (*Service, error) {
 func (s *Service) Start(ctx context.Context) error {
 	utils.Logger.Infof("🚀 Starting Direct Action Bridge Service")
 
+	go s.metrics.Run(ctx, s.eventBus)
+	if s.errorEvents != nil {
+		go s.errorEvents.Run(ctx, s.eventBus)
+	}
+	for _, exporter := range s.exporters {
+		go metrics.RunExporter(ctx, s.metrics.Registry, exporter, s.config.MetricsPushInterval)
+	}
+
+	if s.history != nil {
+		go s.history.Run(ctx, s.eventBus)
+		if s.config.MetricsPersistInterval > 0 {
+			go metrics.RunCounterPersistence(ctx, s.metrics.Registry, s.history, s.config.MetricsPersistInterval)
+		}
+	}
+
+	if s.canary != nil {
+		go s.canary.Run(ctx)
+	}
+
+	if s.scheduler != nil {
+		go s.scheduler.Run(ctx)
+	}
+
+	if s.kafka != nil {
+		go s.kafka.Run(ctx)
+	}
+
+	if s.amqp != nil {
+		go s.amqp.Run(ctx)
+	}
+
+	if s.apiServer != nil {
+		s.apiServer.Start()
+	}
+
+	if s.grpcServer != nil {
+		if err := s.grpcServer.Start(); err != nil {
+			return fmt.Errorf("failed to start gRPC server: %v", err)
+		}
+	}
+
+	if s.modbus != nil {
+		if err := s.modbus.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start Modbus TCP server: %v", err)
+		}
+	}
+
+	if s.opcua != nil {
+		if err := s.opcua.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start OPC UA server: %v", err)
+		}
+	}
+
+	if s.s7 != nil {
+		go s.s7.Run(ctx)
+	}
+
+	if s.enip != nil {
+		if err := s.enip.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start EtherNet/IP adapter: %v", err)
+		}
+	}
+
+	// 로봇 전송 계층 (rosbridge 등, 시작 게이트 대기 전에 연결)
+	for _, handler := range s.handlers {
+		go handler.RunRobotTransport(ctx)
+		go handler.RunEscalation(ctx)
+		go handler.RunStateWorker(ctx)
+		if s.config.PLCHeartbeatTopic != "" {
+			go handler.RunPLCHeartbeatWatchdog(ctx)
+		}
+	}
+
+	if s.status != nil {
+		s.status.Start(ctx)
+	}
+
+	// NBIRTH는 시작 게이트 대기 전에 발행 (호스트가 노드를 먼저 인식하도록)
+	if s.sparkplug != nil {
+		if err := s.sparkplug.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start Sparkplug B node: %v", err)
+		}
+	}
+
 	if err := s.subscriber.SubscribeAll(); err != nil {
 		return err
 	}
 
+	// 명령 구독 이후 선출 시작 (리더가 되기 전 수신 명령은 대기 모드에서 무시)
+	if s.elector != nil {
+		if err := s.elector.Start(ctx); err != nil {
+			return fmt.Errorf("failed to start HA leader election: %v", err)
+		}
+	}
+
+	// 설정 재로드 토픽 구독 (실패해도 SIGHUP으로 재로드 가능)
+	if s.config.ReloadTopic != "" {
+		if err := s.mqttClient.Subscribe(s.config.ReloadTopic, 0, s.handleReloadRequest); err != nil {
+			utils.Logger.Warnf("⚠️ Reload topic subscription failed, SIGHUP reload still available: %v", err)
+		}
+	}
+
+	// 관리 토픽 구독 (재연결 후에도 원격 관리가 가능하도록 다시 구독)
+	if s.config.AdminTopic != "" {
+		if err := s.mqttClient.Subscribe(s.config.AdminTopic, 1, s.handleAdminRequest); err != nil {
+			utils.Logger.Warnf("⚠️ Admin topic subscription failed: %v", err)
+		}
+		s.mqttClient.OnConnect(func() {
+			if err := s.mqttClient.Subscribe(s.config.AdminTopic, 1, s.handleAdminRequest); err != nil {
+				utils.Logger.Errorf("❌ Admin topic resubscription failed: %v", err)
+			}
+		})
+	}
+
+	// PLC 하트비트 구독 (재연결 후에도 감시가 계속되도록 다시 구독)
+	if s.config.PLCHeartbeatTopic != "" {
+		if err := s.mqttClient.Subscribe(s.config.PLCHeartbeatTopic, 0, s.handlePLCHeartbeat); err != nil {
+			return fmt.Errorf("failed to subscribe to PLC heartbeat topic: %v", err)
+		}
+		s.mqttClient.OnConnect(func() {
+			if err := s.mqttClient.Subscribe(s.config.PLCHeartbeatTopic, 0, s.handlePLCHeartbeat); err != nil {
+				utils.Logger.Errorf("❌ PLC heartbeat resubscription failed: %v", err)
+			}
+		})
+		utils.Logger.Infof("💓 PLC heartbeat watchdog enabled: %s (timeout %s)", s.config.PLCHeartbeatTopic, s.config.PLCHeartbeatTimeout)
+	}
+
 	go func() {
 		<-ctx.Done()
 		utils.Logger.Info("Context cancelled, stopping bridge service")
@@ -62,6 +420,161 @@ func (s *Service) Start(ctx context.Context) error {
 // Stop 브릿지 서비스 중지
 func (s *Service) Stop() {
 	utils.Logger.Info("🛑 Stopping Direct Action Bridge Service")
+	if s.apiServer != nil {
+		s.apiServer.Stop()
+	}
+	if s.grpcServer != nil {
+		s.grpcServer.Stop()
+	}
+	if s.modbus != nil {
+		s.modbus.Stop()
+	}
+	if s.opcua != nil {
+		s.opcua.Stop()
+	}
+	if s.enip != nil {
+		s.enip.Stop()
+	}
+	if s.sparkplug != nil {
+		s.sparkplug.Stop()
+	}
+	if s.elector != nil {
+		s.elector.Stop()
+	}
+	if s.status != nil {
+		s.status.Stop()
+	}
 	s.mqttClient.Disconnect(250)
+	if err := s.auditLog.Close(); err != nil {
+		utils.Logger.Errorf("❌ Failed to close audit log: %v", err)
+	}
+	if s.history != nil {
+		if s.config.MetricsPersistInterval > 0 {
+			if err := s.metrics.Registry.SaveCounters(s.history); err != nil {
+				utils.Logger.Errorf("❌ Failed to persist counters: %v", err)
+			}
+		}
+		if err := s.history.Close(); err != nil {
+			utils.Logger.Errorf("❌ Failed to close history database: %v", err)
+		}
+	}
 	utils.Logger.Info("✅ Direct Action Bridge Service Stopped")
 }
+
+// Reload 설정 재로드 후 런타임 적용 가능한 항목 반영 (로그 레벨, 응답 토픽, 타임아웃 등)
+// MQTT 연결과 활성 오더는 유지되며, 재시작이 필요한 변경은 경고만 출력
+func (s *Service) Reload() error {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	utils.Logger.Info("🔄 Reloading configuration")
+
+	next, err := config.Reload()
+	if err != nil {
+		return err
+	}
+	if err := next.Validate(); err != nil {
+		return err
+	}
+
+	logDeprecatedKeys()
+
+	if changed := s.config.RestartRequired(next); len(changed) > 0 {
+		utils.Logger.Warnf("⚠️ Changes require restart and were not applied: %s", strings.Join(changed, ", "))
+	}
+
+	utils.SetupLogger(next.LogLevel)
+	utils.SetPayloadLogging(next.LogPayloadLimit, next.LogSuppressTopicFilters())
+
+	// 경로 구성 변경은 재시작 필요, 같으면 기본 응답 토픽 변경 등을 반영해 다시 해석
+	routes := s.routes
+	if next.CommandRoutes == s.config.CommandRoutes {
+		if routes, err = next.ParseCommandRoutes(); err != nil {
+			return err
+		}
+	}
+	for i, handler := range s.handlers {
+		handler.ApplyConfig(routeConfig(next, routes[i]))
+	}
+
+	utils.Logger.Info("✅ Configuration reloaded")
+	return nil
+}
+
+// handlePLCHeartbeat PLC 하트비트 수신 (페이로드 무관, 모든 로봇 핸들러에 기록)
+// retained 메시지는 PLC가 살아있다는 증거가 아니므로 무시
+func (s *Service) handlePLCHeartbeat(client mqtt.Client, msg mqtt.Message) {
+	if msg.Retained() {
+		return
+	}
+	for _, handler := range s.handlers {
+		handler.RecordPLCHeartbeat()
+	}
+}
+
+// handleReloadRequest 재로드 토픽 메시지 처리 (콜백 블로킹 방지를 위해 비동기 실행)
+func (s *Service) handleReloadRequest(client mqtt.Client, msg mqtt.Message) {
+	utils.Logger.Infof("📨 Reload requested via %s", msg.Topic())
+
+	go func() {
+		if err := s.Reload(); err != nil {
+			utils.Logger.Errorf("❌ Configuration reload failed: %v", err)
+		}
+	}()
+}
+
+// logDeprecatedKeys 사용 중인 지원 중단 설정 키 경고 (키와 대체 키를 필드로 기록)
+func logDeprecatedKeys() {
+	for _, deprecated := range config.DeprecatedUsage() {
+		utils.Logger.WithFields(logrus.Fields{
+			"key":         deprecated.Key,
+			"replacement": deprecated.Replacement,
+			"since":       deprecated.Since,
+			"applied":     deprecated.Applied,
+		}).Warnf("⚠️ Deprecated configuration key %s: use %s instead (%s)", deprecated.Key, deprecated.Replacement, deprecated.Note)
+	}
+}
+
+// routeConfig 경로 핸들러 설정 (COMMAND_ROUTES 미사용이고 응답 토픽 치환이 없으면 공통 설정 그대로)
+func routeConfig(cfg *config.Config, route config.CommandRoute) *config.Config {
+	if cfg.CommandRoutes == "" && cfg.ExpandResponseTopic(cfg.PlcResponseTopic, route.RobotSerialNumber) == cfg.PlcResponseTopic {
+		return cfg
+	}
+	return cfg.ForRoute(route)
+}
+
+// selectHandler 단일 로봇 PLC 전송 계층의 대상 핸들러 (robot 비어있으면 첫 번째 명령 경로)
+func selectHandler(handlers []*messaging.DirectActionHandler, key, robot string) (*messaging.DirectActionHandler, error) {
+	if robot == "" {
+		return handlers[0], nil
+	}
+	for _, handler := range handlers {
+		if handler.RobotSerialNumber() == robot {
+			return handler, nil
+		}
+	}
+	return nil, fmt.Errorf("%s %q has no command route", key, robot)
+}
+
+// newMetricExporters 설정된 push 메트릭 exporter 생성 (METRICS_EXPORTERS=statsd,otlp)
+func newMetricExporters(cfg *config.Config) ([]metrics.Exporter, error) {
+	var exporters []metrics.Exporter
+
+	for _, name := range strings.Split(cfg.MetricsExporters, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+			continue
+		case "statsd":
+			exporter, err := metrics.NewStatsDExporter(cfg.MetricsStatsDAddr, cfg.MetricsStatsDPrefix, cfg.MetricsStatsDDatadogTags)
+			if err != nil {
+				return nil, err
+			}
+			exporters = append(exporters, exporter)
+		case "otlp":
+			exporters = append(exporters, metrics.NewOTLPExporter(cfg.MetricsOTLPEndpoint, cfg.MQTTClientID))
+		default:
+			return nil, fmt.Errorf("unknown metrics exporter: %s", name)
+		}
+	}
+	return exporters, nil
+}
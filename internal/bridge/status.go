@@ -0,0 +1,114 @@
+// internal/bridge/status.go - Bridge Last-Will and Status Topic
+package bridge
+
+import (
+	"context"
+	"encoding/json"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/support"
+	"mqtt-bridge/internal/utils"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// 브릿지 상태 값
+const (
+	bridgeStatusOnline  = "ONLINE"
+	bridgeStatusOffline = "OFFLINE"
+)
+
+// bridgeStatus BRIDGE_STATUS_TOPIC에 retained로 발행하는 브릿지 상태
+type bridgeStatus struct {
+	Status        string    `json:"status"` // ONLINE, OFFLINE
+	Version       string    `json:"version"`
+	Revision      string    `json:"revision,omitempty"`
+	Hostname      string    `json:"hostname"`
+	ClientID      string    `json:"clientId"`
+	StartedAt     time.Time `json:"startedAt"`
+	UptimeSeconds int64     `json:"uptimeSeconds"`
+	Timestamp     time.Time `json:"timestamp"`
+	Graceful      bool      `json:"graceful,omitempty"` // 정상 종료 (유언이면 false)
+}
+
+// statusPublisher 브릿지 상태 발행 (연결/재연결 시 ONLINE, 정상 종료 시 OFFLINE, 비정상 종료는 유언으로 OFFLINE)
+type statusPublisher struct {
+	client    *messaging.MQTTClient
+	config    *config.Config
+	startedAt time.Time
+}
+
+// newBridgeStatus 현재 브릿지 상태
+func newBridgeStatus(cfg *config.Config, status string, startedAt time.Time) bridgeStatus {
+	version := support.CurrentVersion()
+	now := time.Now()
+	return bridgeStatus{
+		Status:        status,
+		Version:       version.Version,
+		Revision:      version.Revision,
+		Hostname:      version.Hostname,
+		ClientID:      cfg.MQTTClientID,
+		StartedAt:     startedAt,
+		UptimeSeconds: int64(now.Sub(startedAt).Seconds()),
+		Timestamp:     now,
+	}
+}
+
+// statusWillOption OFFLINE 유언 설정 (MQTT 연결 전에 적용, 브로커가 비정상 연결 끊김 시 retained로 발행)
+func statusWillOption(cfg *config.Config, startedAt time.Time) messaging.ClientOption {
+	return func(opts *mqtt.ClientOptions) {
+		payload, err := json.Marshal(newBridgeStatus(cfg, bridgeStatusOffline, startedAt))
+		if err != nil {
+			utils.Logger.Errorf("❌ Failed to marshal bridge status will: %v", err)
+			return
+		}
+		opts.SetBinaryWill(cfg.BridgeStatusTopic, payload, 1, true)
+	}
+}
+
+// Start ONLINE 발행, 재연결 시 재발행 등록, BRIDGE_STATUS_INTERVAL마다 uptime 갱신
+func (p *statusPublisher) Start(ctx context.Context) {
+	p.client.OnConnect(func() {
+		p.publish(bridgeStatusOnline)
+	})
+	p.publish(bridgeStatusOnline)
+
+	if p.config.BridgeStatusInterval <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(p.config.BridgeStatusInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if p.client.IsConnected() {
+					p.publish(bridgeStatusOnline)
+				}
+			}
+		}
+	}()
+}
+
+// Stop OFFLINE 발행 (정상 종료 시 유언 대신 직접 발행)
+func (p *statusPublisher) Stop() {
+	p.publish(bridgeStatusOffline)
+}
+
+// publish 상태 retained 발행
+func (p *statusPublisher) publish(status string) {
+	message := newBridgeStatus(p.config, status, p.startedAt)
+	message.Graceful = status == bridgeStatusOffline
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal bridge status: %v", err)
+		return
+	}
+	if err := p.client.Publish(p.config.BridgeStatusTopic, 1, true, payload); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish bridge status %s: %v", status, err)
+	}
+}
@@ -0,0 +1,148 @@
+// internal/canary/canary.go - Scheduled Canary Verification
+package canary
+
+import (
+	"context"
+	"fmt"
+	"mqtt-bridge/internal/alert"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"sync"
+	"time"
+)
+
+// log canary 컴포넌트 로거
+var log = utils.Component("canary")
+
+// Runner 주기적으로 무해한 canary를 실행하여 브로커-브릿지-로봇 전체 경로 검증 (명령 경로의 모든 로봇)
+type Runner struct {
+	config   *config.Config
+	handlers []*messaging.DirectActionHandler
+	webhook  *alert.Webhook
+	window   *Window
+}
+
+// NewRunner 새 canary 실행기 생성
+func NewRunner(cfg *config.Config, handlers []*messaging.DirectActionHandler) (*Runner, error) {
+	window, err := ParseWindow(cfg.CanaryWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		config:   cfg,
+		handlers: handlers,
+		webhook:  alert.NewWebhook(cfg.CanaryWebhookURL),
+		window:   window,
+	}, nil
+}
+
+// Run 설정된 주기로 canary 실행 (ctx 종료 시 반환)
+func (r *Runner) Run(ctx context.Context) {
+	log.Infof("Canary scheduled every %s for %d robot(s) (window: %s)", r.config.CanaryInterval, len(r.handlers), r.window)
+
+	ticker := time.NewTicker(r.config.CanaryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if !r.window.Contains(now) {
+				continue
+			}
+			r.runAll(ctx)
+		}
+	}
+}
+
+// runAll 로봇별 canary 동시 실행 (로봇마다 CANARY_TIMEOUT, 모두 끝날 때까지 대기)
+func (r *Runner) runAll(ctx context.Context) {
+	var wg sync.WaitGroup
+	for _, handler := range r.handlers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.runOnce(ctx, handler)
+		}()
+	}
+	wg.Wait()
+}
+
+// runOnce 로봇 하나의 canary 1회 실행 및 실패 시 웹훅 알림
+func (r *Runner) runOnce(ctx context.Context, handler *messaging.DirectActionHandler) {
+	runCtx, cancel := context.WithTimeout(ctx, r.config.CanaryTimeout)
+	defer cancel()
+
+	var err error
+	if r.config.CanaryCommand == "" {
+		err = r.checkStateRequest(runCtx, handler)
+	} else {
+		err = r.checkCommand(runCtx, handler)
+	}
+
+	robot := handler.RobotSerialNumber()
+	if err == nil {
+		log.Infof("Canary passed for robot %s", robot)
+		return
+	}
+
+	log.Errorf("Canary failed for robot %s: %v", robot, err)
+	alertErr := r.webhook.Send(ctx, alert.Alert{
+		Source:   "canary",
+		Severity: alert.SeverityCritical,
+		Robot:    robot,
+		Summary:  "Bridge canary verification failed",
+		Detail:   err.Error(),
+	})
+	if alertErr != nil {
+		log.Errorf("Failed to send canary alert: %v", alertErr)
+	}
+}
+
+// checkStateRequest stateRequest 전송 후 새 상태 메시지 수신 확인
+func (r *Runner) checkStateRequest(ctx context.Context, handler *messaging.DirectActionHandler) error {
+	sentAt := time.Now()
+	if err := handler.SendStateRequest(); err != nil {
+		return fmt.Errorf("failed to send stateRequest: %v", err)
+	}
+
+	poll := time.NewTicker(500 * time.Millisecond)
+	defer poll.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("no robot state received within %s after stateRequest", r.config.CanaryTimeout)
+		case <-poll.C:
+			if handler.LastStateAt().After(sentAt) {
+				return nil
+			}
+		}
+	}
+}
+
+// checkCommand 설정된 안전 명령 실행 후 성공(S) 확인
+func (r *Runner) checkCommand(ctx context.Context, handler *messaging.DirectActionHandler) error {
+	result := handler.ProcessCommand(r.config.CanaryCommand)
+	if !result.Accepted {
+		return fmt.Errorf("canary command %s rejected: %s", r.config.CanaryCommand, result.Reason)
+	}
+
+	var finalStatus string
+	err := handler.WatchOrder(ctx, result.OrderID, func(event events.Event) error {
+		finalStatus = event.Status
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("canary command %s did not finish (last status %q): %v", r.config.CanaryCommand, finalStatus, err)
+	}
+	if finalStatus != types.PLCStatusSuccess {
+		return fmt.Errorf("canary command %s finished with status %s", r.config.CanaryCommand, finalStatus)
+	}
+	return nil
+}
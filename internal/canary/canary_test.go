@@ -0,0 +1,49 @@
+package canary
+
+import (
+	"context"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/harness"
+	"mqtt-bridge/internal/messaging"
+	"testing"
+	"time"
+)
+
+func TestCheckCommand(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	tests := []struct {
+		outcome harness.Outcome
+		wantErr bool
+	}{
+		{harness.OutcomeSucceed, false},
+		{harness.OutcomeFail, true},
+		{harness.OutcomeHold, true},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.outcome), func(t *testing.T) {
+			h, err := harness.New(cfg, time.Millisecond)
+			if err != nil {
+				t.Fatalf("harness: %v", err)
+			}
+			defer h.Close()
+			h.Robot.SetOutcome(tt.outcome)
+
+			runnerCfg := *h.Config
+			runnerCfg.CanaryCommand = "CAL:I"
+			runner, err := NewRunner(&runnerCfg, []*messaging.DirectActionHandler{h.Handler})
+			if err != nil {
+				t.Fatalf("NewRunner: %v", err)
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			if err := runner.checkCommand(ctx, h.Handler); (err != nil) != tt.wantErr {
+				t.Errorf("checkCommand error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
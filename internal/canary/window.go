@@ -0,0 +1,52 @@
+// internal/canary/window.go - Daily Time Window (e.g. off-shift 22:00-06:00)
+package canary
+
+import (
+	"fmt"
+	"time"
+)
+
+// Window 하루 중 실행 허용 시간대 (자정을 넘는 범위 지원)
+type Window struct {
+	start time.Duration // 자정 기준 시작 시각
+	end   time.Duration // 자정 기준 종료 시각
+}
+
+// ParseWindow "HH:MM-HH:MM" 형식 파싱 (빈 문자열이면 nil = 항상 허용)
+func ParseWindow(value string) (*Window, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var startHour, startMinute, endHour, endMinute int
+	if _, err := fmt.Sscanf(value, "%d:%d-%d:%d", &startHour, &startMinute, &endHour, &endMinute); err != nil {
+		return nil, fmt.Errorf("invalid canary window %q (expected HH:MM-HH:MM): %v", value, err)
+	}
+
+	return &Window{
+		start: time.Duration(startHour)*time.Hour + time.Duration(startMinute)*time.Minute,
+		end:   time.Duration(endHour)*time.Hour + time.Duration(endMinute)*time.Minute,
+	}, nil
+}
+
+// Contains 주어진 시각이 시간대에 포함되는지 확인
+func (w *Window) Contains(t time.Time) bool {
+	if w == nil {
+		return true
+	}
+
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	if w.start <= w.end {
+		return offset >= w.start && offset < w.end
+	}
+	return offset >= w.start || offset < w.end
+}
+
+// String 시간대 문자열 표현
+func (w *Window) String() string {
+	if w == nil {
+		return "always"
+	}
+	return fmt.Sprintf("%02d:%02d-%02d:%02d",
+		int(w.start.Hours()), int(w.start.Minutes())%60, int(w.end.Hours()), int(w.end.Minutes())%60)
+}
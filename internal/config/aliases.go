@@ -0,0 +1,38 @@
+// internal/config/aliases.go - PLC Command Aliases
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseCommandAliases COMMAND_ALIASES 해석 (PLC 기본 명령 -> 실제 Direct Action 명령)
+// 형식: <alias>=<base>:<type>[:<arm>];... (예: HOME=go_home_trajectory:T:R)
+// PLC는 짧은 이름을 계속 사용하고 로봇 쪽 궤적/추론 이름이 바뀌면 이 표만 수정
+func (c *Config) ParseCommandAliases() (map[string]string, error) {
+	aliases := make(map[string]string)
+	for _, entry := range strings.Split(c.CommandAliases, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		alias, target, found := strings.Cut(entry, "=")
+		alias, target = strings.TrimSpace(alias), strings.TrimSpace(target)
+		if !found || alias == "" || target == "" {
+			return nil, fmt.Errorf("alias %q: expected <alias>=<base>:<type>[:<arm>]", entry)
+		}
+		if strings.Contains(alias, ":") {
+			return nil, fmt.Errorf("alias %q: name must not contain ':'", alias)
+		}
+		if _, duplicate := aliases[alias]; duplicate {
+			return nil, fmt.Errorf("alias %q: defined more than once", alias)
+		}
+		parts := strings.Split(target, ":")
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("alias %q: target %q must be a direct action command (<base>:<type>[:<arm>])", alias, target)
+		}
+		aliases[alias] = target
+	}
+	return aliases, nil
+}
@@ -0,0 +1,19 @@
+// internal/config/allowlist.go - Action Name Allowlist
+package config
+
+import "strings"
+
+// ParseNameList 쉼표로 구분된 이름 목록 해석 (비어있으면 nil = 제한 없음)
+func ParseNameList(list string) map[string]bool {
+	var names map[string]bool
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name == "" {
+			continue
+		}
+		if names == nil {
+			names = make(map[string]bool)
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,31 @@
+package config
+
+import "testing"
+
+func TestOptionalTopicsCanBeDisabled(t *testing.T) {
+	tests := []struct {
+		key   string
+		value func(*Config) string
+		def   string
+	}{
+		{"ADMIN_TOPIC", func(c *Config) string { return c.AdminTopic }, "bridge/admin"},
+		{"RELOAD_TOPIC", func(c *Config) string { return c.ReloadTopic }, "bridge/control/reload"},
+		{"BRIDGE_STATUS_TOPIC", func(c *Config) string { return c.BridgeStatusTopic }, "bridge/status"},
+		{"COMMAND_DEAD_LETTER_TOPIC", func(c *Config) string { return c.CommandDeadLetterTopic }, "bridge/deadletter"},
+		{"ERROR_TOPIC", func(c *Config) string { return c.ErrorTopic }, "bridge/errors"},
+		{"AMQP_QUEUE", func(c *Config) string { return c.AMQPQueue }, "bridge.events"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key+"/unset", func(t *testing.T) {
+			if got := tt.value(fromEnv()); got != tt.def {
+				t.Errorf("%s unset = %q, want default %q", tt.key, got, tt.def)
+			}
+		})
+		t.Run(tt.key+"/empty", func(t *testing.T) {
+			t.Setenv(tt.key, "")
+			if got := tt.value(fromEnv()); got != "" {
+				t.Errorf("%s empty = %q, want disabled", tt.key, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// internal/config/deprecation.go - Deprecated Configuration Keys
+package config
+
+import (
+	"os"
+	"sync"
+)
+
+// deprecation 더 이상 사용하지 않는 설정 키
+// rename이면 새 키가 비어있을 때 이전 키 값을 새 키로 옮겨 그대로 동작
+type deprecation struct {
+	key         string
+	replacement string
+	since       string
+	note        string
+	rename      bool
+}
+
+// deprecations 지원 중단 키 목록 (이름 변경 시 여기에 추가하고 최소 한 개 릴리스 동안 유지)
+var deprecations = []deprecation{
+	{
+		key:         "MQTT_PORT",
+		replacement: "MQTT_BROKER",
+		since:       "1.0",
+		note:        "ignored - include the port in MQTT_BROKER (e.g. tcp://broker:1883)",
+	},
+}
+
+// DeprecatedKey 사용 중인 지원 중단 키 (관리자 API, 시작 경고)
+type DeprecatedKey struct {
+	Key         string `json:"key"`
+	Replacement string `json:"replacement"`
+	Since       string `json:"since"`
+	Note        string `json:"note,omitempty"`
+	Applied     bool   `json:"applied"` // 값을 새 키로 옮겨 적용했는지 (false면 무시됨)
+}
+
+var (
+	deprecatedMu    sync.Mutex
+	deprecatedInUse []DeprecatedKey
+	migratedValues  = make(map[string]string) // 새 키 -> 이전 키에서 옮긴 값 (재로드 시 직접 설정과 구분)
+)
+
+// applyDeprecations 설정된 지원 중단 키 확인 및 이름 변경 키 값 이전 (환경 변수 해석 전에 호출)
+// 새 키가 이미 설정되어 있으면 새 키 우선
+func applyDeprecations() {
+	deprecatedMu.Lock()
+	defer deprecatedMu.Unlock()
+
+	var inUse []DeprecatedKey
+	for _, d := range deprecations {
+		value, exists := os.LookupEnv(d.key)
+		if !exists || value == "" {
+			continue
+		}
+
+		used := DeprecatedKey{
+			Key:         d.key,
+			Replacement: d.replacement,
+			Since:       d.since,
+			Note:        d.note,
+		}
+		if d.rename {
+			current := os.Getenv(d.replacement)
+			if migrated, exists := migratedValues[d.replacement]; current == "" || (exists && current == migrated) {
+				os.Setenv(d.replacement, value)
+				migratedValues[d.replacement] = value
+				used.Applied = true
+			} else {
+				used.Note = "ignored - " + d.replacement + " is also set and takes precedence"
+			}
+		}
+		inUse = append(inUse, used)
+	}
+
+	deprecatedInUse = inUse
+}
+
+// DeprecatedUsage 마지막 설정 로드에서 사용된 지원 중단 키
+func DeprecatedUsage() []DeprecatedKey {
+	deprecatedMu.Lock()
+	defer deprecatedMu.Unlock()
+	return append([]DeprecatedKey(nil), deprecatedInUse...)
+}
@@ -0,0 +1,76 @@
+// internal/config/escalation.go - Order Timeout Escalation Policies
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// EscalationPolicy 오더 경과 시간별 단계 (0이면 해당 단계 없음)
+type EscalationPolicy struct {
+	WarnAfter      time.Duration // 경고 이벤트/웹훅
+	CancelAfter    time.Duration // cancelOrder 자동 전송
+	UnhealthyAfter time.Duration // 로봇 비정상 표시 (readiness 실패)
+}
+
+// Enabled 단계가 하나라도 설정되어 있는지 확인
+func (p EscalationPolicy) Enabled() bool {
+	return p.WarnAfter > 0 || p.CancelAfter > 0 || p.UnhealthyAfter > 0
+}
+
+// EscalationPolicies 기본 정책과 기본 명령별 정책
+type EscalationPolicies struct {
+	Default   EscalationPolicy
+	Overrides map[string]EscalationPolicy // baseCommand -> policy
+}
+
+// For 기본 명령에 적용할 정책
+func (p *EscalationPolicies) For(baseCommand string) EscalationPolicy {
+	if policy, exists := p.Overrides[baseCommand]; exists {
+		return policy
+	}
+	return p.Default
+}
+
+// ParseEscalationPolicies ESCALATION_* 설정 해석
+// ESCALATION_POLICIES 형식: <baseCommand>=<warn>/<cancel>/<unhealthy>;... (0은 단계 생략, 예: PICK01=30s/2m/0)
+func (c *Config) ParseEscalationPolicies() (*EscalationPolicies, error) {
+	policies := &EscalationPolicies{
+		Default: EscalationPolicy{
+			WarnAfter:      c.EscalationWarnAfter,
+			CancelAfter:    c.EscalationCancelAfter,
+			UnhealthyAfter: c.EscalationUnhealthyAfter,
+		},
+		Overrides: make(map[string]EscalationPolicy),
+	}
+
+	for _, entry := range strings.Split(c.EscalationPolicies, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		baseCommand, stages, found := strings.Cut(entry, "=")
+		parts := strings.Split(stages, "/")
+		if !found || strings.TrimSpace(baseCommand) == "" || len(parts) != 3 {
+			return nil, fmt.Errorf("policy %q: expected <baseCommand>=<warn>/<cancel>/<unhealthy>", entry)
+		}
+
+		var durations [3]time.Duration
+		for i, part := range parts {
+			duration, err := time.ParseDuration(strings.TrimSpace(part))
+			if err != nil || duration < 0 {
+				return nil, fmt.Errorf("policy %q: invalid duration %q", entry, part)
+			}
+			durations[i] = duration
+		}
+
+		policies.Overrides[strings.TrimSpace(baseCommand)] = EscalationPolicy{
+			WarnAfter:      durations[0],
+			CancelAfter:    durations[1],
+			UnhealthyAfter: durations[2],
+		}
+	}
+	return policies, nil
+}
@@ -0,0 +1,200 @@
+// internal/config/mapping.go - PLC Command Mapping File
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// PrioritySeparator PLC 명령 우선순위 구분자 (예: PICKUP:T:R@STATION_3!5, 명령 맨 끝)
+const PrioritySeparator = "!"
+
+// 파이프라인 액션 배치
+const (
+	PipelineLayoutNode       = "node"       // 한 노드에 여러 액션 (순차 실행, HARD 블로킹)
+	PipelineLayoutSequential = "sequential" // 액션마다 노드 하나, 노드 사이 엣지로 연결
+)
+
+// CommandMapping 매핑 파일 내용 (COMMAND_MAPPING_FILE)
+//
+//	{
+//	  "commands": {
+//	    "PICK_PART:P": {
+//	      "layout": "node",
+//	      "priority": 5,
+//	      "steps": [
+//	        {"type": "I", "name": "DETECT_PART"},
+//	        {"type": "T", "name": "PICK_PART", "arm": "R"}
+//	      ]
+//	    }
+//	  },
+//	  "chains": {
+//	    "RECOVER:W": {
+//	      "steps": [
+//	        {"command": "RESET_GRIPPER:I", "onFailure": "HOME:T:B"},
+//	        {"command": "HOME:T:B"}
+//	      ]
+//	    }
+//	  }
+//	}
+type CommandMapping struct {
+	Commands map[string]MappedCommand `json:"commands"` // PLC 명령 -> 파이프라인
+	Chains   map[string]MappedChain   `json:"chains"`   // PLC 명령 -> 조건부 명령 체인
+}
+
+// MappedCommand PLC 명령 하나가 확장되는 액션 파이프라인
+type MappedCommand struct {
+	Layout   string       `json:"layout"`   // node (기본값), sequential
+	Priority int          `json:"priority"` // 명령에 !N이 없을 때 우선순위 (기본 0)
+	Steps    []MappedStep `json:"steps"`
+}
+
+// MappedStep 파이프라인 단계 (type/name 축약형 또는 actionType/parameters 직접 지정)
+// type W는 PLC 해제 명령(BASE:G)까지 로봇이 대기하는 waitForTrigger 단계 (name은 트리거 이름, 선택)
+type MappedStep struct {
+	Type         string            `json:"type,omitempty"` // I, T, W 또는 등록된 명령 타입
+	Name         string            `json:"name,omitempty"` // 추론/궤적 이름
+	Arm          string            `json:"arm,omitempty"`  // R, L, B (양팔, T 전용)
+	ActionType   string            `json:"actionType,omitempty"`
+	Parameters   []MappedParameter `json:"parameters,omitempty"`
+	BlockingType string            `json:"blockingType,omitempty"` // NONE, SOFT, HARD (비어있으면 기본 규칙)
+}
+
+// MappedChain 단계별 오더를 순서대로 실행하는 명령 체인
+// 단계가 성공(S)하면 다음 단계, 실패하면 onFailure 복구 명령을 실행하고 체인을 실패(F)로 종료
+type MappedChain struct {
+	Steps []ChainStep `json:"steps"`
+}
+
+// ChainStep 체인 단계 (PLC 명령과 같은 문법)
+type ChainStep struct {
+	Command   string `json:"command"`
+	OnFailure string `json:"onFailure,omitempty"` // 실패 시 실행할 복구 명령 (결과와 무관하게 체인은 실패)
+}
+
+// MappedParameter 직접 지정한 액션 파라미터
+type MappedParameter struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// Lookup PLC 명령에 매핑된 파이프라인 검색
+func (m *CommandMapping) Lookup(command string) (MappedCommand, bool) {
+	if m == nil {
+		return MappedCommand{}, false
+	}
+	mapped, exists := m.Commands[command]
+	return mapped, exists
+}
+
+// LookupChain PLC 명령에 매핑된 명령 체인 검색
+func (m *CommandMapping) LookupChain(command string) (MappedChain, bool) {
+	if m == nil {
+		return MappedChain{}, false
+	}
+	chain, exists := m.Chains[command]
+	return chain, exists
+}
+
+// LoadCommandMapping COMMAND_MAPPING_FILE 읽기 및 검증 (경로가 비어있으면 nil)
+func (c *Config) LoadCommandMapping() (*CommandMapping, error) {
+	if c.CommandMappingFile == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(c.CommandMappingFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read command mapping: %v", err)
+	}
+
+	var mapping CommandMapping
+	if err := json.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse command mapping %s: %v", c.CommandMappingFile, err)
+	}
+	for command, mapped := range mapping.Commands {
+		if err := mapped.validate(); err != nil {
+			return nil, fmt.Errorf("command %q: %v", command, err)
+		}
+		if strings.HasSuffix(command, ":C") || strings.HasSuffix(command, ":G") || strings.HasSuffix(command, ":E") {
+			return nil, fmt.Errorf("command %q: cancel/release/emergency stop commands cannot be mapped", command)
+		}
+	}
+	for command, chain := range mapping.Chains {
+		if _, exists := mapping.Commands[command]; exists {
+			return nil, fmt.Errorf("chain %q: already mapped as a command pipeline", command)
+		}
+		if err := chain.validate(mapping.Chains); err != nil {
+			return nil, fmt.Errorf("chain %q: %v", command, err)
+		}
+		if strings.HasSuffix(command, ":C") || strings.HasSuffix(command, ":G") || strings.HasSuffix(command, ":E") {
+			return nil, fmt.Errorf("chain %q: cancel/release/emergency stop commands cannot be mapped", command)
+		}
+	}
+	return &mapping, nil
+}
+
+// validate 체인 검증 (단계는 다른 체인을 호출할 수 없음)
+func (c MappedChain) validate(chains map[string]MappedChain) error {
+	if len(c.Steps) == 0 {
+		return fmt.Errorf("at least one step is required")
+	}
+	for i, step := range c.Steps {
+		if step.Command == "" {
+			return fmt.Errorf("step %d: command is required", i)
+		}
+		for _, command := range []string{step.Command, step.OnFailure} {
+			if _, nested := chains[command]; nested {
+				return fmt.Errorf("step %d: chains cannot run other chains (%s)", i, command)
+			}
+		}
+	}
+	return nil
+}
+
+// validate 파이프라인 검증
+func (m MappedCommand) validate() error {
+	switch m.Layout {
+	case "", PipelineLayoutNode, PipelineLayoutSequential:
+	default:
+		return fmt.Errorf("unknown layout %q (node, sequential)", m.Layout)
+	}
+	if len(m.Steps) == 0 {
+		return fmt.Errorf("at least one step is required")
+	}
+	if m.Priority < 0 {
+		return fmt.Errorf("priority must not be negative")
+	}
+
+	for i, step := range m.Steps {
+		switch step.BlockingType {
+		case "", "NONE", "SOFT", "HARD":
+		default:
+			return fmt.Errorf("step %d: unknown blockingType %q (NONE, SOFT, HARD)", i, step.BlockingType)
+		}
+		if step.ActionType != "" {
+			if step.Type != "" || step.Name != "" || step.Arm != "" {
+				return fmt.Errorf("step %d: actionType cannot be combined with type/name/arm", i)
+			}
+			continue
+		}
+		// W 이외의 타입은 등록된 명령 타입인지 핸들러가 로드 시 확인 (messaging/translator.go)
+		switch step.Type {
+		case "W":
+			if step.Arm != "" {
+				return fmt.Errorf("step %d: arm is not valid for waitForTrigger (W) steps", i)
+			}
+			continue
+		case "":
+			return fmt.Errorf("step %d: type is required (or set actionType)", i)
+		}
+		if step.Arm != "" && step.Arm != "R" && step.Arm != "L" && step.Arm != "B" {
+			return fmt.Errorf("step %d: unknown arm %q, expected R, L or B", i, step.Arm)
+		}
+		if step.Name == "" {
+			return fmt.Errorf("step %d: name is required", i)
+		}
+	}
+	return nil
+}
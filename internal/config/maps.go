@@ -0,0 +1,38 @@
+// internal/config/maps.go - Robot Map IDs
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseRobotMapIDs ROBOT_MAP_IDS 해석 (로봇 시리얼 -> 지도 ID)
+// 형식: <robotSerial>=<mapId>;... (없는 로봇은 MAP_ID)
+func (c *Config) ParseRobotMapIDs() (map[string]string, error) {
+	mapIDs := make(map[string]string)
+	for _, entry := range strings.Split(c.RobotMapIDs, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		robot, mapID, found := strings.Cut(entry, "=")
+		robot, mapID = strings.TrimSpace(robot), strings.TrimSpace(mapID)
+		if !found || robot == "" || mapID == "" {
+			return nil, fmt.Errorf("map %q: expected <robotSerial>=<mapId>", entry)
+		}
+		mapIDs[robot] = mapID
+	}
+	return mapIDs, nil
+}
+
+// RobotMapID 로봇에 적용할 기본 지도 ID (ROBOT_MAP_IDS, 없으면 MAP_ID)
+func (c *Config) RobotMapID() string {
+	mapIDs, err := c.ParseRobotMapIDs()
+	if err == nil {
+		if mapID, exists := mapIDs[c.RobotSerialNumber]; exists {
+			return mapID
+		}
+	}
+	return c.MapID
+}
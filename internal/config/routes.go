@@ -0,0 +1,113 @@
+// internal/config/routes.go - PLC Command Topic Routing
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultCommandTopic COMMAND_ROUTES 미설정 시 PLC 명령 토픽
+const DefaultCommandTopic = "bridge/command"
+
+// ReplyChannelPlaceholder 명령 토픽의 응답 채널 레벨 (예: bridge/command/{replyChannel})
+// 해당 레벨 값으로 받은 명령의 응답은 <응답 토픽>/<채널>로 발행
+const ReplyChannelPlaceholder = "{replyChannel}"
+
+// CommandRoute PLC 명령 토픽 하나를 담당 로봇과 응답 토픽에 연결
+type CommandRoute struct {
+	CommandTopic      string
+	RobotSerialNumber string
+	ResponseTopic     string
+	InterfaceName     string // 비어있으면 ROBOT_INTERFACE_NAME
+	MajorVersion      string // 비어있으면 ROBOT_MAJOR_VERSION
+}
+
+// ParseCommandRoutes COMMAND_ROUTES 해석
+// 형식: <commandTopic>=[<interfaceName>/<majorVersion>/]<robotSerial>[:<responseTopic>];... (응답 토픽 생략 시 PLC_RESPONSE_TOPIC)
+// 로봇 앞의 <interfaceName>/<majorVersion>은 해당 로봇의 토픽 네임스페이스 (생략 시 ROBOT_INTERFACE_NAME, ROBOT_MAJOR_VERSION)
+// commandTopic에 {replyChannel} 레벨이 있으면 해당 레벨 값이 응답 채널 (응답은 <responseTopic>/<채널>)
+// responseTopic의 {serialNumber}, {manufacturer}는 경로 로봇 값으로 치환 (예: bridge/response/{serialNumber})
+// 비어있으면 bridge/command -> ROBOT_SERIAL_NUMBER 단일 경로
+func (c *Config) ParseCommandRoutes() ([]CommandRoute, error) {
+	if strings.TrimSpace(c.CommandRoutes) == "" {
+		return []CommandRoute{{
+			CommandTopic:      DefaultCommandTopic,
+			RobotSerialNumber: c.RobotSerialNumber,
+			ResponseTopic:     c.PlcResponseTopic,
+		}}, nil
+	}
+
+	var routes []CommandRoute
+	for _, entry := range strings.Split(c.CommandRoutes, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		commandTopic, target, found := strings.Cut(entry, "=")
+		if !found {
+			return nil, fmt.Errorf("route %q: expected <commandTopic>=[<interfaceName>/<majorVersion>/]<robotSerial>[:<responseTopic>]", entry)
+		}
+		robot, responseTopic, _ := strings.Cut(target, ":")
+		if responseTopic == "" {
+			responseTopic = c.PlcResponseTopic
+		}
+
+		route := CommandRoute{
+			CommandTopic:  strings.TrimSpace(commandTopic),
+			ResponseTopic: strings.TrimSpace(responseTopic),
+		}
+		switch levels := strings.Split(strings.TrimSpace(robot), "/"); len(levels) {
+		case 1:
+			route.RobotSerialNumber = levels[0]
+		case 3:
+			if levels[0] == "" || levels[1] == "" {
+				return nil, fmt.Errorf("route %q: interface name and major version must not be empty", entry)
+			}
+			route.InterfaceName, route.MajorVersion, route.RobotSerialNumber = levels[0], levels[1], levels[2]
+		default:
+			return nil, fmt.Errorf("route %q: robot must be <robotSerial> or <interfaceName>/<majorVersion>/<robotSerial>", entry)
+		}
+		routes = append(routes, route)
+	}
+	return routes, nil
+}
+
+// CommandSubscriptionTopic 응답 채널 레벨을 '+'로 바꾼 구독 토픽
+func CommandSubscriptionTopic(commandTopic string) string {
+	return strings.Replace(commandTopic, ReplyChannelPlaceholder, "+", 1)
+}
+
+// ReplyChannel 수신 토픽에서 응답 채널 추출 (명령 토픽에 응답 채널 레벨이 없으면 빈 값)
+func ReplyChannel(commandTopic, topic string) string {
+	templateLevels := strings.Split(commandTopic, "/")
+	topicLevels := strings.Split(topic, "/")
+	for i, level := range templateLevels {
+		if level == ReplyChannelPlaceholder && i < len(topicLevels) {
+			return topicLevels[i]
+		}
+	}
+	return ""
+}
+
+// ExpandResponseTopic 응답 토픽의 {serialNumber}, {manufacturer} 치환 (로봇별 응답 토픽)
+func (c *Config) ExpandResponseTopic(topic, serialNumber string) string {
+	return strings.NewReplacer(
+		"{serialNumber}", serialNumber,
+		"{manufacturer}", c.RobotManufacturer,
+	).Replace(topic)
+}
+
+// ForRoute 경로의 로봇/네임스페이스/응답 토픽을 적용한 설정 복사본 생성 (핸들러별 설정)
+func (c *Config) ForRoute(route CommandRoute) *Config {
+	routeConfig := *c
+	routeConfig.RobotSerialNumber = route.RobotSerialNumber
+	if route.InterfaceName != "" {
+		routeConfig.RobotInterfaceName = route.InterfaceName
+	}
+	if route.MajorVersion != "" {
+		routeConfig.RobotMajorVersion = route.MajorVersion
+	}
+	routeConfig.PlcResponseTopic = c.ExpandResponseTopic(route.ResponseTopic, route.RobotSerialNumber)
+	return &routeConfig
+}
@@ -0,0 +1,60 @@
+package config
+
+import "testing"
+
+func TestParseCommandRoutes(t *testing.T) {
+	tests := []struct {
+		routes  string
+		want    CommandRoute
+		wantErr bool
+	}{
+		{routes: "plc/a=R1", want: CommandRoute{CommandTopic: "plc/a", RobotSerialNumber: "R1", ResponseTopic: "bridge/response"}},
+		{routes: "plc/a=R1:plc/a/response", want: CommandRoute{CommandTopic: "plc/a", RobotSerialNumber: "R1", ResponseTopic: "plc/a/response"}},
+		{routes: "plc/a=uagv/v1/R1", want: CommandRoute{CommandTopic: "plc/a", RobotSerialNumber: "R1", ResponseTopic: "bridge/response", InterfaceName: "uagv", MajorVersion: "v1"}},
+		{routes: "plc/a=uagv/v1/R1:plc/a/response", want: CommandRoute{CommandTopic: "plc/a", RobotSerialNumber: "R1", ResponseTopic: "plc/a/response", InterfaceName: "uagv", MajorVersion: "v1"}},
+		{routes: "plc/a", wantErr: true},
+		{routes: "plc/a=uagv/R1", wantErr: true},
+		{routes: "plc/a=/v1/R1", wantErr: true},
+		{routes: "plc/a=uagv//R1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.routes, func(t *testing.T) {
+			cfg := &Config{CommandRoutes: tt.routes, PlcResponseTopic: "bridge/response"}
+			routes, err := cfg.ParseCommandRoutes()
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseCommandRoutes(%q) = %+v, want error", tt.routes, routes)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseCommandRoutes(%q): %v", tt.routes, err)
+			}
+			if len(routes) != 1 || routes[0] != tt.want {
+				t.Errorf("ParseCommandRoutes(%q) = %+v, want %+v", tt.routes, routes, tt.want)
+			}
+		})
+	}
+}
+
+func TestForRouteNamespace(t *testing.T) {
+	base := &Config{RobotInterfaceName: "meili", RobotMajorVersion: "v2", RobotManufacturer: "ACME"}
+	tests := []struct {
+		name  string
+		route CommandRoute
+		want  string
+	}{
+		{"global fallback", CommandRoute{RobotSerialNumber: "R1"}, "meili/v2/ACME/R1/order"},
+		{"route namespace", CommandRoute{RobotSerialNumber: "R2", InterfaceName: "uagv", MajorVersion: "v1"}, "uagv/v1/ACME/R2/order"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := base.ForRoute(tt.route).RobotTopic("order"); got != tt.want {
+				t.Errorf("RobotTopic = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	if base.RobotInterfaceName != "meili" || base.RobotMajorVersion != "v2" {
+		t.Errorf("ForRoute modified the global config: %s/%s", base.RobotInterfaceName, base.RobotMajorVersion)
+	}
+}
@@ -0,0 +1,160 @@
+// internal/config/schedule.go - Scheduled Commands (cron)
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScheduledCommand 정해진 시각에 PLC 명령과 같은 경로로 실행할 명령
+type ScheduledCommand struct {
+	Spec     string // 원본 cron 표현식 (로그용)
+	Command  string
+	Schedule *CronSchedule
+}
+
+// CronSchedule 5필드 cron 표현식 (분 시 일 월 요일)
+type CronSchedule struct {
+	minutes  [60]bool
+	hours    [24]bool
+	days     [32]bool // 1-31
+	months   [13]bool // 1-12
+	weekdays [7]bool  // 0=일요일 (7도 일요일로 허용)
+
+	anyDay     bool // 일 필드가 *
+	anyWeekday bool // 요일 필드가 *
+}
+
+// cronField cron 필드 범위
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7},
+}
+
+// ParseCronSchedule cron 표현식 해석 (*, a-b, */n, a-b/n, 쉼표 목록 지원)
+func ParseCronSchedule(spec string) (*CronSchedule, error) {
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron %q: expected 5 fields (minute hour day month weekday)", spec)
+	}
+
+	schedule := &CronSchedule{
+		anyDay:     fields[2] == "*",
+		anyWeekday: fields[4] == "*",
+	}
+	targets := [5][]bool{
+		schedule.minutes[:], schedule.hours[:], schedule.days[:], schedule.months[:], nil,
+	}
+	for i, field := range fields {
+		values, err := parseCronField(field, cronFields[i])
+		if err != nil {
+			return nil, fmt.Errorf("cron %q: %v", spec, err)
+		}
+		for _, value := range values {
+			if i == 4 {
+				schedule.weekdays[value%7] = true
+				continue
+			}
+			targets[i][value] = true
+		}
+	}
+	return schedule, nil
+}
+
+// parseCronField 필드 하나를 허용 값 목록으로 변환
+func parseCronField(field string, bounds cronField) ([]int, error) {
+	var values []int
+	for _, part := range strings.Split(field, ",") {
+		rangeSpec, stepSpec, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepSpec)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("%s: invalid step %q", bounds.name, stepSpec)
+			}
+			step = n
+		}
+
+		low, high := bounds.min, bounds.max
+		if rangeSpec != "*" {
+			startSpec, endSpec, isRange := strings.Cut(rangeSpec, "-")
+			start, err := strconv.Atoi(startSpec)
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid value %q", bounds.name, part)
+			}
+			low, high = start, start
+			if isRange {
+				if high, err = strconv.Atoi(endSpec); err != nil {
+					return nil, fmt.Errorf("%s: invalid value %q", bounds.name, part)
+				}
+			} else if hasStep {
+				high = bounds.max
+			}
+		}
+		if low < bounds.min || high > bounds.max || low > high {
+			return nil, fmt.Errorf("%s: %q out of range %d-%d", bounds.name, part, bounds.min, bounds.max)
+		}
+
+		for value := low; value <= high; value += step {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
+
+// Matches 주어진 시각(분 단위)이 일정에 해당하는지 확인
+// 일/요일이 모두 지정되면 둘 중 하나만 맞아도 실행 (표준 cron 동작)
+func (s *CronSchedule) Matches(t time.Time) bool {
+	if !s.minutes[t.Minute()] || !s.hours[t.Hour()] || !s.months[t.Month()] {
+		return false
+	}
+
+	dayMatch, weekdayMatch := s.days[t.Day()], s.weekdays[t.Weekday()]
+	switch {
+	case s.anyDay && s.anyWeekday:
+		return true
+	case s.anyDay:
+		return weekdayMatch
+	case s.anyWeekday:
+		return dayMatch
+	default:
+		return dayMatch || weekdayMatch
+	}
+}
+
+// ParseScheduledCommands SCHEDULED_COMMANDS 해석
+// 형식: <분> <시> <일> <월> <요일> <명령>;... (예: 0 2 * * * HOME;30 3 * * 0 calibrate:I)
+func (c *Config) ParseScheduledCommands() ([]ScheduledCommand, error) {
+	var commands []ScheduledCommand
+	for _, entry := range strings.Split(c.ScheduledCommands, ";") {
+		fields := strings.Fields(entry)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != len(cronFields)+1 {
+			return nil, fmt.Errorf("entry %q: expected <minute> <hour> <day> <month> <weekday> <command>", strings.TrimSpace(entry))
+		}
+
+		spec := strings.Join(fields[:len(cronFields)], " ")
+		schedule, err := ParseCronSchedule(spec)
+		if err != nil {
+			return nil, err
+		}
+		commands = append(commands, ScheduledCommand{
+			Spec:     spec,
+			Command:  fields[len(cronFields)],
+			Schedule: schedule,
+		})
+	}
+	return commands, nil
+}
@@ -0,0 +1,48 @@
+// internal/config/templates.go - Order Template Files
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// TemplatePlaceholder 템플릿 자리표시자 (${name})
+var TemplatePlaceholder = regexp.MustCompile(`\$\{([A-Za-z][A-Za-z0-9_]*)\}`)
+
+// LoadOrderTemplates ORDER_TEMPLATE_DIR의 <기본 명령>.json 파일 읽기 (디렉터리가 비어있으면 nil)
+// 파일 내용은 전체 VDA5050 OrderMessage이며 ${orderId}, ${base}, ${arg1} 등 자리표시자 사용 가능
+func (c *Config) LoadOrderTemplates() (map[string]string, error) {
+	if c.OrderTemplateDir == "" {
+		return nil, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(c.OrderTemplateDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no *.json templates in %s", c.OrderTemplateDir)
+	}
+
+	templates := make(map[string]string, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %v", err)
+		}
+
+		// 자리표시자를 숫자로 치환해도 JSON이어야 함 (문자열/숫자 위치 모두 허용)
+		probe := TemplatePlaceholder.ReplaceAllString(string(data), "0")
+		if !json.Valid([]byte(probe)) {
+			return nil, fmt.Errorf("template %s is not valid JSON", path)
+		}
+
+		name := strings.TrimSuffix(filepath.Base(path), ".json")
+		templates[name] = string(data)
+	}
+	return templates, nil
+}
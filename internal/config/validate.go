@@ -0,0 +1,611 @@
+// internal/config/validate.go - Configuration Validation
+package config
+
+import (
+	"database/sql"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// brokerSchemes paho가 지원하는 브로커 URL 스킴
+var brokerSchemes = map[string]bool{
+	"tcp": true, "ssl": true, "tls": true, "mqtt": true, "mqtts": true, "ws": true, "wss": true,
+}
+
+// majorVersionPattern VDA5050 토픽 major version (예: v2)
+var majorVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// messageVersionPattern 메시지 헤더 version 형식 (major.minor.patch)
+var messageVersionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+\.[0-9]+$`)
+
+// ValidationError 설정 검증 오류 목록 (환경 변수 이름 기준)
+type ValidationError struct {
+	Problems []string
+}
+
+// Error error 인터페이스 구현
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Validate 설정 검증 (모든 문제를 모아서 반환)
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// MQTT
+	v.brokerURL("MQTT_BROKER", c.MQTTBroker)
+	v.required("MQTT_CLIENT_ID", c.MQTTClientID)
+	v.publishTopic("PLC_RESPONSE_TOPIC", c.ExpandResponseTopic(c.PlcResponseTopic, c.RobotSerialNumber))
+	v.durationRange("RESPONSE_DEDUP_WINDOW", c.ResponseDedupWindow, 0, time.Hour)
+	v.durationRange("COMMAND_DEDUP_WINDOW", c.CommandDedupWindow, 0, time.Hour)
+
+	// Secrets
+	secretValues := []struct{ name, value string }{
+		{"MQTT_USERNAME", c.MQTTUsername},
+		{"MQTT_PASSWORD", c.MQTTPassword},
+		{"MQTT_TLS_CA", c.MQTTTLSCA},
+		{"MQTT_TLS_CERT", c.MQTTTLSCert},
+		{"MQTT_TLS_KEY", c.MQTTTLSKey},
+	}
+	for _, secret := range secretValues {
+		if strings.HasPrefix(secret.value, "vault:") && c.VaultAddr == "" {
+			v.addf("%s: vault reference requires VAULT_ADDR", secret.name)
+		}
+	}
+	hasCert := c.MQTTTLSCert != "" || c.MQTTTLSCertFile != ""
+	hasKey := c.MQTTTLSKey != "" || c.MQTTTLSKeyFile != ""
+	if hasCert != hasKey {
+		v.addf("MQTT_TLS_CERT/MQTT_TLS_KEY: client certificate and key must be set together")
+	}
+	if c.SecretsRefreshInterval != 0 {
+		v.durationRange("SECRETS_REFRESH_INTERVAL", c.SecretsRefreshInterval, time.Second, 24*time.Hour)
+	}
+
+	// Robot
+	switch c.RobotProtocol {
+	case "vda5050":
+	case "rosbridge":
+		v.websocketURL("ROSBRIDGE_URL", c.RosbridgeURL)
+		v.required("ROSBRIDGE_ACTION", c.RosbridgeAction)
+		if strings.Count(c.RosbridgeActionType, "/") != 2 {
+			v.addf("ROSBRIDGE_ACTION_TYPE: %q must look like package/action/Name", c.RosbridgeActionType)
+		}
+		v.durationRange("ROSBRIDGE_RECONNECT_DELAY", c.RosbridgeReconnectDelay, 100*time.Millisecond, time.Minute)
+	default:
+		v.addf("ROBOT_PROTOCOL: unknown protocol %q (vda5050, rosbridge)", c.RobotProtocol)
+	}
+	v.topicLevel("ROBOT_SERIAL_NUMBER", c.RobotSerialNumber)
+	v.topicLevel("ROBOT_MANUFACTURER", c.RobotManufacturer)
+	v.topicLevel("ROBOT_INTERFACE_NAME", c.RobotInterfaceName)
+	if !majorVersionPattern.MatchString(c.RobotMajorVersion) {
+		v.addf("ROBOT_MAJOR_VERSION: %q must look like v1, v2", c.RobotMajorVersion)
+	}
+	if c.RobotMessageVersion != "" {
+		if !messageVersionPattern.MatchString(c.RobotMessageVersion) {
+			v.addf("ROBOT_MESSAGE_VERSION: %q must look like 1.1.0, 2.0.0", c.RobotMessageVersion)
+		} else if "v"+strings.SplitN(c.RobotMessageVersion, ".", 2)[0] != c.RobotMajorVersion {
+			v.addf("ROBOT_MESSAGE_VERSION: %q does not match ROBOT_MAJOR_VERSION %s", c.RobotMessageVersion, c.RobotMajorVersion)
+		}
+	}
+
+	// Command routes
+	routes, err := c.ParseCommandRoutes()
+	if err != nil {
+		v.addf("COMMAND_ROUTES: %v", err)
+	}
+	commandTopics := make(map[string]bool)
+	robots := make(map[string]bool)
+	for i, route := range routes {
+		name := fmt.Sprintf("COMMAND_ROUTES[%d]", i)
+		v.subscribeTopic(name+" command topic", CommandSubscriptionTopic(route.CommandTopic))
+		if strings.Count(route.CommandTopic, ReplyChannelPlaceholder) > 1 {
+			v.addf("%s: %q uses %s more than once", name, route.CommandTopic, ReplyChannelPlaceholder)
+		} else if strings.Contains(route.CommandTopic, ReplyChannelPlaceholder) && ReplyChannel(route.CommandTopic, CommandSubscriptionTopic(route.CommandTopic)) != "+" {
+			v.addf("%s: %q must use %s as a whole topic level", name, route.CommandTopic, ReplyChannelPlaceholder)
+		}
+		v.topicLevel(name+" robot", route.RobotSerialNumber)
+		if route.InterfaceName != "" {
+			v.topicLevel(name+" interface name", route.InterfaceName)
+		}
+		if route.MajorVersion != "" {
+			if !majorVersionPattern.MatchString(route.MajorVersion) {
+				v.addf("%s: major version %q must look like v1, v2", name, route.MajorVersion)
+			} else if c.RobotMessageVersion != "" && "v"+strings.SplitN(c.RobotMessageVersion, ".", 2)[0] != route.MajorVersion {
+				v.addf("%s: major version %s does not match ROBOT_MESSAGE_VERSION %q", name, route.MajorVersion, c.RobotMessageVersion)
+			}
+		}
+		v.publishTopic(name+" response topic", c.ExpandResponseTopic(route.ResponseTopic, route.RobotSerialNumber))
+		if commandTopics[route.CommandTopic] {
+			v.addf("%s: duplicate command topic %q", name, route.CommandTopic)
+		}
+		if robots[route.RobotSerialNumber] {
+			v.addf("%s: robot %q is already routed (one command topic per robot)", name, route.RobotSerialNumber)
+		}
+		commandTopics[route.CommandTopic] = true
+		robots[route.RobotSerialNumber] = true
+	}
+
+	// Command mapping
+	if _, err := c.LoadCommandMapping(); err != nil {
+		v.addf("COMMAND_MAPPING_FILE: %v", err)
+	}
+	if _, err := c.LoadOrderTemplates(); err != nil {
+		v.addf("ORDER_TEMPLATE_DIR: %v", err)
+	}
+	v.required("TRIGGER_ACTION_TYPE", c.TriggerActionType)
+	v.required("TRIGGER_RELEASE_ACTION", c.TriggerReleaseAction)
+
+	// Order policy
+	switch c.OrderPolicy {
+	case "parallel", "queue", "reject":
+	default:
+		v.addf("ORDER_POLICY: unknown policy %q (parallel, queue, reject)", c.OrderPolicy)
+	}
+	switch c.OperatingModePolicy {
+	case "off", "reject", "queue":
+	default:
+		v.addf("OPERATING_MODE_POLICY: unknown policy %q (off, reject, queue)", c.OperatingModePolicy)
+	}
+	switch c.PausedPolicy {
+	case "off", "resume", "reject":
+	default:
+		v.addf("PAUSED_POLICY: unknown policy %q (off, resume, reject)", c.PausedPolicy)
+	}
+	if c.DeferWhileDriving {
+		v.durationRange("DRIVING_MAX_WAIT", c.DrivingMaxWait, time.Second, time.Hour)
+	}
+	if c.MaxConcurrentOrders < 1 {
+		v.addf("MAX_CONCURRENT_ORDERS: must be at least 1, got %d", c.MaxConcurrentOrders)
+	}
+	if c.PreemptPriority < 0 {
+		v.addf("PREEMPT_PRIORITY: must not be negative, got %d", c.PreemptPriority)
+	}
+	if _, err := c.ParseRobotMapIDs(); err != nil {
+		v.addf("ROBOT_MAP_IDS: %v", err)
+	}
+	if _, err := c.ParseCommandAliases(); err != nil {
+		v.addf("COMMAND_ALIASES: %v", err)
+	}
+	if _, err := c.ParseWaypoints(); err != nil {
+		v.addf("WAYPOINTS: %v", err)
+	} else if _, err := c.ParseNavigationPaths(); err != nil {
+		v.addf("NAV_PATHS: %v", err)
+	}
+
+	// Escalation
+	if _, err := c.ParseEscalationPolicies(); err != nil {
+		v.addf("ESCALATION_POLICIES: %v", err)
+	}
+	v.durationRange("ESCALATION_WARN_AFTER", c.EscalationWarnAfter, 0, 24*time.Hour)
+	v.durationRange("ESCALATION_CANCEL_AFTER", c.EscalationCancelAfter, 0, 24*time.Hour)
+	v.durationRange("ESCALATION_UNHEALTHY_AFTER", c.EscalationUnhealthyAfter, 0, 24*time.Hour)
+
+	// PLC response format
+	switch c.PlcResponseFormat {
+	case "string", "json":
+	default:
+		v.addf("PLC_RESPONSE_FORMAT: unknown format %q (string, json)", c.PlcResponseFormat)
+	}
+
+	// Payload normalization
+	switch c.PayloadCharset {
+	case "utf-8", "latin-1", "auto":
+	default:
+		v.addf("PAYLOAD_CHARSET: unknown charset %q (utf-8, latin-1, auto)", c.PayloadCharset)
+	}
+	switch c.PayloadCase {
+	case "none", "upper", "lower":
+	default:
+		v.addf("PAYLOAD_CASE: unknown case folding %q (none, upper, lower)", c.PayloadCase)
+	}
+
+	// Replay protection
+	v.durationRange("STATE_CLOCK_SKEW", c.StateClockSkew, 0, time.Hour)
+
+	// Topics
+	v.publishTopic("SAFETY_TOPIC", c.SafetyTopic)
+	if c.PositionTopic != "" {
+		v.publishTopic("POSITION_TOPIC", c.PositionTopic)
+		v.durationRange("POSITION_INTERVAL", c.PositionInterval, 0, time.Hour)
+	}
+	if c.VisualizationTopic != "" {
+		v.publishTopic("VISUALIZATION_TOPIC", c.VisualizationTopic)
+	}
+	if c.VisualizationRate <= 0 || c.VisualizationRate > 100 {
+		v.addf("VISUALIZATION_RATE: must be between 0 (exclusive) and 100 per second, got %g", c.VisualizationRate)
+	}
+	if c.RobotSchemaValidation {
+		v.publishTopic("ROBOT_DEAD_LETTER_TOPIC", c.RobotDeadLetterTopic)
+	}
+	if c.CommandDeadLetterTopic != "" {
+		v.publishTopic("COMMAND_DEAD_LETTER_TOPIC", c.CommandDeadLetterTopic)
+	}
+	if c.FaultLatchEnabled {
+		v.publishTopic("FAULT_TOPIC", c.FaultTopic)
+		v.subscribeTopic("FAULT_ACK_TOPIC", c.FaultAckTopic)
+	}
+	if c.CommissioningMode {
+		v.subscribeTopic("COMMISSIONING_CONFIRM_TOPIC", c.CommissioningConfirmTopic)
+		v.durationRange("COMMISSIONING_CONFIRM_TIMEOUT", c.CommissioningConfirmTimeout, 0, 24*time.Hour)
+	}
+	v.durationRange("COMMAND_MAX_DELAY", c.CommandMaxDelay, 0, 24*time.Hour)
+	if c.CommandHook != "" {
+		v.durationRange("COMMAND_HOOK_TIMEOUT", c.CommandHookTimeout, 10*time.Millisecond, 10*time.Second)
+	}
+	if c.AdminTopic != "" {
+		v.publishTopic("ADMIN_TOPIC", c.AdminTopic)
+	}
+	if c.ReloadTopic != "" {
+		v.subscribeTopic("RELOAD_TOPIC", c.ReloadTopic)
+	}
+	if c.OrderIDPrefix != "" {
+		v.topicLevel("ORDER_ID_PREFIX", c.OrderIDPrefix)
+	}
+	switch c.IDFormat {
+	case "ulid", "uuid":
+	default:
+		v.addf("ID_FORMAT: unknown format %q (ulid, uuid)", c.IDFormat)
+	}
+
+	// PLC heartbeat
+	if c.PLCHeartbeatTopic != "" {
+		v.subscribeTopic("PLC_HEARTBEAT_TOPIC", c.PLCHeartbeatTopic)
+		v.durationRange("PLC_HEARTBEAT_TIMEOUT", c.PLCHeartbeatTimeout, 2*time.Second, 24*time.Hour)
+	}
+
+	// HA
+	if c.HAEnabled {
+		v.required("HA_INSTANCE_ID", c.HAInstanceID)
+		v.publishTopic("HA_LEADER_TOPIC", c.HALeaderTopic)
+		v.publishTopic("HA_STATE_TOPIC", c.HAStateTopic)
+		if c.HALeaderTopic == c.HAStateTopic {
+			v.addf("HA_LEADER_TOPIC/HA_STATE_TOPIC: must be different topics")
+		}
+		v.durationRange("HA_LEASE", c.HALease, 3*time.Second, 5*time.Minute)
+	}
+
+	// Bridge status
+	if c.ErrorTopic != "" {
+		v.publishTopic("ERROR_TOPIC", c.ErrorTopic)
+	}
+	if c.BridgeStatusTopic != "" {
+		v.publishTopic("BRIDGE_STATUS_TOPIC", c.BridgeStatusTopic)
+		if c.BridgeStatusInterval < 0 {
+			v.addf("BRIDGE_STATUS_INTERVAL: must not be negative, got %s", c.BridgeStatusInterval)
+		}
+	}
+
+	// Outbox
+	if c.OutboxFile != "" {
+		if c.OutboxMaxMessages < 1 {
+			v.addf("OUTBOX_MAX_MESSAGES: must be at least 1, got %d", c.OutboxMaxMessages)
+		}
+		if c.OutboxMaxAge < 0 {
+			v.addf("OUTBOX_MAX_AGE: must not be negative, got %s", c.OutboxMaxAge)
+		}
+	}
+
+	// HTTP
+	if c.HTTPAddr != "" {
+		if _, _, err := net.SplitHostPort(c.HTTPAddr); err != nil {
+			v.addf("HTTP_ADDR: %q is not host:port (%v)", c.HTTPAddr, err)
+		}
+	}
+	for origin := range ParseNameList(c.HTTPAllowedOrigins) {
+		if parsed, err := url.Parse(origin); err != nil || parsed.Scheme == "" || parsed.Host == "" || parsed.Path != "" {
+			v.addf("HTTP_ALLOWED_ORIGINS: %q is not an origin (scheme://host[:port])", origin)
+		}
+	}
+
+	// gRPC
+	if c.GRPCAddr != "" {
+		if _, _, err := net.SplitHostPort(c.GRPCAddr); err != nil {
+			v.addf("GRPC_ADDR: %q is not host:port (%v)", c.GRPCAddr, err)
+		}
+	}
+
+	// Modbus
+	if c.ModbusAddr != "" {
+		if _, _, err := net.SplitHostPort(c.ModbusAddr); err != nil {
+			v.addf("MODBUS_ADDR: %q is not host:port (%v)", c.ModbusAddr, err)
+		}
+	}
+
+	// OPC UA
+	if c.OPCUAAddr != "" {
+		if _, port, err := net.SplitHostPort(c.OPCUAAddr); err != nil {
+			v.addf("OPCUA_ADDR: %q is not host:port (%v)", c.OPCUAAddr, err)
+		} else if _, err := strconv.Atoi(port); err != nil {
+			v.addf("OPCUA_ADDR: port %q is not numeric", port)
+		}
+	}
+
+	// Sparkplug
+	if c.SparkplugEnabled {
+		v.topicLevel("SPARKPLUG_GROUP_ID", c.SparkplugGroupID)
+		v.topicLevel("SPARKPLUG_EDGE_NODE_ID", c.SparkplugEdgeNodeID)
+	}
+
+	// EtherNet/IP
+	if c.ENIPAddr != "" {
+		if _, _, err := net.SplitHostPort(c.ENIPAddr); err != nil {
+			v.addf("ENIP_ADDR: %q is not host:port (%v)", c.ENIPAddr, err)
+		}
+	}
+
+	// S7
+	if c.S7Addr != "" {
+		if _, _, err := net.SplitHostPort(c.S7Addr); err != nil {
+			v.addf("S7_ADDR: %q is not host:port (%v)", c.S7Addr, err)
+		}
+		if c.S7Rack < 0 || c.S7Rack > 7 || c.S7Slot < 0 || c.S7Slot > 31 {
+			v.addf("S7_RACK/S7_SLOT: rack must be 0-7 and slot 0-31, got %d/%d", c.S7Rack, c.S7Slot)
+		}
+		if c.S7CommandDB < 1 || c.S7StatusDB < 1 || c.S7CommandOffset < 0 || c.S7StatusOffset < 0 {
+			v.addf("S7_*_DB/S7_*_OFFSET: DB numbers must be at least 1 and offsets non-negative")
+		}
+		if c.S7CommandLength < 1 || c.S7CommandLength > 254 {
+			v.addf("S7_COMMAND_LENGTH: must be 1-254, got %d", c.S7CommandLength)
+		}
+		// 같은 DB면 명령 블록(쓰기: PLC)과 상태 블록(쓰기: 브릿지)이 겹치지 않아야 함
+		commandEnd := c.S7CommandOffset + 4 + c.S7CommandLength
+		statusEnd := c.S7StatusOffset + 10 + c.S7CommandLength
+		if c.S7CommandDB == c.S7StatusDB && c.S7CommandOffset < statusEnd && c.S7StatusOffset < commandEnd {
+			v.addf("S7_COMMAND_OFFSET/S7_STATUS_OFFSET: command block [%d,%d) overlaps status block [%d,%d) in DB%d",
+				c.S7CommandOffset, commandEnd, c.S7StatusOffset, statusEnd, c.S7CommandDB)
+		}
+		v.durationRange("S7_POLL_INTERVAL", c.S7PollInterval, 10*time.Millisecond, 10*time.Second)
+	}
+
+	// Kafka
+	if c.KafkaRestURL != "" {
+		v.httpURL("KAFKA_REST_URL", c.KafkaRestURL)
+		v.kafkaTopic("KAFKA_ORDER_TOPIC", c.KafkaOrderTopic)
+		v.kafkaTopic("KAFKA_STATE_TOPIC", c.KafkaStateTopic)
+		if c.KafkaBatchSize < 1 || c.KafkaBatchSize > 10000 {
+			v.addf("KAFKA_BATCH_SIZE: must be 1-10000, got %d", c.KafkaBatchSize)
+		}
+		v.durationRange("KAFKA_STATE_INTERVAL", c.KafkaStateInterval, time.Second, time.Hour)
+		v.durationRange("KAFKA_FLUSH_INTERVAL", c.KafkaFlushInterval, 100*time.Millisecond, time.Minute)
+	}
+
+	// AMQP
+	if c.AMQPURL != "" {
+		if u, err := url.Parse(c.AMQPURL); err != nil {
+			v.addf("AMQP_URL: %q is not a valid URL (%v)", c.AMQPURL, err)
+		} else if u.Scheme != "amqp" && u.Scheme != "amqps" {
+			v.addf("AMQP_URL: unsupported scheme %q (expected amqp:// or amqps://)", u.Scheme)
+		} else if u.Hostname() == "" {
+			v.addf("AMQP_URL: no host")
+		}
+		v.required("AMQP_EXCHANGE", c.AMQPExchange)
+		if len(c.AMQPExchange) > 255 || len(c.AMQPQueue) > 255 || len(c.AMQPBindingKey) > 255 {
+			v.addf("AMQP_EXCHANGE/AMQP_QUEUE/AMQP_BINDING_KEY: must be at most 255 bytes")
+		}
+	}
+
+	// Metrics
+	if c.MetricsMaxCommandLabels < 1 {
+		v.addf("METRICS_MAX_COMMAND_LABELS: must be at least 1, got %d", c.MetricsMaxCommandLabels)
+	}
+	for _, name := range strings.Split(c.MetricsExporters, ",") {
+		switch strings.TrimSpace(name) {
+		case "", "statsd", "otlp":
+		default:
+			v.addf("METRICS_EXPORTERS: unknown exporter %q (supported: statsd, otlp)", name)
+		}
+	}
+	if c.MetricsExporters != "" {
+		v.durationRange("METRICS_PUSH_INTERVAL", c.MetricsPushInterval, time.Second, time.Hour)
+	}
+	if c.HistoryDBPath != "" && c.MetricsPersistInterval != 0 {
+		v.durationRange("METRICS_PERSIST_INTERVAL", c.MetricsPersistInterval, time.Second, time.Hour)
+	}
+
+	// Canary
+	if c.CanaryEnabled {
+		v.durationRange("CANARY_INTERVAL", c.CanaryInterval, time.Minute, 24*time.Hour)
+		v.durationRange("CANARY_TIMEOUT", c.CanaryTimeout, time.Second, 10*time.Minute)
+	}
+
+	// Scheduled commands
+	if _, err := c.ParseScheduledCommands(); err != nil {
+		v.addf("SCHEDULED_COMMANDS: %v", err)
+	}
+	if c.ScheduledCommands != "" {
+		v.durationRange("SCHEDULED_COMMAND_TIMEOUT", c.ScheduledCommandTimeout, time.Second, 24*time.Hour)
+	}
+
+	// Startup / Subscription / InstantActions
+	if c.StartupWaitForRobot {
+		v.durationRange("STARTUP_ROBOT_TIMEOUT", c.StartupRobotTimeout, time.Second, time.Hour)
+	}
+	if c.SubscribeRetries < 0 {
+		v.addf("SUBSCRIBE_RETRIES: must not be negative, got %d", c.SubscribeRetries)
+	}
+	v.durationRange("SUBSCRIBE_RETRY_BACKOFF", c.SubscribeRetryBackoff, 10*time.Millisecond, time.Minute)
+	if c.InstantActionRate < 0 {
+		v.addf("INSTANT_ACTION_RATE: must not be negative, got %g", c.InstantActionRate)
+	}
+	if c.InstantActionBurst < 1 {
+		v.addf("INSTANT_ACTION_BURST: must be at least 1, got %d", c.InstantActionBurst)
+	}
+	v.durationRange("INSTANT_ACTION_COALESCE_WINDOW", c.InstantActionCoalesceWindow, 0, time.Minute)
+	if c.StateQueueSize < 0 || c.StateQueueSize > 10000 {
+		v.addf("STATE_QUEUE_SIZE: must be 0-10000, got %d", c.StateQueueSize)
+	}
+	if c.CommandRateLimit < 0 || c.BaseCommandRateLimit < 0 {
+		v.addf("COMMAND_RATE_LIMIT/BASE_COMMAND_RATE_LIMIT: must not be negative, got %g/%g", c.CommandRateLimit, c.BaseCommandRateLimit)
+	}
+	if c.CommandRateBurst < 1 || c.BaseCommandRateBurst < 1 {
+		v.addf("COMMAND_RATE_BURST/BASE_COMMAND_RATE_BURST: must be at least 1, got %d/%d", c.CommandRateBurst, c.BaseCommandRateBurst)
+	}
+
+	// History (드라이버는 빌드 태그로 링크, 예: -tags sqlite)
+	if c.HistoryDBPath != "" {
+		if !slices.Contains(sql.Drivers(), c.HistoryDBDriver) {
+			v.addf("HISTORY_DB_DRIVER: database driver %q is not compiled into this binary (registered: %v), the sqlite driver is linked with -tags sqlite", c.HistoryDBDriver, sql.Drivers())
+		}
+		if c.HistoryRetention < 0 {
+			v.addf("HISTORY_RETENTION: must not be negative, got %s", c.HistoryRetention)
+		}
+	}
+
+	// Logging
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		v.addf("LOG_LEVEL: unknown level %q (debug, info, warn, error)", c.LogLevel)
+	}
+	switch c.LogFormat {
+	case "text", "json":
+	default:
+		v.addf("LOG_FORMAT: unknown format %q (text, json)", c.LogFormat)
+	}
+	if c.LogFile != "" && c.LogMaxSizeMB < 1 {
+		v.addf("LOG_MAX_SIZE_MB: must be at least 1, got %d", c.LogMaxSizeMB)
+	}
+	if c.LogPayloadLimit < 0 {
+		v.addf("LOG_PAYLOAD_LIMIT: must not be negative, got %d", c.LogPayloadLimit)
+	}
+	for _, filter := range c.LogSuppressTopicFilters() {
+		v.subscribeTopic("LOG_SUPPRESS_TOPICS", filter)
+	}
+
+	if len(v.problems) > 0 {
+		return &ValidationError{Problems: v.problems}
+	}
+	return nil
+}
+
+// validator 검증 문제 수집기
+type validator struct {
+	problems []string
+}
+
+func (v *validator) addf(format string, args ...interface{}) {
+	v.problems = append(v.problems, fmt.Sprintf(format, args...))
+}
+
+func (v *validator) required(name, value string) {
+	if strings.TrimSpace(value) == "" {
+		v.addf("%s: must not be empty", name)
+	}
+}
+
+// brokerURL 브로커 URL 형식 확인 (scheme://host:port)
+func (v *validator) brokerURL(name, value string) {
+	u, err := url.Parse(value)
+	if err != nil {
+		v.addf("%s: %q is not a valid URL (%v)", name, value, err)
+		return
+	}
+	if !brokerSchemes[u.Scheme] {
+		v.addf("%s: %q has unsupported scheme %q (expected tcp://, ssl://, ws:// ...)", name, value, u.Scheme)
+		return
+	}
+	if u.Hostname() == "" {
+		v.addf("%s: %q has no host", name, value)
+	}
+}
+
+// httpURL HTTP URL 형식 확인 (http:// 또는 https://)
+func (v *validator) httpURL(name, value string) {
+	u, err := url.Parse(value)
+	if err != nil {
+		v.addf("%s: %q is not a valid URL (%v)", name, value, err)
+		return
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		v.addf("%s: %q has unsupported scheme %q (expected http:// or https://)", name, value, u.Scheme)
+		return
+	}
+	if u.Hostname() == "" {
+		v.addf("%s: %q has no host", name, value)
+	}
+}
+
+// kafkaTopic Kafka 토픽 이름 확인 (영문, 숫자, '.', '_', '-', 최대 249자)
+func (v *validator) kafkaTopic(name, value string) {
+	if value == "" || len(value) > 249 {
+		v.addf("%s: topic name must be 1-249 characters", name)
+		return
+	}
+	for _, r := range value {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-') {
+			v.addf("%s: %q contains invalid character %q", name, value, r)
+			return
+		}
+	}
+}
+
+// websocketURL 웹소켓 URL 형식 확인 (ws:// 또는 wss://)
+func (v *validator) websocketURL(name, value string) {
+	u, err := url.Parse(value)
+	if err != nil {
+		v.addf("%s: %q is not a valid URL (%v)", name, value, err)
+		return
+	}
+	if u.Scheme != "ws" && u.Scheme != "wss" {
+		v.addf("%s: %q has unsupported scheme %q (expected ws:// or wss://)", name, value, u.Scheme)
+		return
+	}
+	if u.Hostname() == "" {
+		v.addf("%s: %q has no host", name, value)
+	}
+}
+
+// topicLevel 단일 토픽 레벨로 쓰이는 값 확인 (비어있지 않고 '/', '+', '#' 미포함)
+func (v *validator) topicLevel(name, value string) {
+	if value == "" {
+		v.addf("%s: must not be empty", name)
+		return
+	}
+	if strings.ContainsAny(value, "/+#") {
+		v.addf("%s: %q must not contain '/', '+' or '#'", name, value)
+	}
+}
+
+// publishTopic 발행 토픽 확인 (와일드카드 불가)
+func (v *validator) publishTopic(name, value string) {
+	if value == "" {
+		v.addf("%s: must not be empty", name)
+		return
+	}
+	if strings.ContainsAny(value, "+#") {
+		v.addf("%s: %q must not contain wildcards when publishing", name, value)
+	}
+}
+
+// subscribeTopic 구독 토픽 필터 확인 ('#'은 마지막 레벨에만, '+'는 레벨 전체)
+func (v *validator) subscribeTopic(name, value string) {
+	if value == "" {
+		v.addf("%s: must not be empty", name)
+		return
+	}
+
+	levels := strings.Split(value, "/")
+	for i, level := range levels {
+		if strings.Contains(level, "#") && (level != "#" || i != len(levels)-1) {
+			v.addf("%s: %q uses '#' outside the last level", name, value)
+			return
+		}
+		if strings.Contains(level, "+") && level != "+" {
+			v.addf("%s: %q uses '+' inside a level", name, value)
+			return
+		}
+	}
+}
+
+// durationRange 시간 값 범위 확인
+func (v *validator) durationRange(name string, value, min, max time.Duration) {
+	if value < min || value > max {
+		v.addf("%s: %s is out of range [%s, %s]", name, value, min, max)
+	}
+}
@@ -0,0 +1,134 @@
+// internal/config/waypoints.go - Navigation Waypoints and Paths
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PathSeparator 웨이포인트 경로 구분자 (NAV_PATHS, PLC 확장 명령 공통)
+const PathSeparator = ">"
+
+// TargetSeparator PLC 확장 명령의 목적지 웨이포인트 구분자 (예: PICKUP:T:R@STATION_3)
+const TargetSeparator = "@"
+
+// Waypoint 오더 노드 위치
+type Waypoint struct {
+	X     float64
+	Y     float64
+	Theta *float64 // nil이면 방향 자유
+	MapID string
+}
+
+// ParseWaypoint 웨이포인트 해석 (형식: <x>,<y>[,<theta>[,<mapId>]], theta는 라디안이며 비워둘 수 있음)
+func ParseWaypoint(spec string) (Waypoint, error) {
+	fields := strings.Split(strings.TrimSpace(spec), ",")
+	if len(fields) < 2 || len(fields) > 4 {
+		return Waypoint{}, fmt.Errorf("waypoint %q: expected <x>,<y>[,<theta>[,<mapId>]]", spec)
+	}
+
+	var values [3]float64
+	for i, field := range fields[:min(len(fields), 3)] {
+		field = strings.TrimSpace(field)
+		if i == 2 && field == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(field, 64)
+		if err != nil {
+			return Waypoint{}, fmt.Errorf("waypoint %q: invalid number %q", spec, field)
+		}
+		values[i] = value
+	}
+
+	waypoint := Waypoint{X: values[0], Y: values[1]}
+	if len(fields) >= 3 && strings.TrimSpace(fields[2]) != "" {
+		theta := values[2]
+		waypoint.Theta = &theta
+	}
+	if len(fields) == 4 {
+		waypoint.MapID = strings.TrimSpace(fields[3])
+	}
+	return waypoint, nil
+}
+
+// ParseWaypoints WAYPOINTS 해석 (이름 -> 위치)
+// 형식: <name>=<x>,<y>[,<theta>[,<mapId>]];... (예: STATION_3=12.5,4.0,1.57,floor1)
+func (c *Config) ParseWaypoints() (map[string]Waypoint, error) {
+	waypoints := make(map[string]Waypoint)
+	for _, entry := range strings.Split(c.Waypoints, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		name, spec, found := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("waypoint %q: expected <name>=<x>,<y>[,<theta>[,<mapId>]]", entry)
+		}
+		if strings.ContainsAny(name, ",:"+PathSeparator+TargetSeparator+PrioritySeparator) {
+			return nil, fmt.Errorf("waypoint name %q must not contain ',', ':', %q, %q or %q", name, PathSeparator, TargetSeparator, PrioritySeparator)
+		}
+		waypoint, err := ParseWaypoint(spec)
+		if err != nil {
+			return nil, err
+		}
+		waypoints[name] = waypoint
+	}
+	return waypoints, nil
+}
+
+// ParsePath 웨이포인트 경로 해석 (형식: <waypoint>><waypoint>..., 각 웨이포인트는 좌표 또는 등록된 이름)
+func ParsePath(spec string, named map[string]Waypoint) ([]Waypoint, error) {
+	var path []Waypoint
+	for _, part := range strings.Split(spec, PathSeparator) {
+		waypoint, err := ResolveWaypoint(part, named)
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, waypoint)
+	}
+	return path, nil
+}
+
+// ResolveWaypoint 등록된 이름이면 해당 위치, 아니면 좌표로 해석
+func ResolveWaypoint(spec string, named map[string]Waypoint) (Waypoint, error) {
+	spec = strings.TrimSpace(spec)
+	if waypoint, exists := named[spec]; exists {
+		return waypoint, nil
+	}
+	if !strings.Contains(spec, ",") {
+		return Waypoint{}, fmt.Errorf("unknown waypoint %q", spec)
+	}
+	return ParseWaypoint(spec)
+}
+
+// ParseNavigationPaths NAV_PATHS 해석 (기본 명령 -> 경로)
+// 형식: <baseCommand>=<waypoint>>...;... (마지막 웨이포인트에서 액션 실행, WAYPOINTS 이름 사용 가능)
+func (c *Config) ParseNavigationPaths() (map[string][]Waypoint, error) {
+	named, err := c.ParseWaypoints()
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make(map[string][]Waypoint)
+	for _, entry := range strings.Split(c.NavPaths, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		baseCommand, spec, found := strings.Cut(entry, "=")
+		baseCommand = strings.TrimSpace(baseCommand)
+		if !found || baseCommand == "" {
+			return nil, fmt.Errorf("path %q: expected <baseCommand>=<waypoint>>...", entry)
+		}
+		path, err := ParsePath(spec, named)
+		if err != nil {
+			return nil, fmt.Errorf("path %q: %v", baseCommand, err)
+		}
+		paths[baseCommand] = path
+	}
+	return paths, nil
+}
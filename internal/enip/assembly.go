@@ -0,0 +1,155 @@
+// internal/enip/assembly.go - EtherNet/IP Command/Status Assemblies
+package enip
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"sync"
+)
+
+// Adapter EtherNet/IP 어댑터의 어셈블리 모델 (Allen-Bradley PLC가 스캐너)
+// 전송 계층(server.go, io.go)은 수신한 O->T 데이터를 SetOutput에, 전송할 T->O 데이터를 Input에 위임만 한다.
+//
+// 출력 어셈블리 (O->T, 인스턴스 150, PLC 쓰기, little-endian)
+//
+//	0-1    UINT  트리거 (0이 아닌 새 시퀀스 값이면 명령 실행)
+//	2-3    UINT  명령 길이
+//	4-35   SINT[32]  명령 (ASCII, 예: "PICK01:T:L")
+//
+// 입력 어셈블리 (T->O, 인스턴스 100, 브릿지 쓰기)
+//
+//	0-1    UINT  확인 시퀀스 (실행한 트리거 값)
+//	2-3    UINT  수락 결과 (0 없음, 1 수락, 2 거부)
+//	4-5    UINT  마지막 응답 상태 (0 없음, types.PLCStatusCode)
+//	6-7    UINT  응답 카운터 (응답마다 1 증가)
+//	8-9    UINT  응답 명령 길이
+//	10-41  SINT[32]  마지막 응답 명령
+type Adapter struct {
+	handler  *messaging.DirectActionHandler
+	eventBus *events.Bus
+
+	mu     sync.Mutex
+	input  [InputAssemblySize]byte
+	output [OutputAssemblySize]byte
+}
+
+// 어셈블리 인스턴스/크기
+const (
+	InputAssemblyInstance  = 100
+	OutputAssemblyInstance = 150
+	InputAssemblySize      = 42
+	OutputAssemblySize     = 36
+	stringSize             = 32
+)
+
+// 수락 결과 값
+const (
+	acceptedOK       = 1
+	acceptedRejected = 2
+)
+
+// ErrAssemblySize 어셈블리 크기 불일치 (Forward Open 연결 크기 확인 필요)
+var ErrAssemblySize = errors.New("assembly size mismatch")
+
+// NewAdapter 새 어댑터 생성 (로봇 하나의 핸들러)
+func NewAdapter(handler *messaging.DirectActionHandler, eventBus *events.Bus) *Adapter {
+	return &Adapter{
+		handler:  handler,
+		eventBus: eventBus,
+	}
+}
+
+// Run PLC 응답 이벤트를 입력 어셈블리에 반영 (ctx 종료 시 반환)
+func (a *Adapter) Run(ctx context.Context) {
+	eventCh, unsubscribe := a.eventBus.Subscribe(256)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if event.Type != events.TypePLCResponse || event.Robot != a.handler.RobotSerialNumber() {
+				continue
+			}
+
+			a.mu.Lock()
+			binary.LittleEndian.PutUint16(a.input[4:6], types.PLCStatusCode(event.Status))
+			binary.LittleEndian.PutUint16(a.input[6:8], binary.LittleEndian.Uint16(a.input[6:8])+1)
+			putString(a.input[8:], event.Command)
+			a.mu.Unlock()
+		}
+	}
+}
+
+// SetOutput 스캐너가 보낸 출력 어셈블리 반영 (새 트리거면 명령 실행)
+// class 1 I/O는 RPI마다 같은 데이터를 반복 전송하므로 트리거 변경 시에만 실행
+func (a *Adapter) SetOutput(data []byte) error {
+	if len(data) != OutputAssemblySize {
+		return fmt.Errorf("%w: output assembly expects %d bytes, got %d", ErrAssemblySize, OutputAssemblySize, len(data))
+	}
+
+	a.mu.Lock()
+	copy(a.output[:], data)
+	sequence := binary.LittleEndian.Uint16(data[0:2])
+	ack := binary.LittleEndian.Uint16(a.input[0:2])
+	a.mu.Unlock()
+
+	if sequence == 0 || sequence == ack {
+		return nil
+	}
+
+	command := getString(data[2:])
+	utils.Logger.Infof("📨 EtherNet/IP command (sequence %d): '%s'", sequence, command)
+
+	result := a.handler.ProcessCommand(command)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	binary.LittleEndian.PutUint16(a.input[0:2], sequence)
+	accepted := uint16(acceptedRejected)
+	if result.Accepted {
+		accepted = acceptedOK
+	}
+	binary.LittleEndian.PutUint16(a.input[2:4], accepted)
+	return nil
+}
+
+// Input 스캐너로 보낼 입력 어셈블리 (복사본)
+func (a *Adapter) Input() []byte {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]byte(nil), a.input[:]...)
+}
+
+// Output 마지막으로 받은 출력 어셈블리 (복사본, 진단용)
+func (a *Adapter) Output() []byte {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]byte(nil), a.output[:]...)
+}
+
+// putString UINT 길이 + SINT[32] 문자열 기록 (넘치는 부분은 잘림)
+func putString(data []byte, text string) {
+	if len(text) > stringSize {
+		text = text[:stringSize]
+	}
+	binary.LittleEndian.PutUint16(data[0:2], uint16(len(text)))
+	clear(data[2 : 2+stringSize])
+	copy(data[2:], text)
+}
+
+// getString UINT 길이 + SINT[32] 문자열 해석
+func getString(data []byte) string {
+	length := min(int(binary.LittleEndian.Uint16(data[0:2])), stringSize)
+	return string(data[2 : 2+length])
+}
@@ -0,0 +1,256 @@
+// internal/enip/cip.go - CIP Explicit Messaging (Identity, Assembly, Connection Manager)
+package enip
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// CIP 서비스 코드
+const (
+	serviceGetAttributesAll   = 0x01
+	serviceGetAttributeSingle = 0x0E
+	serviceSetAttributeSingle = 0x10
+	serviceForwardClose       = 0x4E
+	serviceUnconnectedSend    = 0x52
+	serviceForwardOpen        = 0x54
+	serviceReplyFlag          = 0x80
+)
+
+// CIP 객체 클래스
+const (
+	classIdentity          = 0x01
+	classAssembly          = 0x04
+	classConnectionManager = 0x06
+)
+
+// attrAssemblyData 어셈블리 데이터 속성
+const attrAssemblyData = 3
+
+// CIP 일반 상태
+const (
+	statusSuccess              = 0x00
+	statusConnectionFailure    = 0x01
+	statusPathSegmentError     = 0x04
+	statusPathDestUnknown      = 0x05
+	statusServiceNotSupported  = 0x08
+	statusAttributeNotSettable = 0x0E
+	statusNotEnoughData        = 0x13
+	statusAttributeNotSupport  = 0x14
+	statusTooMuchData          = 0x15
+)
+
+// errInvalidPath EPATH 해석 실패
+var errInvalidPath = errors.New("invalid CIP path")
+
+// cipPath 요청 경로 (논리 세그먼트만 해석, 포트/데이터 세그먼트는 건너뜀)
+type cipPath struct {
+	class        uint32
+	instance     uint32
+	attribute    uint32
+	hasAttribute bool
+	points       []uint32 // 연결 지점 (Forward Open: O->T, T->O 순서)
+}
+
+// parsePath EPATH 해석
+func parsePath(path []byte) (cipPath, error) {
+	var p cipPath
+	for len(path) > 0 {
+		segment := path[0]
+		switch {
+		case segment&0xE0 == 0x00: // 포트 세그먼트 (라우팅 경로, 브릿지는 최종 대상)
+			size := 2
+			if segment&0x10 != 0 {
+				if len(path) < 2 {
+					return p, errInvalidPath
+				}
+				size = 2 + int(path[1])
+				size += size % 2
+			}
+			if len(path) < size {
+				return p, errInvalidPath
+			}
+			path = path[size:]
+
+		case segment&0xE0 == 0x20: // 논리 세그먼트
+			var value uint32
+			var size int
+			switch segment & 0x03 {
+			case 0:
+				size = 2
+				if len(path) < size {
+					return p, errInvalidPath
+				}
+				value = uint32(path[1])
+			case 1:
+				size = 4
+				if len(path) < size {
+					return p, errInvalidPath
+				}
+				value = uint32(binary.LittleEndian.Uint16(path[2:4]))
+			case 2:
+				size = 6
+				if len(path) < size {
+					return p, errInvalidPath
+				}
+				value = binary.LittleEndian.Uint32(path[2:6])
+			default:
+				return p, errInvalidPath
+			}
+			switch segment & 0x1C {
+			case 0x00:
+				p.class = value
+			case 0x04:
+				p.instance = value
+			case 0x0C:
+				p.points = append(p.points, value)
+			case 0x10:
+				p.attribute, p.hasAttribute = value, true
+			default:
+				return p, fmt.Errorf("%w: unsupported logical segment 0x%02X", errInvalidPath, segment)
+			}
+			path = path[size:]
+
+		case segment == 0x80: // 단순 데이터 세그먼트 (구성 데이터, 사용하지 않음)
+			if len(path) < 2 || len(path) < 2+int(path[1])*2 {
+				return p, errInvalidPath
+			}
+			path = path[2+int(path[1])*2:]
+
+		default:
+			return p, fmt.Errorf("%w: unsupported segment 0x%02X", errInvalidPath, segment)
+		}
+	}
+	return p, nil
+}
+
+// cipRequest Message Router 요청
+type cipRequest struct {
+	service byte
+	path    []byte
+	data    []byte
+}
+
+// parseRequest 서비스 + 경로 크기(워드) + 경로 + 데이터
+func parseRequest(message []byte) (cipRequest, error) {
+	if len(message) < 2 || len(message) < 2+int(message[1])*2 {
+		return cipRequest{}, errShortPacket
+	}
+	pathEnd := 2 + int(message[1])*2
+	return cipRequest{service: message[0], path: message[2:pathEnd], data: message[pathEnd:]}, nil
+}
+
+// cipReply Message Router 응답 (확장 상태는 워드 단위)
+func cipReply(service byte, status byte, extended []uint16, data []byte) []byte {
+	reply := []byte{service | serviceReplyFlag, 0, status, byte(len(extended))}
+	for _, value := range extended {
+		reply = binary.LittleEndian.AppendUint16(reply, value)
+	}
+	return append(reply, data...)
+}
+
+// handleMessage 비연결 메시지 하나 처리 (SendRRData의 Unconnected Data 항목)
+func (s *Server) handleMessage(message []byte, peer ioPeer) []byte {
+	request, err := parseRequest(message)
+	if err != nil {
+		return cipReply(0, statusNotEnoughData, nil, nil)
+	}
+	path, err := parsePath(request.path)
+	if err != nil {
+		return cipReply(request.service, statusPathSegmentError, nil, nil)
+	}
+
+	switch path.class {
+	case classIdentity:
+		if path.instance != 1 {
+			return cipReply(request.service, statusPathDestUnknown, nil, nil)
+		}
+		if request.service != serviceGetAttributesAll {
+			return cipReply(request.service, statusServiceNotSupported, nil, nil)
+		}
+		return cipReply(request.service, statusSuccess, nil, s.identity.attributes())
+
+	case classAssembly:
+		return s.handleAssembly(request, path)
+
+	case classConnectionManager:
+		switch request.service {
+		case serviceForwardOpen:
+			return s.forwardOpen(request, peer)
+		case serviceForwardClose:
+			return s.forwardClose(request)
+		case serviceUnconnectedSend:
+			return s.unconnectedSend(request, peer)
+		}
+		return cipReply(request.service, statusServiceNotSupported, nil, nil)
+	}
+	return cipReply(request.service, statusPathDestUnknown, nil, nil)
+}
+
+// handleAssembly 어셈블리 데이터 읽기/쓰기 (MSG 명령의 CIP Generic Get/Set_Attribute_Single)
+func (s *Server) handleAssembly(request cipRequest, path cipPath) []byte {
+	if path.instance != InputAssemblyInstance && path.instance != OutputAssemblyInstance {
+		return cipReply(request.service, statusPathDestUnknown, nil, nil)
+	}
+	if !path.hasAttribute || path.attribute != attrAssemblyData {
+		return cipReply(request.service, statusAttributeNotSupport, nil, nil)
+	}
+
+	switch request.service {
+	case serviceGetAttributeSingle:
+		if path.instance == InputAssemblyInstance {
+			return cipReply(request.service, statusSuccess, nil, s.adapter.Input())
+		}
+		return cipReply(request.service, statusSuccess, nil, s.adapter.Output())
+
+	case serviceSetAttributeSingle:
+		if path.instance != OutputAssemblyInstance {
+			return cipReply(request.service, statusAttributeNotSettable, nil, nil)
+		}
+		if err := s.adapter.SetOutput(request.data); err != nil {
+			if len(request.data) < OutputAssemblySize {
+				return cipReply(request.service, statusNotEnoughData, nil, nil)
+			}
+			return cipReply(request.service, statusTooMuchData, nil, nil)
+		}
+		return cipReply(request.service, statusSuccess, nil, nil)
+	}
+	return cipReply(request.service, statusServiceNotSupported, nil, nil)
+}
+
+// unconnectedSend Connection Manager를 거쳐 라우팅된 요청 (브릿지가 최종 대상이므로 내장 요청을 직접 처리)
+func (s *Server) unconnectedSend(request cipRequest, peer ioPeer) []byte {
+	data := request.data
+	if len(data) < 4 {
+		return cipReply(request.service, statusNotEnoughData, nil, nil)
+	}
+	size := int(binary.LittleEndian.Uint16(data[2:4]))
+	if len(data) < 4+size {
+		return cipReply(request.service, statusNotEnoughData, nil, nil)
+	}
+	return s.handleMessage(data[4:4+size], peer)
+}
+
+// identity Identity 객체 속성 (ListIdentity, Get_Attributes_All)
+type identity struct {
+	vendorID     uint16
+	deviceType   uint16
+	productCode  uint16
+	revision     [2]byte
+	serialNumber uint32
+	productName  string
+}
+
+// attributes Get_Attributes_All 응답 (속성 1-7)
+func (id identity) attributes() []byte {
+	var b []byte
+	b = binary.LittleEndian.AppendUint16(b, id.vendorID)
+	b = binary.LittleEndian.AppendUint16(b, id.deviceType)
+	b = binary.LittleEndian.AppendUint16(b, id.productCode)
+	b = append(b, id.revision[:]...)
+	b = binary.LittleEndian.AppendUint16(b, 0) // 상태
+	b = binary.LittleEndian.AppendUint32(b, id.serialNumber)
+	b = append(b, byte(len(id.productName)))
+	return append(b, id.productName...)
+}
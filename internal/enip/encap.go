@@ -0,0 +1,132 @@
+// internal/enip/encap.go - EtherNet/IP Encapsulation and Common Packet Format
+package enip
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// 캡슐화 명령 (TCP 44818)
+const (
+	cmdListServices      = 0x0004
+	cmdListIdentity      = 0x0063
+	cmdRegisterSession   = 0x0065
+	cmdUnregisterSession = 0x0066
+	cmdSendRRData        = 0x006F
+)
+
+// 캡슐화 상태
+const (
+	encapSuccess         = 0x0000
+	encapInvalidCommand  = 0x0001
+	encapIncorrectData   = 0x0003
+	encapInvalidSession  = 0x0064
+	encapInvalidLength   = 0x0065
+	encapUnsupportedProt = 0x0069
+)
+
+// Common Packet Format 항목 타입
+const (
+	itemNullAddress      = 0x0000
+	itemListIdentity     = 0x000C
+	itemConnectedData    = 0x00B1
+	itemUnconnectedData  = 0x00B2
+	itemListServices     = 0x0100
+	itemSockaddrOT       = 0x8000
+	itemSockaddrTO       = 0x8001
+	itemSequencedAddress = 0x8002
+)
+
+// encapHeaderSize 캡슐화 헤더 크기
+const encapHeaderSize = 24
+
+// maxEncapData 캡슐화 데이터 최대 크기 (명령/상태 어셈블리는 수십 바이트)
+const maxEncapData = 4096
+
+// errShortPacket CPF 항목 해석 실패
+var errShortPacket = errors.New("short EtherNet/IP packet")
+
+// encapHeader 캡슐화 헤더 (little-endian)
+type encapHeader struct {
+	command       uint16
+	length        uint16
+	session       uint32
+	status        uint32
+	senderContext [8]byte
+	options       uint32
+}
+
+// parseEncapHeader 헤더 24바이트 해석
+func parseEncapHeader(data []byte) encapHeader {
+	header := encapHeader{
+		command: binary.LittleEndian.Uint16(data[0:2]),
+		length:  binary.LittleEndian.Uint16(data[2:4]),
+		session: binary.LittleEndian.Uint32(data[4:8]),
+		status:  binary.LittleEndian.Uint32(data[8:12]),
+		options: binary.LittleEndian.Uint32(data[20:24]),
+	}
+	copy(header.senderContext[:], data[12:20])
+	return header
+}
+
+// encapFrame 응답 프레임 (요청의 명령, 세션, sender context 유지)
+func encapFrame(request encapHeader, status uint32, data []byte) []byte {
+	frame := make([]byte, encapHeaderSize, encapHeaderSize+len(data))
+	binary.LittleEndian.PutUint16(frame[0:2], request.command)
+	binary.LittleEndian.PutUint16(frame[2:4], uint16(len(data)))
+	binary.LittleEndian.PutUint32(frame[4:8], request.session)
+	binary.LittleEndian.PutUint32(frame[8:12], status)
+	copy(frame[12:20], request.senderContext[:])
+	return append(frame, data...)
+}
+
+// cpfItem Common Packet Format 항목
+type cpfItem struct {
+	typeID uint16
+	data   []byte
+}
+
+// parseCPF 항목 목록 해석
+func parseCPF(data []byte) ([]cpfItem, error) {
+	if len(data) < 2 {
+		return nil, errShortPacket
+	}
+	count := int(binary.LittleEndian.Uint16(data[0:2]))
+	data = data[2:]
+
+	items := make([]cpfItem, 0, count)
+	for range count {
+		if len(data) < 4 {
+			return nil, errShortPacket
+		}
+		typeID := binary.LittleEndian.Uint16(data[0:2])
+		length := int(binary.LittleEndian.Uint16(data[2:4]))
+		if len(data) < 4+length {
+			return nil, errShortPacket
+		}
+		items = append(items, cpfItem{typeID: typeID, data: data[4 : 4+length]})
+		data = data[4+length:]
+	}
+	return items, nil
+}
+
+// appendCPF 항목 목록 인코딩
+func appendCPF(b []byte, items ...cpfItem) []byte {
+	b = binary.LittleEndian.AppendUint16(b, uint16(len(items)))
+	for _, item := range items {
+		b = binary.LittleEndian.AppendUint16(b, item.typeID)
+		b = binary.LittleEndian.AppendUint16(b, uint16(len(item.data)))
+		b = append(b, item.data...)
+	}
+	return b
+}
+
+// findItem 타입이 일치하는 첫 번째 항목
+func findItem(items []cpfItem, typeID uint16) (cpfItem, bool) {
+	for _, item := range items {
+		if item.typeID == typeID {
+			return item, true
+		}
+	}
+	return cpfItem{}, false
+}
@@ -0,0 +1,297 @@
+// internal/enip/io.go - Class 1 Implicit I/O Connections (Forward Open, UDP 2222)
+package enip
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+	"mqtt-bridge/internal/utils"
+	"net"
+	"time"
+)
+
+// ioPort class 1 I/O UDP 포트
+const ioPort = 2222
+
+// minRPI 지원하는 최소 RPI
+const minRPI = 2 * time.Millisecond
+
+// 연결 헤더 크기 (Forward Open 연결 크기에 포함)
+const (
+	sequenceCountSize = 2 // class 1 시퀀스 카운트
+	runIdleHeaderSize = 4 // O->T 32비트 Run/Idle 헤더
+)
+
+// Connection Manager 확장 상태
+const (
+	extConnectionInUse       = 0x0100
+	extTransportNotSupported = 0x0103
+	extOwnershipConflict     = 0x0106
+	extConnectionNotFound    = 0x0107
+	extInvalidConnectionType = 0x0108
+	extRPINotSupported       = 0x0111
+	extInvalidOTSize         = 0x0127
+	extInvalidTOSize         = 0x0128
+	extInvalidPathSegment    = 0x0315
+)
+
+// ioPeer Forward Open을 보낸 스캐너 (T->O 전송 대상)
+type ioPeer struct {
+	ip     net.IP
+	toAddr *net.UDPAddr // CPF Sockaddr Info(T->O) 항목이 있으면 해당 주소
+}
+
+// ioConnection Exclusive Owner class 1 연결 (O->T 출력 어셈블리, T->O 입력 어셈블리)
+type ioConnection struct {
+	otID             uint32
+	toID             uint32
+	serial           uint16
+	vendorID         uint16
+	originatorSerial uint32
+	toRPI            time.Duration
+	timeout          time.Duration
+	runIdleHeader    bool
+	target           *net.UDPAddr
+
+	lastConsumed time.Time
+	consumedSeq  uint16
+	consumedAny  bool
+	encapSeq     uint32
+	producedSeq  uint16
+	done         chan struct{}
+}
+
+// sameOriginator Forward Open/Close 연결 식별 (연결 일련번호, 벤더, 스캐너 일련번호)
+func (c *ioConnection) sameOriginator(serial, vendorID uint16, originatorSerial uint32) bool {
+	return c.serial == serial && c.vendorID == vendorID && c.originatorSerial == originatorSerial
+}
+
+// forwardOpen class 1 연결 개설 (스캐너 하나만 출력 어셈블리 소유)
+func (s *Server) forwardOpen(request cipRequest, peer ioPeer) []byte {
+	data := request.data
+	if len(data) < 36 {
+		return cipReply(request.service, statusNotEnoughData, nil, nil)
+	}
+	toID := binary.LittleEndian.Uint32(data[6:10])
+	serial := binary.LittleEndian.Uint16(data[10:12])
+	vendorID := binary.LittleEndian.Uint16(data[12:14])
+	originatorSerial := binary.LittleEndian.Uint32(data[14:18])
+	multiplier := data[18]
+	otRPI := time.Duration(binary.LittleEndian.Uint32(data[22:26])) * time.Microsecond
+	otParams := binary.LittleEndian.Uint16(data[26:28])
+	toRPI := time.Duration(binary.LittleEndian.Uint32(data[28:32])) * time.Microsecond
+	toParams := binary.LittleEndian.Uint16(data[32:34])
+	transport := data[34]
+	pathSize := int(data[35]) * 2
+	if len(data) < 36+pathSize {
+		return cipReply(request.service, statusNotEnoughData, nil, nil)
+	}
+
+	fail := func(extended uint16) []byte {
+		reply := binary.LittleEndian.AppendUint16(nil, serial)
+		reply = binary.LittleEndian.AppendUint16(reply, vendorID)
+		reply = binary.LittleEndian.AppendUint32(reply, originatorSerial)
+		reply = append(reply, 0, 0)
+		utils.Logger.Warnf("⚠️ EtherNet/IP Forward Open from %s rejected (extended status 0x%04X)", peer.ip, extended)
+		return cipReply(request.service, statusConnectionFailure, []uint16{extended}, reply)
+	}
+
+	path, err := parsePath(data[36 : 36+pathSize])
+	if err != nil || path.class != classAssembly || len(path.points) != 2 ||
+		path.points[0] != OutputAssemblyInstance || path.points[1] != InputAssemblyInstance {
+		return fail(extInvalidPathSegment)
+	}
+	if transport&0x0F != 1 {
+		return fail(extTransportNotSupported)
+	}
+	// 연결 타입 (비트 13-14): 10 = 점대점, T->O 멀티캐스트는 지원하지 않음
+	if otParams>>13&0x03 != 2 || toParams>>13&0x03 != 2 {
+		return fail(extInvalidConnectionType)
+	}
+	if otRPI < minRPI || toRPI < minRPI {
+		return fail(extRPINotSupported)
+	}
+
+	otSize := int(otParams & 0x01FF)
+	toSize := int(toParams & 0x01FF)
+	var runIdleHeader bool
+	switch otSize {
+	case sequenceCountSize + OutputAssemblySize:
+	case sequenceCountSize + runIdleHeaderSize + OutputAssemblySize:
+		runIdleHeader = true
+	default:
+		return fail(extInvalidOTSize)
+	}
+	if toSize != sequenceCountSize+InputAssemblySize {
+		return fail(extInvalidTOSize)
+	}
+
+	target := peer.toAddr
+	if target == nil {
+		target = &net.UDPAddr{IP: peer.ip, Port: ioPort}
+	}
+
+	s.mu.Lock()
+	if s.io != nil {
+		owned := s.io.sameOriginator(serial, vendorID, originatorSerial)
+		s.mu.Unlock()
+		if owned {
+			return fail(extConnectionInUse)
+		}
+		return fail(extOwnershipConflict)
+	}
+	conn := &ioConnection{
+		otID:             rand.Uint32(),
+		toID:             toID,
+		serial:           serial,
+		vendorID:         vendorID,
+		originatorSerial: originatorSerial,
+		toRPI:            toRPI,
+		timeout:          otRPI * time.Duration(4<<min(multiplier, 7)),
+		runIdleHeader:    runIdleHeader,
+		target:           target,
+		lastConsumed:     time.Now(),
+		done:             make(chan struct{}),
+	}
+	s.io = conn
+	s.mu.Unlock()
+
+	utils.Logger.Infof("🔗 EtherNet/IP I/O connection opened by %s (RPI %s/%s, timeout %s)", target, otRPI, toRPI, conn.timeout)
+	go s.produce(conn)
+
+	reply := binary.LittleEndian.AppendUint32(nil, conn.otID)
+	reply = binary.LittleEndian.AppendUint32(reply, conn.toID)
+	reply = binary.LittleEndian.AppendUint16(reply, serial)
+	reply = binary.LittleEndian.AppendUint16(reply, vendorID)
+	reply = binary.LittleEndian.AppendUint32(reply, originatorSerial)
+	reply = binary.LittleEndian.AppendUint32(reply, uint32(otRPI/time.Microsecond))
+	reply = binary.LittleEndian.AppendUint32(reply, uint32(toRPI/time.Microsecond))
+	reply = append(reply, 0, 0)
+	return cipReply(request.service, statusSuccess, nil, reply)
+}
+
+// forwardClose class 1 연결 종료
+func (s *Server) forwardClose(request cipRequest) []byte {
+	data := request.data
+	if len(data) < 10 {
+		return cipReply(request.service, statusNotEnoughData, nil, nil)
+	}
+	serial := binary.LittleEndian.Uint16(data[2:4])
+	vendorID := binary.LittleEndian.Uint16(data[4:6])
+	originatorSerial := binary.LittleEndian.Uint32(data[6:10])
+
+	reply := append([]byte(nil), data[2:10]...)
+	reply = append(reply, 0, 0)
+
+	s.mu.Lock()
+	conn := s.io
+	if conn == nil || !conn.sameOriginator(serial, vendorID, originatorSerial) {
+		s.mu.Unlock()
+		return cipReply(request.service, statusConnectionFailure, []uint16{extConnectionNotFound}, reply)
+	}
+	s.closeIO(conn, "closed by scanner")
+	s.mu.Unlock()
+	return cipReply(request.service, statusSuccess, nil, reply)
+}
+
+// closeIO 연결 종료 (잠금 보유 상태에서 호출)
+func (s *Server) closeIO(conn *ioConnection, reason string) {
+	if s.io != conn {
+		return
+	}
+	s.io = nil
+	close(conn.done)
+	utils.Logger.Infof("🔗 EtherNet/IP I/O connection to %s closed: %s", conn.target, reason)
+}
+
+// produce T->O 입력 어셈블리를 RPI마다 전송, O->T 수신이 시간 초과되면 연결 종료
+func (s *Server) produce(conn *ioConnection) {
+	ticker := time.NewTicker(conn.toRPI)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-conn.done:
+			return
+		case <-ticker.C:
+		}
+
+		s.mu.Lock()
+		if time.Since(conn.lastConsumed) > conn.timeout {
+			s.closeIO(conn, "O->T connection timed out")
+			s.mu.Unlock()
+			return
+		}
+		conn.encapSeq++
+		conn.producedSeq++
+		address := binary.LittleEndian.AppendUint32(nil, conn.toID)
+		address = binary.LittleEndian.AppendUint32(address, conn.encapSeq)
+		payload := binary.LittleEndian.AppendUint16(nil, conn.producedSeq)
+		payload = append(payload, s.adapter.Input()...)
+		udp := s.udp
+		s.mu.Unlock()
+
+		packet := appendCPF(nil,
+			cpfItem{typeID: itemSequencedAddress, data: address},
+			cpfItem{typeID: itemConnectedData, data: payload},
+		)
+		if udp == nil {
+			continue
+		}
+		if _, err := udp.WriteToUDP(packet, conn.target); err != nil {
+			utils.Logger.Warnf("⚠️ EtherNet/IP T->O send to %s failed: %v", conn.target, err)
+		}
+	}
+}
+
+// consume O->T 패킷 하나 처리 (Run 상태에서 새 시퀀스일 때만 출력 어셈블리 반영)
+func (s *Server) consume(packet []byte, from *net.UDPAddr) {
+	items, err := parseCPF(packet)
+	if err != nil {
+		return
+	}
+	address, ok := findItem(items, itemSequencedAddress)
+	if !ok || len(address.data) < 8 {
+		return
+	}
+	payload, ok := findItem(items, itemConnectedData)
+	if !ok {
+		return
+	}
+	connectionID := binary.LittleEndian.Uint32(address.data[0:4])
+
+	s.mu.Lock()
+	conn := s.io
+	if conn == nil || conn.otID != connectionID {
+		s.mu.Unlock()
+		return
+	}
+	data := payload.data
+	if len(data) < sequenceCountSize {
+		s.mu.Unlock()
+		return
+	}
+	sequence := binary.LittleEndian.Uint16(data[0:2])
+	data = data[sequenceCountSize:]
+	conn.lastConsumed = time.Now()
+	duplicate := conn.consumedAny && sequence == conn.consumedSeq
+	conn.consumedSeq, conn.consumedAny = sequence, true
+
+	run := true
+	if conn.runIdleHeader {
+		if len(data) < runIdleHeaderSize {
+			s.mu.Unlock()
+			return
+		}
+		run = binary.LittleEndian.Uint32(data[0:4])&0x01 != 0
+		data = data[runIdleHeaderSize:]
+	}
+	s.mu.Unlock()
+
+	// Idle(프로그램 모드)에서는 출력을 반영하지 않음
+	if duplicate || !run {
+		return
+	}
+	if err := s.adapter.SetOutput(data); err != nil {
+		utils.Logger.Warnf("⚠️ EtherNet/IP O->T data from %s ignored: %v", from, err)
+	}
+}
@@ -0,0 +1,322 @@
+// internal/enip/server.go - EtherNet/IP Adapter Server (TCP 44818 explicit, UDP 2222 class 1 I/O)
+package enip
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"io"
+	"math/rand/v2"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// idleTimeout 요청이 없는 세션 종료 시간
+const idleTimeout = 5 * time.Minute
+
+// Identity 객체 값 (ODVA 등록 벤더 ID가 없으므로 시험용 값 사용)
+const (
+	vendorID    = 0xFFFF
+	deviceType  = 0x0C // Communications Adapter
+	productCode = 1
+	productName = "Direct Action Bridge"
+)
+
+// Server EtherNet/IP 어댑터 (Allen-Bradley PLC가 스캐너)
+// 명시적 메시지(MSG Get/Set_Attribute_Single)와 Exclusive Owner class 1 I/O(Generic Ethernet Module) 모두 지원
+type Server struct {
+	addr     string
+	adapter  *Adapter
+	identity identity
+
+	mu        sync.Mutex
+	listener  net.Listener
+	discovery *net.UDPConn // ListIdentity 브로드캐스트 응답 (UDP 44818)
+	udp       *net.UDPConn // class 1 I/O (UDP 2222)
+	conns     map[net.Conn]struct{}
+	io        *ioConnection
+}
+
+// NewServer 새 EtherNet/IP 서버 생성 (로봇 하나의 핸들러)
+func NewServer(addr string, handler *messaging.DirectActionHandler, eventBus *events.Bus) *Server {
+	serial := fnv.New32a()
+	serial.Write([]byte(handler.RobotSerialNumber()))
+
+	return &Server{
+		addr:    addr,
+		adapter: NewAdapter(handler, eventBus),
+		identity: identity{
+			vendorID:     vendorID,
+			deviceType:   deviceType,
+			productCode:  productCode,
+			revision:     [2]byte{1, 1},
+			serialNumber: serial.Sum32(),
+			productName:  productName,
+		},
+		conns: make(map[net.Conn]struct{}),
+	}
+}
+
+// Start 리스너 열기 및 요청 처리 시작 (백그라운드)
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+	discovery, err := net.ListenPacket("udp", s.addr)
+	if err != nil {
+		listener.Close()
+		return err
+	}
+	host, _, _ := net.SplitHostPort(s.addr)
+	udp, err := net.ListenPacket("udp", net.JoinHostPort(host, strconv.Itoa(ioPort)))
+	if err != nil {
+		listener.Close()
+		discovery.Close()
+		return err
+	}
+
+	s.mu.Lock()
+	s.listener = listener
+	s.discovery = discovery.(*net.UDPConn)
+	s.udp = udp.(*net.UDPConn)
+	s.mu.Unlock()
+
+	utils.Logger.Infof("🔌 EtherNet/IP adapter listening on %s (I/O on UDP %d, robot %s)", s.addr, ioPort, s.adapter.handler.RobotSerialNumber())
+	go s.adapter.Run(ctx)
+	go s.accept(listener)
+	go s.serveDiscovery(s.discovery)
+	go s.receiveIO(s.udp)
+	return nil
+}
+
+// Stop 리스너, I/O 연결, 모든 세션 종료
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.io != nil {
+		s.closeIO(s.io, "bridge stopping")
+	}
+	for _, closer := range []io.Closer{s.listener, s.discovery, s.udp} {
+		if closer != nil {
+			closer.Close()
+		}
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	utils.Logger.Info("EtherNet/IP adapter stopped")
+}
+
+// accept 연결 수락 루프 (리스너가 닫히면 반환)
+func (s *Server) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				utils.Logger.Errorf("❌ EtherNet/IP accept failed: %v", err)
+			}
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		go s.serve(conn)
+	}
+}
+
+// serve TCP 세션 하나의 캡슐화 요청 처리
+func (s *Server) serve(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	utils.Logger.Infof("🔌 EtherNet/IP client connected: %s", conn.RemoteAddr())
+
+	var session uint32
+	peerIP := net.IPv4zero
+	if tcpAddr, ok := conn.RemoteAddr().(*net.TCPAddr); ok {
+		peerIP = tcpAddr.IP
+	}
+
+	headerData := make([]byte, encapHeaderSize)
+	for {
+		conn.SetReadDeadline(time.Now().Add(idleTimeout))
+		if _, err := io.ReadFull(conn, headerData); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				utils.Logger.Warnf("⚠️ EtherNet/IP connection closed: %s - %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+		header := parseEncapHeader(headerData)
+		if header.length > maxEncapData {
+			utils.Logger.Warnf("⚠️ Oversized EtherNet/IP frame from %s, closing connection", conn.RemoteAddr())
+			return
+		}
+		data := make([]byte, header.length)
+		if _, err := io.ReadFull(conn, data); err != nil {
+			return
+		}
+
+		var response []byte
+		switch header.command {
+		case cmdRegisterSession:
+			if len(data) != 4 || binary.LittleEndian.Uint16(data[0:2]) != 1 {
+				response = encapFrame(header, encapUnsupportedProt, data)
+				break
+			}
+			if session == 0 {
+				session = newSession()
+			}
+			header.session = session
+			response = encapFrame(header, encapSuccess, data)
+
+		case cmdUnregisterSession:
+			return
+
+		case cmdSendRRData:
+			if session == 0 || header.session != session {
+				response = encapFrame(header, encapInvalidSession, nil)
+				break
+			}
+			response = s.sendRRData(header, data, peerIP)
+
+		default:
+			response = s.handleStateless(header, conn.LocalAddr())
+		}
+
+		if _, err := conn.Write(response); err != nil {
+			return
+		}
+	}
+}
+
+// sendRRData 비연결 명시적 메시지 (Null Address + Unconnected Data 항목)
+func (s *Server) sendRRData(header encapHeader, data []byte, peerIP net.IP) []byte {
+	if len(data) < 6 {
+		return encapFrame(header, encapInvalidLength, nil)
+	}
+	items, err := parseCPF(data[6:])
+	if err != nil {
+		return encapFrame(header, encapInvalidLength, nil)
+	}
+	message, ok := findItem(items, itemUnconnectedData)
+	if !ok {
+		return encapFrame(header, encapIncorrectData, nil)
+	}
+
+	peer := ioPeer{ip: peerIP}
+	if sockaddr, ok := findItem(items, itemSockaddrTO); ok && len(sockaddr.data) >= 8 {
+		ip := net.IPv4(sockaddr.data[4], sockaddr.data[5], sockaddr.data[6], sockaddr.data[7])
+		if ip.IsUnspecified() {
+			ip = peerIP
+		}
+		peer.toAddr = &net.UDPAddr{IP: ip, Port: int(binary.BigEndian.Uint16(sockaddr.data[2:4]))}
+	}
+
+	reply := make([]byte, 6) // 인터페이스 핸들, 시간 제한
+	reply = appendCPF(reply,
+		cpfItem{typeID: itemNullAddress},
+		cpfItem{typeID: itemUnconnectedData, data: s.handleMessage(message.data, peer)},
+	)
+	return encapFrame(header, encapSuccess, reply)
+}
+
+// handleStateless 세션 없이 처리하는 명령 (ListIdentity, ListServices, TCP/UDP 공용)
+func (s *Server) handleStateless(header encapHeader, local net.Addr) []byte {
+	switch header.command {
+	case cmdListIdentity:
+		return encapFrame(header, encapSuccess, appendCPF(nil, cpfItem{typeID: itemListIdentity, data: s.identityItem(local)}))
+	case cmdListServices:
+		service := binary.LittleEndian.AppendUint16(nil, 1)
+		service = binary.LittleEndian.AppendUint16(service, 0x0120) // CIP over TCP, class 0/1 UDP
+		name := make([]byte, 16)
+		copy(name, "Communications")
+		return encapFrame(header, encapSuccess, appendCPF(nil, cpfItem{typeID: itemListServices, data: append(service, name...)}))
+	}
+	return encapFrame(header, encapInvalidCommand, nil)
+}
+
+// identityItem ListIdentity 항목 (프로토콜 버전, 소켓 주소, Identity 속성, 상태)
+func (s *Server) identityItem(local net.Addr) []byte {
+	item := binary.LittleEndian.AppendUint16(nil, 1)
+
+	ip, port := net.IPv4zero.To4(), 44818
+	switch addr := local.(type) {
+	case *net.TCPAddr:
+		port = addr.Port
+		if v4 := addr.IP.To4(); v4 != nil {
+			ip = v4
+		}
+	case *net.UDPAddr:
+		port = addr.Port
+		if v4 := addr.IP.To4(); v4 != nil {
+			ip = v4
+		}
+	}
+	item = binary.BigEndian.AppendUint16(item, 2) // AF_INET
+	item = binary.BigEndian.AppendUint16(item, uint16(port))
+	item = append(item, ip...)
+	item = append(item, make([]byte, 8)...)
+
+	item = append(item, s.identity.attributes()...)
+	return append(item, 0xFF) // 상태 (미구현)
+}
+
+// serveDiscovery UDP ListIdentity/ListServices 요청 응답 (RSLinx 등의 브라우징)
+func (s *Server) serveDiscovery(conn *net.UDPConn) {
+	buffer := make([]byte, 512)
+	for {
+		n, from, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				utils.Logger.Errorf("❌ EtherNet/IP discovery read failed: %v", err)
+			}
+			return
+		}
+		if n < encapHeaderSize {
+			continue
+		}
+		header := parseEncapHeader(buffer[:n])
+		if header.command != cmdListIdentity && header.command != cmdListServices {
+			continue
+		}
+		conn.WriteToUDP(s.handleStateless(header, conn.LocalAddr()), from)
+	}
+}
+
+// receiveIO class 1 O->T 패킷 수신 루프
+func (s *Server) receiveIO(conn *net.UDPConn) {
+	buffer := make([]byte, 1500)
+	for {
+		n, from, err := conn.ReadFromUDP(buffer)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				utils.Logger.Errorf("❌ EtherNet/IP I/O read failed: %v", err)
+			}
+			return
+		}
+		s.consume(buffer[:n], from)
+	}
+}
+
+// newSession 새 세션 핸들 (0은 사용하지 않음)
+func newSession() uint32 {
+	for {
+		if session := rand.Uint32(); session != 0 {
+			return session
+		}
+	}
+}
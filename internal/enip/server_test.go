@@ -0,0 +1,322 @@
+package enip
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/harness"
+	"mqtt-bridge/internal/types"
+	"net"
+	"testing"
+	"time"
+)
+
+// testSession 하네스 핸들러에 연결된 어댑터와 등록된 TCP 세션
+type testSession struct {
+	t       *testing.T
+	h       *harness.Harness
+	server  *Server
+	conn    net.Conn
+	session uint32
+}
+
+func newTestSession(t *testing.T) *testSession {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config: %v", err)
+	}
+	h, err := harness.New(cfg, time.Millisecond)
+	if err != nil {
+		t.Fatalf("harness: %v", err)
+	}
+	t.Cleanup(h.Close)
+
+	server := NewServer("127.0.0.1:0", h.Handler, h.Events)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := server.Start(ctx); err != nil {
+		cancel()
+		t.Fatalf("start: %v", err)
+	}
+	t.Cleanup(func() {
+		server.Stop()
+		cancel()
+	})
+
+	conn, err := net.Dial("tcp", server.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	s := &testSession{t: t, h: h, server: server, conn: conn}
+	header, _ := s.request(cmdRegisterSession, []byte{1, 0, 0, 0})
+	if header.status != encapSuccess || header.session == 0 {
+		t.Fatalf("RegisterSession: status 0x%X, session %d", header.status, header.session)
+	}
+	s.session = header.session
+	return s
+}
+
+// request 캡슐화 요청 하나 전송 후 응답
+func (s *testSession) request(command uint16, data []byte) (encapHeader, []byte) {
+	s.t.Helper()
+	frame := encapFrame(encapHeader{command: command, session: s.session}, 0, data)
+	if _, err := s.conn.Write(frame); err != nil {
+		s.t.Fatalf("write: %v", err)
+	}
+	s.conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	headerData := make([]byte, encapHeaderSize)
+	if _, err := io.ReadFull(s.conn, headerData); err != nil {
+		s.t.Fatalf("read header: %v", err)
+	}
+	header := parseEncapHeader(headerData)
+	reply := make([]byte, header.length)
+	if _, err := io.ReadFull(s.conn, reply); err != nil {
+		s.t.Fatalf("read data: %v", err)
+	}
+	return header, reply
+}
+
+// cip SendRRData로 CIP 요청 전송 후 (일반 상태, 확장 상태, 응답 데이터)
+func (s *testSession) cip(message []byte, extra ...cpfItem) (byte, []uint16, []byte) {
+	s.t.Helper()
+	items := append([]cpfItem{{typeID: itemNullAddress}, {typeID: itemUnconnectedData, data: message}}, extra...)
+	header, reply := s.request(cmdSendRRData, appendCPF(make([]byte, 6), items...))
+	if header.status != encapSuccess {
+		s.t.Fatalf("SendRRData: status 0x%X", header.status)
+	}
+	parsed, err := parseCPF(reply[6:])
+	if err != nil {
+		s.t.Fatalf("SendRRData reply: %v", err)
+	}
+	item, ok := findItem(parsed, itemUnconnectedData)
+	if !ok || len(item.data) < 4 {
+		s.t.Fatalf("SendRRData reply: no unconnected data item")
+	}
+	extended := make([]uint16, item.data[3])
+	for i := range extended {
+		extended[i] = binary.LittleEndian.Uint16(item.data[4+i*2:])
+	}
+	return item.data[2], extended, item.data[4+len(extended)*2:]
+}
+
+// assemblyRequest 어셈블리 데이터 속성 Get/Set_Attribute_Single 요청
+func assemblyRequest(service byte, instance byte, data []byte) []byte {
+	return append([]byte{service, 3, 0x20, classAssembly, 0x24, instance, 0x30, attrAssemblyData}, data...)
+}
+
+// outputAssembly 트리거 + 명령 출력 어셈블리
+func outputAssembly(sequence uint16, command string) []byte {
+	data := make([]byte, OutputAssemblySize)
+	binary.LittleEndian.PutUint16(data[0:2], sequence)
+	putString(data[2:], command)
+	return data
+}
+
+// waitInput 입력 어셈블리가 조건을 만족할 때까지 대기
+func (s *testSession) waitInput(check func(input []byte) bool) []byte {
+	s.t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for {
+		input := s.server.adapter.Input()
+		if check(input) {
+			return input
+		}
+		if time.Now().After(deadline) {
+			s.t.Fatalf("input assembly never matched: % X", input)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestExplicitMessaging(t *testing.T) {
+	s := newTestSession(t)
+
+	tests := []struct {
+		name    string
+		message []byte
+		status  byte
+	}{
+		{"identity", []byte{serviceGetAttributesAll, 2, 0x20, classIdentity, 0x24, 1}, statusSuccess},
+		{"read input", assemblyRequest(serviceGetAttributeSingle, InputAssemblyInstance, nil), statusSuccess},
+		{"write input", assemblyRequest(serviceSetAttributeSingle, InputAssemblyInstance, make([]byte, InputAssemblySize)), statusAttributeNotSettable},
+		{"short output", assemblyRequest(serviceSetAttributeSingle, OutputAssemblyInstance, make([]byte, 4)), statusNotEnoughData},
+		{"unknown instance", assemblyRequest(serviceGetAttributeSingle, 7, nil), statusPathDestUnknown},
+		{"unknown class", []byte{serviceGetAttributesAll, 2, 0x20, 0x70, 0x24, 1}, statusPathDestUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status, _, _ := s.cip(tt.message); status != tt.status {
+				t.Errorf("general status 0x%02X, want 0x%02X", status, tt.status)
+			}
+		})
+	}
+}
+
+func TestExplicitCommand(t *testing.T) {
+	s := newTestSession(t)
+	s.h.Robot.SetOutcome(harness.OutcomeSucceed)
+
+	status, _, _ := s.cip(assemblyRequest(serviceSetAttributeSingle, OutputAssemblyInstance, outputAssembly(1, "CAL:I")))
+	if status != statusSuccess {
+		t.Fatalf("Set_Attribute_Single: status 0x%02X", status)
+	}
+
+	input := s.waitInput(func(input []byte) bool {
+		return binary.LittleEndian.Uint16(input[4:6]) == types.PLCStatusCode(types.PLCStatusSuccess)
+	})
+	if ack := binary.LittleEndian.Uint16(input[0:2]); ack != 1 {
+		t.Errorf("ack sequence %d, want 1", ack)
+	}
+	if accepted := binary.LittleEndian.Uint16(input[2:4]); accepted != acceptedOK {
+		t.Errorf("accepted %d, want %d", accepted, acceptedOK)
+	}
+	if command := getString(input[8:]); command != "CAL" {
+		t.Errorf("response command %q, want CAL", command)
+	}
+
+	_, _, data := s.cip(assemblyRequest(serviceGetAttributeSingle, InputAssemblyInstance, nil))
+	if len(data) != InputAssemblySize {
+		t.Errorf("Get_Attribute_Single: %d bytes, want %d", len(data), InputAssemblySize)
+	}
+}
+
+// forwardOpenRequest class 1 Exclusive Owner Forward Open (O->T 32비트 Run/Idle 헤더)
+func forwardOpenRequest(otSize, toSize int, rpi time.Duration) []byte {
+	data := []byte{0x0A, 0x0E}
+	data = binary.LittleEndian.AppendUint32(data, 0)          // O->T ID (대상이 선택)
+	data = binary.LittleEndian.AppendUint32(data, 0x12345678) // T->O ID
+	data = binary.LittleEndian.AppendUint16(data, 7)          // 연결 일련번호
+	data = binary.LittleEndian.AppendUint16(data, 1)          // 벤더
+	data = binary.LittleEndian.AppendUint32(data, 42)         // 스캐너 일련번호
+	data = append(data, 0, 0, 0, 0)                           // 시간 초과 배수 (x4), 예약
+	data = binary.LittleEndian.AppendUint32(data, uint32(rpi/time.Microsecond))
+	data = binary.LittleEndian.AppendUint16(data, 0x4000|uint16(otSize))
+	data = binary.LittleEndian.AppendUint32(data, uint32(rpi/time.Microsecond))
+	data = binary.LittleEndian.AppendUint16(data, 0x4000|uint16(toSize))
+	data = append(data, 0x01) // class 1 cyclic
+	path := []byte{0x20, classAssembly, 0x24, 1, 0x2C, OutputAssemblyInstance, 0x2C, InputAssemblyInstance}
+	data = append(data, byte(len(path)/2))
+	data = append(data, path...)
+	return append([]byte{serviceForwardOpen, 2, 0x20, classConnectionManager, 0x24, 1}, data...)
+}
+
+func TestForwardOpenValidation(t *testing.T) {
+	s := newTestSession(t)
+
+	tests := []struct {
+		name     string
+		otSize   int
+		toSize   int
+		rpi      time.Duration
+		extended uint16
+	}{
+		{"wrong O->T size", OutputAssemblySize, sequenceCountSize + InputAssemblySize, 10 * time.Millisecond, extInvalidOTSize},
+		{"wrong T->O size", sequenceCountSize + runIdleHeaderSize + OutputAssemblySize, InputAssemblySize, 10 * time.Millisecond, extInvalidTOSize},
+		{"RPI too small", sequenceCountSize + runIdleHeaderSize + OutputAssemblySize, sequenceCountSize + InputAssemblySize, time.Millisecond, extRPINotSupported},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, extended, _ := s.cip(forwardOpenRequest(tt.otSize, tt.toSize, tt.rpi))
+			if status != statusConnectionFailure || len(extended) != 1 || extended[0] != tt.extended {
+				t.Errorf("status 0x%02X %04X, want 0x01 [%04X]", status, extended, tt.extended)
+			}
+		})
+	}
+}
+
+func TestClassOneIO(t *testing.T) {
+	s := newTestSession(t)
+	s.h.Robot.SetOutcome(harness.OutcomeSucceed)
+
+	scanner, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("scanner socket: %v", err)
+	}
+	defer scanner.Close()
+	scannerAddr := scanner.LocalAddr().(*net.UDPAddr)
+
+	sockaddr := binary.BigEndian.AppendUint16(nil, 2)
+	sockaddr = binary.BigEndian.AppendUint16(sockaddr, uint16(scannerAddr.Port))
+	sockaddr = append(sockaddr, 127, 0, 0, 1)
+	sockaddr = append(sockaddr, make([]byte, 8)...)
+
+	status, extended, reply := s.cip(
+		forwardOpenRequest(sequenceCountSize+runIdleHeaderSize+OutputAssemblySize, sequenceCountSize+InputAssemblySize, 10*time.Millisecond),
+		cpfItem{typeID: itemSockaddrTO, data: sockaddr},
+	)
+	if status != statusSuccess {
+		t.Fatalf("Forward Open: status 0x%02X %04X", status, extended)
+	}
+	otID := binary.LittleEndian.Uint32(reply[0:4])
+
+	// 두 번째 스캐너는 소유권 충돌
+	if status, extended, _ := s.cip(forwardOpenRequest(sequenceCountSize+runIdleHeaderSize+OutputAssemblySize, sequenceCountSize+InputAssemblySize, 10*time.Millisecond)); status != statusConnectionFailure || extended[0] != extConnectionInUse {
+		t.Errorf("duplicate Forward Open: status 0x%02X %04X", status, extended)
+	}
+
+	// O->T: Idle 패킷은 무시, Run 패킷은 명령 실행
+	ioTarget := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1), Port: ioPort}
+	send := func(sequence uint16, run bool, data []byte) {
+		address := binary.LittleEndian.AppendUint32(nil, otID)
+		address = binary.LittleEndian.AppendUint32(address, uint32(sequence))
+		payload := binary.LittleEndian.AppendUint16(nil, sequence)
+		header := uint32(0)
+		if run {
+			header = 1
+		}
+		payload = binary.LittleEndian.AppendUint32(payload, header)
+		payload = append(payload, data...)
+		packet := appendCPF(nil, cpfItem{typeID: itemSequencedAddress, data: address}, cpfItem{typeID: itemConnectedData, data: payload})
+		if _, err := scanner.WriteToUDP(packet, ioTarget); err != nil {
+			t.Fatalf("O->T send: %v", err)
+		}
+	}
+	send(1, false, outputAssembly(5, "CAL:I"))
+	time.Sleep(30 * time.Millisecond)
+	if ack := binary.LittleEndian.Uint16(s.server.adapter.Input()[0:2]); ack != 0 {
+		t.Fatalf("idle O->T data executed a command (ack %d)", ack)
+	}
+	send(2, true, outputAssembly(5, "CAL:I"))
+	s.waitInput(func(input []byte) bool {
+		return binary.LittleEndian.Uint16(input[0:2]) == 5
+	})
+
+	// T->O: 입력 어셈블리가 스캐너 주소로 생산됨
+	scanner.SetReadDeadline(time.Now().Add(2 * time.Second))
+	buffer := make([]byte, 1500)
+	n, _, err := scanner.ReadFromUDP(buffer)
+	if err != nil {
+		t.Fatalf("T->O receive: %v", err)
+	}
+	items, err := parseCPF(buffer[:n])
+	if err != nil {
+		t.Fatalf("T->O packet: %v", err)
+	}
+	address, _ := findItem(items, itemSequencedAddress)
+	data, _ := findItem(items, itemConnectedData)
+	if len(address.data) != 8 || binary.LittleEndian.Uint32(address.data[0:4]) != 0x12345678 {
+		t.Errorf("T->O connection ID % X, want 78 56 34 12", address.data)
+	}
+	if len(data.data) != sequenceCountSize+InputAssemblySize {
+		t.Errorf("T->O data %d bytes, want %d", len(data.data), sequenceCountSize+InputAssemblySize)
+	}
+
+	// Forward Close 후 연결 해제
+	closeRequest := []byte{serviceForwardClose, 2, 0x20, classConnectionManager, 0x24, 1, 0x0A, 0x0E}
+	closeRequest = binary.LittleEndian.AppendUint16(closeRequest, 7)
+	closeRequest = binary.LittleEndian.AppendUint16(closeRequest, 1)
+	closeRequest = binary.LittleEndian.AppendUint32(closeRequest, 42)
+	closeRequest = append(closeRequest, 0, 0)
+	if status, _, _ := s.cip(closeRequest); status != statusSuccess {
+		t.Errorf("Forward Close: status 0x%02X", status)
+	}
+	s.server.mu.Lock()
+	open := s.server.io != nil
+	s.server.mu.Unlock()
+	if open {
+		t.Errorf("I/O connection still open after Forward Close")
+	}
+}
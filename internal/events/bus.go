@@ -0,0 +1,99 @@
+// internal/events/bus.go - In-process Bridge Event Bus
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event 브릿지 이벤트 구조체
+type Event struct {
+	Type     string    `json:"type"`
+	Time     time.Time `json:"time"`
+	Robot    string    `json:"robot,omitempty"`
+	Command  string    `json:"command,omitempty"`
+	OrderID  string    `json:"orderId,omitempty"`
+	ActionID string    `json:"actionId,omitempty"`
+	Status   string    `json:"status,omitempty"`
+	Message  string    `json:"message,omitempty"`
+
+	CorrelationID string `json:"correlationId,omitempty"` // PLC 명령 수신부터 응답까지 같은 값
+	ActionType    string `json:"actionType,omitempty"`    // 오더 액션 타입 (오더 이벤트)
+
+	Data interface{} `json:"data,omitempty"` // 이벤트별 추가 데이터 (robot.pose: 위치/속도)
+}
+
+// EventType 열거형
+const (
+	TypeCommandReceived          = "command.received"           // PLC 명령 수신
+	TypeCommandRejected          = "command.rejected"           // PLC 명령 거부 (오더 미발행)
+	TypeOrderPublished           = "order.published"            // 로봇 오더 발행
+	TypeOrderPendingConfirmation = "order.pending_confirmation" // 커미셔닝 모드 운영자 확인 대기
+	TypeOrderDelayed             = "order.delayed"              // delay 파라미터로 발행 지연 (Message: 지연 시간)
+	TypeOrderDeferred            = "order.deferred"             // 로봇 주행 중이라 정지할 때까지 발행 보류 (Message: 최대 대기 시간)
+	TypeOrderStatus              = "order.status"               // 오더 상태 전이 (PLC 응답 상태)
+	TypePLCResponse              = "plc.response"               // PLC 응답 발행
+	TypeActionState              = "action.state"               // 로봇 액션 상태 전이
+	TypeOrderEscalated           = "order.escalated"            // 오더 시간 초과 단계 진입 (Status: warn, cancel, unhealthy)
+	TypeSafetyState              = "safety.state"               // 로봇 안전 상태 변경 (Status: eStop, Message: fieldViolation 여부)
+	TypeRobotMessageInvalid      = "robot_message.invalid"      // 로봇 메시지 스키마 위반으로 격리 (Status: 메시지 종류, Message: 위반 내용)
+	TypePLCHeartbeat             = "plc.heartbeat"              // PLC 하트비트 끊김/복구 (Status: lost, restored)
+	TypeRobotStateDropped        = "robot_state.dropped"        // 상태 대기열이 가득 차 가장 오래된 로봇 상태를 버림
+	TypeHandlerPanic             = "handler.panic"              // 메시지 처리기 패닉 복구 (Status: 구독 토픽 또는 작업자, Message: 패닉 값)
+	TypeMQTTPublishFailed        = "mqtt.publish_failed"        // 브로커 발행 실패 (Status: 토픽, Message: 오류)
+	TypeRobotError               = "robot.error"                // 로봇 보고 오류 변경 (Status: errorLevel, Message: errorType/설명)
+
+	TypeInstantActionSuppressed = "instant_action.suppressed" // InstantActions 전송 억제 (Status: 사유, Message: 액션 타입)
+	TypeRobotPose               = "robot.pose"                // 다운샘플링된 로봇 위치/속도 (Data: types.PoseUpdate, VISUALIZATION_EVENTS)
+)
+
+// Bus 이벤트 발행/구독 버스 (느린 구독자는 이벤트 유실)
+type Bus struct {
+	mu          sync.Mutex
+	subscribers map[chan Event]struct{}
+}
+
+// NewBus 새 이벤트 버스 생성
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[chan Event]struct{}),
+	}
+}
+
+// Publish 모든 구독자에게 이벤트 전달 (블로킹하지 않음)
+func (b *Bus) Publish(event Event) {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			// 구독자 버퍼가 가득 찬 경우 이벤트 폐기
+		}
+	}
+}
+
+// Subscribe 이벤트 구독 (반환된 함수로 구독 해제)
+func (b *Bus) Subscribe(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+
+	b.mu.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, ch)
+			b.mu.Unlock()
+			close(ch)
+		})
+	}
+	return ch, unsubscribe
+}
@@ -0,0 +1,268 @@
+// internal/ha/elector.go - Active/Standby Leader Election over a Retained MQTT Lease
+package ha
+
+import (
+	"context"
+	"encoding/json"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// lease HA_LEADER_TOPIC에 retained로 발행하는 리더 임대
+// 만료 판단은 수신 시각 기준 (인스턴스 간 시계 차이와 무관)
+type lease struct {
+	Instance  string    `json:"instance"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// handoff HA_STATE_TOPIC에 retained로 발행하는 리더의 진행 중인 오더 (로봇 시리얼 -> 오더)
+type handoff struct {
+	Instance  string                           `json:"instance"`
+	Timestamp time.Time                        `json:"timestamp"`
+	Orders    map[string][]messaging.OrderInfo `json:"orders"`
+}
+
+// Elector 두 브릿지 인스턴스 중 리더 하나만 PLC 명령을 처리하도록 선출
+// 리더는 HA_LEASE/3마다 임대를 갱신하고 진행 중인 오더를 발행, 대기 인스턴스는 임대가 HA_LEASE 동안
+// 갱신되지 않으면 임대를 요청하고 다음 주기에 마지막 임대가 자신이면 리더가 됨 (브로커 순서상 마지막 요청이 승리)
+type Elector struct {
+	client   *messaging.MQTTClient
+	config   *config.Config
+	handlers []*messaging.DirectActionHandler
+
+	mu        sync.Mutex
+	leader    bool
+	current   lease     // 마지막 수신 임대
+	seenAt    time.Time // 마지막 임대 수신 시간
+	claimedAt time.Time // 임대 요청 시간 (0이면 요청하지 않음)
+	state     *handoff  // 마지막 수신 인계 상태
+}
+
+// NewElector 새 리더 선출기 생성 (모든 핸들러는 대기 모드로 시작)
+func NewElector(client *messaging.MQTTClient, cfg *config.Config, handlers []*messaging.DirectActionHandler) *Elector {
+	for _, handler := range handlers {
+		handler.SetStandby(true)
+	}
+	return &Elector{
+		client:   client,
+		config:   cfg,
+		handlers: handlers,
+	}
+}
+
+// Start 임대/인계 토픽 구독 후 선출 주기 시작 (재연결 시 다시 구독)
+func (e *Elector) Start(ctx context.Context) error {
+	if err := e.subscribe(); err != nil {
+		return err
+	}
+	e.client.OnConnect(func() {
+		if err := e.subscribe(); err != nil {
+			utils.Logger.Errorf("❌ HA resubscription failed: %v", err)
+		}
+	})
+
+	utils.Logger.Infof("🤝 HA enabled: instance %s, lease %s on %s", e.config.HAInstanceID, e.config.HALease, e.config.HALeaderTopic)
+	go e.run(ctx)
+	return nil
+}
+
+// Stop 리더면 임대를 지워 대기 인스턴스가 바로 인계받도록 함
+func (e *Elector) Stop() {
+	e.mu.Lock()
+	leader := e.leader
+	e.mu.Unlock()
+	if !leader {
+		return
+	}
+
+	e.publishState()
+	if err := e.client.Publish(e.config.HALeaderTopic, 1, true, []byte{}); err != nil {
+		utils.Logger.Errorf("❌ Failed to release HA lease: %v", err)
+	}
+}
+
+// IsLeader 리더 여부
+func (e *Elector) IsLeader() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.leader
+}
+
+// subscribe 임대/인계 토픽 구독
+func (e *Elector) subscribe() error {
+	if err := e.client.Subscribe(e.config.HALeaderTopic, 1, e.handleLease); err != nil {
+		return err
+	}
+	return e.client.Subscribe(e.config.HAStateTopic, 1, e.handleState)
+}
+
+// run 임대 갱신/만료 확인 주기 (ctx 종료 시 반환)
+func (e *Elector) run(ctx context.Context) {
+	ticker := time.NewTicker(e.config.HALease / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e.tick(time.Now())
+		}
+	}
+}
+
+// tick 리더는 임대 갱신과 오더 인계 상태 발행, 대기 인스턴스는 만료 확인 후 임대 요청/승격
+func (e *Elector) tick(now time.Time) {
+	if !e.client.IsConnected() {
+		// 연결이 끊긴 리더는 다른 인스턴스가 임대를 가져갈 수 있으므로 스스로 물러남
+		e.mu.Lock()
+		leader := e.leader
+		e.mu.Unlock()
+		if leader {
+			e.stepDown("MQTT connection lost")
+		}
+		return
+	}
+
+	e.mu.Lock()
+	leader := e.leader
+	ownLease := e.current.Instance == e.config.HAInstanceID
+	expired := e.current.Instance == "" || now.Sub(e.seenAt) > e.config.HALease
+	claimed := !e.claimedAt.IsZero()
+	e.mu.Unlock()
+
+	switch {
+	case leader:
+		e.publishLease()
+		e.publishState()
+	case claimed && ownLease:
+		e.promote()
+	case claimed:
+		// 다른 인스턴스의 요청이 나중에 도착 (그 인스턴스가 리더)
+		e.mu.Lock()
+		e.claimedAt = time.Time{}
+		e.mu.Unlock()
+	case expired:
+		utils.Logger.Warnf("🤝 HA lease expired (last holder %q) - claiming leadership", e.current.Instance)
+		e.mu.Lock()
+		e.claimedAt = now
+		e.mu.Unlock()
+		e.publishLease()
+	}
+}
+
+// handleLease 임대 수신 (리더인데 다른 인스턴스 임대를 받으면 물러남)
+func (e *Elector) handleLease(client mqtt.Client, msg mqtt.Message) {
+	var received lease
+	if len(msg.Payload()) > 0 {
+		if err := json.Unmarshal(msg.Payload(), &received); err != nil {
+			utils.Logger.Errorf("❌ Invalid HA lease: %v", err)
+			return
+		}
+	}
+
+	e.mu.Lock()
+	e.current = received
+	e.seenAt = time.Now()
+	stepDown := e.leader && received.Instance != e.config.HAInstanceID
+	e.mu.Unlock()
+
+	if stepDown {
+		e.stepDown("lease taken by " + received.Instance)
+		// 새 리더가 인계 상태로 오더를 이어받으므로 추적 중단
+		for _, handler := range e.handlers {
+			if released := handler.ReleaseOrders(); released > 0 {
+				utils.Logger.Infof("🤝 Released %d order(s) for robot %s to %s", released, handler.RobotSerialNumber(), received.Instance)
+			}
+		}
+	}
+}
+
+// handleState 리더의 오더 인계 상태 수신 (승격 시 사용)
+func (e *Elector) handleState(client mqtt.Client, msg mqtt.Message) {
+	if len(msg.Payload()) == 0 {
+		return
+	}
+	var received handoff
+	if err := json.Unmarshal(msg.Payload(), &received); err != nil {
+		utils.Logger.Errorf("❌ Invalid HA state handoff: %v", err)
+		return
+	}
+
+	e.mu.Lock()
+	e.state = &received
+	e.mu.Unlock()
+}
+
+// promote 리더로 승격 (이전 리더의 진행 중인 오더를 인계받은 뒤 명령 처리 시작)
+func (e *Elector) promote() {
+	e.mu.Lock()
+	e.leader = true
+	e.claimedAt = time.Time{}
+	state := e.state
+	e.mu.Unlock()
+
+	utils.Logger.Warnf("👑 HA instance %s is now leader", e.config.HAInstanceID)
+	if state != nil && state.Instance != e.config.HAInstanceID {
+		for _, handler := range e.handlers {
+			if imported := handler.ImportOrders(state.Orders[handler.RobotSerialNumber()]); imported > 0 {
+				utils.Logger.Infof("🤝 Took over %d order(s) for robot %s from %s", imported, handler.RobotSerialNumber(), state.Instance)
+			}
+		}
+	}
+	for _, handler := range e.handlers {
+		handler.SetStandby(false)
+	}
+	e.publishLease()
+	e.publishState()
+}
+
+// stepDown 대기 모드로 전환 (연결 끊김으로 물러난 경우 다시 리더가 되면 추적 중인 오더를 이어서 처리)
+func (e *Elector) stepDown(reason string) {
+	e.mu.Lock()
+	e.leader = false
+	e.mu.Unlock()
+
+	utils.Logger.Warnf("💤 HA instance %s stepping down: %s", e.config.HAInstanceID, reason)
+	for _, handler := range e.handlers {
+		handler.SetStandby(true)
+	}
+}
+
+// publishLease 자신의 임대 발행
+func (e *Elector) publishLease() {
+	payload, err := json.Marshal(lease{Instance: e.config.HAInstanceID, Timestamp: time.Now()})
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal HA lease: %v", err)
+		return
+	}
+	if err := e.client.Publish(e.config.HALeaderTopic, 1, true, payload); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish HA lease: %v", err)
+	}
+}
+
+// publishState 진행 중인 오더 인계 상태 발행
+func (e *Elector) publishState() {
+	state := handoff{
+		Instance:  e.config.HAInstanceID,
+		Timestamp: time.Now(),
+		Orders:    make(map[string][]messaging.OrderInfo, len(e.handlers)),
+	}
+	for _, handler := range e.handlers {
+		state.Orders[handler.RobotSerialNumber()] = handler.ExportOrders()
+	}
+
+	payload, err := json.Marshal(state)
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal HA state handoff: %v", err)
+		return
+	}
+	if err := e.client.Publish(e.config.HAStateTopic, 1, true, payload); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish HA state handoff: %v", err)
+	}
+}
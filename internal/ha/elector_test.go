@@ -0,0 +1,158 @@
+package ha
+
+import (
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/harness"
+	"mqtt-bridge/internal/messaging"
+	"testing"
+	"time"
+)
+
+// instance 내장 브로커에 연결된 HA 인스턴스 (핸들러 하나)
+type instance struct {
+	client  *messaging.MQTTClient
+	handler *messaging.DirectActionHandler
+	elector *Elector
+}
+
+func newInstance(t *testing.T, cfg *config.Config, brokerURL, id string) *instance {
+	t.Helper()
+	local := *cfg
+	local.MQTTBroker = brokerURL
+	local.MQTTClientID = "ha-test-" + id
+	local.HAInstanceID = id
+
+	client, err := messaging.NewMQTTClient(&local)
+	if err != nil {
+		t.Fatalf("%s client: %v", id, err)
+	}
+	t.Cleanup(func() { client.Disconnect(100) })
+
+	handler, err := messaging.NewDirectActionHandler(client, &local, events.NewBus())
+	if err != nil {
+		t.Fatalf("%s handler: %v", id, err)
+	}
+	elector := NewElector(client, &local, []*messaging.DirectActionHandler{handler})
+	if err := elector.subscribe(); err != nil {
+		t.Fatalf("%s subscribe: %v", id, err)
+	}
+	return &instance{client: client, handler: handler, elector: elector}
+}
+
+// leaseHolder 마지막으로 수신한 임대 인스턴스
+func (i *instance) leaseHolder() string {
+	i.elector.mu.Lock()
+	defer i.elector.mu.Unlock()
+	return i.elector.current.Instance
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func setup(t *testing.T) (*config.Config, string) {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	cfg.HALease = time.Second
+	cfg.HALeaderTopic = "test/ha/leader"
+	cfg.HAStateTopic = "test/ha/state"
+
+	broker, err := harness.StartBroker()
+	if err != nil {
+		t.Fatalf("StartBroker: %v", err)
+	}
+	t.Cleanup(broker.Close)
+	return cfg, broker.URL
+}
+
+// promote 임대 요청 후 자신의 임대를 수신하면 다음 주기에 승격
+func promote(t *testing.T, i *instance, id string) {
+	t.Helper()
+	i.elector.tick(time.Now())
+	waitFor(t, id+" lease", func() bool { return i.leaseHolder() == id })
+	i.elector.tick(time.Now())
+	if !i.elector.IsLeader() {
+		t.Fatalf("%s not leader after claiming an expired lease", id)
+	}
+}
+
+func TestElectorSingleLeader(t *testing.T) {
+	cfg, url := setup(t)
+	a := newInstance(t, cfg, url, "a")
+	b := newInstance(t, cfg, url, "b")
+
+	if !a.handler.IsStandby() || !b.handler.IsStandby() {
+		t.Fatal("handlers must start in standby")
+	}
+	promote(t, a, "a")
+	if a.handler.IsStandby() {
+		t.Error("leader handler still standby")
+	}
+
+	waitFor(t, "b to see a's lease", func() bool { return b.leaseHolder() == "a" })
+	b.elector.tick(time.Now())
+	b.elector.tick(time.Now())
+	if b.elector.IsLeader() || !b.handler.IsStandby() {
+		t.Error("b took leadership while a's lease is fresh")
+	}
+}
+
+func TestElectorStepDownDiscardsHeldWork(t *testing.T) {
+	tests := []struct {
+		name     string
+		stepDown func(t *testing.T, a, b *instance)
+	}{
+		{"lease taken", func(t *testing.T, a, b *instance) {
+			b.elector.publishLease()
+			waitFor(t, "a to see b's lease", func() bool { return !a.elector.IsLeader() })
+		}},
+		{"connection lost", func(t *testing.T, a, b *instance) {
+			a.client.Disconnect(100)
+			a.elector.tick(time.Now())
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, url := setup(t)
+			cfg.OrderPolicy = messaging.OrderPolicyQueue
+			cfg.MaxConcurrentOrders = 1
+			a := newInstance(t, cfg, url, "a")
+			b := newInstance(t, cfg, url, "b")
+			promote(t, a, "a")
+
+			a.handler.OpenCommandGate()
+			if result := a.handler.ProcessCommand("CAL:I:delay=1m"); !result.Accepted {
+				t.Fatalf("delayed command rejected: %s", result.Reason)
+			}
+			a.handler.ProcessCommand("PICK:T:L")
+			if pending := a.handler.GetPendingOrders(); len(pending) != 1 {
+				t.Fatalf("pending orders before step-down: %d, want 1", len(pending))
+			}
+			if queue := a.handler.GetQueue(); len(queue.Commands) != 1 {
+				t.Fatalf("queued commands before step-down: %d, want 1", len(queue.Commands))
+			}
+
+			tt.stepDown(t, a, b)
+			if a.elector.IsLeader() || !a.handler.IsStandby() {
+				t.Fatal("a did not step down")
+			}
+			if pending := a.handler.GetPendingOrders(); len(pending) != 0 {
+				t.Errorf("pending orders after step-down: %d, want 0", len(pending))
+			}
+			if queue := a.handler.GetQueue(); len(queue.Commands) != 0 {
+				t.Errorf("queued commands after step-down: %d, want 0", len(queue.Commands))
+			}
+		})
+	}
+}
@@ -0,0 +1,53 @@
+// internal/harness/broker.go - Embedded MQTT Broker (mochi-mqtt, loopback only)
+package harness
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+
+	mochi "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+)
+
+// EmbeddedBroker 하네스 전용 내장 MQTT 브로커 (127.0.0.1 임의 포트, 인증 없음)
+type EmbeddedBroker struct {
+	URL    string // 클라이언트 접속 주소 (tcp://127.0.0.1:port)
+	server *mochi.Server
+}
+
+// StartBroker 내장 브로커 시작 (리스너는 백그라운드)
+func StartBroker() (*EmbeddedBroker, error) {
+	// 빈 포트 확보 후 반납 (mochi 리스너는 주소 문자열만 받음)
+	probe, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	address := probe.Addr().String()
+	probe.Close()
+
+	server := mochi.New(&mochi.Options{
+		InlineClient: false,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	if err := server.AddHook(new(auth.AllowHook), nil); err != nil {
+		return nil, err
+	}
+	if err := server.AddListener(listeners.NewTCP(listeners.Config{ID: "harness", Address: address})); err != nil {
+		return nil, fmt.Errorf("embedded broker listen on %s: %w", address, err)
+	}
+	// Serve는 리스너 고루틴만 띄우고 바로 반환
+	if err := server.Serve(); err != nil {
+		server.Close()
+		return nil, err
+	}
+
+	return &EmbeddedBroker{URL: "tcp://" + address, server: server}, nil
+}
+
+// Close 브로커 종료 (모든 클라이언트 연결 해제)
+func (b *EmbeddedBroker) Close() {
+	b.server.Close()
+}
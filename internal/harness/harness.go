@@ -0,0 +1,218 @@
+// internal/harness/harness.go - End-to-end Harness (FakeBroker or embedded MQTT broker + handler + scripted robot)
+package harness
+
+import (
+	"context"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// log 하네스 컴포넌트 로거
+var log = utils.Component("harness")
+
+// Harness 명령 -> 오더 -> 상태 -> 응답 전체 흐름을 실행하는 환경
+// 실제 서비스와 같은 Subscriber/DirectActionHandler를 메모리 브로커(New) 또는 내장 MQTT 브로커(NewEmbedded)에 연결 (로봇 하나)
+type Harness struct {
+	Config  *config.Config
+	Broker  *messaging.FakeBroker // 메모리 모드에서만 설정 (내장 브로커 모드는 nil)
+	Handler *messaging.DirectActionHandler
+	Robot   *Robot
+	Events  *events.Bus // 핸들러 이벤트 (PLC 프론트엔드 연결용)
+
+	plc     messaging.Publisher // PLC 명령 발행
+	cancel  context.CancelFunc
+	closers []func() // 종료 시 역순 실행 (클라이언트, 내장 브로커)
+
+	mu        sync.Mutex
+	responses []types.PLCResponse
+	changed   chan struct{} // 응답 수신 알림
+}
+
+// Factory 하네스 생성 함수 (New 또는 NewEmbedded)
+type Factory func(cfg *config.Config, stepDelay time.Duration) (*Harness, error)
+
+// New 메모리 브로커 하네스 생성 및 시작 (cfg는 복사해서 사용, 명령 경로는 기본 토픽 하나)
+func New(cfg *config.Config, stepDelay time.Duration) (*Harness, error) {
+	local := *cfg
+	local.CommandRoutes = ""
+
+	broker := messaging.NewFakeBroker(&local)
+	h, err := start(&local, broker, broker, broker, stepDelay)
+	if err != nil {
+		return nil, err
+	}
+	h.Broker = broker
+	return h, nil
+}
+
+// NewEmbedded 내장 MQTT 브로커 하네스 생성 및 시작
+// 브릿지, 로봇, PLC가 각각 별도 MQTT 연결(paho)로 실제 TCP 브로커를 거침
+func NewEmbedded(cfg *config.Config, stepDelay time.Duration) (*Harness, error) {
+	local := *cfg
+	local.CommandRoutes = ""
+
+	embedded, err := StartBroker()
+	if err != nil {
+		return nil, err
+	}
+	local.MQTTBroker = embedded.URL
+	closers := []func(){embedded.Close}
+	cleanup := func() {
+		for i := len(closers) - 1; i >= 0; i-- {
+			closers[i]()
+		}
+	}
+
+	clients := make([]*messaging.MQTTClient, 0, 3)
+	for _, role := range []string{"bridge", "robot", "plc"} {
+		clientCfg := local
+		clientCfg.MQTTClientID = local.MQTTClientID + "-harness-" + role
+		client, err := messaging.NewMQTTClient(&clientCfg)
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("%s client: %w", role, err)
+		}
+		closers = append(closers, func() { client.Disconnect(100) })
+		clients = append(clients, client)
+	}
+
+	h, err := start(&local, clients[0], clients[1], clients[2], stepDelay)
+	if err != nil {
+		cleanup()
+		return nil, err
+	}
+	h.closers = closers
+	return h, nil
+}
+
+// start 핸들러, 구독, 로봇 연결 (bridge: 브릿지 연결, robot: 로봇 연결, plc: PLC 연결)
+func start(local *config.Config, bridge, robot, plc messaging.Broker, stepDelay time.Duration) (*Harness, error) {
+	eventBus := events.NewBus()
+	handler, err := messaging.NewDirectActionHandler(bridge, local, eventBus)
+	if err != nil {
+		return nil, err
+	}
+	handler.SetFleet([]*messaging.DirectActionHandler{handler})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h := &Harness{
+		Config:  local,
+		Handler: handler,
+		Robot:   NewRobot(local, robot, stepDelay),
+		Events:  eventBus,
+		plc:     plc,
+		cancel:  cancel,
+		changed: make(chan struct{}, 1),
+	}
+	go handler.RunStateWorker(ctx)
+
+	subscriber := messaging.NewSubscriber(bridge, []messaging.CommandRoute{{CommandTopic: config.DefaultCommandTopic, Handler: handler}})
+	if err := subscriber.SubscribeAll(); err != nil {
+		h.Close()
+		return nil, err
+	}
+	for _, topic := range []string{local.PlcResponseTopic, local.PlcResponseTopic + "/#"} {
+		if err := plc.Subscribe(topic, 0, h.recordResponse); err != nil {
+			h.Close()
+			return nil, err
+		}
+	}
+	if err := h.Robot.Start(); err != nil {
+		h.Close()
+		return nil, err
+	}
+	return h, nil
+}
+
+// Close 하네스 종료
+func (h *Harness) Close() {
+	h.cancel()
+	for i := len(h.closers) - 1; i >= 0; i-- {
+		h.closers[i]()
+	}
+}
+
+// Send PLC 명령 토픽으로 명령 발행
+func (h *Harness) Send(command string) {
+	if err := h.plc.Publish(config.DefaultCommandTopic, 0, false, command); err != nil {
+		log.Errorf("Failed to send %q: %v", command, err)
+	}
+}
+
+// recordResponse PLC 응답 기록
+func (h *Harness) recordResponse(client mqtt.Client, msg mqtt.Message) {
+	response, err := types.ParsePLCResponse(string(msg.Payload()))
+	if err != nil {
+		log.Warnf("Unparseable PLC response %q: %v", msg.Payload(), err)
+		return
+	}
+
+	h.mu.Lock()
+	h.responses = append(h.responses, *response)
+	h.mu.Unlock()
+
+	select {
+	case h.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Mark 현재까지 받은 응답 수 (이후 응답만 확인할 때 기준점)
+func (h *Harness) Mark() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.responses)
+}
+
+// Statuses 명령에 대한 전체 응답 상태 순서
+func (h *Harness) Statuses(command string) []string {
+	return h.StatusesSince(0, command)
+}
+
+// StatusesSince 기준점 이후 명령에 대한 응답 상태 순서 (응답은 기본 명령 기준, 연속 중복은 하나로)
+func (h *Harness) StatusesSince(mark int, command string) []string {
+	baseCommand, _, _ := strings.Cut(command, ":")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var statuses []string
+	for _, response := range h.responses[min(mark, len(h.responses)):] {
+		if response.Command != baseCommand {
+			continue
+		}
+		if n := len(statuses); n > 0 && statuses[n-1] == response.Status {
+			continue
+		}
+		statuses = append(statuses, response.Status)
+	}
+	return statuses
+}
+
+// WaitStatus 기준점 이후 명령이 주어진 상태로 응답될 때까지 대기
+func (h *Harness) WaitStatus(mark int, command, status string, timeout time.Duration) error {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+
+	for {
+		for _, received := range h.StatusesSince(mark, command) {
+			if received == status {
+				return nil
+			}
+		}
+		select {
+		case <-h.changed:
+		case <-deadline.C:
+			return fmt.Errorf("%s: no %s response within %s (got %v)", command, status, timeout, h.StatusesSince(mark, command))
+		}
+	}
+}
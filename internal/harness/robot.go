@@ -0,0 +1,208 @@
+// internal/harness/robot.go - Scripted Fake Robot (VDA5050 order -> state)
+package harness
+
+import (
+	"encoding/json"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// Outcome 스크립트 로봇의 오더 처리 결과
+type Outcome string
+
+const (
+	OutcomeSucceed Outcome = "succeed" // WAITING -> INITIALIZING -> RUNNING -> FINISHED
+	OutcomeFail    Outcome = "fail"    // WAITING -> INITIALIZING -> RUNNING -> FAILED (errors[] 보고)
+	OutcomeHold    Outcome = "hold"    // RUNNING에서 멈춤 (취소/시간 초과 시나리오)
+	OutcomeIgnore  Outcome = "ignore"  // 오더 무시 (state 발행 안 함)
+)
+
+// Robot 오더를 받으면 정해진 결과대로 state를 발행하는 가짜 로봇
+// FakeBroker는 동기 전달이므로 state 발행은 별도 고루틴에서 수행 (핸들러 잠금 재진입 방지)
+type Robot struct {
+	cfg       *config.Config
+	broker    messaging.Broker // FakeBroker 또는 내장 브로커에 연결된 MQTTClient
+	stepDelay time.Duration
+
+	mu       sync.Mutex
+	headerID int64
+	outcome  Outcome
+	orderID  string
+	actions  []*robotAction
+	errors   []map[string]string
+	canceled bool
+}
+
+// robotAction VDA5050 actionStates[] 항목
+type robotAction struct {
+	ActionID     string `json:"actionId"`
+	ActionType   string `json:"actionType"`
+	ActionStatus string `json:"actionStatus"`
+}
+
+// NewRobot 새 스크립트 로봇 생성 (기본 결과: succeed)
+func NewRobot(cfg *config.Config, broker messaging.Broker, stepDelay time.Duration) *Robot {
+	return &Robot{cfg: cfg, broker: broker, stepDelay: stepDelay, outcome: OutcomeSucceed}
+}
+
+// SetOutcome 이후 오더의 처리 결과 설정
+func (r *Robot) SetOutcome(outcome Outcome) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.outcome = outcome
+}
+
+// Start order/instantActions 구독 후 ONLINE 발행
+func (r *Robot) Start() error {
+	if err := r.broker.Subscribe(r.cfg.RobotTopic("order"), 0, r.handleOrder); err != nil {
+		return err
+	}
+	if err := r.broker.Subscribe(r.cfg.RobotTopic("instantActions"), 0, r.handleInstantActions); err != nil {
+		return err
+	}
+	r.publish("connection", map[string]interface{}{"connectionState": "ONLINE"})
+	return nil
+}
+
+// handleOrder 오더 수신 후 결과 스크립트 실행
+func (r *Robot) handleOrder(client mqtt.Client, msg mqtt.Message) {
+	var order types.OrderMessage
+	if err := json.Unmarshal(msg.Payload(), &order); err != nil {
+		log.Errorf("Invalid order: %v", err)
+		return
+	}
+
+	r.mu.Lock()
+	outcome := r.outcome
+	if outcome == OutcomeIgnore {
+		r.mu.Unlock()
+		return
+	}
+	r.orderID = order.OrderID
+	r.actions = nil
+	r.errors = nil
+	r.canceled = false
+	for _, node := range order.Nodes {
+		for _, action := range node.Actions {
+			r.actions = append(r.actions, &robotAction{ActionID: action.ActionID, ActionType: action.ActionType, ActionStatus: "WAITING"})
+		}
+	}
+	orderID := order.OrderID
+	r.mu.Unlock()
+
+	go r.run(orderID, outcome)
+}
+
+// run 결과 스크립트에 따라 모든 액션 상태를 함께 전이
+func (r *Robot) run(orderID string, outcome Outcome) {
+	statuses := []string{"WAITING", "INITIALIZING", "RUNNING"}
+	switch outcome {
+	case OutcomeSucceed:
+		statuses = append(statuses, "FINISHED")
+	case OutcomeFail:
+		statuses = append(statuses, "FAILED")
+	}
+
+	for _, status := range statuses {
+		time.Sleep(r.stepDelay)
+
+		r.mu.Lock()
+		if r.orderID != orderID || r.canceled {
+			r.mu.Unlock()
+			return
+		}
+		for _, action := range r.actions {
+			action.ActionStatus = status
+		}
+		if status == "FAILED" {
+			r.errors = append(r.errors, map[string]string{
+				"errorType":        "actionFailed",
+				"errorLevel":       types.ErrorLevelWarning,
+				"errorDescription": "scripted failure",
+			})
+		}
+		r.mu.Unlock()
+
+		r.publishState()
+	}
+}
+
+// handleInstantActions cancelOrder는 진행 중인 액션을 FAILED로 보고
+func (r *Robot) handleInstantActions(client mqtt.Client, msg mqtt.Message) {
+	var instantActions types.InstantActionsMessage
+	if err := json.Unmarshal(msg.Payload(), &instantActions); err != nil {
+		log.Errorf("Invalid instantActions: %v", err)
+		return
+	}
+
+	canceled := false
+	r.mu.Lock()
+	for _, action := range instantActions.Actions {
+		if action.ActionType != "cancelOrder" {
+			continue
+		}
+		canceled = true
+		r.canceled = true
+		for _, state := range r.actions {
+			if state.ActionStatus != "FINISHED" {
+				state.ActionStatus = "FAILED"
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	if canceled {
+		go func() {
+			time.Sleep(r.stepDelay)
+			r.publishState()
+		}()
+	}
+}
+
+// publishState 현재 상태 발행 (액션 상태는 복사본 사용)
+func (r *Robot) publishState() {
+	r.mu.Lock()
+	actions := make([]robotAction, 0, len(r.actions))
+	for _, action := range r.actions {
+		actions = append(actions, *action)
+	}
+	errors := append([]map[string]string{}, r.errors...)
+	orderID := r.orderID
+	r.mu.Unlock()
+
+	r.publish("state", map[string]interface{}{
+		"orderId":       orderID,
+		"orderUpdateId": 0,
+		"actionStates":  actions,
+		"errors":        errors,
+		"driving":       false,
+		"operatingMode": "AUTOMATIC",
+		"agvPosition":   map[string]interface{}{"x": 0.0, "y": 0.0, "theta": 0.0, "mapId": "", "positionInitialized": true},
+		"safetyState":   map[string]interface{}{"eStop": "NONE", "fieldViolation": false},
+	})
+}
+
+// publish 공통 헤더를 채워 로봇 토픽에 발행
+func (r *Robot) publish(topic string, fields map[string]interface{}) {
+	r.mu.Lock()
+	r.headerID++
+	fields["headerId"] = r.headerID
+	r.mu.Unlock()
+
+	fields["timestamp"] = time.Now().UTC()
+	fields["version"] = "2.0.0"
+	fields["manufacturer"] = r.cfg.RobotManufacturer
+	fields["serialNumber"] = r.cfg.RobotSerialNumber
+
+	payload, err := json.Marshal(fields)
+	if err != nil {
+		log.Errorf("Failed to marshal %s: %v", topic, err)
+		return
+	}
+	r.broker.Publish(r.cfg.RobotTopic(topic), 0, false, payload)
+}
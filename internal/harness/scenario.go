@@ -0,0 +1,89 @@
+// internal/harness/scenario.go - Table-driven End-to-end Scenarios
+package harness
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"strings"
+	"time"
+)
+
+// Scenario 하네스에서 순서대로 실행하는 명령과 기대 응답
+type Scenario struct {
+	Name  string
+	Steps []Step
+}
+
+// Step 로봇 결과 설정 후 명령 전송, 기대 응답 상태 순서 확인
+// Expect의 마지막 상태까지 기다린 뒤 명령 전송 이후 순서(기본 명령 기준, 연속 중복 제외)를 비교
+type Step struct {
+	Robot   Outcome  // 비어있으면 이전 설정 유지
+	Send    string   // PLC 명령
+	Expect  []string // 기대 응답 상태 순서 (비어있으면 확인 안 함)
+	Command string   // 응답 대상 명령 (비어있으면 Send)
+}
+
+// Run 시나리오 실행 (시나리오마다 factory로 새 하네스)
+func Run(factory Factory, cfg *config.Config, scenario Scenario, stepDelay, timeout time.Duration) error {
+	h, err := factory(cfg, stepDelay)
+	if err != nil {
+		return err
+	}
+	defer h.Close()
+
+	for i, step := range scenario.Steps {
+		if step.Robot != "" {
+			h.Robot.SetOutcome(step.Robot)
+		}
+		mark := h.Mark()
+		h.Send(step.Send)
+		if len(step.Expect) == 0 {
+			continue
+		}
+
+		command := step.Command
+		if command == "" {
+			command = step.Send
+		}
+		if err := h.WaitStatus(mark, command, step.Expect[len(step.Expect)-1], timeout); err != nil {
+			return fmt.Errorf("step %d: %v", i, err)
+		}
+		if got := h.StatusesSince(mark, command); strings.Join(got, ",") != strings.Join(step.Expect, ",") {
+			return fmt.Errorf("step %d: %s responses %v, expected %v", i, command, got, step.Expect)
+		}
+	}
+	return nil
+}
+
+// DefaultScenarios 기본 흐름 시나리오 (CI: go test ./internal/harness, go run ./cmd/harness)
+func DefaultScenarios() []Scenario {
+	const (
+		w = types.PLCStatusWaiting
+		i = types.PLCStatusInitializing
+		r = types.PLCStatusRunning
+		s = types.PLCStatusSuccess
+		f = types.PLCStatusFailed
+	)
+	return []Scenario{
+		{Name: "inference succeeds", Steps: []Step{
+			{Robot: OutcomeSucceed, Send: "CAL:I", Expect: []string{w, i, r, s}},
+		}},
+		{Name: "trajectory succeeds", Steps: []Step{
+			{Robot: OutcomeSucceed, Send: "PICK:T:L", Expect: []string{w, i, r, s}},
+		}},
+		{Name: "robot failure reports F", Steps: []Step{
+			{Robot: OutcomeFail, Send: "CAL:I", Expect: []string{w, i, r, f}},
+		}},
+		{Name: "invalid command rejected", Steps: []Step{
+			{Send: "CAL:Z", Expect: []string{f}},
+		}},
+		{Name: "cancel running order", Steps: []Step{
+			{Robot: OutcomeHold, Send: "PICK:T:R", Expect: []string{w, i, r}},
+			{Send: "PICK:C", Expect: []string{f}},
+		}},
+		{Name: "cancel without order fails", Steps: []Step{
+			{Send: "PICK:C", Expect: []string{f}},
+		}},
+	}
+}
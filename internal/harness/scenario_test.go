@@ -0,0 +1,103 @@
+package harness
+
+import (
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"testing"
+	"time"
+)
+
+func TestDefaultScenarios(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+
+	transports := []struct {
+		name    string
+		factory Factory
+	}{
+		{"memory", New},
+		{"embedded", NewEmbedded},
+	}
+	for _, transport := range transports {
+		for _, scenario := range DefaultScenarios() {
+			t.Run(transport.name+"/"+scenario.Name, func(t *testing.T) {
+				if err := Run(transport.factory, cfg, scenario, 5*time.Millisecond, 5*time.Second); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
+
+func TestEmbeddedBrokerRoundTrip(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	h, err := NewEmbedded(cfg, time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewEmbedded: %v", err)
+	}
+	defer h.Close()
+
+	if h.Broker != nil {
+		t.Fatal("embedded harness must not expose the in-memory broker")
+	}
+	mark := h.Mark()
+	h.Send("CAL:I")
+	if err := h.WaitStatus(mark, "CAL:I", types.PLCStatusSuccess, 5*time.Second); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDelayedOrderRechecksAdmission(t *testing.T) {
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	cfg.OperatingModePolicy = messaging.OperatingModePolicyReject
+	cfg.PausedPolicy = messaging.PausedPolicyReject
+
+	tests := []struct {
+		name   string
+		during func(h *Harness) // 지연 중 상태 변경
+		want   string
+	}{
+		{"standby", func(h *Harness) { h.Handler.SetStandby(true) }, types.PLCStatusFailed},
+		{"e-stop", func(h *Harness) {
+			h.Handler.ProcessRobotState([]byte(`{"safetyState":{"eStop":"MANUAL","fieldViolation":false}}`))
+		}, types.PLCStatusSafetyStop},
+		{"manual mode", func(h *Harness) {
+			h.Handler.ProcessRobotState([]byte(`{"operatingMode":"MANUAL"}`))
+		}, types.PLCStatusManualMode},
+		{"paused", func(h *Harness) {
+			h.Handler.ProcessRobotState([]byte(`{"paused":true}`))
+		}, types.PLCStatusPaused},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, err := New(cfg, time.Millisecond)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			defer h.Close()
+
+			const command = "CAL:I:delay=0.1"
+			mark := h.Mark()
+			h.Send(command)
+			if err := h.WaitStatus(mark, command, types.PLCStatusWaiting, time.Second); err != nil {
+				t.Fatal(err)
+			}
+			tt.during(h)
+			if err := h.WaitStatus(mark, command, tt.want, time.Second); err != nil {
+				t.Fatal(err)
+			}
+			if orders := h.Handler.GetPendingOrders(); len(orders) != 0 {
+				t.Errorf("pending orders after release: %d", len(orders))
+			}
+		})
+	}
+}
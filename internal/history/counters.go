@@ -0,0 +1,55 @@
+// internal/history/counters.go - Persistent Metric Counters
+package history
+
+import (
+	"encoding/json"
+	"fmt"
+	"mqtt-bridge/internal/metrics"
+	"time"
+)
+
+// LoadCounters 저장된 카운터 값 조회 (metrics.CounterStore)
+func (s *Store) LoadCounters() ([]metrics.PersistedCounter, error) {
+	rows, err := s.db.Query(`SELECT name, labels, value FROM metric_counters`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load counters: %v", err)
+	}
+	defer rows.Close()
+
+	var counters []metrics.PersistedCounter
+	for rows.Next() {
+		var counter metrics.PersistedCounter
+		var labels string
+		if err := rows.Scan(&counter.Name, &labels, &counter.Value); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(labels), &counter.LabelValues); err != nil {
+			return nil, fmt.Errorf("invalid labels for counter %s: %v", counter.Name, err)
+		}
+		counters = append(counters, counter)
+	}
+	return counters, rows.Err()
+}
+
+// SaveCounters 카운터 값 저장 (시계열별 덮어쓰기, 보존 기간 정리 대상 아님)
+func (s *Store) SaveCounters(counters []metrics.PersistedCounter) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	at := time.Now().UTC().Format(timeLayout)
+	for _, counter := range counters {
+		labels, err := json.Marshal(counter.LabelValues)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.Exec(`INSERT INTO metric_counters (name, labels, value, updated_at) VALUES (?, ?, ?, ?)
+			ON CONFLICT(name, labels) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+			counter.Name, string(labels), counter.Value, at); err != nil {
+			return fmt.Errorf("failed to save counter %s: %v", counter.Name, err)
+		}
+	}
+	return tx.Commit()
+}
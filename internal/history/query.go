@@ -0,0 +1,187 @@
+// internal/history/query.go - Order History Queries
+package history
+
+import (
+	"database/sql"
+	"time"
+)
+
+// defaultQueryLimit 테이블별 기본 최대 조회 건수
+const defaultQueryLimit = 500
+
+// Query 이력 조회 조건 (빈 값은 조건 미적용)
+type Query struct {
+	From    time.Time
+	To      time.Time
+	OrderID string
+	Limit   int
+}
+
+// CommandRecord 수신/거부된 명령 이력
+type CommandRecord struct {
+	Time    string `json:"time"`
+	Robot   string `json:"robot"`
+	Command string `json:"command"`
+	Outcome string `json:"outcome"`
+	Reason  string `json:"reason,omitempty"`
+}
+
+// OrderRecord 오더 이력 (마지막 상태)
+type OrderRecord struct {
+	OrderID   string `json:"orderId"`
+	Robot     string `json:"robot"`
+	Command   string `json:"command"`
+	Status    string `json:"status"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+}
+
+// ActionStateRecord 액션 상태 전이 이력
+type ActionStateRecord struct {
+	Time              string `json:"time"`
+	Robot             string `json:"robot"`
+	OrderID           string `json:"orderId"`
+	ActionID          string `json:"actionId"`
+	ActionStatus      string `json:"actionStatus"`
+	ResultDescription string `json:"resultDescription,omitempty"`
+}
+
+// ResponseRecord PLC 응답 이력
+type ResponseRecord struct {
+	Time    string `json:"time"`
+	Robot   string `json:"robot"`
+	Command string `json:"command"`
+	Status  string `json:"status"`
+	Payload string `json:"payload"`
+}
+
+// Result 이력 조회 결과
+type Result struct {
+	Commands     []CommandRecord     `json:"commands"`
+	Orders       []OrderRecord       `json:"orders"`
+	ActionStates []ActionStateRecord `json:"actionStates"`
+	Responses    []ResponseRecord    `json:"responses"`
+}
+
+// Find 조건에 맞는 이력 조회 (시간 순)
+// OrderID 지정 시 명령/응답은 해당 오더의 명령 기준으로 조회
+func (s *Store) Find(q Query) (*Result, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+
+	from, to := "", "9999"
+	if !q.From.IsZero() {
+		from = q.From.UTC().Format(timeLayout)
+	}
+	if !q.To.IsZero() {
+		to = q.To.UTC().Format(timeLayout)
+	}
+
+	result := &Result{
+		Commands:     []CommandRecord{},
+		Orders:       []OrderRecord{},
+		ActionStates: []ActionStateRecord{},
+		Responses:    []ResponseRecord{},
+	}
+
+	// 오더
+	rows, err := s.db.Query(`SELECT order_id, robot, command, status, created_at, updated_at FROM orders
+		WHERE updated_at >= ? AND created_at <= ? AND (? = '' OR order_id = ?)
+		ORDER BY created_at LIMIT ?`, from, to, q.OrderID, q.OrderID, limit)
+	if err != nil {
+		return nil, err
+	}
+	err = scanRows(rows, func() error {
+		var r OrderRecord
+		if err := rows.Scan(&r.OrderID, &r.Robot, &r.Command, &r.Status, &r.CreatedAt, &r.UpdatedAt); err != nil {
+			return err
+		}
+		result.Orders = append(result.Orders, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 명령 필터 (OrderID 지정 시 해당 오더 명령)
+	command := ""
+	if q.OrderID != "" {
+		if len(result.Orders) == 0 {
+			return result, nil
+		}
+		command = result.Orders[0].Command
+	}
+
+	// 명령
+	rows, err = s.db.Query(`SELECT time, robot, command, outcome, reason FROM commands
+		WHERE time >= ? AND time <= ? AND (? = '' OR command = ?)
+		ORDER BY time LIMIT ?`, from, to, command, command, limit)
+	if err != nil {
+		return nil, err
+	}
+	err = scanRows(rows, func() error {
+		var r CommandRecord
+		if err := rows.Scan(&r.Time, &r.Robot, &r.Command, &r.Outcome, &r.Reason); err != nil {
+			return err
+		}
+		result.Commands = append(result.Commands, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 액션 상태
+	rows, err = s.db.Query(`SELECT time, robot, order_id, action_id, action_status, result_description FROM action_states
+		WHERE time >= ? AND time <= ? AND (? = '' OR order_id = ?)
+		ORDER BY time LIMIT ?`, from, to, q.OrderID, q.OrderID, limit)
+	if err != nil {
+		return nil, err
+	}
+	err = scanRows(rows, func() error {
+		var r ActionStateRecord
+		if err := rows.Scan(&r.Time, &r.Robot, &r.OrderID, &r.ActionID, &r.ActionStatus, &r.ResultDescription); err != nil {
+			return err
+		}
+		result.ActionStates = append(result.ActionStates, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// PLC 응답
+	rows, err = s.db.Query(`SELECT time, robot, command, status, payload FROM responses
+		WHERE time >= ? AND time <= ? AND (? = '' OR command = ?)
+		ORDER BY time LIMIT ?`, from, to, command, command, limit)
+	if err != nil {
+		return nil, err
+	}
+	err = scanRows(rows, func() error {
+		var r ResponseRecord
+		if err := rows.Scan(&r.Time, &r.Robot, &r.Command, &r.Status, &r.Payload); err != nil {
+			return err
+		}
+		result.Responses = append(result.Responses, r)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// scanRows 행 순회 후 닫기
+func scanRows(rows *sql.Rows, scan func() error) error {
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
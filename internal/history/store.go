@@ -0,0 +1,183 @@
+// internal/history/store.go - Order History Store (SQLite)
+package history
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// timeLayout 저장 시간 형식 (UTC, 고정 길이 - 문자열 정렬이 시간 정렬과 일치)
+const timeLayout = "2006-01-02T15:04:05.000000Z"
+
+// pruneInterval 보존 기간 초과 레코드 정리 주기
+const pruneInterval = time.Hour
+
+// schema 이력 테이블 (commands, orders, action_states, responses) 및 누적 카운터 (metric_counters)
+var schema = []string{
+	`CREATE TABLE IF NOT EXISTS commands (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time TEXT NOT NULL,
+		robot TEXT NOT NULL,
+		command TEXT NOT NULL,
+		outcome TEXT NOT NULL,
+		reason TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_commands_time ON commands(time)`,
+	`CREATE TABLE IF NOT EXISTS orders (
+		order_id TEXT PRIMARY KEY,
+		robot TEXT NOT NULL,
+		command TEXT NOT NULL,
+		status TEXT NOT NULL,
+		created_at TEXT NOT NULL,
+		updated_at TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_orders_updated_at ON orders(updated_at)`,
+	`CREATE TABLE IF NOT EXISTS action_states (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time TEXT NOT NULL,
+		robot TEXT NOT NULL,
+		order_id TEXT NOT NULL,
+		action_id TEXT NOT NULL,
+		action_status TEXT NOT NULL,
+		result_description TEXT NOT NULL DEFAULT ''
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_action_states_time ON action_states(time)`,
+	`CREATE TABLE IF NOT EXISTS responses (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		time TEXT NOT NULL,
+		robot TEXT NOT NULL,
+		command TEXT NOT NULL,
+		status TEXT NOT NULL,
+		payload TEXT NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_responses_time ON responses(time)`,
+	`CREATE TABLE IF NOT EXISTS metric_counters (
+		name TEXT NOT NULL,
+		labels TEXT NOT NULL,
+		value REAL NOT NULL,
+		updated_at TEXT NOT NULL,
+		PRIMARY KEY (name, labels)
+	)`,
+}
+
+// Store 명령/오더/액션 상태/응답 전이 이력 저장소
+type Store struct {
+	db        *sql.DB
+	retention time.Duration
+}
+
+// Open 이력 데이터베이스 열기 및 스키마 생성
+// SQLite 드라이버는 "sqlite" 빌드 태그로 링크됨 (cmd/sqlite.go)
+func Open(driver, dsn string, retention time.Duration) (*Store, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history database (driver %q): %v", driver, err)
+	}
+
+	// SQLite는 단일 writer - 연결 하나로 직렬화
+	db.SetMaxOpenConns(1)
+
+	for _, statement := range schema {
+		if _, err := db.Exec(statement); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to create history schema: %v", err)
+		}
+	}
+
+	return &Store{db: db, retention: retention}, nil
+}
+
+// Close 데이터베이스 닫기
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// Run 이벤트 버스를 구독하여 전이 이력 기록 (ctx 종료 시 반환)
+func (s *Store) Run(ctx context.Context, bus *events.Bus) {
+	ch, unsubscribe := bus.Subscribe(1024)
+	defer unsubscribe()
+
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	s.prune()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.prune()
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := s.Record(event); err != nil {
+				utils.Logger.Errorf("❌ Failed to record history event %s: %v", event.Type, err)
+			}
+		}
+	}
+}
+
+// Record 단일 이벤트를 해당 테이블에 기록
+func (s *Store) Record(event events.Event) error {
+	at := event.Time.UTC().Format(timeLayout)
+
+	var err error
+	switch event.Type {
+	case events.TypeCommandReceived:
+		_, err = s.db.Exec(`INSERT INTO commands (time, robot, command, outcome) VALUES (?, ?, ?, 'received')`,
+			at, event.Robot, event.Command)
+	case events.TypeCommandRejected:
+		_, err = s.db.Exec(`INSERT INTO commands (time, robot, command, outcome, reason) VALUES (?, ?, ?, 'rejected', ?)`,
+			at, event.Robot, event.Command, event.Message)
+	case events.TypeOrderPublished:
+		_, err = s.db.Exec(`INSERT OR REPLACE INTO orders (order_id, robot, command, status, created_at, updated_at) VALUES (?, ?, ?, 'PUBLISHED', ?, ?)`,
+			event.OrderID, event.Robot, event.Command, at, at)
+	case events.TypeOrderStatus:
+		_, err = s.db.Exec(`UPDATE orders SET status = ?, updated_at = ? WHERE order_id = ?`,
+			event.Status, at, event.OrderID)
+	case events.TypeActionState:
+		_, err = s.db.Exec(`INSERT INTO action_states (time, robot, order_id, action_id, action_status, result_description) VALUES (?, ?, ?, ?, ?, ?)`,
+			at, event.Robot, event.OrderID, event.ActionID, event.Status, event.Message)
+	case events.TypePLCResponse:
+		_, err = s.db.Exec(`INSERT INTO responses (time, robot, command, status, payload) VALUES (?, ?, ?, ?, ?)`,
+			at, event.Robot, event.Command, event.Status, event.Message)
+	}
+	return err
+}
+
+// prune 보존 기간이 지난 레코드 삭제
+func (s *Store) prune() {
+	if s.retention <= 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-s.retention).UTC().Format(timeLayout)
+	statements := []string{
+		`DELETE FROM commands WHERE time < ?`,
+		`DELETE FROM orders WHERE updated_at < ?`,
+		`DELETE FROM action_states WHERE time < ?`,
+		`DELETE FROM responses WHERE time < ?`,
+	}
+
+	var deleted int64
+	for _, statement := range statements {
+		result, err := s.db.Exec(statement, cutoff)
+		if err != nil {
+			utils.Logger.Errorf("❌ Failed to prune history: %v", err)
+			return
+		}
+		if n, err := result.RowsAffected(); err == nil {
+			deleted += n
+		}
+	}
+
+	if deleted > 0 {
+		utils.Logger.Infof("🧹 Pruned %d history records older than %s", deleted, s.retention)
+	}
+}
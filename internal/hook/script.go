@@ -0,0 +1,185 @@
+// internal/hook/script.go - Embedded Command/Response Hook (Starlark site script)
+package hook
+
+import (
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/utils"
+	"time"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// log 훅 컴포넌트 로거
+var log = utils.Component("hook")
+
+// ErrRejected 훅 스크립트가 PLC 명령을 거부함
+var ErrRejected = errors.New("rejected by command hook")
+
+// 훅 함수 이름 (스크립트에 없으면 해당 방향은 원본 그대로)
+const (
+	commandFunc  = "on_command"  // on_command(command) -> 문자열 | None | reject(reason)
+	responseFunc = "on_response" // on_response(topic, response) -> 문자열 | None | drop()
+)
+
+// Script 사이트 Starlark 스크립트를 브릿지 안에서 실행하는 명령/응답 훅
+// 브릿지를 포크하지 않고 명령 변환/파라미터 추가/검증 가능, 외부 프로세스 없음
+//
+//	def on_command(command):
+//	    if command.startswith("PICK:") and ":speed=" not in command:
+//	        return command + ":speed=0.5"
+//	    if command.startswith("DROP:3"):
+//	        return reject("station 3 is locked")
+//
+//	def on_response(topic, response):
+//	    if response.endswith(":W"):
+//	        return drop()
+//
+// 전역 값은 로드 후 고정(freeze)되므로 호출 간 상태가 남지 않고 여러 핸들러에서 동시에 호출 가능
+// 시간 초과나 스크립트 오류 시 원본을 그대로 사용 (fail-open)
+type Script struct {
+	path       string
+	timeout    time.Duration
+	onCommand  starlark.Callable
+	onResponse starlark.Callable
+}
+
+// NewScript 훅 스크립트 로드 (COMMAND_HOOK, 문법 오류나 최상위 실행 오류는 시작 실패)
+func NewScript(path string, timeout time.Duration) (*Script, error) {
+	s := &Script{path: path, timeout: timeout}
+
+	predeclared := starlark.StringDict{
+		"reject": starlark.NewBuiltin("reject", builtinReject),
+		"drop":   starlark.NewBuiltin("drop", builtinDrop),
+	}
+	thread := s.newThread("load")
+	timer := time.AfterFunc(timeout, func() { thread.Cancel(fmt.Sprintf("not loaded within %s", timeout)) })
+	globals, err := starlark.ExecFileOptions(&syntax.FileOptions{}, thread, path, nil, predeclared)
+	timer.Stop()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load command hook %s: %w", path, err)
+	}
+	globals.Freeze()
+
+	for name, target := range map[string]*starlark.Callable{commandFunc: &s.onCommand, responseFunc: &s.onResponse} {
+		value, exists := globals[name]
+		if !exists {
+			continue
+		}
+		callable, ok := value.(starlark.Callable)
+		if !ok {
+			return nil, fmt.Errorf("command hook %s: %s must be a function, got %s", path, name, value.Type())
+		}
+		*target = callable
+	}
+	if s.onCommand == nil && s.onResponse == nil {
+		return nil, fmt.Errorf("command hook %s defines neither %s nor %s", path, commandFunc, responseFunc)
+	}
+
+	log.Infof("Hook script loaded: %s", path)
+	return s, nil
+}
+
+// Command 수신 PLC 명령 변환 (거부 시 ErrRejected)
+func (s *Script) Command(payload string) (string, error) {
+	if s.onCommand == nil {
+		return payload, nil
+	}
+	result, err := s.call(s.onCommand, starlark.Tuple{starlark.String(payload)})
+	if err != nil {
+		log.Errorf("Command hook failed, using original command: %v", err)
+		return payload, nil
+	}
+
+	switch value := result.(type) {
+	case starlark.NoneType:
+		return payload, nil
+	case starlark.String:
+		return string(value), nil
+	case *verdict:
+		if value.kind == verdictReject {
+			return payload, fmt.Errorf("%w: %s", ErrRejected, value.reason)
+		}
+	}
+	log.Errorf("Command hook returned %s (expected string, None or reject()), using original command", result.Type())
+	return payload, nil
+}
+
+// Response 발행할 PLC 응답 변환 (false면 발행하지 않음)
+func (s *Script) Response(topic, payload string) (string, bool) {
+	if s.onResponse == nil {
+		return payload, true
+	}
+	result, err := s.call(s.onResponse, starlark.Tuple{starlark.String(topic), starlark.String(payload)})
+	if err != nil {
+		log.Errorf("Response hook failed, publishing original response: %v", err)
+		return payload, true
+	}
+
+	switch value := result.(type) {
+	case starlark.NoneType:
+		return payload, true
+	case starlark.String:
+		return string(value), true
+	case *verdict:
+		if value.kind == verdictDrop {
+			return "", false
+		}
+	}
+	log.Errorf("Response hook returned %s (expected string, None or drop()), publishing original response", result.Type())
+	return payload, true
+}
+
+// call 새 스레드에서 훅 함수 호출 (시간 초과 시 스레드 취소)
+func (s *Script) call(fn starlark.Callable, args starlark.Tuple) (starlark.Value, error) {
+	thread := s.newThread(fn.Name())
+	timer := time.AfterFunc(s.timeout, func() { thread.Cancel(fmt.Sprintf("no result within %s", s.timeout)) })
+	defer timer.Stop()
+	return starlark.Call(thread, fn, args, nil)
+}
+
+// newThread 스크립트 실행 스레드 (print는 훅 로그로 전달)
+func (s *Script) newThread(name string) *starlark.Thread {
+	return &starlark.Thread{
+		Name: s.path + ":" + name,
+		Print: func(_ *starlark.Thread, msg string) {
+			log.Info(msg)
+		},
+	}
+}
+
+// 훅 함수의 특수 반환 값 종류
+const (
+	verdictReject = "reject" // 명령 거부
+	verdictDrop   = "drop"   // 응답 발행 안 함
+)
+
+// verdict reject()/drop() 반환 값
+type verdict struct {
+	kind   string
+	reason string
+}
+
+func (v *verdict) String() string        { return fmt.Sprintf("%s(%q)", v.kind, v.reason) }
+func (v *verdict) Type() string          { return v.kind }
+func (v *verdict) Freeze()               {}
+func (v *verdict) Truth() starlark.Bool  { return starlark.True }
+func (v *verdict) Hash() (uint32, error) { return 0, fmt.Errorf("unhashable type: %s", v.kind) }
+
+// builtinReject reject(reason) - on_command에서 반환하면 명령 거부
+func builtinReject(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	var reason string
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &reason); err != nil {
+		return nil, err
+	}
+	return &verdict{kind: verdictReject, reason: reason}, nil
+}
+
+// builtinDrop drop() - on_response에서 반환하면 응답 발행 안 함
+func builtinDrop(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	return &verdict{kind: verdictDrop}, nil
+}
@@ -0,0 +1,115 @@
+package hook
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+const testScript = `
+def on_command(command):
+    if command == "PICK:T:R":
+        return command + ":speed=0.5"
+    if command.startswith("DROP:3"):
+        return reject("station 3 is locked")
+    if command == "BAD":
+        return 42
+    if command == "FAIL":
+        fail("boom")
+    if command == "LOOP":
+        for _ in range(1 << 40):
+            pass
+
+def on_response(topic, response):
+    if response.endswith(":W"):
+        return drop()
+    if topic == "plc/legacy":
+        return response.lower()
+`
+
+func writeScript(t *testing.T, source string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hook.star")
+	if err := os.WriteFile(path, []byte(source), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestScriptCommand(t *testing.T) {
+	script, err := NewScript(writeScript(t, testScript), 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewScript: %v", err)
+	}
+
+	tests := []struct {
+		command  string
+		want     string
+		rejected bool
+	}{
+		{command: "PICK:T:R", want: "PICK:T:R:speed=0.5"},
+		{command: "CAL:I", want: "CAL:I"},
+		{command: "DROP:3:T", want: "DROP:3:T", rejected: true},
+		{command: "BAD", want: "BAD"},   // 잘못된 반환 타입은 원본 사용
+		{command: "FAIL", want: "FAIL"}, // 스크립트 오류는 원본 사용
+		{command: "LOOP", want: "LOOP"}, // 시간 초과는 원본 사용
+	}
+	for _, tt := range tests {
+		t.Run(tt.command, func(t *testing.T) {
+			got, err := script.Command(tt.command)
+			if got != tt.want {
+				t.Errorf("Command(%q) = %q, want %q", tt.command, got, tt.want)
+			}
+			if rejected := errors.Is(err, ErrRejected); rejected != tt.rejected {
+				t.Errorf("Command(%q) error = %v, rejected %v", tt.command, err, tt.rejected)
+			}
+		})
+	}
+}
+
+func TestScriptResponse(t *testing.T) {
+	script, err := NewScript(writeScript(t, testScript), 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("NewScript: %v", err)
+	}
+
+	tests := []struct {
+		topic    string
+		response string
+		want     string
+		publish  bool
+	}{
+		{"bridge/response", "CAL:I:S", "CAL:I:S", true},
+		{"bridge/response", "CAL:I:W", "", false},
+		{"plc/legacy", "CAL:I:S", "cal:i:s", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic+"/"+tt.response, func(t *testing.T) {
+			got, publish := script.Response(tt.topic, tt.response)
+			if got != tt.want || publish != tt.publish {
+				t.Errorf("Response(%q, %q) = %q, %v, want %q, %v", tt.topic, tt.response, got, publish, tt.want, tt.publish)
+			}
+		})
+	}
+}
+
+func TestNewScriptErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+	}{
+		{"syntax error", "def on_command(command)\n    return command\n"},
+		{"no hook functions", "x = 1\n"},
+		{"hook is not a function", "on_command = 1\n"},
+		{"top-level failure", "fail(\"bad config\")\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewScript(writeScript(t, tt.source), time.Second); err == nil {
+				t.Error("NewScript succeeded, want error")
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+// internal/kafka/sink.go - Kafka Sink for Order Events and Robot State (REST Proxy)
+package kafka
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// contentType Kafka REST Proxy v2 JSON 레코드 형식
+const contentType = "application/vnd.kafka.json.v2+json"
+
+// maxPending 전송 실패 시 보관할 최대 레코드 수 (초과 시 오래된 것부터 폐기)
+const maxPending = 10000
+
+// record REST Proxy 레코드 (키는 로봇 시리얼, 같은 로봇 레코드는 같은 파티션)
+type record struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// Sink 오더 수명 주기 이벤트와 로봇 상태 스냅샷을 Kafka 토픽으로 전송
+// Kafka 클라이언트 의존성 없이 Kafka REST Proxy (Confluent v2 API)로 발행
+type Sink struct {
+	config   *config.Config
+	handlers []*messaging.DirectActionHandler
+	eventBus *events.Bus
+	client   *http.Client
+
+	pending map[string][]record // 토픽 -> 전송 대기 레코드
+}
+
+// NewSink 새 Kafka 싱크 생성
+func NewSink(cfg *config.Config, handlers []*messaging.DirectActionHandler, eventBus *events.Bus) *Sink {
+	return &Sink{
+		config:   cfg,
+		handlers: handlers,
+		eventBus: eventBus,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		pending:  make(map[string][]record),
+	}
+}
+
+// Run 이벤트 수집 및 주기 전송 (ctx 종료 시 남은 레코드 전송 후 반환)
+func (s *Sink) Run(ctx context.Context) {
+	eventCh, unsubscribe := s.eventBus.Subscribe(1024)
+	defer unsubscribe()
+
+	flushTicker := time.NewTicker(s.config.KafkaFlushInterval)
+	defer flushTicker.Stop()
+	stateTicker := time.NewTicker(s.config.KafkaStateInterval)
+	defer stateTicker.Stop()
+
+	utils.Logger.Infof("📡 Kafka sink started (REST proxy %s, orders -> %s, state -> %s)",
+		s.config.KafkaRestURL, s.config.KafkaOrderTopic, s.config.KafkaStateTopic)
+
+	for {
+		select {
+		case <-ctx.Done():
+			// 종료 시 마지막 전송은 새 컨텍스트로 (짧은 제한 시간)
+			flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			s.flush(flushCtx)
+			cancel()
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if isOrderLifecycle(event.Type) {
+				s.add(s.config.KafkaOrderTopic, record{Key: event.Robot, Value: event})
+				if len(s.pending[s.config.KafkaOrderTopic]) >= s.config.KafkaBatchSize {
+					s.flush(ctx)
+				}
+			}
+		case <-stateTicker.C:
+			for _, handler := range s.handlers {
+				status := handler.GetRobotStatus()
+				s.add(s.config.KafkaStateTopic, record{Key: status.Robot, Value: stateSnapshot{Time: time.Now(), RobotStatus: status}})
+			}
+		case <-flushTicker.C:
+			s.flush(ctx)
+		}
+	}
+}
+
+// stateSnapshot 로봇 상태 스냅샷 레코드 (수집 시각 포함)
+type stateSnapshot struct {
+	Time time.Time `json:"time"`
+	messaging.RobotStatus
+}
+
+// isOrderLifecycle 오더 수명 주기 이벤트인지 확인 (명령 수신부터 PLC 응답까지)
+func isOrderLifecycle(eventType string) bool {
+	return strings.HasPrefix(eventType, "order.") ||
+		strings.HasPrefix(eventType, "command.") ||
+		eventType == events.TypeActionState ||
+		eventType == events.TypePLCResponse
+}
+
+// add 전송 대기 레코드 추가 (최대 보관 수 초과 시 오래된 것 폐기)
+func (s *Sink) add(topic string, r record) {
+	queue := append(s.pending[topic], r)
+	if dropped := len(queue) - maxPending; dropped > 0 {
+		utils.Logger.Warnf("⚠️ Kafka sink buffer full, dropping %d oldest record(s) for %s", dropped, topic)
+		queue = queue[dropped:]
+	}
+	s.pending[topic] = queue
+}
+
+// flush 토픽별 대기 레코드 전송 (실패한 토픽은 다음 주기에 재시도)
+func (s *Sink) flush(ctx context.Context) {
+	for topic, records := range s.pending {
+		if len(records) == 0 {
+			continue
+		}
+		for len(records) > 0 {
+			batch := records[:min(len(records), s.config.KafkaBatchSize)]
+			if err := s.produce(ctx, topic, batch); err != nil {
+				utils.Logger.Errorf("❌ Kafka produce to %s failed (%d record(s) kept for retry): %v", topic, len(records), err)
+				break
+			}
+			records = records[len(batch):]
+		}
+		s.pending[topic] = records
+	}
+}
+
+// produce REST Proxy로 레코드 배치 발행
+func (s *Sink) produce(ctx context.Context, topic string, records []record) error {
+	body, err := json.Marshal(map[string]interface{}{"records": records})
+	if err != nil {
+		return fmt.Errorf("failed to marshal records: %v", err)
+	}
+
+	url := strings.TrimRight(s.config.KafkaRestURL, "/") + "/topics/" + topic
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("REST proxy returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+// internal/messaging/alias.go - PLC Command Aliases
+package messaging
+
+import "strings"
+
+// resolveCommandAlias 기본 명령이 별칭이면 실제 Direct Action 명령으로 치환 (COMMAND_ALIASES)
+// 나머지 세그먼트(파라미터 등)는 그대로 이어 붙임 (예: HOME:speed=0.5 -> go_home_trajectory:T:R:speed=0.5)
+// 응답/취소/중복 확인은 PLC가 보낸 별칭 명령 기준으로 유지
+// 잠금 보유 상태에서 호출
+func (h *DirectActionHandler) resolveCommandAlias(commandStr string) string {
+	base, rest, _ := strings.Cut(commandStr, ":")
+	target, ok := h.commandAliases[base]
+	if !ok {
+		return commandStr
+	}
+
+	resolved := target
+	if rest != "" {
+		resolved += ":" + rest
+	}
+	h.commandLog(commandStr).WithField("resolved", resolved).Debug("Command alias resolved")
+	return resolved
+}
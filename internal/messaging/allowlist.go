@@ -0,0 +1,89 @@
+// internal/messaging/allowlist.go - Inference/Trajectory Name Allowlist
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+)
+
+// ErrActionNotAllowed 허용 목록에 없는 추론/궤적 이름 (로봇에 보내지 않고 U 응답)
+var ErrActionNotAllowed = errors.New("action name not allowed")
+
+// actionAllowlist 액션 파라미터별 허용 이름 (nil이면 제한 없음)
+type actionAllowlist struct {
+	inferences   map[string]bool // inference_name
+	trajectories map[string]bool // trajectory_name
+}
+
+// newActionAllowlist 설정의 허용 목록 (ALLOWED_INFERENCES, ALLOWED_TRAJECTORIES)
+func newActionAllowlist(cfg *config.Config) actionAllowlist {
+	return actionAllowlist{
+		inferences:   config.ParseNameList(cfg.AllowedInferences),
+		trajectories: config.ParseNameList(cfg.AllowedTrajectories),
+	}
+}
+
+// names 파라미터 키에 해당하는 허용 목록 (확인 대상이 아니거나 제한 없으면 nil)
+func (a actionAllowlist) names(key string) map[string]bool {
+	switch key {
+	case "inference_name":
+		return a.inferences
+	case "trajectory_name":
+		return a.trajectories
+	}
+	return nil
+}
+
+// checkActionAllowed 번역된 액션의 추론/궤적 이름이 설정과 factsheet 허용 목록에 모두 있는지 확인
+func (h *DirectActionHandler) checkActionAllowed(parameters []types.ActionParameter) error {
+	for _, parameter := range parameters {
+		name := fmt.Sprint(parameter.Value)
+		if allowed := h.allowlist.names(parameter.Key); allowed != nil && !allowed[name] {
+			return fmt.Errorf("%w: %s %q is not in the configured allowlist", ErrActionNotAllowed, parameter.Key, name)
+		}
+		if allowed := h.factsheetAllowlist.names(parameter.Key); allowed != nil && !allowed[name] {
+			return fmt.Errorf("%w: %s %q is not reported by robot %s", ErrActionNotAllowed, parameter.Key, name, h.config.RobotSerialNumber)
+		}
+	}
+	return nil
+}
+
+// applyFactsheetAllowlist factsheet가 보고한 추론/궤적 이름 목록 반영 (잠금 보유 상태에서 호출)
+// VDA5050 표준 필드가 아니므로 protocolFeatures.inferenceNames/trajectoryNames를 보고하는 로봇만 적용
+func (h *DirectActionHandler) applyFactsheetAllowlist(factsheetMsg map[string]interface{}) {
+	if !h.config.FactsheetAllowlist {
+		return
+	}
+	manufacturer, _ := factsheetMsg["manufacturer"].(string)
+	serialNumber, _ := factsheetMsg["serialNumber"].(string)
+	if manufacturer != h.config.RobotManufacturer || serialNumber != h.config.RobotSerialNumber {
+		return
+	}
+
+	protocolFeatures, _ := factsheetMsg["protocolFeatures"].(map[string]interface{})
+	allowlist := actionAllowlist{
+		inferences:   factsheetNames(protocolFeatures["inferenceNames"]),
+		trajectories: factsheetNames(protocolFeatures["trajectoryNames"]),
+	}
+	utils.Logger.Infof("📄 Factsheet action names for %s/%s: %d inference(s), %d trajectory(ies)",
+		manufacturer, serialNumber, len(allowlist.inferences), len(allowlist.trajectories))
+	h.factsheetAllowlist = allowlist
+}
+
+// factsheetNames factsheet 이름 배열 해석 (배열이 없으면 nil = 제한 없음)
+func factsheetNames(value interface{}) map[string]bool {
+	list, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+	names := make(map[string]bool, len(list))
+	for _, item := range list {
+		if name, ok := item.(string); ok {
+			names[name] = true
+		}
+	}
+	return names
+}
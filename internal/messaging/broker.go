@@ -0,0 +1,44 @@
+// internal/messaging/broker.go - MQTT Publisher/Subscriber Interfaces
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/config"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// Publisher MQTT 발행 (핸들러가 의존, MQTTClient 또는 FakeBroker)
+type Publisher interface {
+	Publish(topic string, qos byte, retained bool, payload interface{}) error
+}
+
+// TopicSubscriber MQTT 구독 (Subscriber가 의존, MQTTClient 또는 FakeBroker)
+type TopicSubscriber interface {
+	Subscribe(topic string, qos byte, callback mqtt.MessageHandler) error
+	OnConnect(callback func())
+	GetConfig() *config.Config
+}
+
+// Broker 발행과 구독을 모두 제공하는 브로커 연결
+type Broker interface {
+	Publisher
+	TopicSubscriber
+}
+
+var (
+	_ Broker = (*MQTTClient)(nil)
+	_ Broker = (*FakeBroker)(nil)
+)
+
+// payloadBytes 발행 페이로드를 바이트로 변환 (string, []byte, 그 외는 %v)
+func payloadBytes(payload interface{}) []byte {
+	switch v := payload.(type) {
+	case string:
+		return []byte(v)
+	case []byte:
+		return v
+	default:
+		return []byte(fmt.Sprintf("%v", v))
+	}
+}
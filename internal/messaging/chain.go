@@ -0,0 +1,170 @@
+// internal/messaging/chain.go - Conditional Command Chains (workflow)
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+
+	"github.com/sirupsen/logrus"
+)
+
+// chainRun 실행 중인 명령 체인 (기본 명령당 하나)
+type chainRun struct {
+	command       string // PLC 체인 명령
+	correlationID string
+	replyChannel  string
+	cancel        context.CancelFunc
+	stepOrderID   string // 진행 중인 단계 오더
+}
+
+// startChain 명령 체인 시작, PLC에 대기(W) 응답 후 단계를 별도 고루틴에서 순서대로 실행 (잠금 보유 상태에서 호출)
+// 같은 체인이 이미 실행 중이면 재전송으로 보고 대기(W) 재보고
+func (h *DirectActionHandler) startChain(commandStr string, chain config.MappedChain) *CommandResult {
+	baseCommand := h.extractBaseCommand(commandStr)
+	if _, running := h.chains[baseCommand]; running {
+		h.commandLog(commandStr).Info("Chain already running - re-reporting status")
+		h.sendPLCResponse(commandStr, types.PLCStatusWaiting)
+		return newCommandResult(commandStr, "", nil)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	run := &chainRun{
+		command:       commandStr,
+		correlationID: h.correlationID,
+		replyChannel:  h.replyChannel,
+		cancel:        cancel,
+	}
+	h.chains[baseCommand] = run
+
+	h.commandLog(commandStr).WithField("steps", len(chain.Steps)).Info("Chain started")
+	h.sendPLCResponse(commandStr, types.PLCStatusWaiting)
+	go h.runChain(ctx, run, chain)
+	return newCommandResult(commandStr, "", nil)
+}
+
+// runChain 단계를 순서대로 실행 (성공 시 다음 단계, 실패 시 복구 명령 실행 후 중단)
+func (h *DirectActionHandler) runChain(ctx context.Context, run *chainRun, chain config.MappedChain) {
+	status := types.PLCStatusSuccess
+	for _, step := range chain.Steps {
+		err := h.runChainStep(ctx, run, step.Command)
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			continue
+		}
+
+		h.chainLog(run).WithField("step", step.Command).Warnf("Chain step failed: %v", err)
+		if step.OnFailure != "" {
+			if err := h.runChainStep(ctx, run, step.OnFailure); err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				h.chainLog(run).WithField("step", step.OnFailure).Errorf("Chain recovery step failed: %v", err)
+			}
+		}
+		status = types.PLCStatusFailed
+		break
+	}
+	h.finishChain(run, status)
+}
+
+// runChainStep 단계 명령을 체인의 상관관계 ID/응답 채널로 처리하고 오더 최종 상태 대기
+// 체인 시작 시 수신 제한/일시 중지를 이미 통과했으므로 단계는 명령 처리부터 시작
+func (h *DirectActionHandler) runChainStep(ctx context.Context, run *chainRun, command string) error {
+	h.mu.Lock()
+	// 중단은 잠금 보유 상태에서 이루어지므로 여기서 확인하면 중단 후 다음 단계를 시작하지 않음
+	if err := ctx.Err(); err != nil {
+		h.mu.Unlock()
+		return err
+	}
+	restoreCorrelation := h.beginCorrelation(run.correlationID)
+	restoreReply := h.beginReply(run.replyChannel)
+	result := h.processAndRecord(command)
+	if result.Accepted {
+		run.stepOrderID = result.OrderID
+	}
+	restoreReply()
+	restoreCorrelation()
+	h.dispatchQueued()
+	h.mu.Unlock()
+
+	if !result.Accepted {
+		return fmt.Errorf("rejected: %s", result.Reason)
+	}
+	if result.OrderID == "" {
+		return fmt.Errorf("no order created (%s)", result.Reason)
+	}
+
+	var finalStatus string
+	err := h.WatchOrder(ctx, result.OrderID, func(event events.Event) error {
+		finalStatus = event.Status
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if finalStatus != types.PLCStatusSuccess {
+		return fmt.Errorf("finished with status %s", finalStatus)
+	}
+	return nil
+}
+
+// finishChain 체인 최종 상태를 PLC에 응답
+func (h *DirectActionHandler) finishChain(run *chainRun, status string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	baseCommand := h.extractBaseCommand(run.command)
+	if h.chains[baseCommand] != run {
+		return
+	}
+	delete(h.chains, baseCommand)
+	run.cancel()
+
+	defer h.beginCorrelation(run.correlationID)()
+	defer h.beginReply(run.replyChannel)()
+	h.chainLog(run).WithField("status", status).Info("Chain finished")
+	h.sendPLCResponse(run.command, status)
+}
+
+// stopChain 실행 중인 체인 중단 후 체인 명령은 실패(F) 응답 (잠금 보유 상태에서 호출)
+// 진행 중인 단계 오더는 그대로 두므로 필요하면 호출자가 취소 (cancelChainStep)
+func (h *DirectActionHandler) stopChain(run *chainRun, reason string) {
+	delete(h.chains, h.extractBaseCommand(run.command))
+	run.cancel()
+	h.chainLog(run).WithField("reason", reason).Warn("Chain stopped")
+
+	restoreReply := h.beginReply(run.replyChannel)
+	h.publishPLCResponse(types.NewPLCResponse(run.command, types.PLCStatusFailed, "chain stopped: "+reason))
+	restoreReply()
+}
+
+// cancelChainStep 체인의 진행 중인 단계 오더 취소 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) cancelChainStep(run *chainRun, reason string) {
+	if order, exists := h.activeOrders[run.stepOrderID]; exists {
+		if err := h.cancelOrder(order, h.extractBaseCommand(order.Command)+":C"); err != nil {
+			h.orderLog(order).Errorf("Failed to cancel chain step: %v", err)
+		}
+	} else if pending, exists := h.pendingOrders[run.stepOrderID]; exists {
+		h.rejectPendingOrder(pending, reason)
+	}
+}
+
+// stopChains 모든 체인 중단 (비상 정지, PLC 하트비트 끊김 시 진행 중인 오더와 함께 정리, 잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) stopChains(reason string) {
+	for _, run := range h.chains {
+		h.stopChain(run, reason)
+	}
+}
+
+// chainLog 체인 구조화 로그 엔트리
+func (h *DirectActionHandler) chainLog(run *chainRun) *logrus.Entry {
+	return h.robotLog().WithFields(logrus.Fields{
+		"chain":         run.command,
+		"correlationId": run.correlationID,
+	})
+}
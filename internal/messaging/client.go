@@ -2,88 +2,167 @@
 package messaging
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"mqtt-bridge/internal/audit"
 	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/secrets"
 	"mqtt-bridge/internal/utils"
+	"strings"
+	"sync"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 )
 
+// ErrSubscriptionDenied 브로커가 구독을 거부함 (SUBACK 0x80, 대부분 ACL)
+var ErrSubscriptionDenied = errors.New("subscription denied by broker")
+
+// subackFailure SUBACK 실패 반환 코드
+const subackFailure = 0x80
+
 // MQTTClient MQTT 클라이언트 구현체
 type MQTTClient struct {
 	client mqtt.Client
 	config *config.Config
+	audit  *audit.Log  // 송수신 메시지 감사 로그 (nil이면 비활성화)
+	outbox *Outbox     // 연결 끊김 동안 발행 메시지 보관 (nil이면 비활성화)
+	events *events.Bus // 처리기 패닉 이벤트 (nil이면 로그만)
+
+	secrets     *secrets.Store     // 자격 증명/TLS (재연결 시 최신 값 사용)
+	stopSecrets context.CancelFunc // 비밀 값 재조회 중지
+
+	mu        sync.Mutex
+	onConnect []func() // 재연결 시 호출 (최초 연결 제외)
 }
 
+// ClientOption 연결 전 MQTT 옵션 추가 설정 (유언 메시지 등)
+type ClientOption func(opts *mqtt.ClientOptions)
+
 // NewMQTTClient 새 MQTT 클라이언트 생성
-func NewMQTTClient(cfg *config.Config) (*MQTTClient, error) {
+func NewMQTTClient(cfg *config.Config, options ...ClientOption) (*MQTTClient, error) {
 	utils.Logger.Infof("🏗️ Creating MQTT Client")
 
+	secretStore, err := loadMQTTSecrets(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load MQTT secrets: %v", err)
+	}
+	// 연결 오류 등 어떤 로그에도 계정 정보가 남지 않도록 등록
+	utils.RegisterSecret(secretStore.Get(secretMQTTUsername))
+	utils.RegisterSecret(secretStore.Get(secretMQTTPassword))
+
 	opts := mqtt.NewClientOptions()
 	opts.AddBroker(cfg.MQTTBroker)
 	opts.SetClientID(cfg.MQTTClientID)
-	opts.SetUsername(cfg.MQTTUsername)
-	opts.SetPassword(cfg.MQTTPassword)
+	opts.SetCredentialsProvider(func() (string, string) {
+		return secretStore.Get(secretMQTTUsername), secretStore.Get(secretMQTTPassword)
+	})
+	if tlsConfig := newTLSConfig(secretStore); tlsConfig != nil {
+		opts.SetTLSConfig(tlsConfig)
+	}
 	opts.SetKeepAlive(60 * time.Second)
 	opts.SetPingTimeout(10 * time.Second)
 	opts.SetAutoReconnect(true)
 	opts.SetMaxReconnectInterval(10 * time.Second)
 
+	mqttClient := &MQTTClient{
+		config: cfg,
+	}
+
 	// 연결 상태 콜백
 	opts.SetOnConnectHandler(func(c mqtt.Client) {
 		utils.Logger.Info("MQTT client connected")
+		mqttClient.mu.Lock()
+		callbacks := append([]func(){}, mqttClient.onConnect...)
+		outbox := mqttClient.outbox
+		mqttClient.mu.Unlock()
+		if outbox != nil {
+			outbox.flush(mqttClient.publishNow)
+		}
+		for _, callback := range callbacks {
+			callback()
+		}
 	})
 
 	opts.SetConnectionLostHandler(func(c mqtt.Client, err error) {
 		utils.Logger.Errorf("MQTT connection lost: %v", err)
 	})
 
+	for _, option := range options {
+		option(opts)
+	}
+
 	client := mqtt.NewClient(opts)
+	mqttClient.client = client
 
 	// 연결 시도
 	if token := client.Connect(); token.Wait() && token.Error() != nil {
 		return nil, fmt.Errorf("failed to connect to MQTT broker: %v", token.Error())
 	}
 
-	mqttClient := &MQTTClient{
-		client: client,
-		config: cfg,
-	}
+	ctx, cancel := context.WithCancel(context.Background())
+	go secretStore.Run(ctx, cfg.SecretsRefreshInterval)
+
+	mqttClient.secrets = secretStore
+	mqttClient.stopSecrets = cancel
 
 	utils.Logger.Infof("✅ MQTT Client Created")
 	return mqttClient, nil
 }
 
-// Publish 메시지 발행
+// Publish 메시지 발행 (outbox가 설정되어 있으면 PLC 응답/브릿지 상태는 연결이 끊긴 동안 보관 후 재연결 시 전송)
 func (c *MQTTClient) Publish(topic string, qos byte, retained bool, payload interface{}) error {
+	message := outboxMessage{Topic: topic, QoS: qos, Retained: retained, Payload: payloadBytes(payload)}
+
+	c.mu.Lock()
+	outbox := c.outbox
+	c.mu.Unlock()
+	if outbox != nil && c.buffersTopic(topic) {
+		return outbox.publish(message, c.publishNow)
+	}
+	return c.publishNow(message)
+}
+
+// buffersTopic outbox에 보관할 토픽인지 확인 (PLC 응답과 브릿지 상태 토픽, 하위 토픽 포함)
+// 로봇 오더/InstantAction(비상 정지 포함)은 늦게 전달되면 위험하므로 보관하지 않고 바로 실패
+func (c *MQTTClient) buffersTopic(topic string) bool {
+	for _, base := range []string{c.config.PlcResponseTopic, c.config.BridgeStatusTopic} {
+		if base != "" && (topic == base || strings.HasPrefix(topic, base+"/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// publishNow 브로커에 바로 발행
+func (c *MQTTClient) publishNow(message outboxMessage) error {
 	if !c.client.IsConnected() {
 		return fmt.Errorf("MQTT client is not connected")
 	}
 
 	// 📤 발신 메시지 상세 로깅
-	var payloadStr string
-	switch v := payload.(type) {
-	case string:
-		payloadStr = v
-	case []byte:
-		payloadStr = string(v)
-	default:
-		payloadStr = fmt.Sprintf("%v", v)
-	}
-
-	utils.Logger.Infof("📤 MQTT PUBLISH")
-	utils.Logger.Infof("📤 Topic   : %s", topic)
-	utils.Logger.Infof("📤 QoS    : %d, Retained: %v", qos, retained)
-	utils.Logger.Infof("📤 Payload : %s", payloadStr)
+	logPublish(message.Topic, message.QoS, message.Retained, string(message.Payload))
 
-	token := c.client.Publish(topic, qos, retained, payload)
+	token := c.client.Publish(message.Topic, message.QoS, message.Retained, message.Payload)
 	if token.Wait() && token.Error() != nil {
-		utils.Logger.Errorf("❌ MQTT PUBLISH FAILED: %s - %v", topic, token.Error())
+		utils.Logger.Errorf("❌ MQTT PUBLISH FAILED: %s - %v", message.Topic, token.Error())
+		c.mu.Lock()
+		eventBus := c.events
+		c.mu.Unlock()
+		if eventBus != nil {
+			eventBus.Publish(events.Event{
+				Type:    events.TypeMQTTPublishFailed,
+				Status:  message.Topic,
+				Message: token.Error().Error(),
+			})
+		}
 		return fmt.Errorf("failed to publish message: %v", token.Error())
 	}
 
-	utils.Logger.Infof("✅ MQTT PUBLISH SUCCESS: %s", topic)
+	utils.Logger.Infof("✅ MQTT PUBLISH SUCCESS: %s", message.Topic)
+	c.recordAudit(audit.DirectionOutbound, message.Topic, message.QoS, message.Retained, message.Payload)
 	return nil
 }
 
@@ -93,17 +172,70 @@ func (c *MQTTClient) Subscribe(topic string, qos byte, callback mqtt.MessageHand
 		return fmt.Errorf("MQTT client is not connected")
 	}
 
-	token := c.client.Subscribe(topic, qos, callback)
+	c.mu.Lock()
+	eventBus := c.events
+	c.mu.Unlock()
+
+	token := c.client.Subscribe(topic, qos, func(client mqtt.Client, msg mqtt.Message) {
+		defer recoverHandler(eventBus, topic, msg)
+		c.recordAudit(audit.DirectionInbound, msg.Topic(), msg.Qos(), msg.Retained(), msg.Payload())
+		callback(client, msg)
+	})
 	if token.Wait() && token.Error() != nil {
 		return fmt.Errorf("failed to subscribe to topic %s: %v", topic, token.Error())
 	}
 
+	// 브로커 거부는 오류가 아닌 SUBACK 반환 코드로 전달됨
+	if subToken, ok := token.(*mqtt.SubscribeToken); ok {
+		if granted, exists := subToken.Result()[topic]; exists && granted == subackFailure {
+			return fmt.Errorf("%w: %s (SUBACK 0x80, check broker ACL for client %s)", ErrSubscriptionDenied, topic, c.config.MQTTClientID)
+		}
+	}
+
 	utils.Logger.Infof("✅ Subscribed to topic: %s", topic)
 	return nil
 }
 
+// OnConnect 재연결 시 호출할 함수 등록 (최초 연결 이후 등록하므로 재연결에만 호출됨)
+func (c *MQTTClient) OnConnect(callback func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onConnect = append(c.onConnect, callback)
+}
+
+// SetAuditLog 송수신 메시지 감사 로그 설정 (구독 전에 호출)
+func (c *MQTTClient) SetAuditLog(auditLog *audit.Log) {
+	c.audit = auditLog
+}
+
+// SetEventBus 처리기 패닉 이벤트를 발행할 버스 설정 (구독 전에 호출)
+func (c *MQTTClient) SetEventBus(bus *events.Bus) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.events = bus
+}
+
+// SetOutbox 연결 끊김 동안 발행 메시지를 보관할 outbox 설정 (연결되어 있으면 복원된 메시지 바로 전송)
+func (c *MQTTClient) SetOutbox(outbox *Outbox) {
+	c.mu.Lock()
+	c.outbox = outbox
+	c.mu.Unlock()
+
+	if c.client.IsConnected() {
+		outbox.flush(c.publishNow)
+	}
+}
+
+// recordAudit 감사 로그 기록 (실패해도 메시지 처리는 계속)
+func (c *MQTTClient) recordAudit(direction, topic string, qos byte, retained bool, payload []byte) {
+	if err := c.audit.Record(direction, topic, qos, retained, payload); err != nil {
+		utils.Logger.Errorf("❌ Failed to write audit record: %v", err)
+	}
+}
+
 // Disconnect 연결 해제
 func (c *MQTTClient) Disconnect(quiesce uint) {
+	c.stopSecrets()
 	if c.client.IsConnected() {
 		c.client.Disconnect(quiesce)
 		utils.Logger.Info("MQTT client disconnected")
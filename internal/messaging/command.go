@@ -0,0 +1,45 @@
+// internal/messaging/command.go - PLC Command Result
+package messaging
+
+import (
+	"errors"
+	"time"
+)
+
+// CommandResult PLC 명령 처리 결과 (HTTP 게이트웨이 응답용)
+type CommandResult struct {
+	Command  string `json:"command"`
+	OrderID  string `json:"orderId,omitempty"`
+	Accepted bool   `json:"accepted"`
+	Reason   string `json:"reason,omitempty"`
+
+	CorrelationID string `json:"correlationId,omitempty"`
+
+	// 검증 실패 상세 (필드, 위치, 사유)
+	Errors ValidationErrors `json:"errors,omitempty"`
+
+	ReceivedAt time.Time `json:"receivedAt"`
+
+	err error // 거부 원인 (dead-letter 사유 분류용)
+}
+
+// newCommandResult 처리 결과 생성 (err가 nil이면 수락)
+func newCommandResult(command, orderID string, err error) *CommandResult {
+	result := &CommandResult{
+		Command:  command,
+		OrderID:  orderID,
+		Accepted: err == nil,
+
+		ReceivedAt: time.Now(),
+	}
+	if err != nil {
+		result.err = err
+		result.Reason = err.Error()
+
+		var validationErrs ValidationErrors
+		if errors.As(err, &validationErrs) {
+			result.Errors = validationErrs
+		}
+	}
+	return result
+}
@@ -0,0 +1,149 @@
+// internal/messaging/commissioning.go - Commissioning Mode (operator confirmation before publish)
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// pendingOrder 운영자 확인 또는 지연 시간 경과를 기다리는 오더 (생성된 메시지를 그대로 발행)
+type pendingOrder struct {
+	order   *OrderInfo
+	message *OutboundMessage
+	timer   *time.Timer // 확인 시간 초과 시 거부, 지연 실행은 발행
+
+	awaitStationary bool // 로봇이 정지하면 발행 (driving.go)
+}
+
+// holdForConfirmation 오더를 확인 대기로 보관하고 PLC에 대기(W) 응답 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) holdForConfirmation(order *OrderInfo, message *OutboundMessage) {
+	pending := &pendingOrder{order: order, message: message}
+	if timeout := h.config.CommissioningConfirmTimeout; timeout > 0 {
+		orderID := order.OrderID
+		pending.timer = time.AfterFunc(timeout, func() {
+			if err := h.RejectOrder(orderID, "confirmation timeout"); err == nil {
+				h.robotLog().WithFields(logrus.Fields{
+					"orderId": orderID,
+					"timeout": timeout,
+				}).Warn("Commissioning confirmation timed out")
+			}
+		})
+	}
+	h.pendingOrders[order.OrderID] = pending
+
+	h.orderLog(order).Warn("Commissioning mode - order held for operator confirmation")
+	h.respondOrder(order, types.PLCStatusWaiting)
+	h.publishEvent(events.Event{
+		Type:    events.TypeOrderPendingConfirmation,
+		Command: order.Command,
+		OrderID: order.OrderID,
+	})
+}
+
+// ConfirmOrder 확인 대기 오더를 로봇으로 발행
+func (h *DirectActionHandler) ConfirmOrder(orderID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	defer h.dispatchQueued()
+
+	pending, exists := h.pendingOrders[orderID]
+	if !exists {
+		return ErrOrderNotFound
+	}
+	h.removePendingOrder(pending)
+
+	h.orderLog(pending.order).Info("Operator confirmed order")
+	if err := h.publishOrder(pending.order, pending.message); err != nil {
+		utils.Logger.Errorf("❌ Failed to send confirmed order: %v", err)
+		h.respondOrder(pending.order, types.PLCStatusFailed)
+		h.finishOrder(pending.order)
+		return err
+	}
+	return nil
+}
+
+// RejectOrder 확인 대기 오더를 발행하지 않고 실패(F) 처리
+func (h *DirectActionHandler) RejectOrder(orderID, reason string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	defer h.dispatchQueued()
+
+	pending, exists := h.pendingOrders[orderID]
+	if !exists {
+		return ErrOrderNotFound
+	}
+	h.rejectPendingOrder(pending, reason)
+	return nil
+}
+
+// rejectPendingOrder 확인 대기 오더 거부 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) rejectPendingOrder(pending *pendingOrder, reason string) {
+	h.removePendingOrder(pending)
+
+	h.orderLog(pending.order).WithField("reason", reason).Warn("Order rejected before publish")
+	h.respondOrder(pending.order, types.PLCStatusFailed)
+	h.finishOrder(pending.order)
+}
+
+// removePendingOrder 확인 대기 목록에서 제거 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) removePendingOrder(pending *pendingOrder) {
+	if pending.timer != nil {
+		pending.timer.Stop()
+	}
+	delete(h.pendingOrders, pending.order.OrderID)
+}
+
+// findPendingOrder 기본 명령으로 확인 대기 오더 검색 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) findPendingOrder(baseCommand string) *pendingOrder {
+	for _, pending := range h.pendingOrders {
+		if h.extractBaseCommand(pending.order.Command) == baseCommand {
+			return pending
+		}
+	}
+	return nil
+}
+
+// GetPendingOrders 확인 대기 오더 목록 반환 (생성 시간 순)
+func (h *DirectActionHandler) GetPendingOrders() []OrderInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	orders := make([]OrderInfo, 0, len(h.pendingOrders))
+	for _, pending := range h.pendingOrders {
+		orders = append(orders, *pending.order)
+	}
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+	return orders
+}
+
+// parseConfirmation 확인 토픽 메시지 해석 ("<orderID>", "confirm:<orderID>", "reject:<orderID>")
+func parseConfirmation(payload string) (orderID string, confirm bool, err error) {
+	payload = strings.TrimSpace(payload)
+	action, id, found := strings.Cut(payload, ":")
+	if !found {
+		action, id = "confirm", payload
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", false, fmt.Errorf("empty order ID")
+	}
+
+	switch strings.ToLower(strings.TrimSpace(action)) {
+	case "confirm":
+		return id, true, nil
+	case "reject":
+		return id, false, nil
+	default:
+		return "", false, fmt.Errorf("unknown confirmation action %q (expected confirm or reject)", action)
+	}
+}
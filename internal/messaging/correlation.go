@@ -0,0 +1,27 @@
+// internal/messaging/correlation.go - Command Correlation IDs
+package messaging
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// beginCorrelation 명령 처리 시작 (잠금 보유 상태에서 호출, 반환 함수로 이전 값 복원)
+// correlationID가 비어있으면 새로 생성, 처리 중 생성되는 오더/응답/이벤트/로그에 같은 값 기록
+func (h *DirectActionHandler) beginCorrelation(correlationID string) func() {
+	previous := h.correlationID
+	if correlationID == "" {
+		correlationID = h.ids.NewID()
+	}
+	h.correlationID = correlationID
+	return func() {
+		h.correlationID = previous
+	}
+}
+
+// commandLog 명령 처리 구조화 로그 엔트리 (robotLog + correlationId, command)
+func (h *DirectActionHandler) commandLog(command string) *logrus.Entry {
+	return h.robotLog().WithFields(logrus.Fields{
+		"correlationId": h.correlationID,
+		"command":       command,
+	})
+}
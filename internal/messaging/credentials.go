@@ -0,0 +1,104 @@
+// internal/messaging/credentials.go - MQTT Credentials and TLS from Secret Sources
+package messaging
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/secrets"
+	"mqtt-bridge/internal/utils"
+)
+
+// MQTT 비밀 값 이름 (설정 환경 변수 이름과 동일)
+const (
+	secretMQTTUsername = "MQTT_USERNAME"
+	secretMQTTPassword = "MQTT_PASSWORD"
+	secretMQTTTLSCA    = "MQTT_TLS_CA"
+	secretMQTTTLSCert  = "MQTT_TLS_CERT"
+	secretMQTTTLSKey   = "MQTT_TLS_KEY"
+)
+
+// loadMQTTSecrets MQTT 자격 증명/TLS 비밀 값 원천 등록 및 최초 조회
+func loadMQTTSecrets(cfg *config.Config) (*secrets.Store, error) {
+	vault := secrets.NewVault(cfg.VaultAddr, cfg.VaultToken, cfg.VaultTokenFile, cfg.VaultKubernetesRole)
+	store := secrets.NewStore()
+
+	definitions := []struct {
+		name, value, file string
+	}{
+		{secretMQTTUsername, cfg.MQTTUsername, cfg.MQTTUsernameFile},
+		{secretMQTTPassword, cfg.MQTTPassword, cfg.MQTTPasswordFile},
+		{secretMQTTTLSCA, cfg.MQTTTLSCA, cfg.MQTTTLSCAFile},
+		{secretMQTTTLSCert, cfg.MQTTTLSCert, cfg.MQTTTLSCertFile},
+		{secretMQTTTLSKey, cfg.MQTTTLSKey, cfg.MQTTTLSKeyFile},
+	}
+	for _, definition := range definitions {
+		if definition.value == "" && definition.file == "" {
+			continue
+		}
+		source, err := secrets.NewSource(definition.value, definition.file, vault)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", definition.name, err)
+		}
+		if err := store.Add(definition.name, source); err != nil {
+			return nil, err
+		}
+		utils.Logger.Infof("🔑 Secret %s loaded from %s", definition.name, source)
+	}
+	return store, nil
+}
+
+// newTLSConfig TLS 비밀 값이 있으면 TLS 설정 생성 (없으면 nil)
+// 인증서/CA는 핸드셰이크마다 저장소에서 읽으므로 로테이션 후 재연결 시 반영됨
+func newTLSConfig(store *secrets.Store) *tls.Config {
+	hasCA := store.Get(secretMQTTTLSCA) != ""
+	hasCert := store.Get(secretMQTTTLSCert) != ""
+	if !hasCA && !hasCert {
+		return nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if hasCert {
+		tlsConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			cert, err := tls.X509KeyPair([]byte(store.Get(secretMQTTTLSCert)), []byte(store.Get(secretMQTTTLSKey)))
+			if err != nil {
+				return nil, fmt.Errorf("invalid MQTT client certificate: %v", err)
+			}
+			return &cert, nil
+		}
+	}
+
+	if hasCA {
+		// 고정 RootCAs 대신 현재 CA로 직접 검증 (CA 로테이션 지원)
+		tlsConfig.InsecureSkipVerify = true
+		tlsConfig.VerifyConnection = func(state tls.ConnectionState) error {
+			return verifyServerCertificate(state, store.Get(secretMQTTTLSCA))
+		}
+	}
+	return tlsConfig
+}
+
+// verifyServerCertificate 브로커 인증서 체인과 호스트 이름을 CA로 검증
+func verifyServerCertificate(state tls.ConnectionState, caPEM string) error {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(caPEM)) {
+		return fmt.Errorf("no valid certificates in MQTT_TLS_CA")
+	}
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("broker presented no certificate")
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		DNSName:       state.ServerName,
+	})
+	return err
+}
@@ -0,0 +1,95 @@
+// internal/messaging/deadletter.go - Dead-letter Topic for Rejected PLC Commands
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"mqtt-bridge/internal/hook"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// dead-letter 거부 사유 코드
+const (
+	DeadLetterInvalidCommand = "invalid_command"    // 명령 형식 오류 (errors에 상세)
+	DeadLetterRateLimited    = "rate_limited"       // 수신 제한 초과 (L 응답)
+	DeadLetterBusy           = "busy"               // 동시 실행/대기열 한도 (B 응답)
+	DeadLetterEStop          = "estop_active"       // 로봇 비상 정지 중 (X 응답)
+	DeadLetterNotAutomatic   = "not_automatic"      // 로봇 수동/서비스 모드 (M 응답)
+	DeadLetterPaused         = "paused"             // 로봇 일시 정지 중 (P 응답)
+	DeadLetterFaultLatched   = "fault_latched"      // 운영자 확인 전 래치 오류
+	DeadLetterIntakePaused   = "intake_paused"      // 운영자가 명령 수신 일시 중지
+	DeadLetterHookRejected   = "hook_rejected"      // 명령 훅 스크립트가 거부 (COMMAND_HOOK)
+	DeadLetterFactsheet      = "factsheet_mismatch" // 로봇 factsheet에 선언되지 않은 액션/파라미터/블로킹 타입
+	DeadLetterRejected       = "rejected"           // 그 외 거부 (F 응답)
+)
+
+// deadLetterMessage 거부된 PLC 명령 (COMMAND_DEAD_LETTER_TOPIC)
+type deadLetterMessage struct {
+	Robot         string           `json:"robot"`
+	Payload       string           `json:"payload"` // 정규화 전 원본 페이로드
+	Command       string           `json:"command"`
+	Reason        string           `json:"reason"`  // 사유 코드
+	Message       string           `json:"message"` // 사람이 읽는 사유
+	Errors        ValidationErrors `json:"errors,omitempty"`
+	CorrelationID string           `json:"correlationId,omitempty"`
+	ReceivedAt    time.Time        `json:"receivedAt"`
+}
+
+// deadLetterReason 거부 결과의 사유 코드
+func deadLetterReason(result *CommandResult) string {
+	switch {
+	case len(result.Errors) > 0:
+		return DeadLetterInvalidCommand
+	case errors.Is(result.err, ErrCommandRateLimited):
+		return DeadLetterRateLimited
+	case errors.Is(result.err, ErrRobotBusy):
+		return DeadLetterBusy
+	case errors.Is(result.err, ErrEStopActive):
+		return DeadLetterEStop
+	case errors.Is(result.err, ErrNotAutomatic):
+		return DeadLetterNotAutomatic
+	case errors.Is(result.err, ErrRobotPaused):
+		return DeadLetterPaused
+	case errors.Is(result.err, ErrFaultLatched):
+		return DeadLetterFaultLatched
+	case errors.Is(result.err, ErrIntakePaused):
+		return DeadLetterIntakePaused
+	case errors.Is(result.err, hook.ErrRejected):
+		return DeadLetterHookRejected
+	case errors.Is(result.err, ErrFactsheetMismatch):
+		return DeadLetterFactsheet
+	default:
+		return DeadLetterRejected
+	}
+}
+
+// publishDeadLetter 거부된 명령을 원본 페이로드와 사유 코드로 발행 (잠금 보유 상태에서 호출, 토픽이 비어있으면 비활성화)
+func (h *DirectActionHandler) publishDeadLetter(result *CommandResult) {
+	topic := h.config.CommandDeadLetterTopic
+	if topic == "" || result.Accepted {
+		return
+	}
+
+	payload := h.commandPayload
+	if payload == "" {
+		payload = result.Command
+	}
+	msgData, err := json.Marshal(deadLetterMessage{
+		Robot:         h.config.RobotSerialNumber,
+		Payload:       payload,
+		Command:       result.Command,
+		Reason:        deadLetterReason(result),
+		Message:       result.Reason,
+		Errors:        result.Errors,
+		CorrelationID: result.CorrelationID,
+		ReceivedAt:    result.ReceivedAt,
+	})
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal dead-letter command: %v", err)
+		return
+	}
+	if err := h.mqttClient.Publish(topic, 0, false, msgData); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish dead-letter command: %v", err)
+	}
+}
@@ -0,0 +1,152 @@
+// internal/messaging/dedup.go - PLC Response Resend and Duplicate Command Suppression
+package messaging
+
+import (
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// responseDeduper 동일 idempotency key 응답의 재전송 억제 (window 내 1회만 전송)
+type responseDeduper struct {
+	window time.Duration
+	sent   map[string]time.Time // idempotency key -> 전송 시간
+}
+
+// newResponseDeduper 새 응답 중복 억제기 생성 (window 0이면 비활성화)
+func newResponseDeduper(window time.Duration) *responseDeduper {
+	return &responseDeduper{
+		window: window,
+		sent:   make(map[string]time.Time),
+	}
+}
+
+// responseKey 응답 idempotency key 생성 (오더 + 응답 명령 + 상태)
+func responseKey(orderID, command, status string) string {
+	return orderID + "/" + command + "/" + status
+}
+
+// shouldSend 전송 여부 확인 후 전송 기록 (window 내 중복이면 false)
+func (d *responseDeduper) shouldSend(key string) bool {
+	if d.window <= 0 {
+		return true
+	}
+
+	now := time.Now()
+	d.prune(now)
+
+	if _, duplicated := d.sent[key]; duplicated {
+		return false
+	}
+	d.sent[key] = now
+	return true
+}
+
+// prune 만료된 key 정리
+func (d *responseDeduper) prune(now time.Time) {
+	for key, sentAt := range d.sent {
+		if now.Sub(sentAt) > d.window {
+			delete(d.sent, key)
+		}
+	}
+}
+
+// ReplayResponses 재연결 후 진행 중인 명령의 현재 상태 재전송 (연결이 끊긴 동안 PLC가 놓친 응답 보완)
+// 활성/취소 중/확인 대기 오더는 마지막 상태(없으면 W), 대기열 명령은 W
+func (h *DirectActionHandler) ReplayResponses() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.config.ReconnectReplayResponses {
+		return
+	}
+
+	replayed := 0
+	replay := func(order *OrderInfo) {
+		status := order.Status
+		if status == "" {
+			status = types.PLCStatusWaiting
+		}
+		plcResponse := types.NewPLCResponse(order.responseCommand(), status, "")
+		plcResponse.OrderID = order.OrderID
+		plcResponse.ReplyChannel = order.ReplyChannel
+		h.publishPLCResponse(plcResponse)
+		replayed++
+	}
+	for _, orders := range []map[string]*OrderInfo{h.activeOrders, h.canceledOrders} {
+		for _, order := range orders {
+			replay(order)
+		}
+	}
+	for _, pending := range h.pendingOrders {
+		replay(pending.order)
+	}
+	for _, queued := range h.queuedCommands {
+		plcResponse := types.NewPLCResponse(queued.Command, types.PLCStatusWaiting, "")
+		plcResponse.ReplyChannel = queued.ReplyChannel
+		h.publishPLCResponse(plcResponse)
+		replayed++
+	}
+
+	if replayed > 0 {
+		utils.Logger.Infof("🔁 Replayed %d in-progress response(s) for robot %s after reconnect", replayed, h.config.RobotSerialNumber)
+	}
+}
+
+// handleDuplicateCommand PLC 재전송으로 같은 명령을 다시 받은 경우 현재 상태 재보고 (잠금 보유 상태에서 호출)
+// 같은 명령의 오더가 진행 중(활성, 확인 대기)이거나 대기열에 있고 최초 수신 후 COMMAND_DEDUP_WINDOW 이내면 처리 결과 반환
+// 오더는 경로/우선순위를 분리한 명령(command), 대기열은 원본 명령(commandStr)으로 비교
+func (h *DirectActionHandler) handleDuplicateCommand(commandStr, command string) *CommandResult {
+	window := h.config.CommandDedupWindow
+	if window <= 0 {
+		return nil
+	}
+	isRecent := func(receivedAt time.Time) bool {
+		return time.Since(receivedAt) <= window
+	}
+
+	var duplicate *OrderInfo
+	for _, order := range h.activeOrders {
+		if order.Command == command && isRecent(order.CreatedAt) {
+			duplicate = order
+		}
+	}
+	for _, pending := range h.pendingOrders {
+		if pending.order.Command == command && isRecent(pending.order.CreatedAt) {
+			duplicate = pending.order
+		}
+	}
+
+	if duplicate != nil {
+		status := duplicate.Status
+		if status == "" {
+			status = types.PLCStatusWaiting
+		}
+		h.orderLog(duplicate).WithFields(logrus.Fields{
+			"window": window,
+			"status": status,
+		}).Info("Duplicate command - re-reporting order status")
+
+		plcResponse := types.NewPLCResponse(commandStr, status, "")
+		plcResponse.OrderID = duplicate.OrderID
+		h.publishPLCResponse(plcResponse)
+
+		result := newCommandResult(commandStr, duplicate.OrderID, nil)
+		result.Reason = "duplicate of active order"
+		return result
+	}
+
+	for _, queued := range h.queuedCommands {
+		if queued.Command == commandStr && isRecent(queued.QueuedAt) {
+			utils.Logger.Infof("🔁 Duplicate command within %s - still queued: %s", window, commandStr)
+			h.sendPLCResponse(commandStr, types.PLCStatusWaiting)
+
+			result := newCommandResult(commandStr, "", nil)
+			result.Reason = "duplicate of queued command"
+			return result
+		}
+	}
+	return nil
+}
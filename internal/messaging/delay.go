@@ -0,0 +1,124 @@
+// internal/messaging/delay.go - Delayed Command Execution (delay=30s parameter)
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"time"
+)
+
+// delayParamKey 발행 지연 파라미터 (로봇 액션 파라미터로 전달하지 않음)
+const delayParamKey = "delay"
+
+// parseDelayParam delay 파라미터 해석 (Go duration 또는 초 단위 숫자, 예: 30s, 1m30s, 45)
+func parseDelayParam(value interface{}) (time.Duration, error) {
+	var delay time.Duration
+	switch v := value.(type) {
+	case float64:
+		delay = time.Duration(v * float64(time.Second))
+	case string:
+		parsed, err := time.ParseDuration(v)
+		if err != nil {
+			return 0, fmt.Errorf("expected a duration such as 30s or 2m")
+		}
+		delay = parsed
+	default:
+		return 0, fmt.Errorf("expected a duration such as 30s or 2m")
+	}
+	if delay <= 0 {
+		return 0, fmt.Errorf("delay must be positive")
+	}
+	return delay, nil
+}
+
+// holdForDelay 오더를 보관하고 지연 후 발행, 그동안 PLC에 대기(W) 응답 (잠금 보유 상태에서 호출)
+// 확인 대기 오더와 같은 목록에서 관리되므로 취소/비상 정지/선점/중복 확인/상태 조회가 그대로 적용됨
+func (h *DirectActionHandler) holdForDelay(order *OrderInfo, message *OutboundMessage, delay time.Duration) {
+	orderID := order.OrderID
+	pending := &pendingOrder{order: order, message: message}
+	pending.timer = time.AfterFunc(delay, func() {
+		h.releaseDelayedOrder(orderID)
+	})
+	h.pendingOrders[orderID] = pending
+
+	h.orderLog(order).WithField("delay", delay).Info("Order held until delay elapses")
+	h.respondOrder(order, types.PLCStatusWaiting)
+	h.publishEvent(events.Event{
+		Type:    events.TypeOrderDelayed,
+		Command: order.Command,
+		OrderID: orderID,
+		Message: delay.String(),
+	})
+}
+
+// releaseDelayedOrder 지연이 끝난 오더 발행 (이미 취소/거부되었으면 무시)
+func (h *DirectActionHandler) releaseDelayedOrder(orderID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	defer h.dispatchQueued()
+
+	pending, exists := h.pendingOrders[orderID]
+	if !exists {
+		return
+	}
+	h.removePendingOrder(pending)
+
+	if h.failIfNotAdmitted(pending.order) {
+		return
+	}
+	if h.shouldDeferForDriving() {
+		h.holdUntilStationary(pending.order, pending.message)
+		return
+	}
+	h.orderLog(pending.order).Info("Delay elapsed - publishing order")
+	if err := h.publishOrder(pending.order, pending.message); err != nil {
+		h.orderLog(pending.order).Errorf("Failed to send delayed order: %v", err)
+		h.respondOrder(pending.order, types.PLCStatusFailed)
+		h.finishOrder(pending.order)
+	}
+}
+
+// failIfNotAdmitted 보관했던 오더를 발행하기 직전 processCommand의 수락 조건을 다시 확인 (잠금 보유 상태에서 호출)
+// 보관 중 대기 인스턴스 전환, 비상 정지, 오류 래치, 수동 모드, 일시 정지가 생겼으면 사유와 함께 실패 응답 후 true
+func (h *DirectActionHandler) failIfNotAdmitted(order *OrderInfo) bool {
+	status, err := h.admissionFailure(order)
+	if err == nil {
+		return false
+	}
+
+	h.orderLog(order).WithError(err).Warn("Held order no longer admitted - not publishing")
+	h.respondOrderMessage(order, status, err.Error())
+	h.finishOrder(order)
+	h.publishEvent(events.Event{
+		Type:    events.TypeCommandRejected,
+		Command: order.Command,
+		OrderID: order.OrderID,
+		Message: err.Error(),
+	})
+	return true
+}
+
+// admissionFailure 오더를 발행하면 안 되는 이유와 PLC 응답 상태 (잠금 보유 상태에서 호출, 발행해도 되면 nil)
+// 판단 기준은 processCommand의 게이트와 같음 (일시 정지 resume 정책은 재개 요청 후 발행)
+func (h *DirectActionHandler) admissionFailure(order *OrderInfo) (string, error) {
+	switch {
+	case h.standby:
+		return types.PLCStatusFailed, ErrStandby
+	case h.safety.EStopActive():
+		return types.PLCStatusSafetyStop, fmt.Errorf("%w (%s)", ErrEStopActive, h.safety.EStop)
+	case h.isFaultLatched(order.Command):
+		return types.PLCStatusFailed, ErrFaultLatched
+	case !h.acceptsOrders():
+		return types.PLCStatusManualMode, fmt.Errorf("%w (%s)", ErrNotAutomatic, h.operatingMode)
+	case !h.paused || h.config.PausedPolicy == PausedPolicyOff:
+		return "", nil
+	case h.config.PausedPolicy == PausedPolicyResume:
+		if err := h.sendStopPause(); err != nil {
+			return types.PLCStatusFailed, err
+		}
+		return "", nil
+	default:
+		return types.PLCStatusPaused, fmt.Errorf("%w, resume the robot before sending orders", ErrRobotPaused)
+	}
+}
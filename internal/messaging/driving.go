@@ -0,0 +1,77 @@
+// internal/messaging/driving.go - Defer Direct Actions While the Robot Is Driving
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// recordMotion 로봇 주행 상태 기록 후 정지했으면 보류 중인 오더 발행 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) recordMotion(state *RobotState) {
+	if state.Driving != nil {
+		h.driving = *state.Driving
+	}
+	if state.NodeStates != nil {
+		h.pendingNodes = *state.NodeStates
+	}
+	if h.stationary() {
+		h.releaseStationaryOrders()
+	}
+}
+
+// stationary 로봇이 정지 상태인지 확인 (주행 중이 아니고 남은 노드가 없음, 보고하지 않으면 정지로 취급)
+func (h *DirectActionHandler) stationary() bool {
+	return !h.driving && h.pendingNodes == 0
+}
+
+// shouldDeferForDriving 주행 중이라 Direct Action 오더 발행을 보류해야 하는지 확인 (DEFER_WHILE_DRIVING)
+func (h *DirectActionHandler) shouldDeferForDriving() bool {
+	return h.config.DeferWhileDriving && !h.stationary()
+}
+
+// holdUntilStationary 로봇이 정지할 때까지 오더를 보관, 그동안 PLC에 대기(W) 응답 (잠금 보유 상태에서 호출)
+// DRIVING_MAX_WAIT 안에 정지하지 않으면 실패(F), 확인 대기 오더와 같은 목록에서 관리 (취소/비상 정지/선점 적용)
+func (h *DirectActionHandler) holdUntilStationary(order *OrderInfo, message *OutboundMessage) {
+	orderID := order.OrderID
+	maxWait := h.config.DrivingMaxWait
+	pending := &pendingOrder{order: order, message: message, awaitStationary: true}
+	pending.timer = time.AfterFunc(maxWait, func() {
+		reason := fmt.Sprintf("robot still driving after %s", maxWait)
+		if err := h.RejectOrder(orderID, reason); err == nil {
+			utils.Logger.Warnf("⏱️ Deferred order %s failed: %s", orderID, reason)
+		}
+	})
+	h.pendingOrders[orderID] = pending
+
+	h.orderLog(order).WithField("maxWait", maxWait).Info("Robot driving - order held until stationary")
+	h.respondOrder(order, types.PLCStatusWaiting)
+	h.publishEvent(events.Event{
+		Type:    events.TypeOrderDeferred,
+		Command: order.Command,
+		OrderID: orderID,
+		Message: maxWait.String(),
+	})
+}
+
+// releaseStationaryOrders 정지를 기다리던 오더 발행 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) releaseStationaryOrders() {
+	for _, pending := range h.pendingOrders {
+		if !pending.awaitStationary {
+			continue
+		}
+		h.removePendingOrder(pending)
+		if h.failIfNotAdmitted(pending.order) {
+			continue
+		}
+
+		h.orderLog(pending.order).Info("Robot stationary - publishing deferred order")
+		if err := h.publishOrder(pending.order, pending.message); err != nil {
+			h.orderLog(pending.order).Errorf("Failed to send deferred order: %v", err)
+			h.respondOrder(pending.order, types.PLCStatusFailed)
+			h.finishOrder(pending.order)
+		}
+	}
+}
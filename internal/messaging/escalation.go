@@ -0,0 +1,128 @@
+// internal/messaging/escalation.go - Order Timeout Escalation (warn -> cancel -> unhealthy)
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"mqtt-bridge/internal/alert"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// escalationCheckInterval 오더 경과 시간 확인 주기
+const escalationCheckInterval = time.Second
+
+// 단계적 대응 단계 (OrderInfo.escalationStage)
+const (
+	escalationNone = iota
+	escalationWarn
+	escalationCancel
+	escalationUnhealthy
+)
+
+// escalationStageNames 단계 이름 (이벤트 Status, 알림)
+var escalationStageNames = map[int]string{
+	escalationWarn:      "warn",
+	escalationCancel:    "cancel",
+	escalationUnhealthy: "unhealthy",
+}
+
+// RunEscalation 오더 경과 시간 주기 확인 (ctx 종료 시 반환)
+func (h *DirectActionHandler) RunEscalation(ctx context.Context) {
+	ticker := time.NewTicker(escalationCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.mu.Lock()
+			alerts := h.checkEscalations(time.Now())
+			webhook := h.escalationWebhook
+			h.mu.Unlock()
+
+			// 웹훅은 잠금 밖에서 전송 (느린 수신자가 명령 처리를 막지 않도록)
+			for _, a := range alerts {
+				if err := webhook.Send(ctx, a); err != nil {
+					utils.Logger.Errorf("❌ Failed to send escalation alert: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// checkEscalations 정책 시간을 넘긴 오더의 다음 단계 실행 (잠금 보유 상태에서 호출)
+// 취소된 오더는 취소 단계를 건너뛰고 비정상 단계만 확인
+func (h *DirectActionHandler) checkEscalations(now time.Time) []alert.Alert {
+	var alerts []alert.Alert
+
+	for _, orders := range []map[string]*OrderInfo{h.activeOrders, h.canceledOrders} {
+		for _, order := range orders {
+			policy := h.escalationPolicies.For(h.extractBaseCommand(order.Command))
+			if !policy.Enabled() || order.publishedAt.IsZero() {
+				continue
+			}
+			elapsed := now.Sub(order.publishedAt)
+
+			if order.escalationStage < escalationWarn && policy.WarnAfter > 0 && elapsed >= policy.WarnAfter {
+				alerts = append(alerts, h.escalate(order, escalationWarn, elapsed))
+			}
+			if order.escalationStage < escalationCancel && policy.CancelAfter > 0 && elapsed >= policy.CancelAfter && !order.Canceled {
+				alerts = append(alerts, h.escalate(order, escalationCancel, elapsed))
+				if err := h.cancelOrder(order, h.extractBaseCommand(order.Command)+":C"); err != nil {
+					utils.Logger.Errorf("❌ Escalation cancel failed for OrderID %s: %v", order.OrderID, err)
+				}
+			}
+			if order.escalationStage < escalationUnhealthy && policy.UnhealthyAfter > 0 && elapsed >= policy.UnhealthyAfter {
+				alerts = append(alerts, h.escalate(order, escalationUnhealthy, elapsed))
+				h.unhealthyReason = fmt.Sprintf("order %s (%s) exceeded %s", order.OrderID, order.Command, policy.UnhealthyAfter)
+			}
+		}
+	}
+	return alerts
+}
+
+// escalate 오더 단계 기록, 이벤트 발행 및 알림 생성 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) escalate(order *OrderInfo, stage int, elapsed time.Duration) alert.Alert {
+	order.escalationStage = stage
+	stageName := escalationStageNames[stage]
+	summary := fmt.Sprintf("Order %s (%s) running for %s - escalation: %s", order.OrderID, order.Command, elapsed.Round(time.Second), stageName)
+	h.orderLog(order).WithField("stage", stageName).Warn("Order escalated")
+
+	h.publishEvent(events.Event{
+		Type:    events.TypeOrderEscalated,
+		Command: order.Command,
+		OrderID: order.OrderID,
+		Status:  stageName,
+		Message: summary,
+	})
+
+	severity := alert.SeverityWarning
+	if stage == escalationUnhealthy {
+		severity = alert.SeverityCritical
+	}
+	return alert.Alert{
+		Source:   "escalation",
+		Severity: severity,
+		Robot:    h.config.RobotSerialNumber,
+		Summary:  summary,
+	}
+}
+
+// clearUnhealthy 비정상 표시 해제 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) clearUnhealthy(reason string) {
+	if h.unhealthyReason == "" {
+		return
+	}
+	utils.Logger.Infof("💚 Robot marked healthy again (%s), was: %s", reason, h.unhealthyReason)
+	h.unhealthyReason = ""
+}
+
+// UnhealthyReason 로봇 비정상 사유 (정상이면 빈 값, readiness 용)
+func (h *DirectActionHandler) UnhealthyReason() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.unhealthyReason
+}
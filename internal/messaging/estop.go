@@ -0,0 +1,145 @@
+// internal/messaging/estop.go - Emergency Stop PLC Command (BASE:E)
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+)
+
+// emergencyStop 진행 중인 비상 정지 (취소한 오더가 모두 종료되면 E 명령에 S 응답)
+type emergencyStop struct {
+	command  string
+	orderIDs map[string]bool // 종료를 기다리는 취소된 오더
+}
+
+// SetFleet 비상 정지를 함께 전달할 핸들러 목록 설정 (자신 포함, 서비스 생성 시 한 번 호출)
+func (h *DirectActionHandler) SetFleet(handlers []*DirectActionHandler) {
+	h.fleet = handlers
+}
+
+// emergencyStopFleet 모든 로봇에 비상 정지 전송 (잠금 없이 호출, 각 핸들러가 자신의 잠금 사용)
+// 반환값은 이 핸들러(명령을 수신한 로봇)의 처리 결과
+func (h *DirectActionHandler) emergencyStopFleet(commandStr string) *CommandResult {
+	utils.Logger.Warnf("🚨 EMERGENCY STOP received: %s - stopping %d robot(s)", commandStr, max(len(h.fleet), 1))
+
+	result := h.EmergencyStop(commandStr)
+	for _, handler := range h.fleet {
+		if handler != h {
+			handler.EmergencyStop(commandStr)
+		}
+	}
+	return result
+}
+
+// EmergencyStop 이 로봇의 비상 정지 (시작 게이트, 대기열, 오더 처리 정책과 무관하게 즉시 전송)
+// 진행 중인 오더를 HARD 블로킹 cancelOrder로 취소하고 ESTOP_ACTION 전송 (InstantActions 전송 제한 미적용)
+// 취소된 오더는 원래 명령에 최종 상태를 응답하고, 모두 종료되면 E 명령에 S 응답
+func (h *DirectActionHandler) EmergencyStop(commandStr string) *CommandResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	defer h.beginCorrelation("")()
+
+	h.publishEvent(events.Event{
+		Type:    events.TypeCommandReceived,
+		Command: commandStr,
+	})
+	result := newCommandResult(commandStr, "", h.emergencyStop(commandStr))
+	h.recordCommand(result)
+	return result
+}
+
+// emergencyStop 비상 정지 처리 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) emergencyStop(commandStr string) error {
+	// 대기 중인 명령과 확인 대기 오더는 발행하지 않고 실패 처리
+	for _, queued := range h.queuedCommands {
+		h.sendPLCFailure(queued.Command, fmt.Errorf("discarded by emergency stop"))
+	}
+	h.queuedCommands = nil
+	for _, pending := range h.pendingOrders {
+		h.rejectPendingOrder(pending, "emergency stop")
+	}
+	h.stopChains("emergency stop")
+
+	// 추적 중인 오더가 없어도 로봇이 실행 중일 수 있으므로 cancelOrder는 항상 전송
+	stop := &emergencyStop{command: commandStr, orderIDs: make(map[string]bool)}
+	orders := make([]*OrderInfo, 0, len(h.activeOrders))
+	for _, order := range h.activeOrders {
+		orders = append(orders, order)
+	}
+	cancelIDs := []string{""}
+	if len(orders) > 0 {
+		cancelIDs = cancelIDs[:0]
+		for _, order := range orders {
+			cancelIDs = append(cancelIDs, order.OrderID)
+		}
+	}
+
+	var sendErr error
+	for _, orderID := range cancelIDs {
+		if err := h.sendEmergencyInstantAction(h.protocol.BuildCancel(orderID)); err != nil {
+			utils.Logger.Errorf("❌ Emergency cancelOrder failed for robot %s: %v", h.config.RobotSerialNumber, err)
+			sendErr = err
+		}
+	}
+	if h.config.EstopAction != "" {
+		err := h.sendEmergencyInstantAction(h.protocol.BuildInstantAction(InstantActionRequest{
+			ActionType:   h.config.EstopAction,
+			BlockingType: types.BlockingTypeHard,
+		}))
+		if err != nil {
+			utils.Logger.Errorf("❌ Emergency %s failed for robot %s: %v", h.config.EstopAction, h.config.RobotSerialNumber, err)
+			sendErr = err
+		}
+	}
+	if sendErr != nil {
+		h.sendPLCFailure(commandStr, sendErr)
+		return sendErr
+	}
+
+	// 취소된 오더의 최종 상태는 원래 명령으로 응답
+	for _, order := range orders {
+		h.orderLog(order).Warn("Order canceled by emergency stop")
+		delete(h.activeOrders, order.OrderID)
+		order.Canceled = true
+		order.CancelCommand = order.Command
+		h.canceledOrders[order.OrderID] = order
+		stop.orderIDs[order.OrderID] = true
+	}
+
+	if len(stop.orderIDs) == 0 {
+		h.commandLog(commandStr).Warn("Emergency stop sent (no active orders)")
+		h.sendPLCResponse(commandStr, types.PLCStatusSuccess)
+		return nil
+	}
+
+	h.commandLog(commandStr).WithField("orders", len(stop.orderIDs)).Warn("Emergency stop sent - waiting for orders to terminate")
+	h.estop = stop
+	h.sendPLCResponse(commandStr, types.PLCStatusRunning)
+	return nil
+}
+
+// sendEmergencyInstantAction 전송 제한 없이 InstantAction 전송
+func (h *DirectActionHandler) sendEmergencyInstantAction(message *OutboundMessage, err error) error {
+	if err != nil {
+		return err
+	}
+	utils.Logger.Warnf("📤 Sending emergency InstantAction to: %s (ActionID: %s)", message.Topic, message.ActionID)
+	return h.sendToRobot(message)
+}
+
+// trackEmergencyStop 비상 정지로 취소한 오더 종료 확인 (finishOrder에서 호출, 잠금 보유 상태)
+func (h *DirectActionHandler) trackEmergencyStop(order *OrderInfo) {
+	if h.estop == nil || !h.estop.orderIDs[order.OrderID] {
+		return
+	}
+	delete(h.estop.orderIDs, order.OrderID)
+	if len(h.estop.orderIDs) > 0 {
+		return
+	}
+
+	utils.Logger.Warnf("🚨 Emergency stop confirmed for robot %s - all orders terminated", h.config.RobotSerialNumber)
+	h.sendPLCResponse(h.estop.command, types.PLCStatusSuccess)
+	h.estop = nil
+}
@@ -0,0 +1,131 @@
+// internal/messaging/factsheet.go - Factsheet-driven Order Action Validation
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"sort"
+	"strings"
+)
+
+// ErrFactsheetMismatch 로봇 factsheet에 선언되지 않은 액션/파라미터/블로킹 타입 (오더 발행 전 거부)
+var ErrFactsheetMismatch = errors.New("order does not match robot factsheet")
+
+// factsheetAction factsheet protocolFeatures.agvActions 항목
+type factsheetAction struct {
+	parameters    map[string]bool // 파라미터 키 -> 선택 여부 (isOptional)
+	blockingTypes map[string]bool // 비어있으면 제한 없음
+	scopes        map[string]bool // actionScopes (비어있으면 제한 없음)
+}
+
+// applyFactsheetActions factsheet가 선언한 액션 목록 캐시 (잠금 보유 상태에서 호출, FACTSHEET_ACTION_VALIDATION)
+// agvActions가 없는 factsheet는 무시 (검증하지 않음)
+func (h *DirectActionHandler) applyFactsheetActions(factsheetMsg map[string]interface{}) {
+	if !h.config.FactsheetActionValidation {
+		return
+	}
+	manufacturer, _ := factsheetMsg["manufacturer"].(string)
+	serialNumber, _ := factsheetMsg["serialNumber"].(string)
+	if manufacturer != h.config.RobotManufacturer || serialNumber != h.config.RobotSerialNumber {
+		return
+	}
+
+	protocolFeatures, _ := factsheetMsg["protocolFeatures"].(map[string]interface{})
+	agvActions, ok := protocolFeatures["agvActions"].([]interface{})
+	if !ok {
+		return
+	}
+
+	actions := make(map[string]factsheetAction, len(agvActions))
+	for _, item := range agvActions {
+		entry, _ := item.(map[string]interface{})
+		actionType, _ := entry["actionType"].(string)
+		if actionType == "" {
+			continue
+		}
+		action := factsheetAction{
+			parameters:    make(map[string]bool),
+			blockingTypes: factsheetNames(entry["blockingTypes"]),
+			scopes:        factsheetNames(entry["actionScopes"]),
+		}
+		parameters, _ := entry["actionParameters"].([]interface{})
+		for _, parameter := range parameters {
+			fields, _ := parameter.(map[string]interface{})
+			if key, _ := fields["key"].(string); key != "" {
+				optional, _ := fields["isOptional"].(bool)
+				action.parameters[key] = optional
+			}
+		}
+		actions[actionType] = action
+	}
+
+	utils.Logger.Infof("📄 Factsheet actions for %s/%s: %d action type(s)", manufacturer, serialNumber, len(actions))
+	h.factsheetActions = actions
+}
+
+// checkFactsheetActions 오더 노드 액션을 factsheet 선언과 비교 (캐시가 없으면 검증하지 않음)
+// 모든 불일치를 모아 ErrFactsheetMismatch로 반환 (dead-letter 메시지에 그대로 기록)
+func (h *DirectActionHandler) checkFactsheetActions(actions []types.Action) error {
+	if h.factsheetActions == nil {
+		return nil
+	}
+
+	var problems []string
+	for _, action := range actions {
+		declared, exists := h.factsheetActions[action.ActionType]
+		if !exists {
+			problems = append(problems, fmt.Sprintf("action %q is not declared by the robot", action.ActionType))
+			continue
+		}
+		if len(declared.scopes) > 0 && !declared.scopes["NODE"] {
+			problems = append(problems, fmt.Sprintf("action %q cannot be used in order nodes (scopes %s)", action.ActionType, joinNames(declared.scopes)))
+		}
+		if len(declared.blockingTypes) > 0 && !declared.blockingTypes[action.BlockingType] {
+			problems = append(problems, fmt.Sprintf("action %q does not support blockingType %s (supported %s)", action.ActionType, action.BlockingType, joinNames(declared.blockingTypes)))
+		}
+
+		given := make(map[string]bool, len(action.ActionParameters))
+		for _, parameter := range action.ActionParameters {
+			given[parameter.Key] = true
+			if _, known := declared.parameters[parameter.Key]; !known {
+				problems = append(problems, fmt.Sprintf("action %q has undeclared parameter %q", action.ActionType, parameter.Key))
+			}
+		}
+		for key, optional := range declared.parameters {
+			if !optional && !given[key] {
+				problems = append(problems, fmt.Sprintf("action %q is missing required parameter %q", action.ActionType, key))
+			}
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("%w: %s", ErrFactsheetMismatch, strings.Join(problems, "; "))
+}
+
+// checkFactsheetOrder 요청한 오더 액션을 프로토콜과 같은 기본 블로킹 타입으로 factsheet 검증
+func (h *DirectActionHandler) checkFactsheetOrder(requested []OrderAction) error {
+	actions := make([]types.Action, 0, len(requested))
+	for _, request := range requested {
+		blockingType := request.BlockingType
+		if blockingType == "" {
+			blockingType = defaultBlockingType(len(requested))
+		}
+		actions = append(actions, types.Action{ActionType: request.ActionType, BlockingType: blockingType, ActionParameters: request.Parameters})
+	}
+	return h.checkFactsheetActions(actions)
+}
+
+// joinNames 이름 집합을 정렬해 쉼표로 연결 (오류 메시지용)
+func joinNames(names map[string]bool) string {
+	list := make([]string, 0, len(names))
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	return strings.Join(list, ",")
+}
@@ -0,0 +1,145 @@
+// internal/messaging/fakebroker.go - In-memory Broker (handler tests without MQTT)
+package messaging
+
+import (
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/utils"
+	"sync"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// PublishedMessage FakeBroker에 발행된 메시지
+type PublishedMessage struct {
+	Topic    string
+	QoS      byte
+	Retained bool
+	Payload  []byte
+}
+
+// FakeBroker 메모리 내 브로커 (발행 기록, 구독 필터에 맞는 메시지를 즉시 동기 전달)
+// 브로커 없이 핸들러/구독자 로직을 검증하기 위한 구현 (와일드카드 +, # 지원)
+type FakeBroker struct {
+	config *config.Config
+
+	mu            sync.Mutex
+	published     []PublishedMessage
+	subscriptions []fakeSubscription
+	onConnect     []func()
+}
+
+// fakeSubscription 구독 필터와 콜백
+type fakeSubscription struct {
+	filter   string
+	callback mqtt.MessageHandler
+}
+
+// NewFakeBroker 새 메모리 브로커 생성 (cfg는 Subscriber가 토픽 설정을 읽는 데 사용)
+func NewFakeBroker(cfg *config.Config) *FakeBroker {
+	return &FakeBroker{config: cfg}
+}
+
+// Publish 메시지 기록 후 일치하는 구독에 전달
+func (b *FakeBroker) Publish(topic string, qos byte, retained bool, payload interface{}) error {
+	message := PublishedMessage{Topic: topic, QoS: qos, Retained: retained, Payload: payloadBytes(payload)}
+
+	b.mu.Lock()
+	b.published = append(b.published, message)
+	b.mu.Unlock()
+
+	b.deliver(message)
+	return nil
+}
+
+// Subscribe 구독 등록 (같은 필터는 콜백 교체)
+func (b *FakeBroker) Subscribe(topic string, qos byte, callback mqtt.MessageHandler) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for i := range b.subscriptions {
+		if b.subscriptions[i].filter == topic {
+			b.subscriptions[i].callback = callback
+			return nil
+		}
+	}
+	b.subscriptions = append(b.subscriptions, fakeSubscription{filter: topic, callback: callback})
+	return nil
+}
+
+// OnConnect 재연결 콜백 등록 (Reconnect로 호출)
+func (b *FakeBroker) OnConnect(callback func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.onConnect = append(b.onConnect, callback)
+}
+
+// GetConfig 설정 반환
+func (b *FakeBroker) GetConfig() *config.Config {
+	return b.config
+}
+
+// Inject 외부(PLC, 로봇)에서 들어온 메시지처럼 구독자에게 전달 (발행 기록에는 남기지 않음)
+func (b *FakeBroker) Inject(topic string, payload interface{}) {
+	b.deliver(PublishedMessage{Topic: topic, Payload: payloadBytes(payload)})
+}
+
+// Reconnect 재연결을 흉내 내어 OnConnect 콜백 호출
+func (b *FakeBroker) Reconnect() {
+	b.mu.Lock()
+	callbacks := append([]func(){}, b.onConnect...)
+	b.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback()
+	}
+}
+
+// Published 필터에 맞는 발행 메시지 복사본 (발행 순서)
+func (b *FakeBroker) Published(filter string) []PublishedMessage {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var messages []PublishedMessage
+	for _, message := range b.published {
+		if utils.TopicMatches(filter, message.Topic) {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// Reset 발행 기록 삭제 (구독은 유지)
+func (b *FakeBroker) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.published = nil
+}
+
+// deliver 일치하는 구독 콜백 호출 (잠금 없이 호출하여 콜백 안의 재발행 허용)
+func (b *FakeBroker) deliver(message PublishedMessage) {
+	b.mu.Lock()
+	var callbacks []mqtt.MessageHandler
+	for _, subscription := range b.subscriptions {
+		if utils.TopicMatches(subscription.filter, message.Topic) {
+			callbacks = append(callbacks, subscription.callback)
+		}
+	}
+	b.mu.Unlock()
+
+	for _, callback := range callbacks {
+		callback(nil, &fakeMessage{message: message})
+	}
+}
+
+// fakeMessage mqtt.Message 구현
+type fakeMessage struct {
+	message PublishedMessage
+}
+
+func (m *fakeMessage) Duplicate() bool   { return false }
+func (m *fakeMessage) Qos() byte         { return m.message.QoS }
+func (m *fakeMessage) Retained() bool    { return m.message.Retained }
+func (m *fakeMessage) Topic() string     { return m.message.Topic }
+func (m *fakeMessage) MessageID() uint16 { return 0 }
+func (m *fakeMessage) Payload() []byte   { return m.message.Payload }
+func (m *fakeMessage) Ack()              {}
@@ -0,0 +1,117 @@
+// internal/messaging/fault.go - Operator Acknowledgment (Fault Latch)
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/sirupsen/logrus"
+)
+
+// faultAckAll 모든 래치 오류 확인용 페이로드
+const faultAckAll = "ALL"
+
+// ErrFaultLatched 운영자 확인 전까지 거부되는 명령
+var ErrFaultLatched = errors.New("fault latched, operator acknowledgment required")
+
+// HandleFaultAck 운영자 오류 확인 메시지 처리 (페이로드: 기본 명령 또는 ALL)
+func (h *DirectActionHandler) HandleFaultAck(client mqtt.Client, msg mqtt.Message) {
+	command := normalizePayload(h.config, string(msg.Payload()))
+	utils.Logger.Infof("🔓 Fault acknowledgment received: '%s'", command)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if command == faultAckAll {
+		for baseCommand := range h.latchedFaults {
+			h.acknowledgeFault(baseCommand)
+		}
+		return
+	}
+
+	if !h.acknowledgeFault(command) {
+		utils.Logger.Warnf("⚠️ No latched fault found for command: %s", command)
+	}
+}
+
+// AcknowledgeFault 래치된 오류 해제 (해제 후 해당 명령 재시도 허용)
+func (h *DirectActionHandler) AcknowledgeFault(command string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.acknowledgeFault(command)
+}
+
+// acknowledgeFault 래치된 오류 해제 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) acknowledgeFault(command string) bool {
+	baseCommand := h.extractBaseCommand(command)
+
+	fault, exists := h.latchedFaults[baseCommand]
+	if !exists {
+		return false
+	}
+
+	delete(h.latchedFaults, baseCommand)
+	h.clearFault(baseCommand)
+
+	h.commandLog(baseCommand).WithField("orderId", fault.OrderID).Info("Fault acknowledged")
+	return true
+}
+
+// GetLatchedFaults 래치된 오류 목록 반환
+func (h *DirectActionHandler) GetLatchedFaults() []types.FaultEvent {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	faults := make([]types.FaultEvent, 0, len(h.latchedFaults))
+	for _, fault := range h.latchedFaults {
+		faults = append(faults, *fault)
+	}
+	return faults
+}
+
+// isFaultLatched 해당 명령에 래치된 오류가 있는지 확인
+func (h *DirectActionHandler) isFaultLatched(commandStr string) bool {
+	_, exists := h.latchedFaults[h.extractBaseCommand(commandStr)]
+	return exists
+}
+
+// latchFault 운영자 조치가 필요한 오류 래치 및 retained 토픽 발행
+func (h *DirectActionHandler) latchFault(orderID, originalCommand string, robotError *RobotError) {
+	fault := types.NewFaultEvent(originalCommand, orderID)
+	fault.ErrorType = robotError.ErrorType
+	fault.ErrorLevel = robotError.ErrorLevel
+	fault.ErrorDescription = robotError.ErrorDescription
+
+	h.latchedFaults[fault.Command] = fault
+
+	h.commandLog(fault.Command).WithFields(logrus.Fields{
+		"orderId":   orderID,
+		"errorType": fault.ErrorType,
+	}).Error("Fault latched - operator acknowledgment required")
+
+	msgData, err := json.Marshal(fault)
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal fault event: %v", err)
+		return
+	}
+
+	if err := h.mqttClient.Publish(h.faultTopic(fault.Command), 1, true, msgData); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish fault event: %v", err)
+	}
+}
+
+// clearFault retained 오류 메시지 삭제 (빈 페이로드 발행)
+func (h *DirectActionHandler) clearFault(baseCommand string) {
+	if err := h.mqttClient.Publish(h.faultTopic(baseCommand), 1, true, []byte{}); err != nil {
+		utils.Logger.Errorf("❌ Failed to clear retained fault: %v", err)
+	}
+}
+
+// faultTopic 명령별 오류 토픽 생성
+func (h *DirectActionHandler) faultTopic(baseCommand string) string {
+	return fmt.Sprintf("%s/%s", h.config.FaultTopic, baseCommand)
+}
@@ -0,0 +1,83 @@
+// internal/messaging/fleet.go - Per-Robot Status Snapshot (fleet dashboard)
+package messaging
+
+import (
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"time"
+)
+
+// RobotStatus 로봇 하나의 현재 상태 (대시보드 레인)
+type RobotStatus struct {
+	Robot           string             `json:"robot"`
+	Connection      string             `json:"connection"`
+	LastStateAt     time.Time          `json:"lastStateAt"`
+	BatteryCharge   *float64           `json:"batteryCharge,omitempty"` // 로봇이 보고하지 않으면 생략 (%)
+	LastError       *RobotError        `json:"lastError,omitempty"`
+	LastErrorAt     time.Time          `json:"lastErrorAt"`
+	Unhealthy       string             `json:"unhealthy,omitempty"`
+	Safety          *types.SafetyEvent `json:"safety,omitempty"` // 로봇이 보고하지 않으면 생략
+	ActiveOrders    []OrderInfo        `json:"activeOrders"`
+	PendingOrders   int                `json:"pendingOrders"`
+	LatchedFaults   int                `json:"latchedFaults"`
+	CommissioningOn bool               `json:"commissioningMode"`
+}
+
+// GetRobotStatus 로봇 상태 스냅샷
+func (h *DirectActionHandler) GetRobotStatus() RobotStatus {
+	orders := h.GetOrders()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	status := RobotStatus{
+		Robot:           h.config.RobotSerialNumber,
+		Connection:      h.robotConnectionState,
+		LastStateAt:     h.lastStateAt,
+		LastErrorAt:     h.lastErrorAt,
+		Unhealthy:       h.unhealthyReason,
+		ActiveOrders:    orders,
+		PendingOrders:   len(h.pendingOrders),
+		LatchedFaults:   len(h.latchedFaults),
+		CommissioningOn: h.config.CommissioningMode,
+	}
+	if h.batteryCharge != nil {
+		charge := *h.batteryCharge
+		status.BatteryCharge = &charge
+	}
+	if h.lastError != nil {
+		robotError := *h.lastError
+		status.LastError = &robotError
+	}
+	if h.safety != nil {
+		safety := *h.safety
+		status.Safety = &safety
+	}
+	return status
+}
+
+// recordRobotHealth state의 배터리/오류 기록 (잠금 보유 상태에서 호출)
+// 오류가 사라져도 마지막 오류는 유지 (대시보드에서 시간과 함께 표시)
+func (h *DirectActionHandler) recordRobotHealth(state *RobotState) {
+	if state.BatteryCharge != nil {
+		charge := *state.BatteryCharge
+		h.batteryCharge = &charge
+	}
+	if len(state.Errors) == 0 {
+		return
+	}
+
+	robotError := state.Errors[0]
+	if fatal := state.FatalError(); fatal != nil {
+		robotError = *fatal
+	}
+	if h.lastError == nil || *h.lastError != robotError {
+		h.lastErrorAt = time.Now()
+		h.publishEvent(events.Event{
+			Type:    events.TypeRobotError,
+			Status:  robotError.ErrorLevel,
+			Message: robotError.ErrorType + ": " + robotError.ErrorDescription,
+		})
+	}
+	h.lastError = &robotError
+}
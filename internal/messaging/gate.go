@@ -0,0 +1,71 @@
+// internal/messaging/gate.go - Startup Gate (wait for robot ONLINE)
+package messaging
+
+import (
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// maxBufferedCommands 시작 게이트가 닫혀 있는 동안 보관할 최대 명령 수
+const maxBufferedCommands = 100
+
+// markRobotOnline 로봇 최초 ONLINE 알림 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) markRobotOnline() {
+	h.onlineOnce.Do(func() {
+		close(h.onlineCh)
+	})
+}
+
+// WaitForRobotOnline 로봇이 ONLINE을 보고할 때까지 대기 (타임아웃 시 false)
+func (h *DirectActionHandler) WaitForRobotOnline(timeout time.Duration) bool {
+	select {
+	case <-h.onlineCh:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// bufferedCommand 게이트가 열리기 전 수신한 명령과 응답 채널
+type bufferedCommand struct {
+	command      string
+	replyChannel string
+}
+
+// bufferCommand 게이트가 닫힌 동안 수신한 명령 보관 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) bufferCommand(command, replyChannel string) *CommandResult {
+	result := newCommandResult(command, "", nil)
+	if len(h.bufferedCommands) >= maxBufferedCommands {
+		utils.Logger.Errorf("❌ Startup command buffer full, dropping command: %s", command)
+		result.Accepted = false
+		result.Reason = "startup command buffer full"
+		return result
+	}
+
+	utils.Logger.Infof("⏸️ Robot not ONLINE yet - buffering command: %s", command)
+	h.bufferedCommands = append(h.bufferedCommands, bufferedCommand{command: command, replyChannel: replyChannel})
+	result.Reason = "buffered until robot is ONLINE"
+	return result
+}
+
+// OpenCommandGate 명령 처리 시작 및 보관된 명령을 수신 순서대로 처리
+func (h *DirectActionHandler) OpenCommandGate() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.commandGateOpen {
+		return
+	}
+	h.commandGateOpen = true
+
+	buffered := h.bufferedCommands
+	h.bufferedCommands = nil
+	if len(buffered) > 0 {
+		utils.Logger.Infof("▶️ Processing %d buffered command(s)", len(buffered))
+	}
+	for _, entry := range buffered {
+		restore := h.beginReply(entry.replyChannel)
+		h.processAndRecord(entry.command)
+		restore()
+	}
+}
@@ -0,0 +1,159 @@
+// internal/messaging/grammar.go - PLC Command Grammar
+package messaging
+
+import (
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PLC 명령 문법
+//
+//	command  = control | action
+//	control  = base ":" ( "C" | "E" | "G" | "Q" )          ; 취소, 비상 정지, 트리거 해제, 상태 조회
+//	action   = head [ path ] [ "@" target ] [ "!" priority ]
+//	head     = base [ ":" type [ ":" arm ] ] [ ":" params ]
+//	params   = key "=" value *( "," key "=" value )          ; delay는 발행 지연 (delay.go)
+//	path     = 1*( ">" waypoint )                             ; 좌표 x,y[,theta[,map]] 또는 WAYPOINTS 이름
+//	target   = waypoint                                       ; 경로의 마지막 웨이포인트 (액션 노드 위치)
+//	priority = 1*DIGIT
+//
+// type은 등록된 명령 타입 (translator.go), 파이프라인/템플릿/체인 명령은 type 없이 이름만으로도 사용 가능
+// 대상 로봇은 명령이 수신된 토픽(로봇별 핸들러)으로 결정됨
+
+// CommandKind 명령 종류 (마지막 세그먼트의 제어 접미사로 결정)
+type CommandKind string
+
+const (
+	CommandKindAction  CommandKind = "action"
+	CommandKindCancel  CommandKind = "cancel"  // BASE:C
+	CommandKindEStop   CommandKind = "estop"   // BASE:E
+	CommandKindRelease CommandKind = "release" // BASE:G
+	CommandKindQuery   CommandKind = "query"   // BASE:Q
+)
+
+// controlSuffixes 제어 명령 접미사 -> 명령 종류
+var controlSuffixes = map[string]CommandKind{
+	"C": CommandKindCancel,
+	"E": CommandKindEStop,
+	"G": CommandKindRelease,
+	"Q": CommandKindQuery,
+}
+
+// noSegment 세그먼트에 속하지 않는 구성 요소(경로, 목적지, 우선순위)의 오류 위치
+const noSegment = -1
+
+// ParsedCommand 문법에 따라 분해된 PLC 명령
+type ParsedCommand struct {
+	Raw     string      `json:"raw"`
+	Command string      `json:"command"` // 경로/목적지/우선순위를 뗀 명령 (head, 매핑 조회와 오더 추적 기준)
+	Kind    CommandKind `json:"kind"`
+
+	Base   string                  `json:"base"`
+	Type   string                  `json:"type,omitempty"`
+	Arm    string                  `json:"arm,omitempty"`
+	Params []types.ActionParameter `json:"params,omitempty"` // delay 제외
+	Delay  time.Duration           `json:"delay,omitempty"`
+
+	Path        string `json:"path,omitempty"`   // '>'로 구분된 웨이포인트 목록 원문
+	Target      string `json:"target,omitempty"` // '@' 뒤 목적지 원문
+	HasPath     bool   `json:"-"`
+	HasTarget   bool   `json:"-"`
+	Priority    int    `json:"priority,omitempty"`
+	HasPriority bool   `json:"-"`
+}
+
+// commandKind 명령 종류 판별 (마지막 ':' 세그먼트가 제어 접미사인지만 확인)
+// 비상 정지는 나머지 문법 오류와 무관하게 항상 비상 정지로 처리되어야 하므로 별도 파싱 없이 판별
+func commandKind(commandStr string) CommandKind {
+	index := strings.LastIndex(commandStr, ":")
+	if index < 0 {
+		return CommandKindAction
+	}
+	if kind, exists := controlSuffixes[commandStr[index+1:]]; exists {
+		return kind
+	}
+	return CommandKindAction
+}
+
+// ParseCommand PLC 명령을 문법에 따라 분해 (오류는 위치를 포함한 ValidationErrors)
+// 명령 타입 등록 여부와 팔 코드는 Direct Action 검증(validation.go)에서 확인
+func ParseCommand(commandStr string) (*ParsedCommand, error) {
+	parsed, errs := parseCommand(commandStr)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return parsed, nil
+}
+
+// parseCommand 명령 분해 (오류가 있어도 해석된 만큼 채워서 반환)
+func parseCommand(commandStr string) (*ParsedCommand, ValidationErrors) {
+	parsed := &ParsedCommand{Raw: commandStr, Command: commandStr, Kind: commandKind(commandStr)}
+	var errs ValidationErrors
+
+	if parsed.Kind != CommandKindAction {
+		segments := strings.Split(commandStr, ":")
+		parsed.Base = segments[0]
+		if parsed.Base == "" {
+			errs = append(errs, ValidationError{Field: "base", Position: 0, Reason: "base command is required"})
+		}
+		if len(segments) > 2 {
+			errs = append(errs, ValidationError{Field: "command", Position: 1, Value: strings.Join(segments[1:len(segments)-1], ":"), Reason: "control commands take only a base command"})
+		}
+		return parsed, errs
+	}
+
+	head := commandStr
+	if index := strings.LastIndex(head, config.PrioritySeparator); index >= 0 {
+		value := head[index+1:]
+		head = head[:index]
+		priority, err := strconv.Atoi(value)
+		if err != nil || priority < 0 {
+			errs = append(errs, ValidationError{Field: "priority", Position: noSegment, Value: value, Reason: "expected a non-negative integer"})
+		}
+		parsed.Priority, parsed.HasPriority = priority, true
+	}
+	head, parsed.Target, parsed.HasTarget = strings.Cut(head, config.TargetSeparator)
+	if parsed.HasTarget && strings.TrimSpace(parsed.Target) == "" {
+		errs = append(errs, ValidationError{Field: "target", Position: noSegment, Reason: "target waypoint is required after " + config.TargetSeparator})
+	}
+	head, parsed.Path, parsed.HasPath = strings.Cut(head, config.PathSeparator)
+	if parsed.HasPath && strings.TrimSpace(parsed.Path) == "" {
+		errs = append(errs, ValidationError{Field: "path", Position: noSegment, Reason: "waypoint is required after " + config.PathSeparator})
+	}
+	parsed.Command = head
+
+	segments := strings.Split(head, ":")
+	parsed.Base = segments[0]
+	if parsed.Base == "" {
+		errs = append(errs, ValidationError{Field: "base", Position: 0, Reason: "base command is required"})
+	}
+
+	// 마지막 세그먼트가 key=value 목록이면 추가 파라미터
+	if last := len(segments) - 1; last >= 2 && strings.Contains(segments[last], "=") {
+		params, paramErrs := parseCommandParams(segments[last], last)
+		errs = append(errs, paramErrs...)
+		parsed.Params, parsed.Delay, paramErrs = extractDelayParam(params, last)
+		errs = append(errs, paramErrs...)
+		segments = segments[:last]
+	}
+
+	last := len(segments) - 1
+	if last >= 1 {
+		if kind, exists := controlSuffixes[segments[last]]; exists {
+			errs = append(errs, ValidationError{Field: "command", Position: last, Value: segments[last], Reason: "control suffix for " + string(kind) + " must end the command"})
+		}
+	}
+	if len(segments) >= 2 {
+		parsed.Type = segments[1]
+	}
+	if len(segments) >= 3 {
+		parsed.Arm = segments[2]
+	}
+	if len(segments) > 3 {
+		errs = append(errs, ValidationError{Field: "command", Position: 3, Value: strings.Join(segments[3:], ":"), Reason: "unexpected trailing segments"})
+	}
+	return parsed, errs
+}
@@ -0,0 +1,79 @@
+package messaging
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCommand(t *testing.T) {
+	tests := []struct {
+		input    string
+		kind     CommandKind
+		command  string
+		base     string
+		typ      string
+		arm      string
+		path     string
+		target   string
+		priority int
+		delay    time.Duration
+		params   int
+	}{
+		{input: "CAL:I", kind: CommandKindAction, command: "CAL:I", base: "CAL", typ: "I"},
+		{input: "PICK:T:L", kind: CommandKindAction, command: "PICK:T:L", base: "PICK", typ: "T", arm: "L"},
+		{input: "CAL:C", kind: CommandKindCancel, command: "CAL:C", base: "CAL"},
+		{input: "CAL:E", kind: CommandKindEStop, command: "CAL:E", base: "CAL"},
+		{input: "CAL:G", kind: CommandKindRelease, command: "CAL:G", base: "CAL"},
+		{input: "CAL:Q", kind: CommandKindQuery, command: "CAL:Q", base: "CAL"},
+		{input: "MOVE:T:L:speed=2,delay=1s", kind: CommandKindAction, command: "MOVE:T:L:speed=2,delay=1s", base: "MOVE", typ: "T", arm: "L", delay: time.Second, params: 1},
+		{input: "MOVE>A>B@C!3", kind: CommandKindAction, command: "MOVE", base: "MOVE", path: "A>B", target: "C", priority: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			parsed, err := ParseCommand(tt.input)
+			if err != nil {
+				t.Fatalf("ParseCommand(%q): %v", tt.input, err)
+			}
+			if parsed.Kind != tt.kind || parsed.Command != tt.command || parsed.Base != tt.base || parsed.Type != tt.typ || parsed.Arm != tt.arm {
+				t.Errorf("got kind=%s command=%q base=%q type=%q arm=%q", parsed.Kind, parsed.Command, parsed.Base, parsed.Type, parsed.Arm)
+			}
+			if parsed.Path != tt.path || parsed.Target != tt.target || parsed.Priority != tt.priority {
+				t.Errorf("got path=%q target=%q priority=%d", parsed.Path, parsed.Target, parsed.Priority)
+			}
+			if parsed.Delay != tt.delay || len(parsed.Params) != tt.params {
+				t.Errorf("got delay=%s params=%v", parsed.Delay, parsed.Params)
+			}
+		})
+	}
+}
+
+func TestParseCommandErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		field string
+	}{
+		{":I", "base"},
+		{"CAL:X:C", "command"},
+		{"CAL:C:speed=1", "command"},
+		{"CAL:I!x", "priority"},
+		{"CAL:I!-1", "priority"},
+		{"MOVE@", "target"},
+		{"MOVE>", "path"},
+		{"A:B:C:D", "command"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := ParseCommand(tt.input)
+			errs, ok := err.(ValidationErrors)
+			if !ok || len(errs) == 0 {
+				t.Fatalf("ParseCommand(%q) = %v, want ValidationErrors", tt.input, err)
+			}
+			for _, e := range errs {
+				if e.Field == tt.field {
+					return
+				}
+			}
+			t.Errorf("ParseCommand(%q) errors %v, want field %q", tt.input, errs, tt.field)
+		})
+	}
+}
@@ -2,155 +2,586 @@
 package messaging
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"mqtt-bridge/internal/alert"
 	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/hook"
+	"mqtt-bridge/internal/schema"
 	"mqtt-bridge/internal/types"
 	"mqtt-bridge/internal/utils"
 	"strings"
+	"sync"
 	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/sirupsen/logrus"
 )
 
 // DirectActionHandler Direct Action 처리 핸들러
 type DirectActionHandler struct {
-	mqttClient     *MQTTClient
+	mqttClient     Publisher         // MQTT 발행 (MQTTClient, 테스트는 FakeBroker)
+	protocol       RobotProtocol     // 로봇측 메시지 변환 (기본 VDA5050)
+	ids            utils.IDGenerator // 오더/노드/액션 ID 생성 (ID_FORMAT)
 	config         *config.Config
-	activeOrders   map[string]string // orderID -> original command mapping
-	canceledOrders map[string]string // orderID -> original cancel command mapping (취소된 오더 추적)
+	eventBus       *events.Bus
+	activeOrders   map[string]*OrderInfo        // orderID -> order (original command, status)
+	canceledOrders map[string]*OrderInfo        // orderID -> order (취소된 오더 추적)
+	latchedFaults  map[string]*types.FaultEvent // baseCommand -> latched fault (운영자 확인 대기)
+	chains         map[string]*chainRun         // baseCommand -> 실행 중인 명령 체인 (chain.go)
+	hook           *hook.Script                 // 명령/응답 훅 (COMMAND_HOOK, nil이면 없음)
+	pendingOrders  map[string]*pendingOrder     // orderID -> 발행 전 보관 오더 (커미셔닝 확인 대기, delay 지연 실행)
+	recentCommands []*CommandResult             // 최근 수신 명령 (관리자 API)
+	recentOrders   []*OrderInfo                 // 최근 종료된 오더 (관리자 API, SSE)
+	responseDedup  *responseDeduper             // 최종 응답 재전송 억제
+
+	instantActionThrottle *instantActionThrottle // 로봇별 InstantActions 전송 제한/병합
+	commandRates          *commandRateLimiter    // PLC 명령 수신 제한 (ratelimit.go)
+	stateQueue            *stateQueue            // 로봇 상태 작업 대기열 (nil이면 콜백에서 처리, statequeue.go)
+
+	commandMapping *config.CommandMapping       // 파이프라인 명령 매핑 (nil이면 없음)
+	orderTemplates map[string]string            // 기본 명령 -> 오더 템플릿 (templates.go)
+	navPaths       map[string][]config.Waypoint // 기본 명령 -> 이동 경로 (path.go)
+	waypoints      map[string]config.Waypoint   // 이름 -> 위치 (WAYPOINTS)
+	commandAliases map[string]string            // PLC 별칭 -> Direct Action 명령 (COMMAND_ALIASES)
+
+	allowlist          actionAllowlist            // 설정의 추론/궤적 이름 허용 목록 (allowlist.go)
+	factsheetAllowlist actionAllowlist            // factsheet가 보고한 이름 목록 (FACTSHEET_ALLOWLIST)
+	factsheetActions   map[string]factsheetAction // factsheet가 선언한 액션 (FACTSHEET_ACTION_VALIDATION, nil이면 검증 안 함)
+
+	escalationPolicies *config.EscalationPolicies // 오더 시간 초과 단계 정책
+	escalationWebhook  *alert.Webhook             // 단계 진입 알림 (nil이면 비활성화)
+	unhealthyReason    string                     // 비어있지 않으면 로봇 비정상 (readiness 실패)
+
+	lastPLCHeartbeat time.Time // 마지막 PLC 하트비트 수신 시간 (heartbeat.go)
+	plcHeartbeatLost bool      // PLC_HEARTBEAT_TIMEOUT 초과 후 아직 복구되지 않음
+
+	commandGateOpen     bool              // false면 명령을 처리하지 않고 보관 (시작 게이트)
+	bufferedCommands    []bufferedCommand // 게이트가 열리기 전 수신한 명령
+	queuedCommands      []*QueuedCommand  // 진행 중인 오더 종료를 기다리는 명령 (우선순위 순, queue.go)
+	factsheetOrderLimit int               // factsheet가 보고한 동시 오더 한도 (0이면 없음)
+
+	standby        bool   // HA 대기 인스턴스 (standby.go)
+	correlationID  string // 처리 중인 PLC 명령의 상관관계 ID (correlation.go)
+	commandPayload string // 처리 중인 PLC 명령의 정규화 전 페이로드 (deadletter.go)
+	intakePaused   bool   // 운영자가 명령 수신 일시 중지 (intake.go)
+	replyChannel   string // 처리 중인 PLC 명령의 응답 채널 (reply.go)
+
+	fleet  []*DirectActionHandler // 비상 정지를 함께 전달할 핸들러 (estop.go)
+	estop  *emergencyStop         // 종료 확인 대기 중인 비상 정지
+	safety *types.SafetyEvent     // 마지막 보고 안전 상태 (safety.go)
+
+	operatingMode string // 마지막 보고 운전 모드 (mode.go)
+	paused        bool   // 마지막 보고 일시 정지 상태 (pause.go)
+	driving       bool   // 마지막 보고 주행 여부 (driving.go)
+	pendingNodes  int    // 마지막 보고 남은 노드 수 (nodeStates)
+
+	position            *types.PositionUpdate // 마지막 발행 위치 (position.go)
+	positionPublishedAt time.Time
+	visualization       visualizationSampler // visualization 다운샘플링 (visualization.go)
+
+	schemaValidator *schema.Validator // 로봇 메시지 스키마 검증 (schema.go)
+	onlineCh        chan struct{}     // 로봇 최초 ONLINE 시 닫힘
+	onlineOnce      sync.Once
+
+	mu                   sync.Mutex
+	robotConnectionState string      // 마지막 로봇 연결 상태 (ONLINE, OFFLINE, CONNECTIONBROKEN)
+	lastStateAt          time.Time   // 마지막 로봇 상태 수신 시간
+	batteryCharge        *float64    // 마지막 보고 배터리 잔량 (대시보드)
+	lastError            *RobotError // 마지막 보고 오류 (대시보드)
+	lastErrorAt          time.Time
 }
 
-// NewDirectActionHandler 새 Direct Action 핸들러 생성
-func NewDirectActionHandler(mqttClient *MQTTClient, cfg *config.Config) *DirectActionHandler {
+// NewDirectActionHandler 새 Direct Action 핸들러 생성 (ID_FORMAT의 ID 생성기 사용)
+func NewDirectActionHandler(mqttClient Publisher, cfg *config.Config, eventBus *events.Bus) (*DirectActionHandler, error) {
+	ids, err := utils.NewIDGenerator(cfg.IDFormat)
+	if err != nil {
+		return nil, err
+	}
+	return NewDirectActionHandlerWithIDs(mqttClient, cfg, eventBus, ids)
+}
+
+// NewDirectActionHandlerWithIDs 지정한 ID 생성기로 Direct Action 핸들러 생성 (오더/노드/액션 ID)
+func NewDirectActionHandlerWithIDs(mqttClient Publisher, cfg *config.Config, eventBus *events.Bus, ids utils.IDGenerator) (*DirectActionHandler, error) {
 	utils.Logger.Infof("🏗️ Creating Direct Action Handler")
 
+	protocol, err := newRobotProtocol(cfg, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	escalationPolicies, err := cfg.ParseEscalationPolicies()
+	if err != nil {
+		return nil, err
+	}
+
+	commandMapping, err := loadCommandMapping(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	orderTemplates, err := cfg.LoadOrderTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	navPaths, err := cfg.ParseNavigationPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	waypoints, err := cfg.ParseWaypoints()
+	if err != nil {
+		return nil, err
+	}
+
+	commandAliases, err := cfg.ParseCommandAliases()
+	if err != nil {
+		return nil, err
+	}
+
+	schemaValidator, err := schema.NewValidator()
+	if err != nil {
+		return nil, err
+	}
+
 	handler := &DirectActionHandler{
-		mqttClient:     mqttClient,
-		config:         cfg,
-		activeOrders:   make(map[string]string),
-		canceledOrders: make(map[string]string),
+		mqttClient:            mqttClient,
+		protocol:              protocol,
+		ids:                   ids,
+		config:                cfg,
+		eventBus:              eventBus,
+		activeOrders:          make(map[string]*OrderInfo),
+		canceledOrders:        make(map[string]*OrderInfo),
+		latchedFaults:         make(map[string]*types.FaultEvent),
+		chains:                make(map[string]*chainRun),
+		pendingOrders:         make(map[string]*pendingOrder),
+		responseDedup:         newResponseDeduper(cfg.ResponseDedupWindow),
+		instantActionThrottle: newInstantActionThrottle(cfg.InstantActionRate, cfg.InstantActionBurst, cfg.InstantActionCoalesceWindow),
+		commandRates:          newCommandRateLimiter(),
+		stateQueue:            newStateQueue(cfg.StateQueueSize),
+		escalationPolicies:    escalationPolicies,
+		commandMapping:        commandMapping,
+		orderTemplates:        orderTemplates,
+		navPaths:              navPaths,
+		waypoints:             waypoints,
+		commandAliases:        commandAliases,
+		visualization:         visualizationSampler{rate: cfg.VisualizationRate},
+		allowlist:             newActionAllowlist(cfg),
+		schemaValidator:       schemaValidator,
+		escalationWebhook:     alert.NewWebhook(cfg.EscalationWebhookURL),
+		commandGateOpen:       !(cfg.StartupWaitForRobot && cfg.StartupBufferCommands),
+		onlineCh:              make(chan struct{}),
 	}
 
-	utils.Logger.Infof("✅ Direct Action Handler Created")
-	return handler
+	utils.Logger.Infof("✅ Direct Action Handler Created (robot protocol: %s)", protocol.Name())
+	return handler, nil
 }
 
-// HandlePLCCommand PLC 명령 처리 (Direct Action만)
+// HandlePLCCommand PLC 명령 메시지 처리 (MQTT)
 func (h *DirectActionHandler) HandlePLCCommand(client mqtt.Client, msg mqtt.Message) {
-	commandStr := strings.TrimSpace(string(msg.Payload()))
-	utils.Logger.Infof("🎯 PLC Command received: '%s'", commandStr)
+	h.ProcessCommand(string(msg.Payload()))
+}
+
+// ProcessCommand PLC 명령 처리 (Direct Action만, MQTT/HTTP 공통 파이프라인)
+func (h *DirectActionHandler) ProcessCommand(payload string) *CommandResult {
+	return h.ProcessCommandWithReply(payload, "")
+}
+
+// ProcessCommandWithReply 응답 채널을 지정한 PLC 명령 처리 (응답과 이후 오더 상태는 <응답 토픽>/<채널>로 발행)
+func (h *DirectActionHandler) ProcessCommandWithReply(payload, replyChannel string) *CommandResult {
+	h.mu.Lock()
+	command := normalizePayload(h.config, payload)
+	standby := h.standby
+	h.mu.Unlock()
+
+	// HA 대기 인스턴스는 응답하지 않음 (같은 명령을 리더가 처리)
+	if standby {
+		utils.Logger.Debugf("💤 Standby - ignoring PLC command: %s", command)
+		return newCommandResult(command, "", ErrStandby)
+	}
+
+	// 비상 정지는 모든 로봇 핸들러에 전달 (다른 핸들러 잠금을 사용하므로 잠금 없이 처리)
+	if commandKind(command) == CommandKindEStop {
+		return h.emergencyStopFleet(command)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	defer h.dispatchQueued()
+	defer h.beginCorrelation("")()
+	defer h.beginReply(replyChannel)()
+	h.commandPayload = payload
+	defer func() { h.commandPayload = "" }()
+
+	command, result := h.applyCommandHook(command)
+	if result != nil {
+		return result
+	}
+
+	if result := h.checkIntake(command); result != nil {
+		return result
+	}
+	if result := h.checkCommandRate(command); result != nil {
+		return result
+	}
+
+	if !h.commandGateOpen && commandKind(command) != CommandKindQuery {
+		return h.bufferCommand(command, replyChannel)
+	}
+	return h.processAndRecord(command)
+}
+
+// processAndRecord 명령 처리 후 기록 및 거부 이벤트 발행 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) processAndRecord(command string) *CommandResult {
+	if h.correlationID == "" {
+		defer h.beginCorrelation("")()
+	}
+	result := h.processCommand(command)
+	h.recordCommand(result)
+	if !result.Accepted {
+		h.publishEvent(events.Event{
+			Type:    events.TypeCommandRejected,
+			Command: result.Command,
+			Message: result.Reason,
+		})
+		h.publishDeadLetter(result)
+	}
+	return result
+}
+
+// publishEvent 로봇 정보를 채워 브릿지 이벤트 발행
+func (h *DirectActionHandler) publishEvent(event events.Event) {
+	event.Robot = h.config.RobotSerialNumber
+	if event.CorrelationID == "" {
+		event.CorrelationID = h.correlationID
+	}
+	h.eventBus.Publish(event)
+}
+
+// processCommand PLC 명령 처리 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) processCommand(commandStr string) *CommandResult {
+	h.commandLog(commandStr).Infof("🎯 PLC Command received: '%s'", commandStr)
+	h.publishEvent(events.Event{
+		Type:    events.TypeCommandReceived,
+		Command: commandStr,
+	})
+
+	// 문법에 따라 명령 분해 (grammar.go, 오류 위치와 사유를 실패 응답에 포함)
+	parsed, err := ParseCommand(commandStr)
+	if err != nil {
+		utils.Logger.Errorf("❌ Malformed command rejected: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return newCommandResult(commandStr, "", err)
+	}
 
 	// 취소 명령 확인
-	if h.isCancelCommand(commandStr) {
-		h.handleCancelCommand(commandStr)
-		return
+	if parsed.Kind == CommandKindCancel {
+		orderID, err := h.handleCancelCommand(commandStr)
+		return newCommandResult(commandStr, orderID, err)
+	}
+
+	// 상태 조회는 로봇 상태와 무관하게 캐시로 응답
+	if parsed.Kind == CommandKindQuery {
+		orderID, err := h.handleQueryCommand(commandStr)
+		return newCommandResult(commandStr, orderID, err)
+	}
+
+	// 로봇 비상 정지 중에는 취소 외 명령 거부 (fail-fast)
+	if result := h.rejectIfEStop(commandStr); result != nil {
+		return result
+	}
+
+	// 트리거 해제 명령 확인
+	if parsed.Kind == CommandKindRelease {
+		orderID, err := h.handleReleaseCommand(commandStr)
+		return newCommandResult(commandStr, orderID, err)
+	}
+
+	// 확장 명령의 웨이포인트 경로/목적지 해석 (path.go 참고)
+	command, priority := parsed.Command, parsed.Priority
+	path, err := h.resolveCommandPath(parsed)
+	if err != nil {
+		utils.Logger.Errorf("❌ Invalid waypoint path: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return newCommandResult(commandStr, "", err)
+	}
+
+	// PLC 재전송 확인 (진행 중인 같은 명령은 새 오더 대신 현재 상태 재보고)
+	if result := h.handleDuplicateCommand(commandStr, command); result != nil {
+		return result
+	}
+
+	// 매핑 파일의 명령 체인 (단계는 각자 일반 명령으로 처리됨)
+	if chain, isChain := h.commandMapping.LookupChain(command); isChain {
+		if parsed.HasPath || parsed.HasTarget {
+			err := fmt.Errorf("waypoint paths cannot be combined with command chains")
+			h.sendPLCFailure(commandStr, err)
+			return newCommandResult(commandStr, "", err)
+		}
+		return h.startChain(commandStr, chain)
+	}
+
+	// 매핑 파일의 파이프라인 명령, 기본 명령 이름의 오더 템플릿 확인
+	pipeline, isPipeline := h.commandMapping.Lookup(command)
+	template, isTemplate := h.orderTemplates[h.extractBaseCommand(command)]
+	if isPipeline && !parsed.HasPriority {
+		priority = pipeline.Priority
 	}
 
-	// Direct Action 명령인지 확인
-	if !h.isDirectActionCommand(commandStr) {
+	// 별칭 치환 후 Direct Action 명령인지 확인
+	actionCommand := h.resolveCommandAlias(command)
+	if !isPipeline && !isTemplate && !h.isDirectActionCommand(actionCommand) {
 		utils.Logger.Errorf("❌ Non-direct action command rejected: %s", commandStr)
-		h.sendPLCResponse(commandStr, types.PLCStatusFailed)
-		return
+		err := fmt.Errorf("not a direct action command")
+		if _, parseErr := parseDirectCommand(actionCommand); parseErr != nil {
+			err = parseErr
+		}
+		h.sendPLCFailure(commandStr, err)
+		return newCommandResult(commandStr, "", err)
+	}
+
+	// 래치된 오류 확인 (운영자 확인 전까지 재시도 거부)
+	if h.isFaultLatched(commandStr) {
+		utils.Logger.Errorf("❌ Command rejected - fault latched, operator acknowledgment required: %s", commandStr)
+		err := ErrFaultLatched
+		h.sendPLCFailure(commandStr, err)
+		return newCommandResult(commandStr, "", err)
+	}
+
+	// 로봇이 수동/서비스 모드면 정책에 따라 대기 또는 거부 (OPERATING_MODE_POLICY)
+	if result := h.gateOperatingMode(commandStr, priority); result != nil {
+		return result
 	}
 
-	// Direct Action 처리
-	h.handleDirectAction(commandStr)
+	// 로봇이 일시 정지 중이면 정책에 따라 재개 후 발행 또는 거부 (PAUSED_POLICY)
+	if result := h.gatePaused(commandStr); result != nil {
+		return result
+	}
+
+	// 높은 우선순위는 진행 중인 오더를 선점, 아니면 동시 실행 한도에 따라 대기 또는 거부 (ORDER_POLICY)
+	if h.shouldPreempt(priority) {
+		if err := h.preemptOrders(commandStr); err != nil {
+			utils.Logger.Errorf("❌ %v", err)
+			h.sendPLCFailure(commandStr, err)
+			return newCommandResult(commandStr, "", err)
+		}
+	} else if result := h.admitCommand(commandStr, priority); result != nil {
+		return result
+	}
+
+	// 파이프라인, 템플릿 또는 Direct Action 처리
+	if isPipeline {
+		orderID, err := h.handlePipelineCommand(command, pipeline, path)
+		return newCommandResult(commandStr, orderID, err)
+	}
+	if isTemplate {
+		if parsed.HasPath || parsed.HasTarget {
+			err := fmt.Errorf("waypoint paths cannot be combined with order templates")
+			h.sendPLCFailure(commandStr, err)
+			return newCommandResult(commandStr, "", err)
+		}
+		orderID, err := h.handleTemplateCommand(command, template)
+		return newCommandResult(commandStr, orderID, err)
+	}
+	orderID, err := h.handleDirectAction(command, actionCommand, path)
+	return newCommandResult(commandStr, orderID, err)
 }
 
-// HandleRobotState 로봇 상태 메시지 처리
+// HandleRobotState 로봇 상태 메시지 처리 (MQTT)
 func (h *DirectActionHandler) HandleRobotState(client mqtt.Client, msg mqtt.Message) {
-	utils.Logger.Debugf("📊 Processing robot state message")
+	if !h.acceptRobotMessage(schema.MessageState, msg.Topic(), msg.Payload()) {
+		return
+	}
+	h.ProcessRobotState(msg.Payload())
+}
+
+// ProcessRobotState 로봇 상태 메시지 처리 (MQTT/로봇 전송 계층 공통)
+func (h *DirectActionHandler) ProcessRobotState(payload []byte) {
+	h.robotLog().Debug("Processing robot state message")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	defer h.dispatchQueued()
 
-	var stateMsg map[string]interface{}
-	if err := json.Unmarshal(msg.Payload(), &stateMsg); err != nil {
-		utils.Logger.Errorf("❌ Failed to parse robot state: %v", err)
+	state, err := h.protocol.ParseState(payload)
+	if err != nil {
+		h.robotLog().WithError(err).Error("Failed to parse robot state")
 		return
 	}
 
-	// agvPosition.positionInitialized 확인 (false이면 initPosition 전송)
-	if agvPosition, hasAgvPosition := stateMsg["agvPosition"].(map[string]interface{}); hasAgvPosition {
-		if positionInitialized, hasPosition := agvPosition["positionInitialized"].(bool); hasPosition {
-			if !positionInitialized {
-				utils.Logger.Infof("🎯 Position not initialized (agvPosition.positionInitialized=false) - sending initPosition action")
-				if err := h.sendInitPositionAction(); err != nil {
-					utils.Logger.Errorf("❌ Failed to send initPosition action: %v", err)
-				} else {
-					utils.Logger.Infof("✅ InitPosition action sent due to agvPosition.positionInitialized=false")
-				}
-			}
+	h.lastStateAt = time.Now()
+	h.recordRobotHealth(state)
+	h.recordSafetyState(state)
+	h.recordPosition(state)
+	h.recordOperatingMode(state)
+	h.recordPaused(state)
+	h.recordMotion(state)
+	h.failRejectedOrders(state)
+
+	// 위치 미초기화 보고 시 initPosition 전송
+	if state.PositionInitialized != nil && !*state.PositionInitialized {
+		h.robotLog().Info("Position not initialized (agvPosition.positionInitialized=false) - sending initPosition action")
+		if err := h.sendInitPositionAction(); err != nil {
+			h.robotLog().WithError(err).Error("Failed to send initPosition action")
 		}
 	}
 
 	// OrderID 확인
-	orderID, hasOrderID := stateMsg["orderId"].(string)
-	if hasOrderID && orderID != "" {
-		actionStates, hasActions := stateMsg["actionStates"].([]interface{})
-
-		// 취소된 오더인지 확인 (PLC 취소 요청한 경우)
-		if originalCancelCommand, exists := h.canceledOrders[orderID]; exists {
-			if hasActions {
-				utils.Logger.Infof("🔍 Processing canceled order states for OrderID: %s", orderID)
-				h.processCanceledOrderStates(orderID, originalCancelCommand, actionStates)
-			}
+	if state.OrderID == "" {
+		return
+	}
+
+	// 취소된 오더인지 확인 (PLC 취소 요청한 경우)
+	if order, exists := h.canceledOrders[state.OrderID]; exists {
+		if h.isReplayedState(order, state) {
 			return
 		}
+		if len(state.ActionStates) > 0 {
+			h.orderLog(order).Info("Processing canceled order states")
+			h.processCanceledOrderStates(order, state.ActionStates)
+		}
+		return
+	}
 
-		// 활성 오더 처리 (일반 실행 중이거나 로봇 자체 취소된 경우)
-		originalCommand, exists := h.activeOrders[orderID]
-		if exists {
-			if hasActions {
-				utils.Logger.Infof("🔍 Processing action states for OrderID: %s (Command: %s)", orderID, originalCommand)
-				h.processActionStates(orderID, originalCommand, actionStates)
-			}
+	// 활성 오더 처리 (일반 실행 중이거나 로봇 자체 취소된 경우)
+	if order, exists := h.activeOrders[state.OrderID]; exists && len(state.ActionStates) > 0 {
+		if h.isReplayedState(order, state) {
+			return
 		}
+		h.orderLog(order).Info("Processing action states")
+		h.processActionStates(order, state.ActionStates, state.FatalError())
+	}
+}
+
+// isReplayedState 오더 발행 이전에 생성된 state인지 확인 (잠금 보유 상태에서 호출)
+// orderId가 재사용되면 (저장소 초기화 등) 이전 오더의 retained state가 새 오더를 완료 처리할 수 있음
+func (h *DirectActionHandler) isReplayedState(order *OrderInfo, state *RobotState) bool {
+	if !h.config.StateReplayProtection || state.Timestamp.IsZero() {
+		return false
 	}
+
+	dispatchedAt := order.publishedAt
+	if dispatchedAt.IsZero() {
+		dispatchedAt = order.CreatedAt
+	}
+	if !state.Timestamp.Before(dispatchedAt.Add(-h.config.StateClockSkew)) {
+		return false
+	}
+
+	h.orderLog(order).WithField("stateTimestamp", state.Timestamp).
+		Warnf("Ignoring state older than order dispatch (%s) - possible orderId reuse", dispatchedAt.Format(time.RFC3339Nano))
+	return true
 }
 
 // HandleRobotConnection 로봇 연결 상태 메시지 처리
 func (h *DirectActionHandler) HandleRobotConnection(client mqtt.Client, msg mqtt.Message) {
-	utils.Logger.Debugf("📡 Processing robot connection message")
+	h.robotLog().Debug("Processing robot connection message")
+	if !h.acceptRobotMessage(schema.MessageConnection, msg.Topic(), msg.Payload()) {
+		return
+	}
 
 	var connectionMsg map[string]interface{}
 	if err := json.Unmarshal(msg.Payload(), &connectionMsg); err != nil {
-		utils.Logger.Errorf("❌ Failed to parse robot connection: %v", err)
+		h.robotLog().WithError(err).Error("Failed to parse robot connection")
 		return
 	}
 
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	// 보고된 프로토콜 버전 확인
+	h.negotiateVersion(connectionMsg)
+
 	// connectionState 확인
 	if connectionState, hasState := connectionMsg["connectionState"].(string); hasState {
-		utils.Logger.Infof("🔗 Robot connection state: %s", connectionState)
-
-		switch connectionState {
-		case "ONLINE":
-			utils.Logger.Infof("✅ Robot is ONLINE - sending initPosition")
-			h.handleRobotOnline()
-		case "CONNECTIONBROKEN":
-			utils.Logger.Warnf("⚠️ Robot connection is BROKEN")
-			h.handleRobotConnectionBroken()
-		case "OFFLINE":
-			utils.Logger.Warnf("⚠️ Robot is OFFLINE")
-			h.handleRobotOffline()
-		default:
-			utils.Logger.Infof("ℹ️ Unknown robot connection state: %s", connectionState)
-		}
+		h.applyConnectionState(connectionState)
+	}
+}
+
+// ProcessRobotConnection 로봇 연결 상태 처리 (로봇 전송 계층용)
+func (h *DirectActionHandler) ProcessRobotConnection(connectionState string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.applyConnectionState(connectionState)
+}
+
+// applyConnectionState 연결 상태 반영 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) applyConnectionState(connectionState string) {
+	h.robotLog().WithField("connectionState", connectionState).Info("Robot connection state")
+
+	h.robotConnectionState = connectionState
+
+	switch connectionState {
+	case "ONLINE":
+		h.markRobotOnline()
+		h.clearUnhealthy("robot reported ONLINE")
+		h.handleRobotOnline()
+		h.dispatchQueued()
+	case "CONNECTIONBROKEN":
+		h.handleRobotConnectionBroken()
+	case "OFFLINE":
+		h.handleRobotOffline()
+	default:
+		h.robotLog().WithField("connectionState", connectionState).Info("Unknown robot connection state")
 	}
 }
 
+// RobotSerialNumber 담당 로봇 시리얼 번호
+func (h *DirectActionHandler) RobotSerialNumber() string {
+	return h.config.RobotSerialNumber
+}
+
+// GetRobotConnectionState 마지막으로 수신한 로봇 연결 상태 반환
+func (h *DirectActionHandler) GetRobotConnectionState() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.robotConnectionState
+}
+
+// LastStateAt 마지막 로봇 상태 수신 시간 반환
+func (h *DirectActionHandler) LastStateAt() time.Time {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.lastStateAt
+}
+
+// SendStateRequest stateRequest InstantAction 전송 (로봇에 즉시 상태 보고 요청)
+func (h *DirectActionHandler) SendStateRequest() error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if send, err := h.allowInstantAction("stateRequest", ""); !send {
+		return err
+	}
+
+	message, err := h.protocol.BuildInstantAction(InstantActionRequest{
+		ActionType:   "stateRequest",
+		BlockingType: types.BlockingTypeNone,
+	})
+	if err != nil {
+		return err
+	}
+
+	h.robotLog().WithField("topic", message.Topic).Info("Sending stateRequest via InstantActions")
+	return h.sendToRobot(message)
+}
+
 // handleRobotOnline 로봇이 온라인 상태일 때 initPosition 전송
 func (h *DirectActionHandler) handleRobotOnline() {
-	utils.Logger.Infof("🎯 Robot is now ONLINE - sending initPosition action")
+	h.robotLog().Info("Robot is ONLINE - sending initPosition action")
 
 	if err := h.sendInitPositionAction(); err != nil {
-		utils.Logger.Errorf("❌ Failed to send initPosition action: %v", err)
-	} else {
-		utils.Logger.Infof("✅ InitPosition action sent successfully")
+		h.robotLog().WithError(err).Error("Failed to send initPosition action")
 	}
 }
 
 // handleRobotConnectionBroken 로봇 연결이 끊어진 상태 처리
 func (h *DirectActionHandler) handleRobotConnectionBroken() {
-	utils.Logger.Warnf("⚠️ Robot connection is broken - pausing command processing")
+	h.robotLog().Warn("Robot connection is broken - pausing command processing")
 
 	// 연결이 복구될 때까지 새로운 명령 처리를 일시 중단할 수 있음
 	// 필요에 따라 추가 로직 구현
@@ -158,37 +589,34 @@ func (h *DirectActionHandler) handleRobotConnectionBroken() {
 
 // handleRobotOffline 로봇이 오프라인 상태일 때 처리
 func (h *DirectActionHandler) handleRobotOffline() {
-	utils.Logger.Warnf("⚠️ Robot went OFFLINE - cleaning up active orders")
+	h.robotLog().Warn("Robot went OFFLINE - cleaning up active orders")
 
 	// 활성 오더들을 실패 처리
-	for orderID, originalCommand := range h.activeOrders {
-		utils.Logger.Warnf("⚠️ Marking active order as failed due to offline: %s", orderID)
-		h.sendPLCResponse(originalCommand, types.PLCStatusFailed)
+	for _, order := range h.activeOrders {
+		h.orderLog(order).Warn("Marking active order as failed due to offline")
+		h.respondOrder(order, types.PLCStatusFailed)
 	}
 
 	// 취소된 오더들도 실패 처리
-	for orderID, originalCancelCommand := range h.canceledOrders {
-		utils.Logger.Warnf("⚠️ Marking canceled order as failed due to offline: %s", orderID)
-		h.sendPLCResponse(originalCancelCommand, types.PLCStatusFailed)
+	for _, order := range h.canceledOrders {
+		h.orderLog(order).Warn("Marking canceled order as failed due to offline")
+		h.respondOrder(order, types.PLCStatusFailed)
 	}
 
 	// 활성 오더 맵 정리
-	h.activeOrders = make(map[string]string)
-	h.canceledOrders = make(map[string]string)
+	for _, order := range h.activeOrders {
+		h.finishOrder(order)
+	}
+	for _, order := range h.canceledOrders {
+		h.finishOrder(order)
+	}
 }
 
 // sendInitPositionAction initPosition InstantAction 전송
 func (h *DirectActionHandler) sendInitPositionAction() error {
-	// InstantActions 메시지 생성
-	instantActions := types.NewInstantActionsMessage(
-		h.getNextHeaderID(),
-		h.config.RobotManufacturer,
-		h.config.RobotSerialNumber,
-	)
-
-	// initPosition 액션 생성
-	actionID := h.generateActionID()
-	initAction := types.NewInstantAction("initPosition", actionID, types.BlockingTypeNone)
+	if send, err := h.allowInstantAction("initPosition", ""); !send {
+		return err
+	}
 
 	// pose 파라미터 생성
 	poseValue := map[string]interface{}{
@@ -199,324 +627,394 @@ func (h *DirectActionHandler) sendInitPositionAction() error {
 		"y":          0.0,
 	}
 
-	// 액션에 pose 파라미터 추가
-	initAction.AddParameter("pose", poseValue)
-
-	// InstantActions에 액션 추가
-	instantActions.AddAction(initAction)
-
-	// JSON 마샬링
-	msgData, err := json.Marshal(instantActions)
+	message, err := h.protocol.BuildInstantAction(InstantActionRequest{
+		ActionType:   "initPosition",
+		BlockingType: types.BlockingTypeNone,
+		Parameters:   []types.ActionParameter{{Key: "pose", Value: poseValue}},
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal initPosition instant actions: %v", err)
+		return err
 	}
 
-	// 전송
-	topic := fmt.Sprintf("meili/v2/%s/%s/instantActions", h.config.RobotManufacturer, h.config.RobotSerialNumber)
-
-	utils.Logger.Infof("📤 Sending InitPosition via InstantActions to: %s", topic)
-	utils.Logger.Infof("📤 InitPosition Details: ActionID=%s", actionID)
-
-	if err := h.mqttClient.Publish(topic, 0, false, msgData); err != nil {
+	if err := h.sendToRobot(message); err != nil {
 		return fmt.Errorf("failed to publish initPosition action: %v", err)
 	}
 
-	utils.Logger.Infof("✅ InitPosition action sent successfully via InstantActions")
+	h.robotLog().WithFields(logrus.Fields{
+		"topic":    message.Topic,
+		"actionId": message.ActionID,
+	}).Info("InitPosition action sent via InstantActions")
 	return nil
 }
 
-// isDirectActionCommand Direct Action 명령인지 확인
+// isDirectActionCommand Direct Action 명령인지 확인 (두 번째 세그먼트가 등록된 명령 타입)
 func (h *DirectActionHandler) isDirectActionCommand(commandStr string) bool {
-	return strings.HasSuffix(commandStr, ":I") || strings.Contains(commandStr, ":T")
-}
-
-// isCancelCommand 취소 명령인지 확인
-func (h *DirectActionHandler) isCancelCommand(commandStr string) bool {
-	return strings.HasSuffix(commandStr, ":C")
+	parsed, err := ParseCommand(commandStr)
+	if err != nil || parsed.Kind != CommandKindAction {
+		return false
+	}
+	_, exists := lookupCommandTranslator(parsed.Type)
+	return exists
 }
 
-// handleDirectAction Direct Action 처리
-func (h *DirectActionHandler) handleDirectAction(commandStr string) {
-	parts := strings.Split(commandStr, ":")
-	if len(parts) < 2 {
-		h.sendPLCResponse(commandStr, types.PLCStatusFailed)
-		return
+// handleDirectAction Direct Action 처리 (actionCommand는 별칭 치환된 명령, 오더는 PLC 명령 기준으로 추적)
+func (h *DirectActionHandler) handleDirectAction(commandStr, actionCommand string, path []config.Waypoint) (string, error) {
+	command, err := parseDirectCommand(actionCommand)
+	if err != nil {
+		utils.Logger.Errorf("❌ Command validation failed: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
 	}
 
-	baseCommand := parts[0]
-	cmdType := rune(parts[1][0])
-	armParam := ""
-	if len(parts) >= 3 {
-		armParam = parts[2]
+	if maxDelay := h.config.CommandMaxDelay; command.Delay > maxDelay {
+		err := fmt.Errorf("delay %s exceeds COMMAND_MAX_DELAY %s", command.Delay, maxDelay)
+		utils.Logger.Errorf("❌ Command validation failed: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
 	}
 
-	// Direct Action 오더 전송
-	orderID, err := h.sendDirectActionOrder(baseCommand, cmdType, armParam)
+	// Direct Action 오더 생성
+	orderID, message, err := h.buildDirectActionOrder(command, path)
 	if err != nil {
+		utils.Logger.Errorf("❌ Failed to build direct action order: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+
+	// 지연 실행: 커미셔닝 모드가 아니면 지연 후 발행 (커미셔닝 모드는 운영자 확인이 우선)
+	if command.Delay > 0 && !h.config.CommissioningMode {
+		h.holdForDelay(h.newDispatchedOrder(commandStr, orderID, message), message, command.Delay)
+		return orderID, nil
+	}
+
+	// 주행 중이면 정지할 때까지 보류 (DEFER_WHILE_DRIVING, 커미셔닝 모드는 운영자 확인이 우선)
+	if h.shouldDeferForDriving() && !h.config.CommissioningMode {
+		h.holdUntilStationary(h.newDispatchedOrder(commandStr, orderID, message), message)
+		return orderID, nil
+	}
+	return h.dispatchOrder(commandStr, orderID, message)
+}
+
+// newDispatchedOrder 발행할 오더의 추적 정보 생성 (현재 명령의 상관관계 ID/응답 채널 포함)
+func (h *DirectActionHandler) newDispatchedOrder(commandStr, orderID string, message *OutboundMessage) *OrderInfo {
+	order := newOrderInfo(orderID, commandStr)
+	order.CorrelationID = h.correlationID
+	order.ActionType = message.ActionType
+	order.ReplyChannel = h.replyChannel
+	order.triggerActionIDs = message.TriggerActionIDs
+	return order
+}
+
+// dispatchOrder 생성된 오더 발행 (커미셔닝 모드면 운영자 확인 대기)
+func (h *DirectActionHandler) dispatchOrder(commandStr, orderID string, message *OutboundMessage) (string, error) {
+	order := h.newDispatchedOrder(commandStr, orderID, message)
+
+	// 커미셔닝 모드: 운영자 확인 후 발행
+	if h.config.CommissioningMode {
+		h.holdForConfirmation(order, message)
+		return orderID, nil
+	}
+
+	if err := h.publishOrder(order, message); err != nil {
 		utils.Logger.Errorf("❌ Failed to send direct action order: %v", err)
-		h.sendPLCResponse(commandStr, types.PLCStatusFailed)
-		return
+		h.sendPLCFailure(commandStr, err)
+		return "", err
 	}
+	return orderID, nil
+}
 
-	// OrderID와 원본 명령 매핑 저장
-	h.activeOrders[orderID] = commandStr
+// publishOrder 오더를 로봇으로 전송하고 활성 오더로 추적 시작
+func (h *DirectActionHandler) publishOrder(order *OrderInfo, message *OutboundMessage) error {
+	h.orderLog(order).WithField("topic", message.Topic).Info("Sending robot order")
+
+	if err := h.sendToRobot(message); err != nil {
+		return err
+	}
 
-	utils.Logger.Infof("✅ Direct action order sent: %s (OrderID: %s)", commandStr, orderID)
+	// OrderID와 원본 명령 매핑 저장
+	order.publishedAt = time.Now()
+	h.activeOrders[order.OrderID] = order
+	h.publishEvent(events.Event{
+		Type:          events.TypeOrderPublished,
+		Command:       order.Command,
+		OrderID:       order.OrderID,
+		CorrelationID: order.CorrelationID,
+		ActionType:    order.ActionType,
+	})
+
+	h.orderLog(order).Info("Direct action order sent")
+	return nil
 }
 
 // handleCancelCommand 취소 명령 처리
-func (h *DirectActionHandler) handleCancelCommand(commandStr string) {
+func (h *DirectActionHandler) handleCancelCommand(commandStr string) (string, error) {
 	baseCommand := h.extractBaseCommand(commandStr)
 
+	// 실행 중인 명령 체인은 중단하고 진행 중인 단계 오더 취소
+	if run, exists := h.chains[baseCommand]; exists {
+		h.stopChain(run, "canceled by PLC")
+		h.cancelChainStep(run, "canceled by PLC")
+		h.sendPLCResponse(commandStr, types.PLCStatusSuccess)
+		return run.stepOrderID, nil
+	}
+
+	// 확인 대기 중인 오더는 로봇에 보내지 않고 폐기
+	if pending := h.findPendingOrder(baseCommand); pending != nil {
+		h.rejectPendingOrder(pending, "canceled by PLC before confirmation")
+		h.sendPLCResponse(commandStr, types.PLCStatusSuccess)
+		return pending.order.OrderID, nil
+	}
+
 	// 해당 명령에 대한 활성 오더 찾기
-	var targetOrderID string
-	for orderID, originalCommand := range h.activeOrders {
-		if h.extractBaseCommand(originalCommand) == baseCommand {
-			targetOrderID = orderID
+	var targetOrder *OrderInfo
+	for _, order := range h.activeOrders {
+		if h.extractBaseCommand(order.Command) == baseCommand {
+			targetOrder = order
 			break
 		}
 	}
 
-	if targetOrderID == "" {
+	if targetOrder == nil {
 		utils.Logger.Warnf("⚠️ No active order found for command: %s", baseCommand)
-		h.sendPLCResponse(commandStr, types.PLCStatusFailed)
-		return
+		err := fmt.Errorf("no active order found for command %s", baseCommand)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
 	}
 
-	// InstantActions로 취소 명령 전송
-	if err := h.sendCancelOrder(targetOrderID); err != nil {
+	// InstantActions로 취소 명령 전송 후 취소된 오더로 이동
+	if err := h.cancelOrder(targetOrder, commandStr); err != nil {
 		utils.Logger.Errorf("❌ Failed to send cancel order: %v", err)
-		h.sendPLCResponse(commandStr, types.PLCStatusFailed)
-		return
+		h.sendPLCFailure(commandStr, err)
+		return "", err
 	}
 
-	// 활성 오더에서 제거하고 취소된 오더로 이동
-	delete(h.activeOrders, targetOrderID)
-	h.canceledOrders[targetOrderID] = commandStr
-
-	utils.Logger.Infof("✅ Cancel order sent for: %s (OrderID: %s)", baseCommand, targetOrderID)
+	h.orderLog(targetOrder).Info("Cancel order sent")
+	return targetOrder.OrderID, nil
 }
 
-// sendDirectActionOrder Direct Action 오더 전송 (구조체 사용)
-func (h *DirectActionHandler) sendDirectActionOrder(baseCommand string, commandType rune, armParam string) (string, error) {
-	// 액션 타입과 파라미터 결정
-	actionType, actionParameters := h.buildActionParameters(baseCommand, commandType, armParam)
-	if actionType == "" {
-		return "", fmt.Errorf("invalid direct action command type: %c", commandType)
-	}
+// buildDirectActionOrder Direct Action 오더 메시지 생성
+func (h *DirectActionHandler) buildDirectActionOrder(command *directCommand, path []config.Waypoint) (string, *OutboundMessage, error) {
+	baseCommand := command.Base
 
-	// ID 생성
-	orderID := h.generateOrderID()
-	nodeID := h.generateNodeID()
-	actionID := h.generateActionID()
-
-	// 오더 생성
-	order := h.buildOrder(orderID, nodeID, actionID, baseCommand, actionType, actionParameters)
+	// 액션 타입과 파라미터 결정 (PLC가 덧붙인 key=value 파라미터는 뒤에 추가)
+	actionType, actionParameters, err := h.buildActionParameters(baseCommand, command.Type, command.Arm)
+	if err != nil {
+		return "", nil, err
+	}
+	actionParameters = append(actionParameters, command.Params...)
 
-	// JSON 마샬링 및 전송
-	return h.publishOrder(order, orderID, actionType, baseCommand)
-}
+	actions := []OrderAction{{ActionType: actionType, Parameters: actionParameters}}
+	if err := h.checkFactsheetOrder(actions); err != nil {
+		return "", nil, err
+	}
 
-// buildActionParameters 액션 파라미터 구성
-func (h *DirectActionHandler) buildActionParameters(baseCommand string, commandType rune, armParam string) (string, []types.ActionParameter) {
-	switch commandType {
-	case 'I':
-		return "Roboligent Robin - Inference", []types.ActionParameter{
-			{Key: "inference_name", Value: baseCommand},
-		}
-	case 'T':
-		return "Roboligent Robin - Follow Trajectory", []types.ActionParameter{
-			{Key: "trajectory_name", Value: baseCommand},
-			{Key: "arm", Value: h.parseArmParam(armParam)},
-		}
-	default:
-		return "", nil
+	orderID := h.generateOrderID()
+	message, err := h.protocol.BuildOrder(OrderRequest{
+		OrderID:     orderID,
+		BaseCommand: baseCommand,
+		Actions:     actions,
+		Path:        path,
+	})
+	if err != nil {
+		return "", nil, err
 	}
+	message.ActionType = actionType
+
+	h.robotLog().WithFields(logrus.Fields{
+		"orderId":     orderID,
+		"actionType":  actionType,
+		"baseCommand": baseCommand,
+	}).Info("Direct action order built")
+	return orderID, message, nil
 }
 
-// buildOrder 오더 구조체 생성
-func (h *DirectActionHandler) buildOrder(orderID, nodeID, actionID, baseCommand, actionType string, actionParameters []types.ActionParameter) *types.OrderMessage {
-	// 오더 생성
-	order := types.NewOrderMessage(
-		h.getNextHeaderID(),
-		h.config.RobotManufacturer,
-		h.config.RobotSerialNumber,
-		orderID,
-		0,
-	)
-
-	// 노드 생성 및 설정
-	node := types.NewNode(nodeID, 1, true)
-	nodeDescription := fmt.Sprintf("Direct action for command %s", baseCommand)
-	node.NodeDescription = &nodeDescription
-	node.NodePosition = h.createDefaultNodePosition()
-
-	// 액션 생성 및 설정
-	action := types.NewAction(actionType, actionID, types.BlockingTypeNone)
-	actionDescription := fmt.Sprintf("Execute %s for %s", actionType, baseCommand)
-	action.ActionDescription = &actionDescription
-	action.ActionParameters = actionParameters
-
-	// 노드에 액션 추가, 오더에 노드 추가
-	node.AddAction(action)
-	order.AddNode(node)
-
-	return order
+// buildActionParameters 등록된 명령 타입 번역기로 액션 타입/파라미터 구성 (translator.go)
+func (h *DirectActionHandler) buildActionParameters(baseCommand, commandType, armParam string) (string, []types.ActionParameter, error) {
+	translator, exists := lookupCommandTranslator(commandType)
+	if !exists {
+		return "", nil, fmt.Errorf("invalid direct action command type: %s", commandType)
+	}
+	actionType, parameters, err := translator.Translate(baseCommand, armParam)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := h.checkActionAllowed(parameters); err != nil {
+		return "", nil, err
+	}
+	return actionType, parameters, nil
 }
 
-// createDefaultNodePosition 기본 노드 위치 생성
-func (h *DirectActionHandler) createDefaultNodePosition() *types.NodePosition {
-	theta := 0.0
-	allowedDeviationXY := 0.0
-	allowedDeviationTheta := 0.0
-	mapDescription := ""
-
-	return &types.NodePosition{
-		X:                     0.0,
-		Y:                     0.0,
-		Theta:                 &theta,
-		AllowedDeviationXY:    &allowedDeviationXY,
-		AllowedDeviationTheta: &allowedDeviationTheta,
-		MapID:                 "",
-		MapDescription:        &mapDescription,
+// sendCancelOrder 로봇에 오더 취소 전송
+func (h *DirectActionHandler) sendCancelOrder(orderID string) error {
+	if send, err := h.allowInstantAction("cancelOrder", orderID); !send {
+		return err
 	}
-}
 
-// publishOrder 오더 발행
-func (h *DirectActionHandler) publishOrder(order *types.OrderMessage, orderID, actionType, baseCommand string) (string, error) {
-	msgData, err := json.Marshal(order)
+	message, err := h.protocol.BuildCancel(orderID)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal order: %v", err)
+		return err
 	}
 
-	topic := fmt.Sprintf("meili/v2/%s/%s/order", h.config.RobotManufacturer, h.config.RobotSerialNumber)
-
-	utils.Logger.Infof("📤 Sending Robot Order to: %s", topic)
-	utils.Logger.Infof("📤 Order Details: OrderID=%s, ActionType=%s, BaseCommand=%s", orderID, actionType, baseCommand)
-
-	if err := h.mqttClient.Publish(topic, 0, false, msgData); err != nil {
-		return "", err
+	if err := h.sendToRobot(message); err != nil {
+		return err
 	}
 
-	utils.Logger.Infof("✅ Robot Order sent successfully: OrderID=%s", orderID)
-	return orderID, nil
+	h.robotLog().WithFields(logrus.Fields{
+		"topic":    message.Topic,
+		"orderId":  orderID,
+		"actionId": message.ActionID,
+	}).Info("Cancel order sent via InstantActions")
+	return nil
 }
 
-// sendCancelOrder InstantActions로 취소 명령 전송
-func (h *DirectActionHandler) sendCancelOrder(orderID string) error {
-	// InstantActions 메시지 생성
-	instantActions := types.NewInstantActionsMessage(
-		h.getNextHeaderID(),
-		h.config.RobotManufacturer,
-		h.config.RobotSerialNumber,
-	)
-
-	// 취소 액션 생성
-	actionID := h.generateActionID()
-	cancelAction := types.NewInstantAction("cancelOrder", actionID, types.BlockingTypeHard)
-
-	// InstantActions에 액션 추가
-	instantActions.AddAction(cancelAction)
-
-	// JSON 마샬링
-	msgData, err := json.Marshal(instantActions)
-	if err != nil {
-		return fmt.Errorf("failed to marshal instant actions: %v", err)
+// sendToRobot 프로토콜이 생성한 메시지를 로봇으로 전송 (전송 계층이 없으면 MQTT)
+func (h *DirectActionHandler) sendToRobot(message *OutboundMessage) error {
+	if transport, ok := h.protocol.(RobotTransport); ok {
+		return transport.Send(message)
 	}
+	return h.mqttClient.Publish(message.Topic, 0, false, message.Payload)
+}
 
-	// 전송
-	topic := fmt.Sprintf("meili/v2/%s/%s/instantActions", h.config.RobotManufacturer, h.config.RobotSerialNumber)
-
-	utils.Logger.Infof("📤 Sending Cancel Order via InstantActions to: %s", topic)
-	utils.Logger.Infof("📤 Cancel Details: OrderID=%s, ActionID=%s", orderID, actionID)
+// UsesRobotTransport 로봇과 MQTT 대신 자체 전송 계층으로 통신하는지 여부
+func (h *DirectActionHandler) UsesRobotTransport() bool {
+	_, ok := h.protocol.(RobotTransport)
+	return ok
+}
 
-	if err := h.mqttClient.Publish(topic, 0, false, msgData); err != nil {
-		return err
+// RunRobotTransport 로봇 전송 계층 실행 (MQTT 프로토콜이면 즉시 반환, ctx 종료 시 반환)
+func (h *DirectActionHandler) RunRobotTransport(ctx context.Context) {
+	transport, ok := h.protocol.(RobotTransport)
+	if !ok {
+		return
 	}
-
-	utils.Logger.Infof("✅ Cancel order sent successfully via InstantActions")
-	return nil
+	transport.Run(ctx, h)
 }
 
 // processActionStates 액션 상태 처리
-func (h *DirectActionHandler) processActionStates(orderID, originalCommand string, actionStates []interface{}) {
+func (h *DirectActionHandler) processActionStates(order *OrderInfo, actionStates []ActionState, fatalError *RobotError) {
+	orderID := order.OrderID
+
 	// 액션 상태들을 확인하여 전체 상태 결정
 	statusCounts := make(map[string]int)
 
 	for _, actionState := range actionStates {
-		if actionMap, ok := actionState.(map[string]interface{}); ok {
-			if actionStatus, hasStatus := actionMap["actionStatus"].(string); hasStatus {
-				statusCounts[actionStatus]++
-				if actionID, _ := actionMap["actionId"].(string); actionID != "" {
-					utils.Logger.Infof("🔍 Action %s status: %s", actionID, actionStatus)
-				}
-			}
+		h.trackActionState(order, actionState)
+		statusCounts[actionState.ActionStatus]++
+		if actionState.ActionID != "" {
+			h.orderLog(order).WithFields(logrus.Fields{
+				"actionId":     actionState.ActionID,
+				"actionStatus": actionState.ActionStatus,
+			}).Info("Action status")
 		}
 	}
+	order.Progress = computeProgress(actionStates)
 
 	// 상태에 따른 응답 결정 및 전송 (우선순위 순서)
 	switch {
 	case statusCounts["FAILED"] > 0:
-		utils.Logger.Errorf("❌ Action failed for OrderID: %s", orderID)
-		h.sendPLCResponse(originalCommand, types.PLCStatusFailed)
-		delete(h.activeOrders, orderID)
+		h.orderLog(order).Error("Action failed")
+		if h.config.FaultLatchEnabled && fatalError != nil {
+			h.latchFault(orderID, order.Command, fatalError)
+		}
+		h.respondOrder(order, types.PLCStatusFailed)
+		h.finishOrder(order)
 	case statusCounts["FINISHED"] > 0 && statusCounts["RUNNING"] == 0 && statusCounts["INITIALIZING"] == 0 && statusCounts["WAITING"] == 0:
-		utils.Logger.Infof("✅ All actions finished for OrderID: %s", orderID)
-		h.sendPLCResponse(originalCommand, types.PLCStatusSuccess)
-		delete(h.activeOrders, orderID)
+		h.orderLog(order).Info("All actions finished")
+		h.respondOrder(order, types.PLCStatusSuccess)
+		h.finishOrder(order)
+	case statusCounts["RUNNING"] > 0 && awaitingTrigger(order):
+		h.orderLog(order).Info("Waiting for PLC trigger release")
+		h.respondOrder(order, types.PLCStatusWaiting)
 	case statusCounts["RUNNING"] > 0:
-		utils.Logger.Infof("🏃 Action running for OrderID: %s", orderID)
-		h.sendPLCResponse(originalCommand, types.PLCStatusRunning)
+		h.orderLog(order).WithField("progress", order.Progress).Info("Action running")
+		h.respondOrder(order, types.PLCStatusRunning)
 	case statusCounts["INITIALIZING"] > 0:
-		utils.Logger.Infof("🔄 Action initializing for OrderID: %s", orderID)
-		h.sendPLCResponse(originalCommand, types.PLCStatusInitializing)
+		h.orderLog(order).Info("Action initializing")
+		h.respondOrder(order, types.PLCStatusInitializing)
 	case statusCounts["WAITING"] > 0:
-		utils.Logger.Infof("⏳ Action waiting for OrderID: %s", orderID)
-		h.sendPLCResponse(originalCommand, types.PLCStatusWaiting)
+		h.orderLog(order).Info("Action waiting")
+		h.respondOrder(order, types.PLCStatusWaiting)
 	}
 }
 
 // processCanceledOrderStates 취소된 오더 상태 처리 (PLC 취소 요청 후)
-func (h *DirectActionHandler) processCanceledOrderStates(orderID, originalCancelCommand string, actionStates []interface{}) {
+func (h *DirectActionHandler) processCanceledOrderStates(order *OrderInfo, actionStates []ActionState) {
 	// 취소된 오더의 액션 상태에 따라 취소 명령에 대한 응답 처리
 	for _, actionState := range actionStates {
-		if actionMap, ok := actionState.(map[string]interface{}); ok {
-			if actionStatus, hasStatus := actionMap["actionStatus"].(string); hasStatus {
-				actionID, _ := actionMap["actionId"].(string)
-				utils.Logger.Infof("🔍 Canceled Order Action %s status: %s", actionID, actionStatus)
-
-				switch actionStatus {
-				case "FAILED":
-					utils.Logger.Infof("✅ Canceled order action failed as expected: %s", orderID)
-					h.sendPLCResponse(originalCancelCommand, types.PLCStatusFailed)
-					delete(h.canceledOrders, orderID)
-					return
-				case "FINISHED":
-					utils.Logger.Infof("✅ Canceled order action finished: %s", orderID)
-					h.sendPLCResponse(originalCancelCommand, types.PLCStatusSuccess)
-					delete(h.canceledOrders, orderID)
-					return
-				}
-			}
+		h.trackActionState(order, actionState)
+		h.orderLog(order).WithFields(logrus.Fields{
+			"actionId":     actionState.ActionID,
+			"actionStatus": actionState.ActionStatus,
+		}).Info("Canceled order action status")
+
+		switch actionState.ActionStatus {
+		case "FAILED":
+			h.orderLog(order).Info("Canceled order action failed as expected")
+			h.respondOrder(order, types.PLCStatusFailed)
+			h.finishOrder(order)
+			return
+		case "FINISHED":
+			h.orderLog(order).Info("Canceled order action finished")
+			h.respondOrder(order, types.PLCStatusSuccess)
+			h.finishOrder(order)
+			return
 		}
 	}
 }
 
 // sendPLCResponse PLC에 응답 전송 (구조체 사용)
 func (h *DirectActionHandler) sendPLCResponse(command, status string) {
-	// PLC 응답 구조체 생성
-	plcResponse := types.NewPLCResponse(command, status, "")
+	h.publishPLCResponse(types.NewPLCResponse(command, status, ""))
+}
 
-	// 기존 형식의 응답 문자열 생성 (COMMAND:STATUS)
-	responseStr := plcResponse.ToResponseString()
+// sendPLCFailure PLC에 실패 응답 전송 (JSON 형식이면 오류 메시지 포함, 허용 목록에 없는 이름은 U)
+func (h *DirectActionHandler) sendPLCFailure(command string, err error) {
+	status := types.PLCStatusFailed
+	if errors.Is(err, ErrActionNotAllowed) {
+		status = types.PLCStatusNotAllowed
+	}
+	h.publishPLCResponse(types.NewPLCResponse(command, status, err.Error()))
+}
+
+// publishPLCResponse 설정된 형식(PLC_RESPONSE_FORMAT)으로 응답 발행 및 이벤트 기록
+func (h *DirectActionHandler) publishPLCResponse(plcResponse *types.PLCResponse) {
+	// 기본은 기존 형식의 응답 문자열 (COMMAND:STATUS)
+	if plcResponse.CorrelationID == "" {
+		plcResponse.CorrelationID = h.correlationID
+	}
+	if plcResponse.ReplyChannel == "" {
+		plcResponse.ReplyChannel = h.replyChannel
+	}
+	responseTopic := h.responseTopic(plcResponse.ReplyChannel)
+	responseStr := plcResponse.Format(h.config.PlcResponseFormat)
+	status := plcResponse.Status
+	h.commandLog(plcResponse.Command).WithFields(logrus.Fields{
+		"correlationId": plcResponse.CorrelationID,
+		"orderId":       plcResponse.OrderID,
+		"status":        status,
+	}).Info("PLC response")
+
+	// 응답 훅이 발행하지 않도록 하면 이벤트도 기록하지 않음
+	responseStr, publish := h.applyResponseHook(responseTopic, responseStr)
+	if !publish {
+		h.commandLog(plcResponse.Command).WithField("status", status).Debug("PLC response dropped by hook")
+		return
+	}
 
-	utils.Logger.Infof("📤 MQTT PUBLISH")
-	utils.Logger.Infof("📤 Topic   : %s", h.config.PlcResponseTopic)
-	utils.Logger.Infof("📤 QoS    : %d, Retained: %v", 0, false)
-	utils.Logger.Infof("📤 Payload : %s", responseStr)
+	// Sparkplug B 모드에서는 이벤트를 받은 노드가 NDATA로 발행
+	if !h.config.SparkplugEnabled {
+		// MQTTClient.Publish에서 이미 성공/실패 로그를 모두 출력하므로 여기서는 제거
+		h.mqttClient.Publish(responseTopic, 0, false, responseStr)
+	}
 
-	// MQTTClient.Publish에서 이미 성공/실패 로그를 모두 출력하므로 여기서는 제거
-	h.mqttClient.Publish(h.config.PlcResponseTopic, 0, false, responseStr)
+	h.publishEvent(events.Event{
+		Type:          events.TypePLCResponse,
+		Command:       plcResponse.Command,
+		OrderID:       plcResponse.OrderID,
+		Status:        status,
+		Message:       responseStr,
+		CorrelationID: plcResponse.CorrelationID,
+	})
 }
 
 // extractBaseCommand 기본 명령 추출
@@ -528,34 +1026,27 @@ func (h *DirectActionHandler) extractBaseCommand(command string) string {
 	return command
 }
 
-// parseArmParam 팔 파라미터 파싱
-func (h *DirectActionHandler) parseArmParam(armParam string) string {
-	switch armParam {
-	case "R", "":
-		return "right"
-	case "L":
-		return "left"
-	default:
-		return "right" // 기본값
-	}
+// armValues PLC 팔 코드 -> 로봇 arm 파라미터 값
+var armValues = map[string]string{
+	"":  "right", // 생략 시 오른팔
+	"R": "right",
+	"L": "left",
+	"B": "both",
 }
 
-// ID 생성 헬퍼 함수들
-func (h *DirectActionHandler) generateOrderID() string {
-	return fmt.Sprintf("%016x", time.Now().UnixNano())
-}
-
-func (h *DirectActionHandler) generateNodeID() string {
-	return fmt.Sprintf("%016x", time.Now().UnixNano()+1)
-}
-
-func (h *DirectActionHandler) generateActionID() string {
-	return fmt.Sprintf("%016x", time.Now().UnixNano()+2)
+// parseArm 팔 파라미터 파싱 (알 수 없는 값은 오류)
+func parseArm(armParam string) (string, error) {
+	value, exists := armValues[armParam]
+	if !exists {
+		return "", fmt.Errorf("unknown arm %q, expected R, L or B", armParam)
+	}
+	return value, nil
 }
 
-var headerIDCounter int64
-
-func (h *DirectActionHandler) getNextHeaderID() int64 {
-	headerIDCounter++
-	return headerIDCounter
+// generateOrderID 오더 ID 생성 ({prefix}-{ID}, 시스템 간 로그 상관관계 추적용)
+func (h *DirectActionHandler) generateOrderID() string {
+	if h.config.OrderIDPrefix == "" {
+		return h.ids.NewID()
+	}
+	return h.config.OrderIDPrefix + "-" + h.ids.NewID()
 }
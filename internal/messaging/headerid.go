@@ -0,0 +1,98 @@
+// internal/messaging/headerid.go - Persistent VDA5050 Header ID Counters
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/utils"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// headerIDStore 토픽별 VDA5050 headerId 카운터 (동시 접근 보호)
+// 파일 경로가 있으면 증가할 때마다 저장하여 재시작 후에도 이어서 증가
+type headerIDStore struct {
+	mu       sync.Mutex
+	path     string
+	counters map[string]int64 // 토픽 -> 마지막 headerId
+}
+
+// headerIDStores 파일 경로별 공유 저장소 (같은 파일을 쓰는 여러 로봇 경로가 하나의 저장소 사용)
+var headerIDStores = struct {
+	mu     sync.Mutex
+	byPath map[string]*headerIDStore
+}{byPath: make(map[string]*headerIDStore)}
+
+// openHeaderIDStore 헤더 ID 저장소 열기 (path가 비어있으면 메모리만 사용, 파일이 없으면 0부터 시작)
+func openHeaderIDStore(path string) (*headerIDStore, error) {
+	if path == "" {
+		return &headerIDStore{counters: make(map[string]int64)}, nil
+	}
+
+	headerIDStores.mu.Lock()
+	defer headerIDStores.mu.Unlock()
+
+	if store, exists := headerIDStores.byPath[path]; exists {
+		return store, nil
+	}
+
+	store := &headerIDStore{path: path, counters: make(map[string]int64)}
+	data, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return nil, fmt.Errorf("failed to read header ID file: %v", err)
+	default:
+		if err := json.Unmarshal(data, &store.counters); err != nil {
+			return nil, fmt.Errorf("failed to parse header ID file %s: %v", path, err)
+		}
+		utils.Logger.Infof("🔢 Restored header IDs for %d topic(s) from %s", len(store.counters), path)
+	}
+
+	headerIDStores.byPath[path] = store
+	return store, nil
+}
+
+// Next 토픽의 다음 headerId
+// 저장 실패 시에도 메모리 카운터는 증가 (메시지 전송을 막지 않음)
+func (s *headerIDStore) Next(topic string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.counters[topic]++
+	if s.path != "" {
+		if err := s.save(); err != nil {
+			utils.Logger.Errorf("❌ Failed to persist header IDs: %v", err)
+		}
+	}
+	return s.counters[topic]
+}
+
+// save 임시 파일에 쓴 뒤 교체 (쓰는 도중 종료되어도 이전 내용 유지)
+func (s *headerIDStore) save() error {
+	data, err := json.Marshal(s.counters)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,107 @@
+// internal/messaging/heartbeat.go - PLC Heartbeat Watchdog
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"mqtt-bridge/internal/alert"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// heartbeatCheckInterval PLC 하트비트 경과 시간 확인 주기
+const heartbeatCheckInterval = time.Second
+
+// RecordPLCHeartbeat PLC 하트비트 수신 기록 (끊김 상태였으면 복구)
+func (h *DirectActionHandler) RecordPLCHeartbeat() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.lastPLCHeartbeat = time.Now()
+	if !h.plcHeartbeatLost {
+		return
+	}
+	h.plcHeartbeatLost = false
+	utils.Logger.Infof("💓 PLC heartbeat restored for robot %s", h.config.RobotSerialNumber)
+	h.publishEvent(events.Event{
+		Type:   events.TypePLCHeartbeat,
+		Status: "restored",
+	})
+}
+
+// RunPLCHeartbeatWatchdog PLC 하트비트 주기 확인 (PLC_HEARTBEAT_TIMEOUT 동안 없으면 알림, 설정 시 진행 중인 오더 취소)
+// 시작 시점부터 시간을 재므로 PLC가 시작 후 한 번도 보내지 않아도 감지, ctx 종료 시 반환
+func (h *DirectActionHandler) RunPLCHeartbeatWatchdog(ctx context.Context) {
+	h.mu.Lock()
+	h.lastPLCHeartbeat = time.Now()
+	h.mu.Unlock()
+
+	ticker := time.NewTicker(heartbeatCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.mu.Lock()
+			lostAlert := h.checkPLCHeartbeat(time.Now())
+			webhook := h.escalationWebhook
+			h.mu.Unlock()
+
+			// 웹훅은 잠금 밖에서 전송 (느린 수신자가 명령 처리를 막지 않도록)
+			if lostAlert != nil {
+				if err := webhook.Send(ctx, *lostAlert); err != nil {
+					utils.Logger.Errorf("❌ Failed to send PLC heartbeat alert: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// checkPLCHeartbeat 하트비트 시간 초과 확인, 처음 초과한 경우 알림 반환 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) checkPLCHeartbeat(now time.Time) *alert.Alert {
+	timeout := h.config.PLCHeartbeatTimeout
+	elapsed := now.Sub(h.lastPLCHeartbeat)
+	if h.plcHeartbeatLost || timeout <= 0 || elapsed < timeout {
+		return nil
+	}
+	h.plcHeartbeatLost = true
+
+	summary := fmt.Sprintf("No PLC heartbeat for %s (timeout %s)", elapsed.Round(time.Second), timeout)
+	utils.Logger.Errorf("💔 %s - robot %s", summary, h.config.RobotSerialNumber)
+	if h.config.PLCHeartbeatCancelOrders {
+		h.cancelForLostPLC()
+	}
+
+	h.publishEvent(events.Event{
+		Type:    events.TypePLCHeartbeat,
+		Status:  "lost",
+		Message: summary,
+	})
+	return &alert.Alert{
+		Source:   "plc-heartbeat",
+		Severity: alert.SeverityCritical,
+		Robot:    h.config.RobotSerialNumber,
+		Summary:  summary,
+	}
+}
+
+// cancelForLostPLC PLC가 결과를 받을 수 없으므로 진행 중/대기 중인 작업 중단 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) cancelForLostPLC() {
+	for _, queued := range h.queuedCommands {
+		h.sendPLCFailure(queued.Command, fmt.Errorf("discarded: PLC heartbeat lost"))
+	}
+	h.queuedCommands = nil
+	for _, pending := range h.pendingOrders {
+		h.rejectPendingOrder(pending, "PLC heartbeat lost")
+	}
+	h.stopChains("PLC heartbeat lost")
+	for _, order := range h.activeOrders {
+		h.orderLog(order).Warn("Canceling order: PLC heartbeat lost")
+		if err := h.cancelOrder(order, h.extractBaseCommand(order.Command)+":C"); err != nil {
+			utils.Logger.Errorf("❌ PLC heartbeat cancel failed for OrderID %s: %v", order.OrderID, err)
+		}
+	}
+}
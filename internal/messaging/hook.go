@@ -0,0 +1,51 @@
+// internal/messaging/hook.go - Command/Response Hook Integration (COMMAND_HOOK)
+package messaging
+
+import (
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/hook"
+)
+
+// SetHook 명령/응답 훅 설정 (모든 경로 핸들러가 같은 훅 스크립트 공유, nil이면 비활성화)
+func (h *DirectActionHandler) SetHook(script *hook.Script) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.hook = script
+}
+
+// applyCommandHook 수신 명령을 훅으로 변환 (잠금 보유 상태에서 호출, 거부되면 결과 반환)
+// 비상 정지 명령은 훅을 거치지 않음
+func (h *DirectActionHandler) applyCommandHook(commandStr string) (string, *CommandResult) {
+	if h.hook == nil {
+		return commandStr, nil
+	}
+
+	rewritten, err := h.hook.Command(commandStr)
+	if err == nil {
+		if rewritten != commandStr {
+			h.commandLog(commandStr).WithField("rewritten", rewritten).Info("Command rewritten by hook")
+		}
+		return rewritten, nil
+	}
+
+	h.commandLog(commandStr).Warnf("Command rejected by hook: %v", err)
+	h.sendPLCFailure(commandStr, err)
+
+	result := newCommandResult(commandStr, "", err)
+	h.recordCommand(result)
+	h.publishEvent(events.Event{
+		Type:    events.TypeCommandRejected,
+		Command: commandStr,
+		Message: result.Reason,
+	})
+	h.publishDeadLetter(result)
+	return commandStr, result
+}
+
+// applyResponseHook 발행할 PLC 응답을 훅으로 변환 (잠금 보유 상태에서 호출, false면 발행 안 함)
+func (h *DirectActionHandler) applyResponseHook(topic, payload string) (string, bool) {
+	if h.hook == nil {
+		return payload, true
+	}
+	return h.hook.Response(topic, payload)
+}
@@ -0,0 +1,54 @@
+// internal/messaging/intake.go - Pause/Resume PLC Command Intake
+package messaging
+
+import (
+	"errors"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/utils"
+)
+
+// ErrIntakePaused 운영자가 명령 수신을 일시 중지함
+var ErrIntakePaused = errors.New("command intake paused")
+
+// SetIntakePaused 명령 수신 일시 중지/재개 (중지 중 새 명령은 F 응답, 취소/비상 정지/트리거 해제는 처리)
+func (h *DirectActionHandler) SetIntakePaused(paused bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.intakePaused == paused {
+		return
+	}
+	h.intakePaused = paused
+	if paused {
+		utils.Logger.Warnf("⏸️ Robot %s command intake paused", h.config.RobotSerialNumber)
+		return
+	}
+	utils.Logger.Infof("▶️ Robot %s command intake resumed", h.config.RobotSerialNumber)
+}
+
+// IntakePaused 명령 수신 일시 중지 여부
+func (h *DirectActionHandler) IntakePaused() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.intakePaused
+}
+
+// checkIntake 명령 수신 중지 확인 (잠금 보유 상태에서 호출, 처리해도 되면 nil)
+func (h *DirectActionHandler) checkIntake(commandStr string) *CommandResult {
+	if !h.intakePaused || isRateLimitExempt(commandStr) {
+		return nil
+	}
+
+	h.commandLog(commandStr).Warn("Command rejected - intake paused")
+	h.sendPLCFailure(commandStr, ErrIntakePaused)
+
+	result := newCommandResult(commandStr, "", ErrIntakePaused)
+	h.recordCommand(result)
+	h.publishEvent(events.Event{
+		Type:    events.TypeCommandRejected,
+		Command: commandStr,
+		Message: result.Reason,
+	})
+	h.publishDeadLetter(result)
+	return result
+}
@@ -0,0 +1,87 @@
+// internal/messaging/maps.go - VDA5050 Map Management Instant Actions
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+)
+
+// 지도 관리 InstantAction (VDA5050 2.1)
+const (
+	MapActionDownload = "downloadMap"
+	MapActionEnable   = "enableMap"
+	MapActionDelete   = "deleteMap"
+)
+
+// MapActionRequest 지도 관리 요청 (관리자 API)
+type MapActionRequest struct {
+	MapID           string `json:"mapId"`
+	MapVersion      string `json:"mapVersion"`
+	MapDownloadLink string `json:"mapDownloadLink,omitempty"` // downloadMap 전용
+	MapHash         string `json:"mapHash,omitempty"`         // downloadMap 전용 (선택)
+}
+
+// MapID 로봇에 적용되는 기본 지도 ID
+func (h *DirectActionHandler) MapID() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.config.RobotMapID()
+}
+
+// SendMapAction 지도 다운로드/활성화/삭제 InstantAction 전송, 생성된 액션 ID 반환
+// 결과는 로봇 state의 actionStates로 보고됨 (이벤트 스트림의 action.state)
+func (h *DirectActionHandler) SendMapAction(actionType string, req MapActionRequest) (string, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if req.MapID == "" || req.MapVersion == "" {
+		return "", fmt.Errorf("mapId and mapVersion are required")
+	}
+
+	parameters := []types.ActionParameter{
+		{Key: "mapId", Value: req.MapID},
+		{Key: "mapVersion", Value: req.MapVersion},
+	}
+	blockingType := types.BlockingTypeNone
+	switch actionType {
+	case MapActionDownload:
+		if req.MapDownloadLink == "" {
+			return "", fmt.Errorf("mapDownloadLink is required for %s", actionType)
+		}
+		parameters = append(parameters, types.ActionParameter{Key: "mapDownloadLink", Value: req.MapDownloadLink})
+		if req.MapHash != "" {
+			parameters = append(parameters, types.ActionParameter{Key: "mapHash", Value: req.MapHash})
+		}
+	case MapActionEnable:
+		// 같은 지도의 다른 버전은 로봇이 비활성화, 주행 중 전환 방지
+		blockingType = types.BlockingTypeHard
+	case MapActionDelete:
+	default:
+		return "", fmt.Errorf("unknown map action %q", actionType)
+	}
+
+	if send, err := h.allowInstantAction(actionType, req.MapID); !send {
+		if err == nil {
+			err = fmt.Errorf("%s for map %s already in progress", actionType, req.MapID)
+		}
+		return "", err
+	}
+
+	message, err := h.protocol.BuildInstantAction(InstantActionRequest{
+		ActionType:   actionType,
+		BlockingType: blockingType,
+		Parameters:   parameters,
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := h.sendToRobot(message); err != nil {
+		return "", fmt.Errorf("failed to send %s: %v", actionType, err)
+	}
+
+	utils.Logger.Infof("🗺️ %s sent to %s (map %s v%s, ActionID: %s)",
+		actionType, h.config.RobotSerialNumber, req.MapID, req.MapVersion, message.ActionID)
+	return message.ActionID, nil
+}
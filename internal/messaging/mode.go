@@ -0,0 +1,64 @@
+// internal/messaging/mode.go - Robot Operating Mode Gating
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+)
+
+// 운전 모드 정책 (OPERATING_MODE_POLICY, 로봇이 MANUAL/SERVICE/TEACHIN 모드일 때)
+const (
+	OperatingModePolicyOff    = "off"    // 확인하지 않음
+	OperatingModePolicyReject = "reject" // M 응답으로 거부
+	OperatingModePolicyQueue  = "queue"  // AUTOMATIC(또는 SEMIAUTOMATIC)으로 돌아올 때까지 대기열에서 대기
+)
+
+// ErrNotAutomatic 로봇이 자동 운전 모드가 아니어서 거부된 명령 (M 응답)
+var ErrNotAutomatic = errors.New("robot not in automatic mode")
+
+// manualOperatingModes 브릿지 오더를 실행하지 않는 운전 모드 (VDA5050 operatingMode)
+var manualOperatingModes = map[string]bool{
+	"MANUAL":  true,
+	"SERVICE": true,
+	"TEACHIN": true,
+}
+
+// recordOperatingMode 로봇이 보고한 운전 모드 기록 (잠금 보유 상태에서 호출, 보고하지 않으면 유지)
+func (h *DirectActionHandler) recordOperatingMode(state *RobotState) {
+	if state.OperatingMode == "" || state.OperatingMode == h.operatingMode {
+		return
+	}
+	utils.Logger.Infof("🕹️ Robot %s operating mode: %s -> %s", h.config.RobotSerialNumber, displayMode(h.operatingMode), state.OperatingMode)
+	h.operatingMode = state.OperatingMode
+}
+
+// displayMode 로그용 모드 이름 (보고 전이면 UNKNOWN)
+func displayMode(mode string) string {
+	if mode == "" {
+		return "UNKNOWN"
+	}
+	return mode
+}
+
+// acceptsOrders 현재 운전 모드에서 오더를 발행해도 되는지 확인 (정책이 off거나 모드를 모르면 허용)
+func (h *DirectActionHandler) acceptsOrders() bool {
+	return h.config.OperatingModePolicy == OperatingModePolicyOff || !manualOperatingModes[h.operatingMode]
+}
+
+// gateOperatingMode 자동 운전 모드가 아니면 정책에 따라 대기열 추가 또는 M 응답 (잠금 보유 상태에서 호출)
+// 바로 처리해도 되면 nil 반환
+func (h *DirectActionHandler) gateOperatingMode(commandStr string, priority int) *CommandResult {
+	if h.acceptsOrders() {
+		return nil
+	}
+	if h.config.OperatingModePolicy == OperatingModePolicyQueue {
+		return h.enqueueCommand(commandStr, priority)
+	}
+
+	utils.Logger.Errorf("❌ Command rejected - robot in %s mode: %s", h.operatingMode, commandStr)
+	err := fmt.Errorf("%w (%s)", ErrNotAutomatic, h.operatingMode)
+	h.publishPLCResponse(types.NewPLCResponse(commandStr, types.PLCStatusManualMode, err.Error()))
+	return newCommandResult(commandStr, "", err)
+}
@@ -0,0 +1,61 @@
+// internal/messaging/normalize.go - PLC Payload Normalization (charset, BOM, whitespace, case)
+package messaging
+
+import (
+	"mqtt-bridge/internal/config"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// utf8BOM UTF-8 바이트 순서 표시
+const utf8BOM = "\xEF\xBB\xBF"
+
+// normalizePayload 게이트웨이 특성(문자셋, BOM, 공백, 대소문자)을 정리한 PLC 페이로드 (명령 파싱 전)
+func normalizePayload(cfg *config.Config, payload string) string {
+	// 문자셋 변환 전에 제거 (Latin-1로 해석하면 "ï»¿"가 됨)
+	if cfg.PayloadStripBOM {
+		payload = strings.TrimPrefix(payload, utf8BOM)
+	}
+
+	switch cfg.PayloadCharset {
+	case "latin-1":
+		payload = decodeLatin1(payload)
+	case "auto":
+		// UTF-8로 해석되지 않으면 Latin-1 게이트웨이로 간주
+		if !utf8.ValidString(payload) {
+			payload = decodeLatin1(payload)
+		}
+	}
+
+	// NUL 패딩(고정 길이 PLC 문자열), NBSP 등 유니코드 공백까지 제거
+	payload = strings.TrimFunc(payload, func(r rune) bool {
+		return unicode.IsSpace(r) || r == 0
+	})
+	if cfg.PayloadRemoveWhitespace {
+		payload = strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) || r == 0 {
+				return -1
+			}
+			return r
+		}, payload)
+	}
+
+	switch cfg.PayloadCase {
+	case "upper":
+		payload = strings.ToUpper(payload)
+	case "lower":
+		payload = strings.ToLower(payload)
+	}
+	return payload
+}
+
+// decodeLatin1 ISO-8859-1 바이트를 UTF-8 문자열로 변환 (바이트 하나 = 코드 포인트 하나)
+func decodeLatin1(payload string) string {
+	var sb strings.Builder
+	sb.Grow(len(payload))
+	for i := 0; i < len(payload); i++ {
+		sb.WriteRune(rune(payload[i]))
+	}
+	return sb.String()
+}
@@ -0,0 +1,297 @@
+// internal/messaging/orders.go - Order Tracking
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// maxRecentCommands 보관할 최근 명령 수
+const maxRecentCommands = 100
+
+// maxRecentOrders 보관할 최근 종료 오더 수
+const maxRecentOrders = 100
+
+// OrderInfo 추적 중인 오더 정보
+type OrderInfo struct {
+	OrderID       string    `json:"orderId"`
+	Command       string    `json:"command"`                 // 원본 PLC 명령
+	CancelCommand string    `json:"cancelCommand,omitempty"` // 취소 명령 (취소된 오더)
+	Status        string    `json:"status"`                  // 마지막 PLC 응답 상태
+	Canceled      bool      `json:"canceled"`
+	Progress      int       `json:"progress"` // 진행률 (0-100, progress.go 참고)
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+	CorrelationID string    `json:"correlationId,omitempty"` // 오더를 만든 PLC 명령의 상관관계 ID
+	ActionType    string    `json:"actionType,omitempty"`    // 오더 액션 타입 (메트릭 라벨)
+	ReplyChannel  string    `json:"replyChannel,omitempty"`  // 오더를 만든 PLC 명령의 응답 채널 (reply.go)
+
+	actionStatuses  map[string]string // actionID -> 마지막 액션 상태 (전이 감지용)
+	publishedAt     time.Time         // 로봇으로 발행한 시간 (시간 초과 단계 기준)
+	escalationStage int               // 진행된 시간 초과 단계
+
+	triggerActionIDs []string // PLC 해제를 기다리는 waitForTrigger 액션 (trigger.go)
+}
+
+// newOrderInfo 새 오더 정보 생성
+func newOrderInfo(orderID, command string) *OrderInfo {
+	now := time.Now()
+	return &OrderInfo{
+		OrderID:        orderID,
+		Command:        command,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		actionStatuses: make(map[string]string),
+	}
+}
+
+// responseCommand PLC 응답 대상 명령 반환 (취소된 오더는 취소 명령)
+func (o *OrderInfo) responseCommand() string {
+	if o.Canceled {
+		return o.CancelCommand
+	}
+	return o.Command
+}
+
+// respondOrder 오더 상태 갱신, PLC 응답 전송 및 상태 전이 이벤트 발행
+// 최종 상태(S/F)는 idempotency key로 중복 전송을 억제 (PLC 단계 로직 재실행 방지)
+func (h *DirectActionHandler) respondOrder(order *OrderInfo, status string) {
+	h.respondOrderMessage(order, status, "")
+}
+
+// respondOrderMessage respondOrder와 같으나 PLC 응답에 사유 포함 (JSON 형식이면 errorMessage)
+func (h *DirectActionHandler) respondOrderMessage(order *OrderInfo, status, message string) {
+	if types.IsTerminalStatus(status) && !h.responseDedup.shouldSend(responseKey(order.OrderID, order.responseCommand(), status)) {
+		h.orderLog(order).WithField("status", status).Warn("Suppressed duplicate terminal response")
+		return
+	}
+
+	if order.Status != status {
+		h.orderLog(order).WithFields(logrus.Fields{"from": order.Status, "to": status}).Info("Order status changed")
+	}
+	order.Status = status
+	order.UpdatedAt = time.Now()
+	plcResponse := types.NewPLCResponse(order.responseCommand(), status, message)
+	plcResponse.OrderID = order.OrderID
+	plcResponse.CorrelationID = order.CorrelationID
+	plcResponse.ReplyChannel = order.ReplyChannel
+	if status == types.PLCStatusRunning && h.config.PlcResponseProgress {
+		progress := order.Progress
+		plcResponse.Progress = &progress
+	}
+	h.publishPLCResponse(plcResponse)
+
+	h.publishEvent(events.Event{
+		Type:          events.TypeOrderStatus,
+		Command:       order.responseCommand(),
+		OrderID:       order.OrderID,
+		Status:        status,
+		Message:       message,
+		CorrelationID: order.CorrelationID,
+		ActionType:    order.ActionType,
+	})
+}
+
+// trackActionState 액션 상태가 바뀐 경우에만 액션 상태 전이 이벤트 발행
+func (h *DirectActionHandler) trackActionState(order *OrderInfo, actionState ActionState) {
+	if actionState.ActionID == "" || order.actionStatuses[actionState.ActionID] == actionState.ActionStatus {
+		return
+	}
+	order.actionStatuses[actionState.ActionID] = actionState.ActionStatus
+
+	h.publishEvent(events.Event{
+		Type:     events.TypeActionState,
+		Command:  order.responseCommand(),
+		OrderID:  order.OrderID,
+		ActionID: actionState.ActionID,
+		Status:   actionState.ActionStatus,
+		Message:  actionState.ResultDescription,
+
+		CorrelationID: order.CorrelationID,
+	})
+}
+
+// finishOrder 종료된 오더를 추적 맵에서 제거하고 최근 오더로 보관
+func (h *DirectActionHandler) finishOrder(order *OrderInfo) {
+	h.orderLog(order).WithField("status", order.Status).Info("Order finished")
+
+	delete(h.activeOrders, order.OrderID)
+	delete(h.canceledOrders, order.OrderID)
+	h.trackEmergencyStop(order)
+	if order.escalationStage >= escalationUnhealthy {
+		h.clearUnhealthy("escalated order " + order.OrderID + " finished")
+	}
+
+	h.recentOrders = append(h.recentOrders, order)
+	if len(h.recentOrders) > maxRecentOrders {
+		h.recentOrders = h.recentOrders[len(h.recentOrders)-maxRecentOrders:]
+	}
+}
+
+// robotLog 로봇 관련 구조화 로그 엔트리 (component, manufacturer, serialNumber)
+func (h *DirectActionHandler) robotLog() *logrus.Entry {
+	return utils.Component("handler").WithFields(logrus.Fields{
+		"manufacturer": h.config.RobotManufacturer,
+		"serialNumber": h.config.RobotSerialNumber,
+	})
+}
+
+// orderLog 오더 관련 구조화 로그 엔트리 (robotLog + orderId, command, correlationId)
+func (h *DirectActionHandler) orderLog(order *OrderInfo) *logrus.Entry {
+	return h.robotLog().WithFields(logrus.Fields{
+		"orderId":       order.OrderID,
+		"command":       order.responseCommand(),
+		"correlationId": order.CorrelationID,
+	})
+}
+
+// GetOrders 활성/취소된 오더 목록 반환 (생성 시간 순)
+func (h *DirectActionHandler) GetOrders() []OrderInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	orders := make([]OrderInfo, 0, len(h.activeOrders)+len(h.canceledOrders))
+	for _, order := range h.activeOrders {
+		orders = append(orders, *order)
+	}
+	for _, order := range h.canceledOrders {
+		orders = append(orders, *order)
+	}
+
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].CreatedAt.Before(orders[j].CreatedAt)
+	})
+	return orders
+}
+
+// GetOrder 오더 조회 (활성, 취소, 확인 대기, 최근 종료 오더)
+func (h *DirectActionHandler) GetOrder(orderID string) (OrderInfo, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if order, exists := h.activeOrders[orderID]; exists {
+		return *order, true
+	}
+	if order, exists := h.canceledOrders[orderID]; exists {
+		return *order, true
+	}
+	if pending, exists := h.pendingOrders[orderID]; exists {
+		return *pending.order, true
+	}
+	for _, order := range h.recentOrders {
+		if order.OrderID == orderID {
+			return *order, true
+		}
+	}
+	return OrderInfo{}, false
+}
+
+// ErrOrderNotFound 조회한 오더가 없음
+var ErrOrderNotFound = errors.New("order not found")
+
+// WatchOrder 오더의 상태 전이를 최종 상태까지 send로 전달 (SSE, gRPC 스트리밍 공용)
+func (h *DirectActionHandler) WatchOrder(ctx context.Context, orderID string, send func(events.Event) error) error {
+	// 현재 상태 조회 전에 구독하여 그 사이의 전이를 놓치지 않음
+	eventCh, unsubscribe := h.eventBus.Subscribe(32)
+	defer unsubscribe()
+
+	order, exists := h.GetOrder(orderID)
+	if !exists {
+		return ErrOrderNotFound
+	}
+
+	// 현재 상태 먼저 전달
+	if order.Status != "" {
+		if err := send(events.Event{
+			Type:    events.TypeOrderStatus,
+			Time:    order.UpdatedAt,
+			Command: order.responseCommand(),
+			OrderID: order.OrderID,
+			Status:  order.Status,
+		}); err != nil {
+			return err
+		}
+		if types.IsTerminalStatus(order.Status) {
+			return nil
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-eventCh:
+			if !ok {
+				return nil
+			}
+			if event.Type != events.TypeOrderStatus || event.OrderID != orderID {
+				continue
+			}
+			if err := send(event); err != nil {
+				return err
+			}
+			if types.IsTerminalStatus(event.Status) {
+				return nil
+			}
+		}
+	}
+}
+
+// CancelOrder 오더 수동 취소 (관리자 API)
+func (h *DirectActionHandler) CancelOrder(orderID string) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	order, exists := h.activeOrders[orderID]
+	if !exists {
+		return fmt.Errorf("no active order found: %s", orderID)
+	}
+
+	h.orderLog(order).Info("Manual cancel requested")
+	return h.cancelOrder(order, h.extractBaseCommand(order.Command)+":C")
+}
+
+// cancelOrder 취소 명령 전송 후 활성 오더를 취소된 오더로 이동
+func (h *DirectActionHandler) cancelOrder(order *OrderInfo, cancelCommand string) error {
+	if err := h.sendCancelOrder(order.OrderID); err != nil {
+		return err
+	}
+
+	delete(h.activeOrders, order.OrderID)
+	order.Canceled = true
+	order.CancelCommand = cancelCommand
+	order.UpdatedAt = time.Now()
+	h.canceledOrders[order.OrderID] = order
+	return nil
+}
+
+// GetRecentCommands 최근 수신 명령 목록 반환 (오래된 순)
+func (h *DirectActionHandler) GetRecentCommands() []CommandResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	commands := make([]CommandResult, 0, len(h.recentCommands))
+	for _, command := range h.recentCommands {
+		commands = append(commands, *command)
+	}
+	return commands
+}
+
+// recordCommand 최근 명령 기록
+func (h *DirectActionHandler) recordCommand(result *CommandResult) {
+	if result.CorrelationID == "" {
+		result.CorrelationID = h.correlationID
+	}
+	h.recentCommands = append(h.recentCommands, result)
+	if len(h.recentCommands) > maxRecentCommands {
+		h.recentCommands = h.recentCommands[len(h.recentCommands)-maxRecentCommands:]
+	}
+}
@@ -0,0 +1,199 @@
+// internal/messaging/outbox.go - Store-and-Forward Outbox for Broker Outages
+package messaging
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/utils"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrOutboxFull 보관 한도 초과로 메시지를 보관하지 못함
+var ErrOutboxFull = errors.New("outbox full")
+
+// outboxMessage 브로커에 전달하지 못해 보관 중인 발행 메시지
+type outboxMessage struct {
+	Topic    string    `json:"topic"`
+	QoS      byte      `json:"qos"`
+	Retained bool      `json:"retained"`
+	Payload  []byte    `json:"payload"`
+	QueuedAt time.Time `json:"queuedAt"`
+}
+
+// Outbox 브로커 연결이 끊긴 동안 발행 메시지(PLC 응답/브릿지 상태)를 파일에 보관하고 재연결 시 순서대로 전송
+// 보관 중인 메시지가 있으면 새 메시지도 뒤에 보관 (발행 순서 유지)
+type Outbox struct {
+	mu          sync.Mutex
+	path        string
+	maxMessages int
+	maxAge      time.Duration // 0이면 만료 없음
+	messages    []outboxMessage
+}
+
+// OpenOutbox 보관 파일 열기 (이전 실행에서 전송하지 못한 메시지 복원)
+func OpenOutbox(path string, maxMessages int, maxAge time.Duration) (*Outbox, error) {
+	outbox := &Outbox{path: path, maxMessages: maxMessages, maxAge: maxAge}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return outbox, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open outbox: %v", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var message outboxMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			// 쓰는 도중 종료된 마지막 줄은 버림
+			utils.Logger.Warnf("⚠️ Skipping corrupt outbox record: %v", err)
+			continue
+		}
+		outbox.messages = append(outbox.messages, message)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read outbox: %v", err)
+	}
+
+	if len(outbox.messages) > 0 {
+		utils.Logger.Infof("📦 Restored %d buffered message(s) from %s", len(outbox.messages), path)
+	}
+	return outbox, nil
+}
+
+// Len 보관 중인 메시지 수
+func (o *Outbox) Len() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.messages)
+}
+
+// publish 보관 중인 메시지가 없으면 바로 전송, 전송 실패 시 또는 보관 중인 메시지가 있으면 보관
+func (o *Outbox) publish(message outboxMessage, send func(outboxMessage) error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.messages) == 0 {
+		err := send(message)
+		if err == nil {
+			return nil
+		}
+		utils.Logger.Warnf("📦 Publish to %s failed, buffering: %v", message.Topic, err)
+	}
+	return o.add(message)
+}
+
+// add 메시지 보관 (잠금 보유 상태에서 호출)
+func (o *Outbox) add(message outboxMessage) error {
+	if len(o.messages) >= o.maxMessages {
+		utils.Logger.Errorf("❌ Outbox full (%d messages), dropping message for %s", len(o.messages), message.Topic)
+		return fmt.Errorf("%w: %d message(s) buffered", ErrOutboxFull, len(o.messages))
+	}
+
+	message.QueuedAt = time.Now()
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal outbox message: %v", err)
+	}
+
+	file, err := os.OpenFile(o.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open outbox: %v", err)
+	}
+	defer file.Close()
+
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write outbox: %v", err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync outbox: %v", err)
+	}
+
+	o.messages = append(o.messages, message)
+	utils.Logger.Infof("📦 Buffered message for %s (%d in outbox)", message.Topic, len(o.messages))
+	return nil
+}
+
+// flush 보관된 메시지를 순서대로 전송 (실패하면 중단하고 남은 메시지는 다음 연결 때 전송)
+// 전송하는 동안 새 발행은 대기 (보관된 메시지보다 먼저 전송되지 않도록)
+func (o *Outbox) flush(send func(outboxMessage) error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if len(o.messages) == 0 {
+		return
+	}
+	utils.Logger.Infof("📦 Flushing %d buffered message(s)", len(o.messages))
+
+	sent, expired := 0, 0
+	var sendErr error
+	for _, message := range o.messages {
+		if o.maxAge > 0 && time.Since(message.QueuedAt) > o.maxAge {
+			utils.Logger.Warnf("⏰ Dropping expired buffered message for %s (queued %s ago)",
+				message.Topic, time.Since(message.QueuedAt).Round(time.Second))
+			expired++
+			continue
+		}
+		if sendErr = send(message); sendErr != nil {
+			break
+		}
+		sent++
+	}
+
+	o.messages = o.messages[sent+expired:]
+	if err := o.rewrite(); err != nil {
+		utils.Logger.Errorf("❌ Failed to rewrite outbox: %v", err)
+	}
+
+	if sendErr != nil {
+		utils.Logger.Errorf("❌ Outbox flush stopped after %d message(s), %d remaining: %v", sent, len(o.messages), sendErr)
+		return
+	}
+	utils.Logger.Infof("✅ Outbox flushed: %d sent, %d expired", sent, expired)
+}
+
+// rewrite 남은 메시지로 보관 파일 교체 (잠금 보유 상태에서 호출)
+func (o *Outbox) rewrite() error {
+	if len(o.messages) == 0 {
+		if err := os.Remove(o.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(o.path), filepath.Base(o.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	for _, message := range o.messages {
+		data, err := json.Marshal(message)
+		if err != nil {
+			tmp.Close()
+			return err
+		}
+		writer.Write(append(data, '\n'))
+	}
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), o.path)
+}
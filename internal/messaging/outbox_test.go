@@ -0,0 +1,72 @@
+package messaging
+
+import (
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"path/filepath"
+	"testing"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// disconnectedClient 연결하지 않은 MQTT 클라이언트 (outbox 사용)
+func disconnectedClient(t *testing.T) (*MQTTClient, *config.Config) {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("load config: %v", err)
+	}
+	outbox, err := OpenOutbox(filepath.Join(t.TempDir(), "outbox.jsonl"), 100, cfg.OutboxMaxAge)
+	if err != nil {
+		t.Fatalf("OpenOutbox: %v", err)
+	}
+	client := &MQTTClient{client: mqtt.NewClient(mqtt.NewClientOptions()), config: cfg}
+	client.SetOutbox(outbox)
+	return client, cfg
+}
+
+func TestOutboxBuffersOnlyPLCResponseAndStatus(t *testing.T) {
+	client, cfg := disconnectedClient(t)
+
+	tests := []struct {
+		topic    string
+		buffered bool
+	}{
+		{cfg.PlcResponseTopic, true},
+		{cfg.PlcResponseTopic + "/line1", true},
+		{cfg.BridgeStatusTopic, true},
+		{cfg.PlcResponseTopic + "x", false},
+		{"meili/v2/Roboligent/DEX0002/order", false},
+		{"meili/v2/Roboligent/DEX0002/instantActions", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			before := client.outbox.Len()
+			err := client.Publish(tt.topic, 0, false, "payload")
+			if buffered := client.outbox.Len() > before; buffered != tt.buffered {
+				t.Errorf("buffered = %v, want %v", buffered, tt.buffered)
+			}
+			if (err == nil) != tt.buffered {
+				t.Errorf("Publish error = %v, want failure only for unbuffered topics", err)
+			}
+		})
+	}
+}
+
+func TestEmergencyStopFailsWhileDisconnected(t *testing.T) {
+	client, cfg := disconnectedClient(t)
+	handler, err := NewDirectActionHandler(client, cfg, events.NewBus())
+	if err != nil {
+		t.Fatalf("NewDirectActionHandler: %v", err)
+	}
+	handler.SetFleet([]*DirectActionHandler{handler})
+
+	result := handler.ProcessCommand("CAL:E")
+	if result.Accepted {
+		t.Fatal("e-stop accepted while the broker is unreachable")
+	}
+	// PLC에는 재연결 후 실패 응답만 전달 (로봇 메시지는 보관하지 않음)
+	if len(client.outbox.messages) != 1 || client.outbox.messages[0].Topic != cfg.PlcResponseTopic {
+		t.Fatalf("outbox = %+v, want only the PLC failure response", client.outbox.messages)
+	}
+}
@@ -0,0 +1,109 @@
+// internal/messaging/path.go - Multi-node Order Paths (VDA5050)
+package messaging
+
+import (
+	"fmt"
+	"math"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+)
+
+// pathBuilder 노드를 순서대로 추가하며 이전 노드와 엣지로 연결
+// 노드 sequenceId는 1, 3, 5..., 엣지는 그 사이 2, 4...
+type pathBuilder struct {
+	order       *types.OrderMessage
+	nodeID      string
+	description string
+	mapID       string // 지도 ID가 없는 위치에 적용 (MAP_ID, ROBOT_MAP_IDS)
+	trajectory  bool   // 엣지에 직선 NURBS 궤적 포함
+}
+
+// newPathBuilder 새 경로 빌더 생성
+func newPathBuilder(order *types.OrderMessage, nodeID, baseCommand, mapID string, trajectory bool) *pathBuilder {
+	return &pathBuilder{
+		order:       order,
+		nodeID:      nodeID,
+		description: fmt.Sprintf("Direct action for command %s", baseCommand),
+		mapID:       mapID,
+		trajectory:  trajectory,
+	}
+}
+
+// addNode 노드 추가 (두 번째 노드부터 이전 노드와 엣지 연결), 추가된 노드 반환
+// 반환된 포인터는 다음 addNode 호출 전까지만 유효
+func (b *pathBuilder) addNode(position *types.NodePosition) *types.Node {
+	index := len(b.order.Nodes)
+	id := b.nodeID
+	if index > 0 {
+		id = fmt.Sprintf("%s-%d", b.nodeID, index+1)
+	}
+
+	node := types.NewNode(id, 2*index+1, true)
+	description := b.description
+	node.NodeDescription = &description
+	if position.MapID == "" {
+		position.MapID = b.mapID
+	}
+	node.NodePosition = position
+
+	if index > 0 {
+		previous := b.order.Nodes[index-1]
+		edge := types.NewEdge(fmt.Sprintf("%s-edge-%d", b.nodeID, index), 2*index, true, previous.NodeID, id)
+		length := math.Hypot(position.X-previous.NodePosition.X, position.Y-previous.NodePosition.Y)
+		edge.Length = &length
+		if b.trajectory && length > 0 {
+			edge.Trajectory = straightTrajectory(previous.NodePosition, position)
+		}
+		b.order.AddEdge(edge)
+	}
+
+	b.order.AddNode(node)
+	return &b.order.Nodes[len(b.order.Nodes)-1]
+}
+
+// straightTrajectory 두 노드를 잇는 1차 NURBS (직선)
+func straightTrajectory(from, to *types.NodePosition) *types.Trajectory {
+	return &types.Trajectory{
+		Degree:     1,
+		KnotVector: []float64{0, 0, 1, 1},
+		ControlPoints: []types.ControlPoint{
+			{X: from.X, Y: from.Y},
+			{X: to.X, Y: to.Y},
+		},
+	}
+}
+
+// waypointPosition 웨이포인트를 노드 위치로 변환 (지도 ID가 없으면 빌더가 로봇 기본 지도 적용)
+func waypointPosition(waypoint config.Waypoint) *types.NodePosition {
+	position := defaultNodePosition()
+	position.X = waypoint.X
+	position.Y = waypoint.Y
+	position.Theta = waypoint.Theta
+	position.MapID = waypoint.MapID
+	return position
+}
+
+// resolveCommandPath 명령의 웨이포인트 경로/목적지 해석 (웨이포인트는 좌표 또는 WAYPOINTS 이름)
+// 목적지는 경로의 마지막 웨이포인트로 추가되어 액션 노드 위치가 됨
+// 명령에 경로/목적지가 없으면 NAV_PATHS의 기본 명령별 경로 (둘 다 없으면 nil)
+func (h *DirectActionHandler) resolveCommandPath(parsed *ParsedCommand) ([]config.Waypoint, error) {
+	if !parsed.HasTarget && !parsed.HasPath {
+		return h.navPaths[parsed.Base], nil
+	}
+
+	var path []config.Waypoint
+	if parsed.HasPath {
+		var err error
+		if path, err = config.ParsePath(parsed.Path, h.waypoints); err != nil {
+			return nil, ValidationErrors{{Field: "path", Position: noSegment, Value: parsed.Path, Reason: err.Error()}}
+		}
+	}
+	if parsed.HasTarget {
+		waypoint, err := config.ResolveWaypoint(parsed.Target, h.waypoints)
+		if err != nil {
+			return nil, ValidationErrors{{Field: "target", Position: noSegment, Value: parsed.Target, Reason: err.Error()}}
+		}
+		path = append(path, waypoint)
+	}
+	return path, nil
+}
@@ -0,0 +1,75 @@
+// internal/messaging/pause.go - Robot Paused State Handling
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+)
+
+// 일시 정지 정책 (PAUSED_POLICY, 로봇이 paused를 보고할 때 새 오더 명령 처리)
+const (
+	PausedPolicyOff    = "off"    // 확인하지 않음 (오더가 WAITING으로 남을 수 있음)
+	PausedPolicyResume = "resume" // stopPause InstantAction 전송 후 오더 발행
+	PausedPolicyReject = "reject" // P 응답으로 거부
+)
+
+// ErrRobotPaused 로봇 일시 정지 중 거부된 명령 (P 응답)
+var ErrRobotPaused = errors.New("robot paused")
+
+// recordPaused 로봇이 보고한 일시 정지 상태 기록 (잠금 보유 상태에서 호출, 보고하지 않으면 유지)
+func (h *DirectActionHandler) recordPaused(state *RobotState) {
+	if state.Paused == nil || *state.Paused == h.paused {
+		return
+	}
+	if *state.Paused {
+		utils.Logger.Warnf("⏸️ Robot %s paused", h.config.RobotSerialNumber)
+	} else {
+		utils.Logger.Infof("▶️ Robot %s resumed", h.config.RobotSerialNumber)
+	}
+	h.paused = *state.Paused
+}
+
+// gatePaused 로봇이 일시 정지 중이면 정책에 따라 재개 요청 또는 P 응답 (잠금 보유 상태에서 호출)
+// 오더를 발행해도 되면 nil 반환
+func (h *DirectActionHandler) gatePaused(commandStr string) *CommandResult {
+	if !h.paused || h.config.PausedPolicy == PausedPolicyOff {
+		return nil
+	}
+
+	if h.config.PausedPolicy == PausedPolicyResume {
+		if err := h.sendStopPause(); err != nil {
+			utils.Logger.Errorf("❌ Failed to resume paused robot for %s: %v", commandStr, err)
+			h.sendPLCFailure(commandStr, err)
+			return newCommandResult(commandStr, "", err)
+		}
+		return nil
+	}
+
+	utils.Logger.Errorf("❌ Command rejected - robot paused: %s", commandStr)
+	err := fmt.Errorf("%w, resume the robot before sending orders", ErrRobotPaused)
+	h.publishPLCResponse(types.NewPLCResponse(commandStr, types.PLCStatusPaused, err.Error()))
+	return newCommandResult(commandStr, "", err)
+}
+
+// sendStopPause 로봇에 stopPause InstantAction 전송 (전송 제한으로 억제되면 오류 없이 생략)
+func (h *DirectActionHandler) sendStopPause() error {
+	if send, err := h.allowInstantAction("stopPause", ""); !send {
+		return err
+	}
+
+	message, err := h.protocol.BuildInstantAction(InstantActionRequest{
+		ActionType:   "stopPause",
+		BlockingType: types.BlockingTypeHard,
+	})
+	if err != nil {
+		return err
+	}
+	if err := h.sendToRobot(message); err != nil {
+		return fmt.Errorf("failed to publish stopPause action: %v", err)
+	}
+
+	h.robotLog().WithField("actionId", message.ActionID).Info("Robot paused - stopPause sent before new order")
+	return nil
+}
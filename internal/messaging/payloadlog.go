@@ -0,0 +1,30 @@
+// internal/messaging/payloadlog.go - MQTT Message Logging
+package messaging
+
+import (
+	"mqtt-bridge/internal/utils"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// logReceived 수신 메시지 상세 로깅 (제외 토픽은 생략, 페이로드는 길이 제한)
+func logReceived(msg mqtt.Message) {
+	if utils.PayloadLogSuppressed(msg.Topic()) {
+		return
+	}
+	utils.Logger.Infof("📨 MQTT RECEIVED")
+	utils.Logger.Infof("📨 Topic   : %s", msg.Topic())
+	utils.Logger.Infof("📨 QoS    : %d, MessageID: %d", msg.Qos(), msg.MessageID())
+	utils.Logger.Infof("📨 Payload : %s", utils.TruncatePayload(string(msg.Payload())))
+}
+
+// logPublish 발신 메시지 상세 로깅 (제외 토픽은 생략, 페이로드는 길이 제한)
+func logPublish(topic string, qos byte, retained bool, payload string) {
+	if utils.PayloadLogSuppressed(topic) {
+		return
+	}
+	utils.Logger.Infof("📤 MQTT PUBLISH")
+	utils.Logger.Infof("📤 Topic   : %s", topic)
+	utils.Logger.Infof("📤 QoS    : %d, Retained: %v", qos, retained)
+	utils.Logger.Infof("📤 Payload : %s", utils.TruncatePayload(payload))
+}
@@ -0,0 +1,102 @@
+// internal/messaging/pipeline.go - Multi-action Command Pipelines
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// handlePipelineCommand 매핑 파일에 정의된 파이프라인 명령을 여러 액션 오더로 처리
+// 상태는 일반 오더와 같이 집계 (모든 액션 완료 시 S, 하나라도 실패 시 F)
+func (h *DirectActionHandler) handlePipelineCommand(commandStr string, pipeline config.MappedCommand, path []config.Waypoint) (string, error) {
+	baseCommand := h.extractBaseCommand(commandStr)
+
+	actions := make([]OrderAction, 0, len(pipeline.Steps))
+	for _, step := range pipeline.Steps {
+		action, err := h.pipelineAction(step)
+		if err != nil {
+			utils.Logger.Errorf("❌ Invalid pipeline step for %s: %v", commandStr, err)
+			h.sendPLCFailure(commandStr, err)
+			return "", err
+		}
+		actions = append(actions, action)
+	}
+	if err := h.checkFactsheetOrder(actions); err != nil {
+		utils.Logger.Errorf("❌ Pipeline %s rejected: %v", commandStr, err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+
+	orderID := h.generateOrderID()
+	message, err := h.protocol.BuildOrder(OrderRequest{
+		OrderID:     orderID,
+		BaseCommand: baseCommand,
+		Actions:     actions,
+		Sequential:  pipeline.Layout == config.PipelineLayoutSequential,
+		Path:        path,
+	})
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to build pipeline order: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+	message.ActionType = "pipeline"
+	if len(actions) == 1 {
+		message.ActionType = actions[0].ActionType
+	}
+
+	h.commandLog(commandStr).WithFields(logrus.Fields{
+		"orderId": orderID,
+		"steps":   len(actions),
+	}).Info("Pipeline order built")
+	return h.dispatchOrder(commandStr, orderID, message)
+}
+
+// loadCommandMapping 매핑 파일 읽기 및 단계 명령 타입 확인 (등록된 번역기가 있는 타입만 허용)
+func loadCommandMapping(cfg *config.Config) (*config.CommandMapping, error) {
+	mapping, err := cfg.LoadCommandMapping()
+	if err != nil || mapping == nil {
+		return mapping, err
+	}
+
+	for command, mapped := range mapping.Commands {
+		for i, step := range mapped.Steps {
+			if step.ActionType != "" || step.Type == "W" {
+				continue
+			}
+			translator, exists := lookupCommandTranslator(step.Type)
+			switch {
+			case !exists:
+				return nil, fmt.Errorf("command %q: step %d: unknown type %q, expected W or one of %s", command, i, step.Type, strings.Join(CommandTypes(), ", "))
+			case step.Arm != "" && !translator.AcceptsArm():
+				return nil, fmt.Errorf("command %q: step %d: arm is not valid for %s steps", command, i, step.Type)
+			}
+		}
+	}
+	return mapping, nil
+}
+
+// pipelineAction 파이프라인 단계를 오더 액션으로 변환 (type 축약형은 Direct Action과 같은 번역기 사용)
+func (h *DirectActionHandler) pipelineAction(step config.MappedStep) (OrderAction, error) {
+	if step.ActionType != "" {
+		parameters := make([]types.ActionParameter, 0, len(step.Parameters))
+		for _, parameter := range step.Parameters {
+			parameters = append(parameters, types.ActionParameter{Key: parameter.Key, Value: parameter.Value})
+		}
+		return OrderAction{ActionType: step.ActionType, Parameters: parameters, BlockingType: step.BlockingType}, nil
+	}
+	if step.Type == "W" {
+		return h.triggerAction(step), nil
+	}
+
+	actionType, parameters, err := h.buildActionParameters(step.Name, step.Type, step.Arm)
+	if err != nil {
+		return OrderAction{}, err
+	}
+	return OrderAction{ActionType: actionType, Parameters: parameters, BlockingType: step.BlockingType}, nil
+}
@@ -0,0 +1,51 @@
+// internal/messaging/position.go - Robot Position Publishing for Monitoring
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// recordPosition 로봇 위치를 모니터링 토픽으로 발행 (잠금 보유 상태에서 호출, POSITION_TOPIC이 비어있으면 비활성화)
+// POSITION_INTERVAL마다 최대 한 번, 위치가 바뀐 경우만 발행
+func (h *DirectActionHandler) recordPosition(state *RobotState) {
+	if h.config.PositionTopic == "" || state.Position == nil {
+		return
+	}
+	now := time.Now()
+	if now.Sub(h.positionPublishedAt) < h.config.PositionInterval {
+		return
+	}
+
+	position := &types.PositionUpdate{
+		Robot:     h.config.RobotSerialNumber,
+		X:         state.Position.X,
+		Y:         state.Position.Y,
+		Theta:     state.Position.Theta,
+		MapID:     state.Position.MapID,
+		UpdatedAt: now,
+	}
+	if position.SamePose(h.position) {
+		return
+	}
+
+	msgData, err := json.Marshal(position)
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal robot position: %v", err)
+		return
+	}
+	if err := h.mqttClient.Publish(h.positionTopic(), 0, true, msgData); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish robot position: %v", err)
+		return
+	}
+	h.position = position
+	h.positionPublishedAt = now
+}
+
+// positionTopic 로봇별 위치 토픽
+func (h *DirectActionHandler) positionTopic() string {
+	return fmt.Sprintf("%s/%s", h.config.PositionTopic, h.config.RobotSerialNumber)
+}
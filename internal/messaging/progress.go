@@ -0,0 +1,44 @@
+// internal/messaging/progress.go - Action Progress Estimation
+package messaging
+
+import (
+	"regexp"
+	"strconv"
+)
+
+// progressPattern resultDescription의 진행률 표기 (예: "45%", "trajectory 62.5 %")
+var progressPattern = regexp.MustCompile(`(\d{1,3}(?:\.\d+)?)\s*%`)
+
+// computeProgress 오더 진행률 계산 (0-100)
+// 완료 액션은 100, 진행 중 액션은 resultDescription의 백분율(없으면 0)로 보고 평균
+func computeProgress(actionStates []ActionState) int {
+	if len(actionStates) == 0 {
+		return 0
+	}
+
+	total := 0.0
+	for _, actionState := range actionStates {
+		switch actionState.ActionStatus {
+		case "FINISHED":
+			total += 100
+		case "RUNNING":
+			if percent, ok := parseProgress(actionState.ResultDescription); ok {
+				total += percent
+			}
+		}
+	}
+	return int(total / float64(len(actionStates)))
+}
+
+// parseProgress resultDescription에서 백분율 추출 (0-100 범위만 인정)
+func parseProgress(description string) (float64, bool) {
+	match := progressPattern.FindStringSubmatch(description)
+	if match == nil {
+		return 0, false
+	}
+	percent, err := strconv.ParseFloat(match[1], 64)
+	if err != nil || percent > 100 {
+		return 0, false
+	}
+	return percent, true
+}
@@ -0,0 +1,152 @@
+// internal/messaging/protocol.go - Robot Protocol Abstraction
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// RobotProtocol 로봇측 메시지 변환 계층 (기본 구현: VDA5050)
+// PLC측 로직(명령 파싱, 오더 추적, 응답)은 프로토콜과 무관하게 재사용됨
+type RobotProtocol interface {
+	// Name 프로토콜 이름 (로그/설정용)
+	Name() string
+	// BuildOrder 오더 메시지 생성 (액션 하나 이상)
+	BuildOrder(req OrderRequest) (*OutboundMessage, error)
+	// BuildCancel 오더 취소 메시지 생성
+	BuildCancel(orderID string) (*OutboundMessage, error)
+	// BuildInstantAction 즉시 액션 메시지 생성 (stateRequest, initPosition 등)
+	BuildInstantAction(req InstantActionRequest) (*OutboundMessage, error)
+	// ParseState 로봇 상태 메시지 해석
+	ParseState(payload []byte) (*RobotState, error)
+}
+
+// RobotTransport MQTT 대신 자체 연결로 로봇과 통신하는 프로토콜 (선택 구현, 예: rosbridge)
+type RobotTransport interface {
+	// Send 메시지 전송
+	Send(message *OutboundMessage) error
+	// Run 연결 유지 및 수신 메시지 전달 (ctx 종료 시 반환)
+	Run(ctx context.Context, sink RobotSink)
+}
+
+// RobotSink 전송 계층이 수신한 로봇 상태/연결 상태를 전달받는 대상 (DirectActionHandler)
+type RobotSink interface {
+	ProcessRobotState(payload []byte)
+	ProcessRobotConnection(connectionState string)
+}
+
+// OrderRequest 프로토콜 독립 오더 요청
+type OrderRequest struct {
+	OrderID     string
+	BaseCommand string
+	Actions     []OrderAction     // 실행 순서대로
+	Sequential  bool              // 액션마다 노드 하나 (false면 한 노드에 모든 액션)
+	Path        []config.Waypoint // 이동 경로 (마지막 웨이포인트에서 액션 실행, 비어있으면 기본 위치)
+}
+
+// OrderAction 오더에 포함될 액션
+type OrderAction struct {
+	ActionType   string
+	Parameters   []types.ActionParameter
+	BlockingType string // 비어있으면 액션 수에 따라 결정 (하나면 NONE, 여러 개면 HARD)
+	Trigger      bool   // PLC 해제 명령을 기다리는 waitForTrigger 단계 (trigger.go)
+}
+
+// defaultBlockingType BlockingType을 지정하지 않은 액션의 블로킹 타입
+// 여러 액션은 순서대로 하나씩 실행되도록 HARD, 하나면 NONE
+func defaultBlockingType(actionCount int) string {
+	if actionCount > 1 {
+		return types.BlockingTypeHard
+	}
+	return types.BlockingTypeNone
+}
+
+// InstantActionRequest 프로토콜 독립 즉시 액션 요청
+type InstantActionRequest struct {
+	ActionType   string
+	BlockingType string
+	Parameters   []types.ActionParameter
+}
+
+// OutboundMessage 로봇으로 전송할 메시지
+type OutboundMessage struct {
+	Topic    string
+	Payload  []byte
+	ActionID string // 생성된 액션 ID (로그용)
+
+	ActionType string // 오더 액션 타입 (메트릭 라벨용, 여러 액션이면 pipeline/template)
+
+	TriggerActionIDs []string // Trigger 액션에 생성된 액션 ID (순서대로)
+}
+
+// RobotState 프로토콜 독립 로봇 상태
+type RobotState struct {
+	OrderID             string
+	Timestamp           time.Time // 로봇이 보고한 state 생성 시각 (보고하지 않으면 zero)
+	ActionStates        []ActionState
+	Errors              []RobotError
+	PositionInitialized *bool        // nil이면 로봇이 보고하지 않음
+	BatteryCharge       *float64     // 배터리 잔량 (%), nil이면 로봇이 보고하지 않음
+	SafetyState         *SafetyState // nil이면 로봇이 보고하지 않음
+	Position            *Position    // nil이면 로봇이 보고하지 않음
+	OperatingMode       string       // AUTOMATIC, SEMIAUTOMATIC, MANUAL, SERVICE, TEACHIN (비어있으면 보고하지 않음)
+	Paused              *bool        // nil이면 로봇이 보고하지 않음
+	Driving             *bool        // nil이면 로봇이 보고하지 않음
+	NodeStates          *int         // 남은 노드 수 (nodeStates), nil이면 로봇이 보고하지 않음
+}
+
+// Position 로봇 위치 (VDA5050 agvPosition)
+type Position struct {
+	X     float64
+	Y     float64
+	Theta float64
+	MapID string
+}
+
+// SafetyState 로봇 안전 상태
+type SafetyState struct {
+	EStop          string // AUTOACK, MANUAL, REMOTE, NONE
+	FieldViolation bool
+}
+
+// ActionState 액션 상태 (WAITING, INITIALIZING, RUNNING, FINISHED, FAILED)
+type ActionState struct {
+	ActionID          string
+	ActionType        string
+	ActionStatus      string
+	ResultDescription string
+}
+
+// RobotError 로봇 보고 오류
+type RobotError struct {
+	ErrorType        string
+	ErrorLevel       string
+	ErrorDescription string
+	OrderID          string // errorReferences의 orderId (오류가 가리키는 오더, 없으면 "")
+}
+
+// FatalError 운영자 조치가 필요한 (FATAL) 오류 검색
+func (s *RobotState) FatalError() *RobotError {
+	for i := range s.Errors {
+		if s.Errors[i].ErrorLevel == types.ErrorLevelFatal {
+			return &s.Errors[i]
+		}
+	}
+	return nil
+}
+
+// newRobotProtocol 설정된 로봇 프로토콜 생성 (ROBOT_PROTOCOL, 노드/액션 ID는 ids로 생성)
+func newRobotProtocol(cfg *config.Config, ids utils.IDGenerator) (RobotProtocol, error) {
+	switch cfg.RobotProtocol {
+	case "", "vda5050":
+		return newVDA5050Protocol(cfg, ids)
+	case "rosbridge":
+		return newRosbridgeProtocol(cfg, ids), nil
+	default:
+		return nil, fmt.Errorf("unknown robot protocol: %s", cfg.RobotProtocol)
+	}
+}
@@ -0,0 +1,64 @@
+// internal/messaging/query.go - PLC Status Query (BASE:Q)
+package messaging
+
+import (
+	"mqtt-bridge/internal/types"
+)
+
+// handleQueryCommand 기본 명령의 최신 상태를 캐시에서 응답 (로봇 트래픽 없음, 잠금 보유 상태에서 호출)
+// 진행/취소/확인 대기/종료된 오더 중 가장 최근 오더의 상태, 대기열에만 있으면 W, 없으면 N
+func (h *DirectActionHandler) handleQueryCommand(commandStr string) (string, error) {
+	baseCommand := h.extractBaseCommand(commandStr)
+
+	order := h.latestOrder(baseCommand)
+	if order == nil {
+		for _, queued := range h.queuedCommands {
+			if h.extractBaseCommand(queued.Command) == baseCommand {
+				h.sendPLCResponse(commandStr, types.PLCStatusWaiting)
+				return "", nil
+			}
+		}
+		h.sendPLCResponse(commandStr, types.PLCStatusNotFound)
+		return "", nil
+	}
+
+	status := order.Status
+	if status == "" {
+		status = types.PLCStatusWaiting
+	}
+	plcResponse := types.NewPLCResponse(commandStr, status, "")
+	plcResponse.OrderID = order.OrderID
+	if status == types.PLCStatusRunning && h.config.PlcResponseProgress {
+		progress := order.Progress
+		plcResponse.Progress = &progress
+	}
+	h.publishPLCResponse(plcResponse)
+	return order.OrderID, nil
+}
+
+// latestOrder 기본 명령의 가장 최근에 갱신된 오더 (잠금 보유 상태에서 호출, 없으면 nil)
+func (h *DirectActionHandler) latestOrder(baseCommand string) *OrderInfo {
+	var latest *OrderInfo
+	consider := func(order *OrderInfo) {
+		if h.extractBaseCommand(order.Command) != baseCommand {
+			return
+		}
+		if latest == nil || order.UpdatedAt.After(latest.UpdatedAt) {
+			latest = order
+		}
+	}
+
+	for _, order := range h.activeOrders {
+		consider(order)
+	}
+	for _, order := range h.canceledOrders {
+		consider(order)
+	}
+	for _, pending := range h.pendingOrders {
+		consider(pending.order)
+	}
+	for _, order := range h.recentOrders {
+		consider(order)
+	}
+	return latest
+}
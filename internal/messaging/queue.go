@@ -0,0 +1,212 @@
+// internal/messaging/queue.go - Command Priority, Queueing and Preemption
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// 오더 처리 정책 (ORDER_POLICY, 진행 중인 오더가 MAX_CONCURRENT_ORDERS에 도달했을 때)
+const (
+	OrderPolicyParallel = "parallel" // 제한 없이 바로 발행 (여러 오더 동시 진행)
+	OrderPolicyQueue    = "queue"    // 진행 중인 오더가 끝날 때까지 대기열에서 대기
+	OrderPolicyReject   = "reject"   // 실패(F) 응답으로 거부
+)
+
+// ErrQueuedCommandNotFound 대기열에 없는 명령
+var ErrQueuedCommandNotFound = errors.New("queued command not found")
+
+// ErrRobotBusy 동시 실행 한도 또는 대기열 한도로 거부된 명령 (B 응답)
+var ErrRobotBusy = errors.New("robot busy")
+
+// maxQueuedCommands 대기열에 보관할 최대 명령 수
+const maxQueuedCommands = 100
+
+// QueuedCommand 진행 중인 오더 종료를 기다리는 PLC 명령
+type QueuedCommand struct {
+	ID       string    `json:"id"`
+	Command  string    `json:"command"` // 원본 PLC 명령 (우선순위 포함)
+	Priority int       `json:"priority"`
+	QueuedAt time.Time `json:"queuedAt"`
+
+	CorrelationID string `json:"correlationId,omitempty"` // 수신 시 상관관계 ID (처리 시 그대로 사용)
+	ReplyChannel  string `json:"replyChannel,omitempty"`  // 수신 시 응답 채널 (처리 시 그대로 사용)
+}
+
+// ordersInProgress 로봇에서 진행/취소 중이거나 발행 확인 대기 중인 오더 수
+func (h *DirectActionHandler) ordersInProgress() int {
+	return len(h.activeOrders) + len(h.canceledOrders) + len(h.pendingOrders)
+}
+
+// orderLimit 동시 실행 한도 (MAX_CONCURRENT_ORDERS, factsheet 한도가 더 작으면 factsheet)
+func (h *DirectActionHandler) orderLimit() int {
+	if h.factsheetOrderLimit > 0 && h.factsheetOrderLimit < h.config.MaxConcurrentOrders {
+		return h.factsheetOrderLimit
+	}
+	return h.config.MaxConcurrentOrders
+}
+
+// atCapacity 진행 중인 오더가 동시 실행 한도에 도달했는지 확인 (parallel 정책은 제한 없음)
+func (h *DirectActionHandler) atCapacity() bool {
+	return h.config.OrderPolicy != OrderPolicyParallel && h.ordersInProgress() >= h.orderLimit()
+}
+
+// applyFactsheetOrderLimit factsheet가 보고한 동시 오더 한도 반영 (잠금 보유 상태에서 호출)
+// VDA5050 표준 필드가 아니므로 protocolLimits.maxConcurrentOrders를 보고하는 로봇만 적용
+func (h *DirectActionHandler) applyFactsheetOrderLimit(factsheetMsg map[string]interface{}) {
+	if !h.config.FactsheetOrderLimit {
+		return
+	}
+	manufacturer, _ := factsheetMsg["manufacturer"].(string)
+	serialNumber, _ := factsheetMsg["serialNumber"].(string)
+	if manufacturer != h.config.RobotManufacturer || serialNumber != h.config.RobotSerialNumber {
+		return
+	}
+
+	protocolLimits, _ := factsheetMsg["protocolLimits"].(map[string]interface{})
+	limit, ok := protocolLimits["maxConcurrentOrders"].(float64)
+	if !ok || limit < 1 || int(limit) == h.factsheetOrderLimit {
+		return
+	}
+
+	utils.Logger.Infof("📄 Factsheet order limit for %s/%s: %d (MAX_CONCURRENT_ORDERS %d)",
+		manufacturer, serialNumber, int(limit), h.config.MaxConcurrentOrders)
+	h.factsheetOrderLimit = int(limit)
+}
+
+// admitCommand 동시 실행 한도에 도달한 경우 정책에 따라 대기열 추가 또는 거부 (잠금 보유 상태에서 호출)
+// 바로 처리해도 되면 nil 반환
+func (h *DirectActionHandler) admitCommand(commandStr string, priority int) *CommandResult {
+	if !h.atCapacity() {
+		return nil
+	}
+	if h.config.OrderPolicy == OrderPolicyQueue {
+		return h.enqueueCommand(commandStr, priority)
+	}
+
+	utils.Logger.Warnf("🚫 %d order(s) in progress - rejecting command: %s", h.ordersInProgress(), commandStr)
+	return h.rejectBusy(commandStr, fmt.Errorf("%w: %d of %d order(s) in progress", ErrRobotBusy, h.ordersInProgress(), h.orderLimit()))
+}
+
+// rejectBusy 명령을 실행하지 않고 사용 중(B) 응답 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) rejectBusy(commandStr string, err error) *CommandResult {
+	h.publishPLCResponse(types.NewPLCResponse(commandStr, types.PLCStatusBusy, err.Error()))
+	return newCommandResult(commandStr, "", err)
+}
+
+// shouldPreempt 활성 오더를 취소하고 발행할 우선순위인지 확인
+func (h *DirectActionHandler) shouldPreempt(priority int) bool {
+	return h.config.PreemptPriority > 0 && priority >= h.config.PreemptPriority
+}
+
+// enqueueCommand 명령을 우선순위 순서로 대기열에 추가 (같은 우선순위는 수신 순서, 잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) enqueueCommand(commandStr string, priority int) *CommandResult {
+	if len(h.queuedCommands) >= maxQueuedCommands {
+		utils.Logger.Errorf("❌ Command queue full, rejecting command: %s", commandStr)
+		return h.rejectBusy(commandStr, fmt.Errorf("%w: command queue full", ErrRobotBusy))
+	}
+
+	queued := &QueuedCommand{ID: utils.NewULID(), Command: commandStr, Priority: priority, QueuedAt: time.Now(), CorrelationID: h.correlationID, ReplyChannel: h.replyChannel}
+	position := len(h.queuedCommands)
+	for i, existing := range h.queuedCommands {
+		if priority > existing.Priority {
+			position = i
+			break
+		}
+	}
+	h.queuedCommands = append(h.queuedCommands, nil)
+	copy(h.queuedCommands[position+1:], h.queuedCommands[position:])
+	h.queuedCommands[position] = queued
+
+	utils.Logger.Infof("⏸️ Order in progress - queued command: %s (priority %d, position %d/%d)",
+		commandStr, priority, position+1, len(h.queuedCommands))
+	h.sendPLCResponse(commandStr, types.PLCStatusWaiting)
+
+	result := newCommandResult(commandStr, "", nil)
+	result.Reason = fmt.Sprintf("queued at position %d", position+1)
+	return result
+}
+
+// preemptOrders 높은 우선순위 명령을 위해 진행 중인 오더 취소 (잠금 보유 상태에서 호출)
+// 취소된 오더의 최종 상태는 원래 명령으로 응답 (PLC가 중단된 명령을 알 수 있도록)
+func (h *DirectActionHandler) preemptOrders(commandStr string) error {
+	for _, pending := range h.pendingOrders {
+		h.rejectPendingOrder(pending, "preempted by "+commandStr)
+	}
+	for _, run := range h.chains {
+		// 체인 자신의 단계가 선점하는 경우는 제외 (단계는 체인의 상관관계 ID로 처리됨)
+		if run.correlationID != h.correlationID {
+			h.stopChain(run, "preempted by "+commandStr)
+		}
+	}
+	for _, order := range h.activeOrders {
+		h.orderLog(order).Warnf("Preempting order for higher priority command %s", commandStr)
+		if err := h.cancelOrder(order, order.Command); err != nil {
+			return fmt.Errorf("failed to preempt order %s: %v", order.OrderID, err)
+		}
+	}
+	return nil
+}
+
+// dispatchQueued 동시 실행 한도에 여유가 있으면 대기열의 다음 명령 처리 (잠금 보유 상태에서 호출)
+// 오더를 종료시킬 수 있는 진입점 끝에서 호출, 로봇이 OFFLINE이거나 수동 모드면 다시 오더를 받을 때까지 보관
+// 정책이 parallel로 바뀌면 남은 명령을 모두 처리, 대기 인스턴스는 처리하지 않음
+func (h *DirectActionHandler) dispatchQueued() {
+	for len(h.queuedCommands) > 0 && !h.standby && !h.atCapacity() && h.robotConnectionState != "OFFLINE" && h.acceptsOrders() {
+		next := h.queuedCommands[0]
+		h.queuedCommands = h.queuedCommands[1:]
+
+		restore := h.beginCorrelation(next.CorrelationID)
+		restoreReply := h.beginReply(next.ReplyChannel)
+		h.commandLog(next.Command).Infof("▶️ Dispatching queued command: %s (waited %s)", next.Command, time.Since(next.QueuedAt).Round(time.Millisecond))
+		h.processAndRecord(next.Command)
+		restoreReply()
+		restore()
+	}
+}
+
+// QueueStatus 대기열 상태 (관리자 API)
+type QueueStatus struct {
+	Policy              string          `json:"policy"`
+	MaxConcurrentOrders int             `json:"maxConcurrentOrders"` // factsheet 한도 반영
+	OrdersInProgress    int             `json:"ordersInProgress"`
+	Commands            []QueuedCommand `json:"commands"` // 처리 순서
+}
+
+// GetQueue 대기열 상태 반환
+func (h *DirectActionHandler) GetQueue() QueueStatus {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	commands := make([]QueuedCommand, 0, len(h.queuedCommands))
+	for _, queued := range h.queuedCommands {
+		commands = append(commands, *queued)
+	}
+	return QueueStatus{
+		Policy:              h.config.OrderPolicy,
+		MaxConcurrentOrders: h.orderLimit(),
+		OrdersInProgress:    h.ordersInProgress(),
+		Commands:            commands,
+	}
+}
+
+// RemoveQueuedCommand 대기 중인 명령을 처리하지 않고 제거 (PLC에는 실패 응답)
+func (h *DirectActionHandler) RemoveQueuedCommand(id string) (QueuedCommand, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, queued := range h.queuedCommands {
+		if queued.ID != id {
+			continue
+		}
+		h.queuedCommands = append(h.queuedCommands[:i], h.queuedCommands[i+1:]...)
+
+		utils.Logger.Warnf("🚫 Queued command removed by operator: %s", queued.Command)
+		h.sendPLCFailure(queued.Command, fmt.Errorf("removed from queue by operator"))
+		return *queued, nil
+	}
+	return QueuedCommand{}, ErrQueuedCommandNotFound
+}
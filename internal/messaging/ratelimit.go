@@ -0,0 +1,79 @@
+// internal/messaging/ratelimit.go - Inbound PLC Command Rate Limiting
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// ErrCommandRateLimited PLC 명령 수신 한도 초과 (명령 미실행)
+var ErrCommandRateLimited = errors.New("command rate limit exceeded")
+
+// commandRateLimiter 명령 경로(토픽) 전체와 기본 명령별 토큰 버킷 (PLC 프로그램 루프로부터 로봇 보호)
+type commandRateLimiter struct {
+	route *tokenBucket            // 핸들러(명령 토픽) 전체
+	bases map[string]*tokenBucket // 기본 명령 -> 버킷
+}
+
+// newCommandRateLimiter 새 명령 수신 제한기 생성
+func newCommandRateLimiter() *commandRateLimiter {
+	return &commandRateLimiter{bases: make(map[string]*tokenBucket)}
+}
+
+// isRateLimitExempt 한도와 무관하게 처리하는 명령 (취소/비상 정지/트리거 해제는 로봇을 멈추거나 진행시키는 명령, 상태 조회는 로봇 트래픽 없음)
+func isRateLimitExempt(commandStr string) bool {
+	return commandKind(commandStr) != CommandKindAction
+}
+
+// checkCommandRate 명령 수신 한도 확인 (COMMAND_RATE_LIMIT, BASE_COMMAND_RATE_LIMIT, 잠금 보유 상태에서 호출)
+// 초과 시 한도 초과(L) 응답 후 결과 반환, 처리해도 되면 nil
+func (h *DirectActionHandler) checkCommandRate(commandStr string) *CommandResult {
+	if isRateLimitExempt(commandStr) {
+		return nil
+	}
+	now := time.Now()
+
+	var err error
+	if rate := h.config.CommandRateLimit; rate > 0 {
+		burst := float64(h.config.CommandRateBurst)
+		if h.commandRates.route == nil {
+			h.commandRates.route = &tokenBucket{tokens: burst, last: now}
+		}
+		if !h.commandRates.route.take(now, rate, burst) {
+			err = fmt.Errorf("%w: more than %g command(s)/s on this route", ErrCommandRateLimited, rate)
+		}
+	}
+	if rate := h.config.BaseCommandRateLimit; err == nil && rate > 0 {
+		burst := float64(h.config.BaseCommandRateBurst)
+		baseCommand := h.extractBaseCommand(commandStr)
+		bucket := h.commandRates.bases[baseCommand]
+		if bucket == nil {
+			bucket = &tokenBucket{tokens: burst, last: now}
+			h.commandRates.bases[baseCommand] = bucket
+		}
+		if !bucket.take(now, rate, burst) {
+			err = fmt.Errorf("%w: more than %g %s command(s)/s", ErrCommandRateLimited, rate, baseCommand)
+		}
+	}
+	if err == nil {
+		return nil
+	}
+
+	utils.Logger.Warnf("🚦 Rate limited PLC command for robot %s: %s (%v)", h.config.RobotSerialNumber, commandStr, err)
+	h.publishPLCResponse(types.NewPLCResponse(commandStr, types.PLCStatusRateLimited, err.Error()))
+
+	result := newCommandResult(commandStr, "", err)
+	h.recordCommand(result)
+	h.publishEvent(events.Event{
+		Type:    events.TypeCommandRejected,
+		Command: commandStr,
+		Status:  types.PLCStatusRateLimited,
+		Message: result.Reason,
+	})
+	h.publishDeadLetter(result)
+	return result
+}
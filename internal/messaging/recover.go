@@ -0,0 +1,40 @@
+// internal/messaging/recover.go - Panic Recovery for Message Handlers
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/utils"
+	"runtime/debug"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// maxPanicPayloadLog 패닉 로그에 남길 최대 페이로드 길이
+const maxPanicPayloadLog = 1024
+
+// recoverHandler 메시지 처리 중 발생한 패닉을 복구하고 토픽/페이로드/스택 기록 (defer로 직접 호출해야 recover 동작)
+// 복구하지 않으면 paho 라우터 고루틴과 함께 브릿지 전체가 종료됨
+func recoverHandler(bus *events.Bus, source string, msg mqtt.Message) {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	payload := msg.Payload()
+	truncated := ""
+	if len(payload) > maxPanicPayloadLog {
+		payload = payload[:maxPanicPayloadLog]
+		truncated = "...(truncated)"
+	}
+	utils.Logger.Errorf("💥 Recovered panic in %s handler (topic %s): %v\nPayload: %s%s\n%s",
+		source, msg.Topic(), recovered, payload, truncated, debug.Stack())
+
+	if bus != nil {
+		bus.Publish(events.Event{
+			Type:    events.TypeHandlerPanic,
+			Status:  source,
+			Message: fmt.Sprint(recovered),
+		})
+	}
+}
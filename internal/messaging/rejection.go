@@ -0,0 +1,34 @@
+// internal/messaging/rejection.go - Robot Order Rejection Detection (errorReferences)
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/types"
+)
+
+// orderIDReferenceKey 오더를 가리키는 VDA5050 errorReferences 키
+const orderIDReferenceKey = "orderId"
+
+// failRejectedOrders 로봇이 거부한 활성 오더를 바로 실패 처리 (잠금 보유 상태에서 호출)
+// 오류가 errorReferences로 오더를 가리키는데 state의 orderId가 그 오더가 아니면 로봇이 오더를 받아들이지 않은 것
+// (거부된 오더는 action state가 오지 않으므로 처리하지 않으면 계속 대기)
+func (h *DirectActionHandler) failRejectedOrders(state *RobotState) {
+	for _, robotError := range state.Errors {
+		orderID := robotError.OrderID
+		if orderID == "" || orderID == state.OrderID {
+			continue
+		}
+		order, exists := h.activeOrders[orderID]
+		if !exists {
+			continue
+		}
+
+		message := fmt.Sprintf("order rejected by robot: %s", robotError.ErrorType)
+		if robotError.ErrorDescription != "" {
+			message += ": " + robotError.ErrorDescription
+		}
+		h.orderLog(order).WithField("errorType", robotError.ErrorType).Errorf("❌ %s", message)
+		h.respondOrderMessage(order, types.PLCStatusFailed, message)
+		h.finishOrder(order)
+	}
+}
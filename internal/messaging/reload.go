@@ -0,0 +1,138 @@
+// internal/messaging/reload.go - Runtime Configuration Reload
+package messaging
+
+import (
+	"mqtt-bridge/internal/alert"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/utils"
+)
+
+// ApplyConfig 재시작 없이 적용 가능한 설정 반영 (MQTT 연결, 활성 오더 유지)
+// 응답 토픽/형식, 응답/명령 중복 억제 구간, InstantActions 전송 제한, 커미셔닝 모드 해제/설정, 오더 처리 정책/동시 실행 한도/선점 우선순위/운전 모드 정책/일시 정지 정책/주행 중 보류, 비상 정지 액션, 로봇 메시지 스키마 검증, 거부 명령 dead-letter 토픽, 명령 지연 최대값, 위치 모니터링 토픽/간격, visualization 전달 빈도, PLC 하트비트 시간 초과/오더 취소, 재연결 응답 재전송, PLC 명령 수신 제한, 명령 매핑/오더 템플릿/이동 경로/웨이포인트/명령 별칭/추론·궤적 이름 허용 목록, 시간 초과 단계 정책, 페이로드 정리
+func (h *DirectActionHandler) ApplyConfig(next *config.Config) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.config.PlcResponseTopic != next.PlcResponseTopic {
+		utils.Logger.Infof("🔄 PLC response topic: %s -> %s", h.config.PlcResponseTopic, next.PlcResponseTopic)
+		h.config.PlcResponseTopic = next.PlcResponseTopic
+	}
+
+	if h.config.PlcResponseFormat != next.PlcResponseFormat {
+		utils.Logger.Infof("🔄 PLC response format: %s -> %s", h.config.PlcResponseFormat, next.PlcResponseFormat)
+		h.config.PlcResponseFormat = next.PlcResponseFormat
+	}
+	h.config.PlcResponseProgress = next.PlcResponseProgress
+
+	if h.config.ResponseDedupWindow != next.ResponseDedupWindow {
+		utils.Logger.Infof("🔄 Response dedup window: %s -> %s", h.config.ResponseDedupWindow, next.ResponseDedupWindow)
+		h.config.ResponseDedupWindow = next.ResponseDedupWindow
+		h.responseDedup.window = next.ResponseDedupWindow
+	}
+	h.config.CommandDedupWindow = next.CommandDedupWindow
+
+	// 확인 토픽은 시작 시 구독되므로 모드 전환 후 확인은 관리자 API로도 가능
+	if h.config.CommissioningMode != next.CommissioningMode {
+		utils.Logger.Infof("🔄 Commissioning mode: %v -> %v (%d order(s) still awaiting confirmation)",
+			h.config.CommissioningMode, next.CommissioningMode, len(h.pendingOrders))
+		h.config.CommissioningMode = next.CommissioningMode
+	}
+	h.config.CommissioningConfirmTimeout = next.CommissioningConfirmTimeout
+
+	if h.config.OrderPolicy != next.OrderPolicy || h.config.MaxConcurrentOrders != next.MaxConcurrentOrders {
+		utils.Logger.Infof("🔄 Order policy: %s (max %d) -> %s (max %d), %d command(s) queued",
+			h.config.OrderPolicy, h.config.MaxConcurrentOrders, next.OrderPolicy, next.MaxConcurrentOrders, len(h.queuedCommands))
+		h.config.OrderPolicy = next.OrderPolicy
+		h.config.MaxConcurrentOrders = next.MaxConcurrentOrders
+		defer h.dispatchQueued()
+	}
+	h.config.PreemptPriority = next.PreemptPriority
+	if h.config.OperatingModePolicy != next.OperatingModePolicy {
+		utils.Logger.Infof("🔄 Operating mode policy: %s -> %s", h.config.OperatingModePolicy, next.OperatingModePolicy)
+		h.config.OperatingModePolicy = next.OperatingModePolicy
+		defer h.dispatchQueued()
+	}
+	h.config.PausedPolicy = next.PausedPolicy
+	h.config.DeferWhileDriving = next.DeferWhileDriving
+	h.config.DrivingMaxWait = next.DrivingMaxWait
+	h.config.EstopAction = next.EstopAction
+
+	h.config.RobotSchemaValidation = next.RobotSchemaValidation
+	h.config.PLCHeartbeatTimeout = next.PLCHeartbeatTimeout
+	h.config.PLCHeartbeatCancelOrders = next.PLCHeartbeatCancelOrders
+	h.config.ReconnectReplayResponses = next.ReconnectReplayResponses
+	h.config.RobotDeadLetterTopic = next.RobotDeadLetterTopic
+	h.config.CommandDeadLetterTopic = next.CommandDeadLetterTopic
+	h.config.CommandMaxDelay = next.CommandMaxDelay
+	h.config.PositionTopic = next.PositionTopic
+	h.config.PositionInterval = next.PositionInterval
+	h.config.VisualizationRate = next.VisualizationRate
+	h.visualization.setRate(next.VisualizationRate)
+
+	h.config.PayloadCharset = next.PayloadCharset
+	h.config.PayloadStripBOM = next.PayloadStripBOM
+	h.config.PayloadRemoveWhitespace = next.PayloadRemoveWhitespace
+	h.config.PayloadCase = next.PayloadCase
+
+	// 매핑 파일은 리로드 시 다시 읽음 (파일만 수정한 경우도 반영)
+	if mapping, err := loadCommandMapping(next); err == nil {
+		h.commandMapping = mapping
+		h.config.CommandMappingFile = next.CommandMappingFile
+	} else {
+		utils.Logger.Errorf("❌ Command mapping not reloaded: %v", err)
+	}
+
+	if templates, err := next.LoadOrderTemplates(); err == nil {
+		h.orderTemplates = templates
+		h.config.OrderTemplateDir = next.OrderTemplateDir
+	} else {
+		utils.Logger.Errorf("❌ Order templates not reloaded: %v", err)
+	}
+
+	if paths, err := next.ParseNavigationPaths(); err == nil {
+		h.navPaths = paths
+		h.config.NavPaths = next.NavPaths
+	}
+	if waypoints, err := next.ParseWaypoints(); err == nil {
+		h.waypoints = waypoints
+		h.config.Waypoints = next.Waypoints
+	}
+	if aliases, err := next.ParseCommandAliases(); err == nil {
+		h.commandAliases = aliases
+		h.config.CommandAliases = next.CommandAliases
+	}
+	h.config.AllowedInferences = next.AllowedInferences
+	h.config.AllowedTrajectories = next.AllowedTrajectories
+	h.allowlist = newActionAllowlist(next)
+	h.config.NavEdgeTrajectory = next.NavEdgeTrajectory
+	h.config.MapID = next.MapID
+	h.config.RobotMapIDs = next.RobotMapIDs
+
+	if policies, err := next.ParseEscalationPolicies(); err == nil {
+		h.escalationPolicies = policies
+		h.escalationWebhook = alert.NewWebhook(next.EscalationWebhookURL)
+		h.config.EscalationWebhookURL = next.EscalationWebhookURL
+	}
+
+	if h.config.InstantActionRate != next.InstantActionRate ||
+		h.config.InstantActionBurst != next.InstantActionBurst ||
+		h.config.InstantActionCoalesceWindow != next.InstantActionCoalesceWindow {
+		utils.Logger.Infof("🔄 InstantAction limits: rate=%.2f/s burst=%d coalesce=%s",
+			next.InstantActionRate, next.InstantActionBurst, next.InstantActionCoalesceWindow)
+		h.config.InstantActionRate = next.InstantActionRate
+		h.config.InstantActionBurst = next.InstantActionBurst
+		h.config.InstantActionCoalesceWindow = next.InstantActionCoalesceWindow
+		h.instantActionThrottle = newInstantActionThrottle(next.InstantActionRate, next.InstantActionBurst, next.InstantActionCoalesceWindow)
+	}
+
+	if h.config.CommandRateLimit != next.CommandRateLimit || h.config.CommandRateBurst != next.CommandRateBurst ||
+		h.config.BaseCommandRateLimit != next.BaseCommandRateLimit || h.config.BaseCommandRateBurst != next.BaseCommandRateBurst {
+		utils.Logger.Infof("🔄 Command rate limits: route=%.2f/s burst=%d, base command=%.2f/s burst=%d",
+			next.CommandRateLimit, next.CommandRateBurst, next.BaseCommandRateLimit, next.BaseCommandRateBurst)
+		h.config.CommandRateLimit = next.CommandRateLimit
+		h.config.CommandRateBurst = next.CommandRateBurst
+		h.config.BaseCommandRateLimit = next.BaseCommandRateLimit
+		h.config.BaseCommandRateBurst = next.BaseCommandRateBurst
+		h.commandRates = newCommandRateLimiter()
+	}
+}
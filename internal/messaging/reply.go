@@ -0,0 +1,20 @@
+// internal/messaging/reply.go - Per-command Response Channels
+package messaging
+
+// beginReply 명령 처리 중 응답 채널 설정 (잠금 보유 상태에서 호출, 반환 함수로 이전 값 복원)
+// 처리 중 생성된 오더는 같은 채널로 이후 상태를 응답 (COMMAND_ROUTES의 {replyChannel} 참고)
+func (h *DirectActionHandler) beginReply(replyChannel string) func() {
+	previous := h.replyChannel
+	h.replyChannel = replyChannel
+	return func() {
+		h.replyChannel = previous
+	}
+}
+
+// responseTopic 응답 채널의 PLC 응답 토픽 (채널이 없으면 경로 응답 토픽)
+func (h *DirectActionHandler) responseTopic(replyChannel string) string {
+	if replyChannel == "" {
+		return h.config.PlcResponseTopic
+	}
+	return h.config.PlcResponseTopic + "/" + replyChannel
+}
@@ -0,0 +1,316 @@
+// internal/messaging/rosbridge.go - ROS 2 Robot Protocol (rosbridge websocket)
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// ROS 2 action_msgs/GoalStatus 값
+const (
+	rosGoalStatusSucceeded = 4
+	rosGoalStatusCanceled  = 5
+	rosGoalStatusAborted   = 6
+)
+
+// maxRosbridgeBackoff rosbridge 재연결 최대 대기 시간
+const maxRosbridgeBackoff = 30 * time.Second
+
+// rosbridgeProtocol ROS 2 액션을 rosbridge_server 웹소켓으로 호출
+// 모든 명령은 하나의 범용 액션(ROSBRIDGE_ACTION)의 goal로 전송되며,
+// 로봇측 노드가 goal의 action_type/parameters를 해석함
+type rosbridgeProtocol struct {
+	config *config.Config
+	ids    utils.IDGenerator
+
+	mu         sync.Mutex
+	conn       *websocket.Conn
+	goals      map[string]*rosbridgeGoal // goal id -> goal
+	orderGoals map[string]string         // orderID -> goal id
+}
+
+// rosbridgeGoal 전송한 goal 정보
+type rosbridgeGoal struct {
+	orderID    string // 즉시 액션이면 빈 값
+	actionType string
+}
+
+// rosKeyValue goal 파라미터 (diagnostic_msgs/KeyValue 형식)
+type rosKeyValue struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// rosbridgeGoalArgs 범용 액션 goal 필드
+type rosbridgeGoalArgs struct {
+	ActionType string        `json:"action_type"`
+	OrderID    string        `json:"order_id"`
+	Parameters []rosKeyValue `json:"parameters"`
+}
+
+// rosbridgeFrame rosbridge v2 프로토콜 메시지 (송수신 공통 필드)
+type rosbridgeFrame struct {
+	Op         string             `json:"op"`
+	ID         string             `json:"id,omitempty"`
+	Action     string             `json:"action,omitempty"`
+	ActionType string             `json:"action_type,omitempty"`
+	Args       *rosbridgeGoalArgs `json:"args,omitempty"`
+	Feedback   bool               `json:"feedback,omitempty"`
+	Status     *int               `json:"status,omitempty"`
+	Result     *bool              `json:"result,omitempty"`
+	Values     json.RawMessage    `json:"values,omitempty"`
+}
+
+// newRosbridgeProtocol 새 rosbridge 프로토콜 생성
+func newRosbridgeProtocol(cfg *config.Config, ids utils.IDGenerator) *rosbridgeProtocol {
+	return &rosbridgeProtocol{
+		config:     cfg,
+		ids:        ids,
+		goals:      make(map[string]*rosbridgeGoal),
+		orderGoals: make(map[string]string),
+	}
+}
+
+// Name 프로토콜 이름
+func (p *rosbridgeProtocol) Name() string {
+	return "rosbridge"
+}
+
+// BuildOrder 오더를 send_action_goal 요청으로 변환
+// goal 하나가 액션 하나에 대응하므로 여러 액션 파이프라인과 이동 경로는 지원하지 않음
+func (p *rosbridgeProtocol) BuildOrder(req OrderRequest) (*OutboundMessage, error) {
+	if len(req.Actions) != 1 {
+		return nil, fmt.Errorf("rosbridge protocol supports exactly one action per order, got %d", len(req.Actions))
+	}
+	if len(req.Path) > 0 {
+		return nil, fmt.Errorf("waypoint paths are not supported by rosbridge protocol")
+	}
+	message, goalID, err := p.buildGoal(req.OrderID, req.Actions[0].ActionType, req.Actions[0].Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.orderGoals[req.OrderID] = goalID
+	p.mu.Unlock()
+	return message, nil
+}
+
+// BuildCancel 오더의 goal에 대한 cancel_action_goal 요청 생성
+func (p *rosbridgeProtocol) BuildCancel(orderID string) (*OutboundMessage, error) {
+	p.mu.Lock()
+	goalID, exists := p.orderGoals[orderID]
+	p.mu.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("no rosbridge goal for order %s", orderID)
+	}
+
+	frame := rosbridgeFrame{Op: "cancel_action_goal", ID: goalID, Action: p.config.RosbridgeAction}
+	msgData, err := json.Marshal(frame)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal cancel_action_goal: %v", err)
+	}
+	return &OutboundMessage{Topic: p.config.RosbridgeAction, Payload: msgData, ActionID: goalID}, nil
+}
+
+// BuildInstantAction 즉시 액션을 주문 없는 goal로 변환
+// stateRequest는 rosbridge가 피드백을 자동으로 전달하므로 지원하지 않음
+func (p *rosbridgeProtocol) BuildInstantAction(req InstantActionRequest) (*OutboundMessage, error) {
+	if req.ActionType == "stateRequest" {
+		return nil, fmt.Errorf("stateRequest is not supported by rosbridge protocol")
+	}
+	message, _, err := p.buildGoal("", req.ActionType, req.Parameters)
+	return message, err
+}
+
+// buildGoal send_action_goal 요청 생성 및 goal 등록
+func (p *rosbridgeProtocol) buildGoal(orderID, actionType string, parameters []types.ActionParameter) (*OutboundMessage, string, error) {
+	args := &rosbridgeGoalArgs{
+		ActionType: actionType,
+		OrderID:    orderID,
+		Parameters: make([]rosKeyValue, 0, len(parameters)),
+	}
+	for _, parameter := range parameters {
+		value, err := rosParameterValue(parameter.Value)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid parameter %s: %v", parameter.Key, err)
+		}
+		args.Parameters = append(args.Parameters, rosKeyValue{Key: parameter.Key, Value: value})
+	}
+
+	goalID := p.ids.NewID()
+	frame := rosbridgeFrame{
+		Op:         "send_action_goal",
+		ID:         goalID,
+		Action:     p.config.RosbridgeAction,
+		ActionType: p.config.RosbridgeActionType,
+		Args:       args,
+		Feedback:   true,
+	}
+	msgData, err := json.Marshal(frame)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal send_action_goal: %v", err)
+	}
+
+	p.mu.Lock()
+	p.goals[goalID] = &rosbridgeGoal{orderID: orderID, actionType: actionType}
+	p.mu.Unlock()
+
+	return &OutboundMessage{Topic: p.config.RosbridgeAction, Payload: msgData, ActionID: goalID}, goalID, nil
+}
+
+// rosParameterValue 파라미터 값을 문자열로 변환 (문자열 외 값은 JSON)
+func rosParameterValue(value interface{}) (string, error) {
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ParseState action_feedback/action_result 메시지를 액션 상태로 변환
+// 피드백은 RUNNING, 결과는 GoalStatus에 따라 FINISHED/FAILED
+func (p *rosbridgeProtocol) ParseState(payload []byte) (*RobotState, error) {
+	var frame rosbridgeFrame
+	if err := json.Unmarshal(payload, &frame); err != nil {
+		return nil, err
+	}
+
+	state := &RobotState{}
+	if frame.Op != "action_feedback" && frame.Op != "action_result" {
+		return state, nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	goal, exists := p.goals[frame.ID]
+	if !exists {
+		return state, nil
+	}
+
+	actionState := ActionState{
+		ActionID:     frame.ID,
+		ActionType:   goal.actionType,
+		ActionStatus: "RUNNING",
+	}
+	if frame.Op == "action_result" {
+		actionState.ActionStatus, actionState.ResultDescription = rosGoalResult(frame)
+		p.forgetGoal(frame.ID, goal)
+	}
+
+	state.OrderID = goal.orderID
+	state.ActionStates = []ActionState{actionState}
+	return state, nil
+}
+
+// rosGoalResult action_result를 액션 상태와 설명으로 변환
+func rosGoalResult(frame rosbridgeFrame) (string, string) {
+	if frame.Result != nil && !*frame.Result {
+		return "FAILED", fmt.Sprintf("goal failed: %s", string(frame.Values))
+	}
+	if frame.Status == nil {
+		return "FINISHED", ""
+	}
+
+	switch *frame.Status {
+	case rosGoalStatusSucceeded:
+		return "FINISHED", ""
+	case rosGoalStatusCanceled:
+		return "FAILED", "goal canceled"
+	case rosGoalStatusAborted:
+		return "FAILED", "goal aborted"
+	default:
+		return "FAILED", fmt.Sprintf("unexpected goal status %d", *frame.Status)
+	}
+}
+
+// forgetGoal 종료된 goal 정리 (잠금 보유 상태에서 호출)
+func (p *rosbridgeProtocol) forgetGoal(goalID string, goal *rosbridgeGoal) {
+	delete(p.goals, goalID)
+	if goal.orderID != "" {
+		delete(p.orderGoals, goal.orderID)
+	}
+}
+
+// Send rosbridge 웹소켓으로 메시지 전송
+func (p *rosbridgeProtocol) Send(message *OutboundMessage) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.conn == nil {
+		return fmt.Errorf("rosbridge not connected: %s", p.config.RosbridgeURL)
+	}
+	return p.conn.WriteMessage(websocket.TextMessage, message.Payload)
+}
+
+// Run rosbridge 연결 유지 (끊기면 재연결, 연결 상태를 ONLINE/OFFLINE으로 전달)
+func (p *rosbridgeProtocol) Run(ctx context.Context, sink RobotSink) {
+	delay := p.config.RosbridgeReconnectDelay
+
+	for {
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, p.config.RosbridgeURL, nil)
+		if err != nil {
+			utils.Logger.Warnf("⚠️ rosbridge connection failed (%s), retrying in %v: %v", p.config.RosbridgeURL, delay, err)
+		} else {
+			utils.Logger.Infof("✅ Connected to rosbridge: %s", p.config.RosbridgeURL)
+			delay = p.config.RosbridgeReconnectDelay
+			p.serve(ctx, conn, sink)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > maxRosbridgeBackoff {
+			delay = maxRosbridgeBackoff
+		}
+	}
+}
+
+// serve 연결 하나의 수신 루프 (연결 종료 또는 ctx 종료 시 반환)
+func (p *rosbridgeProtocol) serve(ctx context.Context, conn *websocket.Conn, sink RobotSink) {
+	p.mu.Lock()
+	p.conn = conn
+	p.mu.Unlock()
+
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+
+	sink.ProcessRobotConnection("ONLINE")
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() == nil {
+				utils.Logger.Warnf("⚠️ rosbridge connection lost: %v", err)
+			}
+			break
+		}
+		sink.ProcessRobotState(data)
+	}
+
+	// 연결이 끊기면 진행 중인 goal 결과를 받을 수 없음
+	p.mu.Lock()
+	p.conn = nil
+	p.goals = make(map[string]*rosbridgeGoal)
+	p.orderGoals = make(map[string]string)
+	p.mu.Unlock()
+	conn.Close()
+
+	if ctx.Err() == nil {
+		sink.ProcessRobotConnection("OFFLINE")
+	}
+}
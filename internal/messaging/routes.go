@@ -0,0 +1,45 @@
+// internal/messaging/routes.go - Handler Lookup Across Command Routes
+package messaging
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownRobot 명령 경로가 없는 로봇
+var ErrUnknownRobot = errors.New("unknown robot")
+
+// HandlerForRobot 로봇 시리얼의 핸들러 (robot이 비어있으면 첫 번째 명령 경로)
+func HandlerForRobot(handlers []*DirectActionHandler, robot string) (*DirectActionHandler, error) {
+	if robot == "" {
+		return handlers[0], nil
+	}
+	for _, handler := range handlers {
+		if handler.RobotSerialNumber() == robot {
+			return handler, nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", ErrUnknownRobot, robot)
+}
+
+// HandlersForRobot 로봇 시리얼의 핸들러 목록 (robot이 비어있으면 전체 명령 경로)
+func HandlersForRobot(handlers []*DirectActionHandler, robot string) ([]*DirectActionHandler, error) {
+	if robot == "" {
+		return handlers, nil
+	}
+	handler, err := HandlerForRobot(handlers, robot)
+	if err != nil {
+		return nil, err
+	}
+	return []*DirectActionHandler{handler}, nil
+}
+
+// HandlerForOrder 오더를 추적 중인 핸들러 (진행/취소/확인 대기/최근 오더, 없으면 ErrOrderNotFound)
+func HandlerForOrder(handlers []*DirectActionHandler, orderID string) (*DirectActionHandler, error) {
+	for _, handler := range handlers {
+		if _, exists := handler.GetOrder(orderID); exists {
+			return handler, nil
+		}
+	}
+	return nil, ErrOrderNotFound
+}
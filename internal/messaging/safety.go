@@ -0,0 +1,75 @@
+// internal/messaging/safety.go - Robot Safety State Monitoring
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"strconv"
+	"time"
+)
+
+// recordSafetyState 안전 상태가 바뀌면 안전 토픽(retained)과 이벤트 발행 (잠금 보유 상태에서 호출)
+func (h *DirectActionHandler) recordSafetyState(state *RobotState) {
+	if state.SafetyState == nil {
+		return
+	}
+	if h.safety != nil && h.safety.EStop == state.SafetyState.EStop && h.safety.FieldViolation == state.SafetyState.FieldViolation {
+		return
+	}
+
+	safety := &types.SafetyEvent{
+		Robot:          h.config.RobotSerialNumber,
+		EStop:          state.SafetyState.EStop,
+		FieldViolation: state.SafetyState.FieldViolation,
+		UpdatedAt:      time.Now(),
+	}
+	switch {
+	case safety.EStopActive():
+		utils.Logger.Errorf("🛑 Robot %s e-stop active (%s) - refusing new PLC commands", safety.Robot, safety.EStop)
+	case h.safety.EStopActive():
+		utils.Logger.Infof("✅ Robot %s e-stop released", safety.Robot)
+	}
+	if safety.FieldViolation {
+		utils.Logger.Warnf("⚠️ Robot %s protective field violation", safety.Robot)
+	}
+	h.safety = safety
+
+	h.publishEvent(events.Event{
+		Type:    events.TypeSafetyState,
+		Status:  safety.EStop,
+		Message: "fieldViolation=" + strconv.FormatBool(safety.FieldViolation),
+	})
+
+	msgData, err := json.Marshal(safety)
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal safety state: %v", err)
+		return
+	}
+	if err := h.mqttClient.Publish(h.safetyTopic(), 0, true, msgData); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish safety state: %v", err)
+	}
+}
+
+// ErrEStopActive 로봇 비상 정지 중 거부된 명령 (X 응답)
+var ErrEStopActive = errors.New("robot e-stop active")
+
+// rejectIfEStop 비상 정지 중이면 명령을 실행하지 않고 X 응답 (잠금 보유 상태에서 호출, 거부하지 않으면 nil)
+func (h *DirectActionHandler) rejectIfEStop(commandStr string) *CommandResult {
+	if !h.safety.EStopActive() {
+		return nil
+	}
+
+	utils.Logger.Errorf("❌ Command rejected - robot e-stop active (%s): %s", h.safety.EStop, commandStr)
+	err := fmt.Errorf("%w (%s)", ErrEStopActive, h.safety.EStop)
+	h.publishPLCResponse(types.NewPLCResponse(commandStr, types.PLCStatusSafetyStop, err.Error()))
+	return newCommandResult(commandStr, "", err)
+}
+
+// safetyTopic 로봇별 안전 상태 토픽
+func (h *DirectActionHandler) safetyTopic() string {
+	return fmt.Sprintf("%s/%s", h.config.SafetyTopic, h.config.RobotSerialNumber)
+}
@@ -0,0 +1,65 @@
+// internal/messaging/schema.go - Robot Message Schema Validation and Quarantine
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/schema"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// quarantinedMessage 스키마 위반으로 처리하지 않은 로봇 메시지 (dead-letter 토픽)
+type quarantinedMessage struct {
+	Robot      string             `json:"robot"`
+	Topic      string             `json:"topic"`
+	Message    string             `json:"message"` // state, connection, factsheet
+	Payload    string             `json:"payload"`
+	Violations []schema.Violation `json:"violations"`
+	ReceivedAt time.Time          `json:"receivedAt"`
+}
+
+// acceptRobotMessage 로봇 메시지 스키마 검증 (ROBOT_SCHEMA_VALIDATION)
+// 위반 시 일부만 해석하지 않고 dead-letter 토픽으로 격리한 뒤 false 반환 (잠금 없이 호출)
+func (h *DirectActionHandler) acceptRobotMessage(message, topic string, payload []byte) bool {
+	h.mu.Lock()
+	enabled, deadLetterTopic := h.config.RobotSchemaValidation, h.config.RobotDeadLetterTopic
+	h.mu.Unlock()
+	if !enabled {
+		return true
+	}
+
+	err := h.schemaValidator.Validate(message, payload)
+	if err == nil {
+		return true
+	}
+
+	var violations schema.Violations
+	if !errors.As(err, &violations) {
+		violations = schema.Violations{{Path: "$", Reason: err.Error()}}
+	}
+	utils.Logger.Errorf("❌ Robot %s message failed schema validation, quarantined: %v", message, violations)
+	h.publishEvent(events.Event{
+		Type:    events.TypeRobotMessageInvalid,
+		Status:  message,
+		Message: violations.Error(),
+	})
+
+	msgData, err := json.Marshal(quarantinedMessage{
+		Robot:      h.config.RobotSerialNumber,
+		Topic:      topic,
+		Message:    message,
+		Payload:    string(payload),
+		Violations: violations,
+		ReceivedAt: time.Now(),
+	})
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to marshal quarantined message: %v", err)
+		return false
+	}
+	if err := h.mqttClient.Publish(deadLetterTopic, 0, false, msgData); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish quarantined message: %v", err)
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+// internal/messaging/standby.go - HA Standby Mode and Active Order Handoff
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// ErrStandby 대기(standby) 인스턴스는 PLC 명령을 처리하지 않음 (리더가 처리)
+var ErrStandby = errors.New("bridge instance is standby")
+
+// SetStandby 대기 모드 설정 (HA 리더 선출 결과, 대기 중에는 PLC 명령을 응답 없이 무시)
+func (h *DirectActionHandler) SetStandby(standby bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.standby == standby {
+		return
+	}
+	h.standby = standby
+	if standby {
+		utils.Logger.Warnf("💤 Robot %s handler is standby - ignoring PLC commands", h.config.RobotSerialNumber)
+		h.discardForStandby()
+		return
+	}
+	utils.Logger.Infof("👑 Robot %s handler is active - processing PLC commands", h.config.RobotSerialNumber)
+	defer h.dispatchQueued()
+}
+
+// discardForStandby 아직 발행하지 않은 작업 정리 (잠금 보유 상태에서 호출)
+// 대기열/보관/지연 오더와 체인은 인계 대상이 아니므로 타이머를 멈추고 실패 응답 (PLC가 새 리더로 재전송)
+// 진행 중인 오더는 인계 또는 재승격을 위해 유지
+func (h *DirectActionHandler) discardForStandby() {
+	for _, queued := range h.queuedCommands {
+		h.sendPLCFailure(queued.Command, fmt.Errorf("discarded: %w", ErrStandby))
+	}
+	h.queuedCommands = nil
+	for _, buffered := range h.bufferedCommands {
+		restore := h.beginReply(buffered.replyChannel)
+		h.sendPLCFailure(buffered.command, fmt.Errorf("discarded: %w", ErrStandby))
+		restore()
+	}
+	h.bufferedCommands = nil
+	for _, pending := range h.pendingOrders {
+		h.rejectPendingOrder(pending, ErrStandby.Error())
+	}
+	h.stopChains(ErrStandby.Error())
+}
+
+// IsStandby 대기 모드 여부
+func (h *DirectActionHandler) IsStandby() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.standby
+}
+
+// ExportOrders 진행/취소 중인 오더 복사본 (HA 상태 인계)
+func (h *DirectActionHandler) ExportOrders() []OrderInfo {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	orders := make([]OrderInfo, 0, len(h.activeOrders)+len(h.canceledOrders))
+	for _, tracked := range []map[string]*OrderInfo{h.activeOrders, h.canceledOrders} {
+		for _, order := range tracked {
+			orders = append(orders, *order)
+		}
+	}
+	return orders
+}
+
+// ImportOrders 이전 리더가 인계한 오더를 추적 목록에 추가 (이미 추적 중인 오더는 유지)
+// 인계된 오더는 로봇 상태로 종료를 확인하고 원래 명령에 최종 상태 응답
+func (h *DirectActionHandler) ImportOrders(orders []OrderInfo) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	imported := 0
+	for i := range orders {
+		order := orders[i]
+		if _, exists := h.activeOrders[order.OrderID]; exists {
+			continue
+		}
+		if _, exists := h.canceledOrders[order.OrderID]; exists {
+			continue
+		}
+
+		order.actionStatuses = make(map[string]string)
+		order.publishedAt = time.Now() // 시간 초과 단계는 인계 시점부터
+		if order.Canceled {
+			h.canceledOrders[order.OrderID] = &order
+		} else {
+			h.activeOrders[order.OrderID] = &order
+		}
+		h.orderLog(&order).Info("Order handed over from previous leader")
+		imported++
+	}
+	return imported
+}
+
+// ReleaseOrders 새 리더가 인계받은 오더 추적 중단 (PLC 응답 없이 제거, 두 인스턴스가 같은 오더에 응답하지 않도록)
+func (h *DirectActionHandler) ReleaseOrders() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	released := len(h.activeOrders) + len(h.canceledOrders)
+	h.activeOrders = make(map[string]*OrderInfo)
+	h.canceledOrders = make(map[string]*OrderInfo)
+	return released
+}
@@ -0,0 +1,77 @@
+// internal/messaging/statequeue.go - Robot State Worker Queue
+package messaging
+
+import (
+	"context"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/utils"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// stateQueue 로봇 상태 메시지 대기열 (paho 콜백을 막지 않도록 작업자가 순서대로 처리)
+// 가득 차면 가장 오래된 상태를 버림 (최신 상태가 더 중요)
+type stateQueue struct {
+	messages chan mqtt.Message
+}
+
+// newStateQueue 새 상태 대기열 생성 (size 0이면 nil, 콜백에서 바로 처리)
+func newStateQueue(size int) *stateQueue {
+	if size <= 0 {
+		return nil
+	}
+	return &stateQueue{messages: make(chan mqtt.Message, size)}
+}
+
+// EnqueueRobotState 로봇 상태 메시지를 대기열에 추가 (STATE_QUEUE_SIZE 0이면 바로 처리)
+// 같은 로봇의 상태는 구독 콜백 하나에서만 들어오므로 버린 뒤 추가는 항상 성공
+func (h *DirectActionHandler) EnqueueRobotState(client mqtt.Client, msg mqtt.Message) {
+	if h.stateQueue == nil {
+		h.HandleRobotState(client, msg)
+		return
+	}
+
+	for {
+		select {
+		case h.stateQueue.messages <- msg:
+			return
+		default:
+		}
+
+		select {
+		case <-h.stateQueue.messages:
+			utils.Logger.Debugf("Robot %s state queue full (%d) - dropping oldest state", h.config.RobotSerialNumber, cap(h.stateQueue.messages))
+			h.publishEvent(events.Event{Type: events.TypeRobotStateDropped})
+		default:
+		}
+	}
+}
+
+// StateQueueDepth 처리 대기 중인 로봇 상태 수 (메트릭)
+func (h *DirectActionHandler) StateQueueDepth() int {
+	if h.stateQueue == nil {
+		return 0
+	}
+	return len(h.stateQueue.messages)
+}
+
+// RunStateWorker 대기열의 로봇 상태를 순서대로 처리 (ctx 종료 시 반환)
+func (h *DirectActionHandler) RunStateWorker(ctx context.Context) {
+	if h.stateQueue == nil {
+		return
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-h.stateQueue.messages:
+			h.handleQueuedState(msg)
+		}
+	}
+}
+
+// handleQueuedState 대기열의 상태 하나 처리 (패닉이 나도 작업자는 계속 실행)
+func (h *DirectActionHandler) handleQueuedState(msg mqtt.Message) {
+	defer recoverHandler(h.eventBus, "state worker", msg)
+	h.HandleRobotState(nil, msg)
+}
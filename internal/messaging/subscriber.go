@@ -2,25 +2,63 @@
 package messaging
 
 import (
+	"errors"
 	"fmt"
+	"mqtt-bridge/internal/config"
 	"mqtt-bridge/internal/utils"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 )
 
 // Subscriber MQTT 구독 관리자
 type Subscriber struct {
-	client  *MQTTClient
-	handler *DirectActionHandler
+	client     TopicSubscriber
+	routes     []CommandRoute
+	byRobot    map[string]*DirectActionHandler // 로봇 시리얼 -> 핸들러 (로봇 토픽 라우팅)
+	subscribed atomic.Bool                     // 필수 구독 완료 여부 (readiness 용)
+	started    atomic.Bool                     // SubscribeAll 완료 (이전 재연결은 readiness를 바꾸지 않음)
+
+	mu     sync.Mutex
+	failed map[string]string // 실패한 비필수 구독 토픽 -> 오류 (degraded 모드)
+	active []subscription    // 구독 완료된 토픽 (재연결 시 다시 구독)
+}
+
+// maxSubscribeBackoff 구독 재시도 최대 대기 시간
+const maxSubscribeBackoff = 30 * time.Second
+
+// subscription 구독 토픽 정의
+type subscription struct {
+	topic       string
+	description string
+	handler     mqtt.MessageHandler
+	critical    bool // 실패 시 시작 중단 (false면 degraded 모드로 계속)
+}
+
+// CommandRoute PLC 명령 토픽과 담당 핸들러 (핸들러 하나가 로봇 하나를 담당)
+type CommandRoute struct {
+	CommandTopic string
+	Handler      *DirectActionHandler
 }
 
 // NewSubscriber 새 구독자 생성
-func NewSubscriber(client *MQTTClient, handler *DirectActionHandler) *Subscriber {
+func NewSubscriber(client TopicSubscriber, routes []CommandRoute) *Subscriber {
 	utils.Logger.Infof("🏗️ Creating MQTT Subscriber")
 
 	subscriber := &Subscriber{
 		client:  client,
-		handler: handler,
+		routes:  routes,
+		byRobot: make(map[string]*DirectActionHandler, len(routes)),
+		failed:  make(map[string]string),
+	}
+	for _, route := range routes {
+		subscriber.byRobot[route.Handler.config.RobotSerialNumber] = route.Handler
+		utils.Logger.Infof("🔀 Command route: %s -> robot %s (responses to %s)",
+			route.CommandTopic, route.Handler.config.RobotSerialNumber, route.Handler.config.PlcResponseTopic)
 	}
 
 	utils.Logger.Infof("✅ MQTT Subscriber Created")
@@ -31,73 +69,383 @@ func NewSubscriber(client *MQTTClient, handler *DirectActionHandler) *Subscriber
 func (s *Subscriber) SubscribeAll() error {
 	utils.Logger.Infof("🔔 Starting Subscriptions")
 
-	// 구독할 토픽들
-	subscriptions := []struct {
-		topic       string
-		description string
-		handler     mqtt.MessageHandler
-	}{
-		{
-			topic:       "bridge/command",
-			description: "PLC Commands",
-			handler:     s.handlePLCCommand,
-		},
-		{
-			topic:       "meili/v2/+/+/state",
-			description: "Robot States",
-			handler:     s.handleRobotState,
-		},
-		{
-			topic:       "meili/v2/+/+/connection",
-			description: "Robot Connection States",
-			handler:     s.handleRobotConnection,
-		},
+	// clean session이므로 재연결 시 브로커에 구독이 남아있지 않음 (다시 구독 후 SUBACK 확인)
+	s.client.OnConnect(s.handleReconnect)
+
+	cfg := s.client.GetConfig()
+	robotTopicPrefixes := s.robotTopicPrefixes()
+
+	// PLC 명령 토픽 (경로별, 시작 게이트 사용 시 로봇 ONLINE 이후 구독)
+	// Sparkplug B 모드에서는 명령이 NCMD로 수신되므로 원시 명령 토픽을 구독하지 않음
+	commandSubscriptions := make([]subscription, 0, len(s.routes))
+	for _, route := range s.routes {
+		if cfg.SparkplugEnabled {
+			break
+		}
+		commandSubscriptions = append(commandSubscriptions, subscription{
+			topic:       config.CommandSubscriptionTopic(route.CommandTopic),
+			description: "PLC Commands for " + route.Handler.config.RobotSerialNumber,
+			critical:    true,
+			handler:     s.commandHandler(route),
+		})
+	}
+
+	// 구독할 토픽들 (로봇 전송 계층 사용 시 로봇 토픽은 MQTT로 수신하지 않음)
+	var subscriptions []subscription
+	usesRobotTransport := s.routes[0].Handler.UsesRobotTransport()
+	for _, robotTopicPrefix := range robotTopicPrefixes {
+		if usesRobotTransport {
+			break
+		}
+		subscriptions = append(subscriptions,
+			subscription{
+				topic:       robotTopicPrefix + "/+/+/state",
+				description: "Robot States",
+				critical:    true,
+				handler:     s.handleRobotState,
+			},
+			subscription{
+				topic:       robotTopicPrefix + "/+/+/connection",
+				description: "Robot Connection States",
+				critical:    true,
+				handler:     s.handleRobotConnection,
+			},
+		)
+
+		// 로봇 factsheet 토픽 (버전 협상, factsheet 동시 오더 한도, 이름 목록 또는 액션 검증 사용 시)
+		if cfg.ProtocolAutoNegotiate || cfg.FactsheetOrderLimit || cfg.FactsheetAllowlist || cfg.FactsheetActionValidation {
+			subscriptions = append(subscriptions, subscription{
+				topic:       robotTopicPrefix + "/+/+/factsheet",
+				description: "Robot Factsheets",
+				handler:     s.handleRobotFactsheet,
+			})
+		}
+
+		// 로봇 visualization 토픽 (다운샘플링 후 경량 토픽/이벤트 스트림으로 전달 시)
+		if cfg.VisualizationTopic != "" || cfg.VisualizationEvents {
+			subscriptions = append(subscriptions, subscription{
+				topic:       robotTopicPrefix + "/+/+/visualization",
+				description: "Robot Visualization",
+				handler:     s.handleRobotVisualization,
+			})
+		}
+	}
+
+	// 운영자 오류 확인 토픽 (Fault Latch 활성화 시)
+	if cfg.FaultLatchEnabled {
+		subscriptions = append(subscriptions, subscription{
+			topic:       cfg.FaultAckTopic,
+			description: "Operator Fault Acknowledgments",
+			handler:     s.handleFaultAck,
+		})
+	}
+
+	// 운영자 오더 확인 토픽 (커미셔닝 모드)
+	if cfg.CommissioningMode {
+		subscriptions = append(subscriptions, subscription{
+			topic:       cfg.CommissioningConfirmTopic,
+			description: "Commissioning Order Confirmations",
+			critical:    true,
+			handler:     s.handleOrderConfirmation,
+		})
 	}
 
 	// 각 토픽 구독
+	if !cfg.StartupWaitForRobot {
+		subscriptions = append(commandSubscriptions, subscriptions...)
+	}
+	if err := s.subscribeEach(subscriptions); err != nil {
+		return err
+	}
+
+	// 시작 게이트: 로봇 ONLINE 확인 후 PLC 명령 처리 시작
+	if cfg.StartupWaitForRobot {
+		if err := s.openCommandGate(commandSubscriptions); err != nil {
+			return err
+		}
+	}
+
+	s.started.Store(true)
+	s.subscribed.Store(true)
+	if failed := s.FailedSubscriptions(); len(failed) > 0 {
+		utils.Logger.Warnf("⚠️ Subscriptions completed in degraded mode (%d failed)", len(failed))
+	} else {
+		utils.Logger.Infof("🎉 All subscriptions completed")
+	}
+	return nil
+}
+
+// subscribeEach 토픽 목록 구독 (필수 구독 실패 시 중단, 비필수는 degraded 기록)
+func (s *Subscriber) subscribeEach(subscriptions []subscription) error {
+	for _, sub := range subscriptions {
+		if err := s.subscribe(sub); err != nil {
+			if sub.critical {
+				return fmt.Errorf("failed to subscribe to %s (%s): %w", sub.topic, sub.description, err)
+			}
+
+			utils.Logger.Warnf("⚠️ Non-critical subscription failed, continuing in degraded mode: %s - %v", sub.topic, err)
+			s.mu.Lock()
+			s.failed[sub.topic] = err.Error()
+			s.mu.Unlock()
+			continue
+		}
+
+		s.mu.Lock()
+		s.active = append(s.active, sub)
+		s.mu.Unlock()
+	}
+	return nil
+}
+
+// handleReconnect 재연결 후 구독 복구, 진행 중인 명령의 현재 상태 재전송
+func (s *Subscriber) handleReconnect() {
+	s.Resubscribe()
+	for _, route := range s.routes {
+		route.Handler.ReplayResponses()
+	}
+}
+
+// Resubscribe 구독 완료된 토픽을 다시 구독하고 브로커 SUBACK으로 확인
+// 필수 구독이 실패하면 readiness 실패 (다음 재연결 때 다시 시도), 비필수는 degraded 기록
+func (s *Subscriber) Resubscribe() {
+	s.mu.Lock()
+	subscriptions := append([]subscription{}, s.active...)
+	s.mu.Unlock()
+	if len(subscriptions) == 0 {
+		return
+	}
+
+	utils.Logger.Infof("🔔 Reconnected - restoring %d subscription(s)", len(subscriptions))
+	restored := 0
+	criticalFailed := false
 	for _, sub := range subscriptions {
+		err := s.subscribe(sub)
+
+		s.mu.Lock()
+		if err == nil {
+			delete(s.failed, sub.topic)
+			restored++
+		} else {
+			s.failed[sub.topic] = err.Error()
+		}
+		s.mu.Unlock()
+
+		if err != nil && sub.critical {
+			utils.Logger.Errorf("❌ Critical resubscription failed: %s (%s) - %v", sub.topic, sub.description, err)
+			criticalFailed = true
+		}
+	}
+
+	s.subscribed.Store(s.started.Load() && !criticalFailed)
+	if restored == len(subscriptions) {
+		utils.Logger.Infof("🎉 All %d subscription(s) restored", restored)
+	} else {
+		utils.Logger.Warnf("⚠️ Restored %d of %d subscription(s)", restored, len(subscriptions))
+	}
+}
+
+// openCommandGate 로봇 ONLINE(또는 타임아웃)까지 대기 후 PLC 명령 구독/처리 시작
+// 명령 보관 모드에서는 먼저 구독하여 대기 중 수신 명령을 잃지 않음
+// 여러 경로는 같은 타임아웃 안에서 로봇별로 대기
+func (s *Subscriber) openCommandGate(commandSubscriptions []subscription) error {
+	cfg := s.client.GetConfig()
+
+	if cfg.StartupBufferCommands {
+		if err := s.subscribeEach(commandSubscriptions); err != nil {
+			return err
+		}
+	}
+
+	utils.Logger.Infof("⏳ Waiting up to %s for robot to report ONLINE before accepting commands", cfg.StartupRobotTimeout)
+	deadline := time.Now().Add(cfg.StartupRobotTimeout)
+	for _, route := range s.routes {
+		robot := route.Handler.config.RobotSerialNumber
+		if route.Handler.WaitForRobotOnline(time.Until(deadline)) {
+			utils.Logger.Infof("✅ Robot %s ONLINE - accepting PLC commands", robot)
+		} else {
+			utils.Logger.Warnf("⚠️ Robot %s did not report ONLINE within %s - accepting PLC commands anyway", robot, cfg.StartupRobotTimeout)
+		}
+	}
+
+	if !cfg.StartupBufferCommands {
+		if err := s.subscribeEach(commandSubscriptions); err != nil {
+			return err
+		}
+	}
+
+	for _, route := range s.routes {
+		route.Handler.OpenCommandGate()
+	}
+	return nil
+}
+
+// subscribe 단일 토픽 구독 (일시적 실패는 지수 백오프로 재시도, ACL 거부는 즉시 실패)
+func (s *Subscriber) subscribe(sub subscription) error {
+	cfg := s.client.GetConfig()
+	backoff := cfg.SubscribeRetryBackoff
+
+	for attempt := 0; ; attempt++ {
 		utils.Logger.Infof("🔔 Subscribing to: %s (%s)", sub.topic, sub.description)
 
 		err := s.client.Subscribe(sub.topic, 0, sub.handler)
-		if err != nil {
-			utils.Logger.Errorf("❌ Subscription failed: %s - %v", sub.topic, err)
-			return fmt.Errorf("failed to subscribe to %s: %v", sub.topic, err)
+		if err == nil {
+			utils.Logger.Infof("✅ Subscription success: %s", sub.topic)
+			return nil
+		}
+
+		if errors.Is(err, ErrSubscriptionDenied) {
+			utils.Logger.Errorf("❌ Subscription denied by broker (ACL?): %s - %v", sub.topic, err)
+			return err
+		}
+
+		if attempt >= cfg.SubscribeRetries {
+			utils.Logger.Errorf("❌ Subscription failed after %d attempts: %s - %v", attempt+1, sub.topic, err)
+			return err
 		}
 
-		utils.Logger.Infof("✅ Subscription success: %s", sub.topic)
+		utils.Logger.Warnf("⚠️ Subscription failed, retrying in %s: %s - %v", backoff, sub.topic, err)
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxSubscribeBackoff {
+			backoff = maxSubscribeBackoff
+		}
 	}
+}
 
-	utils.Logger.Infof("🎉 All subscriptions completed")
-	return nil
+// FailedSubscriptions 실패한 비필수 구독 목록 (topic -> 오류)
+func (s *Subscriber) FailedSubscriptions() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	failed := make(map[string]string, len(s.failed))
+	for topic, reason := range s.failed {
+		failed[topic] = reason
+	}
+	return failed
+}
+
+// IsSubscribed 모든 구독 완료 여부 확인
+func (s *Subscriber) IsSubscribed() bool {
+	return s.subscribed.Load()
 }
 
-// handlePLCCommand PLC 명령 메시지 처리
-func (s *Subscriber) handlePLCCommand(client mqtt.Client, msg mqtt.Message) {
-	utils.Logger.Infof("📨 MQTT RECEIVED")
-	utils.Logger.Infof("📨 Topic   : %s", msg.Topic())
-	utils.Logger.Infof("📨 QoS    : %d, MessageID: %d", msg.Qos(), msg.MessageID())
-	utils.Logger.Infof("📨 Payload : %s", string(msg.Payload()))
+// commandHandler 경로의 핸들러로 전달하는 PLC 명령 메시지 처리기
+// 명령 토픽에 응답 채널 레벨이 있으면 수신 토픽의 채널로 응답
+func (s *Subscriber) commandHandler(route CommandRoute) mqtt.MessageHandler {
+	return func(client mqtt.Client, msg mqtt.Message) {
+		logReceived(msg)
 
-	s.handler.HandlePLCCommand(client, msg)
+		replyChannel := config.ReplyChannel(route.CommandTopic, msg.Topic())
+		route.Handler.ProcessCommandWithReply(string(msg.Payload()), replyChannel)
+	}
+}
+
+// robotHandler 로봇 토픽({interface}/{version}/{manufacturer}/{serialNumber}/...)의 담당 핸들러
+// 경로가 하나면 시리얼과 무관하게 해당 핸들러 (기존 동작 유지)
+func (s *Subscriber) robotHandler(topic string) *DirectActionHandler {
+	if len(s.routes) == 1 {
+		return s.routes[0].Handler
+	}
+
+	levels := strings.Split(topic, "/")
+	if len(levels) < 5 {
+		return nil
+	}
+	handler, exists := s.byRobot[levels[3]]
+	if !exists {
+		utils.Logger.Debugf("Ignoring message for unrouted robot: %s", topic)
+		return nil
+	}
+	// 같은 시리얼이라도 경로의 네임스페이스가 아니면 무시 (버전 협상 시 major version은 확인하지 않음)
+	if levels[0] != handler.config.RobotInterfaceName ||
+		(!s.client.GetConfig().ProtocolAutoNegotiate && levels[1] != handler.config.RobotMajorVersion) {
+		utils.Logger.Debugf("Ignoring message outside the route namespace of robot %s: %s", levels[3], topic)
+		return nil
+	}
+	return handler
+}
+
+// robotTopicPrefixes 경로별 로봇 토픽 접두사 (중복 제외, 버전 협상 시 모든 major version)
+func (s *Subscriber) robotTopicPrefixes() []string {
+	autoNegotiate := s.client.GetConfig().ProtocolAutoNegotiate
+
+	var prefixes []string
+	for _, route := range s.routes {
+		prefix := route.Handler.config.RobotTopicPrefix()
+		if autoNegotiate {
+			prefix = route.Handler.config.RobotInterfaceName + "/+"
+		}
+		if !slices.Contains(prefixes, prefix) {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
 }
 
 // handleRobotState 로봇 상태 메시지 처리
 func (s *Subscriber) handleRobotState(client mqtt.Client, msg mqtt.Message) {
 	// 로봇 상태 메시지도 전체 페이로드 출력 (줄이지 않음)
-	utils.Logger.Infof("📨 MQTT RECEIVED")
-	utils.Logger.Infof("📨 Topic   : %s", msg.Topic())
-	utils.Logger.Infof("📨 QoS    : %d, MessageID: %d", msg.Qos(), msg.MessageID())
-	utils.Logger.Infof("📨 Payload : %s", string(msg.Payload()))
+	logReceived(msg)
 
-	s.handler.HandleRobotState(client, msg)
+	if handler := s.robotHandler(msg.Topic()); handler != nil {
+		handler.EnqueueRobotState(client, msg)
+	}
 }
 
 // handleRobotConnection 로봇 연결 상태 메시지 처리
 func (s *Subscriber) handleRobotConnection(client mqtt.Client, msg mqtt.Message) {
-	utils.Logger.Infof("📨 MQTT RECEIVED")
-	utils.Logger.Infof("📨 Topic   : %s", msg.Topic())
-	utils.Logger.Infof("📨 QoS    : %d, MessageID: %d", msg.Qos(), msg.MessageID())
-	utils.Logger.Infof("📨 Payload : %s", string(msg.Payload()))
+	logReceived(msg)
+
+	if handler := s.robotHandler(msg.Topic()); handler != nil {
+		handler.HandleRobotConnection(client, msg)
+	}
+}
+
+// handleFaultAck 운영자 오류 확인 메시지 처리
+func (s *Subscriber) handleFaultAck(client mqtt.Client, msg mqtt.Message) {
+	logReceived(msg)
+
+	// 래치된 오류는 핸들러(로봇)별이므로 모든 경로에 전달
+	for _, route := range s.routes {
+		route.Handler.HandleFaultAck(client, msg)
+	}
+}
+
+// handleRobotFactsheet 로봇 factsheet 메시지 처리
+func (s *Subscriber) handleRobotFactsheet(client mqtt.Client, msg mqtt.Message) {
+	logReceived(msg)
+
+	if handler := s.robotHandler(msg.Topic()); handler != nil {
+		handler.HandleFactsheet(client, msg)
+	}
+}
+
+// handleRobotVisualization 로봇 visualization 메시지 처리 (고주기이므로 수신 로그 생략)
+func (s *Subscriber) handleRobotVisualization(client mqtt.Client, msg mqtt.Message) {
+	if handler := s.robotHandler(msg.Topic()); handler != nil {
+		handler.HandleVisualization(client, msg)
+	}
+}
+
+// handleOrderConfirmation 커미셔닝 오더 확인/거부 메시지 처리 (오더를 가진 경로의 핸들러에 전달)
+func (s *Subscriber) handleOrderConfirmation(client mqtt.Client, msg mqtt.Message) {
+	logReceived(msg)
+
+	orderID, confirm, err := parseConfirmation(string(msg.Payload()))
+	if err != nil {
+		utils.Logger.Errorf("❌ Invalid order confirmation: %v", err)
+		return
+	}
 
-	s.handler.HandleRobotConnection(client, msg)
+	for _, route := range s.routes {
+		if confirm {
+			err = route.Handler.ConfirmOrder(orderID)
+		} else {
+			err = route.Handler.RejectOrder(orderID, "rejected by operator")
+		}
+		if !errors.Is(err, ErrOrderNotFound) {
+			return
+		}
+	}
+	utils.Logger.Warnf("⚠️ No order awaiting confirmation: %s", orderID)
 }
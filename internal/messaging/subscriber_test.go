@@ -0,0 +1,39 @@
+package messaging
+
+import (
+	"mqtt-bridge/internal/config"
+	"slices"
+	"testing"
+)
+
+func TestRouteNamespaces(t *testing.T) {
+	global := &config.Config{RobotInterfaceName: "meili", RobotMajorVersion: "v2", RobotManufacturer: "ACME"}
+	r1 := &DirectActionHandler{config: global.ForRoute(config.CommandRoute{RobotSerialNumber: "R1"})}
+	r2 := &DirectActionHandler{config: global.ForRoute(config.CommandRoute{RobotSerialNumber: "R2", InterfaceName: "uagv", MajorVersion: "v1"})}
+	subscriber := NewSubscriber(NewFakeBroker(global), []CommandRoute{
+		{CommandTopic: "plc/1", Handler: r1},
+		{CommandTopic: "plc/2", Handler: r2},
+	})
+
+	if got, want := subscriber.robotTopicPrefixes(), []string{"meili/v2", "uagv/v1"}; !slices.Equal(got, want) {
+		t.Errorf("robotTopicPrefixes = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		topic string
+		want  *DirectActionHandler
+	}{
+		{"meili/v2/ACME/R1/state", r1},
+		{"uagv/v1/ACME/R2/state", r2},
+		{"meili/v2/ACME/R2/state", nil}, // R2는 uagv/v1 네임스페이스
+		{"uagv/v2/ACME/R2/state", nil},
+		{"meili/v2/ACME/R3/state", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.topic, func(t *testing.T) {
+			if got := subscriber.robotHandler(tt.topic); got != tt.want {
+				t.Errorf("robotHandler(%q) = %p, want %p", tt.topic, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+// internal/messaging/templates.go - Order Templates with Parameter Substitution
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"strings"
+)
+
+// OrderTemplateBuilder 미리 작성된 전체 오더를 발행 메시지로 변환 (선택 구현, VDA5050만 지원)
+type OrderTemplateBuilder interface {
+	// BuildTemplateOrder 헤더(headerId, timestamp, version, manufacturer, serialNumber) 채워서 메시지 생성
+	BuildTemplateOrder(order *types.OrderMessage) (*OutboundMessage, error)
+}
+
+// handleTemplateCommand 기본 명령 이름으로 선택된 템플릿으로 오더 생성 후 처리
+func (h *DirectActionHandler) handleTemplateCommand(commandStr, template string) (string, error) {
+	builder, ok := h.protocol.(OrderTemplateBuilder)
+	if !ok {
+		err := fmt.Errorf("order templates are not supported by %s protocol", h.protocol.Name())
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+
+	orderID := h.generateOrderID()
+	order, err := h.instantiateTemplate(template, orderID, commandStr)
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to instantiate order template: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+
+	var actions []types.Action
+	for _, node := range order.Nodes {
+		actions = append(actions, node.Actions...)
+	}
+	if err := h.checkFactsheetActions(actions); err != nil {
+		utils.Logger.Errorf("❌ Template order %s rejected: %v", commandStr, err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+
+	message, err := builder.BuildTemplateOrder(order)
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to build template order: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+	message.ActionType = "template"
+
+	utils.Logger.Infof("📤 Template Order Details: OrderID=%s, Command=%s, Nodes=%d, Edges=%d",
+		orderID, commandStr, len(order.Nodes), len(order.Edges))
+	return h.dispatchOrder(commandStr, orderID, message)
+}
+
+// instantiateTemplate 자리표시자 치환 후 오더 해석
+// ${orderId}, ${command}, ${base}, ${arg1}..${argN} (':' 뒤 세그먼트), ${serialNumber}, ${manufacturer}
+// 정의되지 않은 자리표시자는 오류 (템플릿 작성 실수를 PLC 실패로 드러냄)
+func (h *DirectActionHandler) instantiateTemplate(template, orderID, commandStr string) (*types.OrderMessage, error) {
+	parts := strings.Split(commandStr, ":")
+	values := map[string]string{
+		"orderId":      orderID,
+		"command":      commandStr,
+		"base":         parts[0],
+		"serialNumber": h.config.RobotSerialNumber,
+		"manufacturer": h.config.RobotManufacturer,
+	}
+	for i, part := range parts[1:] {
+		values[fmt.Sprintf("arg%d", i+1)] = part
+	}
+
+	var missing []string
+	payload := config.TemplatePlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		name := config.TemplatePlaceholder.FindStringSubmatch(placeholder)[1]
+		value, exists := values[name]
+		if !exists {
+			missing = append(missing, name)
+			return "0"
+		}
+		// JSON 문자열 안에 들어가도 안전하도록 이스케이프
+		escaped, _ := json.Marshal(value)
+		return string(escaped[1 : len(escaped)-1])
+	})
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("undefined template placeholder(s): %s", strings.Join(missing, ", "))
+	}
+
+	var order types.OrderMessage
+	if err := json.Unmarshal([]byte(payload), &order); err != nil {
+		return nil, fmt.Errorf("template is not a valid order after substitution: %v", err)
+	}
+	if len(order.Nodes) == 0 {
+		return nil, fmt.Errorf("template order has no nodes")
+	}
+	order.OrderID = orderID
+	return &order, nil
+}
@@ -0,0 +1,131 @@
+// internal/messaging/throttle.go - Per-Robot InstantActions Rate Limiting
+package messaging
+
+import (
+	"errors"
+	"mqtt-bridge/internal/events"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrInstantActionRateLimited 로봇별 InstantActions 전송 한도 초과
+var ErrInstantActionRateLimited = errors.New("instant action rate limit exceeded")
+
+// 억제 사유 (instant_action.suppressed 이벤트 Status)
+const (
+	suppressCoalesced   = "coalesced"    // 같은 액션이 coalesce window 내에 이미 전송됨
+	suppressRateLimited = "rate_limited" // 토큰 버킷 소진
+)
+
+// instantActionThrottle 로봇별 토큰 버킷 + 동일 액션 병합
+type instantActionThrottle struct {
+	rate           float64       // 초당 토큰 (0이면 제한 없음)
+	burst          float64       // 버킷 크기
+	coalesceWindow time.Duration // 동일 액션 병합 구간 (0이면 비활성화)
+
+	buckets  map[string]*tokenBucket         // robot -> 버킷
+	lastSent map[string]map[string]time.Time // robot -> coalesce key -> 마지막 전송 시간
+}
+
+// tokenBucket 토큰 버킷 상태
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// take 경과 시간만큼 토큰을 채운 뒤 하나 사용 (토큰이 없으면 false)
+func (b *tokenBucket) take(now time.Time, rate, burst float64) bool {
+	b.tokens += now.Sub(b.last).Seconds() * rate
+	if b.tokens > burst {
+		b.tokens = burst
+	}
+	b.last = now
+
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// newInstantActionThrottle 새 InstantActions 제한기 생성
+func newInstantActionThrottle(rate float64, burst int, coalesceWindow time.Duration) *instantActionThrottle {
+	if burst < 1 {
+		burst = 1
+	}
+	return &instantActionThrottle{
+		rate:           rate,
+		burst:          float64(burst),
+		coalesceWindow: coalesceWindow,
+		buckets:        make(map[string]*tokenBucket),
+		lastSent:       make(map[string]map[string]time.Time),
+	}
+}
+
+// allow 전송 가능 여부 확인 (억제 시 사유 반환, 잠금 보유 상태에서 호출)
+// exempt이면 병합만 적용하고 토큰 버킷은 확인/소모하지 않음 (안전 관련 액션)
+func (t *instantActionThrottle) allow(robot, coalesceKey string, exempt bool, now time.Time) (bool, string) {
+	sent := t.lastSent[robot]
+	if sent == nil {
+		sent = make(map[string]time.Time)
+		t.lastSent[robot] = sent
+	}
+
+	if t.coalesceWindow > 0 {
+		if last, exists := sent[coalesceKey]; exists && now.Sub(last) < t.coalesceWindow {
+			return false, suppressCoalesced
+		}
+		for key, last := range sent {
+			if now.Sub(last) >= t.coalesceWindow {
+				delete(sent, key)
+			}
+		}
+	}
+
+	if t.rate > 0 && !exempt {
+		bucket := t.buckets[robot]
+		if bucket == nil {
+			bucket = &tokenBucket{tokens: t.burst, last: now}
+			t.buckets[robot] = bucket
+		}
+		if !bucket.take(now, t.rate, t.burst) {
+			return false, suppressRateLimited
+		}
+	}
+
+	sent[coalesceKey] = now
+	return true, ""
+}
+
+// isSafetyInstantAction 전송 한도와 무관하게 보내는 액션 (로봇을 멈추는 취소/비상 정지, 중복 병합만 적용)
+func (h *DirectActionHandler) isSafetyInstantAction(actionType string) bool {
+	return actionType == "cancelOrder" || actionType == h.config.EstopAction
+}
+
+// allowInstantAction InstantActions 전송 전 제한 확인
+// 병합된 경우 (false, nil) - 앞선 동일 액션이 처리 중이므로 성공으로 간주
+// 한도 초과 시 (false, ErrInstantActionRateLimited), 안전 관련 액션은 한도 초과 없음
+func (h *DirectActionHandler) allowInstantAction(actionType, orderID string) (bool, error) {
+	allowed, reason := h.instantActionThrottle.allow(h.config.RobotSerialNumber, actionType+"/"+orderID, h.isSafetyInstantAction(actionType), time.Now())
+	if allowed {
+		return true, nil
+	}
+
+	h.robotLog().WithFields(logrus.Fields{
+		"actionType": actionType,
+		"orderId":    orderID,
+		"reason":     reason,
+	}).Warn("InstantAction suppressed")
+	h.publishEvent(events.Event{
+		Type:    events.TypeInstantActionSuppressed,
+		OrderID: orderID,
+		Status:  reason,
+		Message: actionType,
+	})
+
+	if reason == suppressRateLimited {
+		return false, ErrInstantActionRateLimited
+	}
+	return false, nil
+}
@@ -0,0 +1,63 @@
+package messaging
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInstantActionThrottle(t *testing.T) {
+	start := time.Unix(0, 0)
+	tests := []struct {
+		name   string
+		exempt bool
+		keys   []string // 같은 시각에 순서대로 전송
+		want   []string // 억제 사유 ("" = 전송)
+	}{
+		{
+			name: "burst then rate limited",
+			keys: []string{"stateRequest/1", "stateRequest/2", "stateRequest/3"},
+			want: []string{"", "", suppressRateLimited},
+		},
+		{
+			name: "duplicate coalesced",
+			keys: []string{"stopPause/", "stopPause/"},
+			want: []string{"", suppressCoalesced},
+		},
+		{
+			name:   "exempt never rate limited",
+			exempt: true,
+			keys:   []string{"cancelOrder/1", "cancelOrder/2", "cancelOrder/3", "cancelOrder/4"},
+			want:   []string{"", "", "", ""},
+		},
+		{
+			name:   "exempt duplicate still coalesced",
+			exempt: true,
+			keys:   []string{"cancelOrder/1", "cancelOrder/1"},
+			want:   []string{"", suppressCoalesced},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			throttle := newInstantActionThrottle(1, 2, time.Second)
+			for i, key := range tt.keys {
+				_, reason := throttle.allow("robot", key, tt.exempt, start)
+				if reason != tt.want[i] {
+					t.Errorf("send %d (%s): reason %q, want %q", i, key, reason, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestExemptActionsDoNotConsumeTokens(t *testing.T) {
+	now := time.Unix(0, 0)
+	throttle := newInstantActionThrottle(1, 1, 0)
+	for i := range 5 {
+		if allowed, _ := throttle.allow("robot", "cancelOrder/x", true, now); !allowed {
+			t.Fatalf("exempt send %d suppressed", i)
+		}
+	}
+	if allowed, reason := throttle.allow("robot", "stateRequest/", false, now); !allowed {
+		t.Fatalf("regular action suppressed after exempt sends: %s", reason)
+	}
+}
@@ -0,0 +1,98 @@
+// internal/messaging/translator.go - Command Type Registry (BASE:TYPE -> robot action)
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/types"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// CommandTranslator PLC 명령 타입(두 번째 세그먼트)을 로봇 액션으로 변환
+// 다른 패키지에서 init()으로 RegisterCommandTranslator를 호출해 새 타입 추가 (I, T는 기본 등록)
+type CommandTranslator interface {
+	// Translate 기본 명령 이름과 팔 코드(없으면 "")로 액션 타입/파라미터 생성
+	Translate(base, arm string) (actionType string, parameters []types.ActionParameter, err error)
+	// AcceptsArm 세 번째 세그먼트(팔 코드 R, L, B) 허용 여부
+	AcceptsArm() bool
+}
+
+// reservedCommandSuffixes 브릿지 제어 명령 접미사 (번역기로 등록 불가)
+// C 취소, E 비상 정지, G 트리거 해제, Q 상태 조회, W 파이프라인 트리거 대기 단계
+var reservedCommandSuffixes = map[string]bool{"C": true, "E": true, "G": true, "Q": true, "W": true}
+
+var (
+	translatorsMu sync.RWMutex
+	translators   = make(map[string]CommandTranslator)
+)
+
+func init() {
+	RegisterCommandTranslator("I", inferenceTranslator{})
+	RegisterCommandTranslator("T", trajectoryTranslator{})
+}
+
+// RegisterCommandTranslator 명령 타입 번역기 등록 (중복/예약 접미사는 프로그래밍 오류로 panic)
+func RegisterCommandTranslator(suffix string, translator CommandTranslator) {
+	if suffix == "" || strings.ContainsAny(suffix, ":=,>@!") {
+		panic(fmt.Sprintf("messaging: invalid command type suffix %q", suffix))
+	}
+	if reservedCommandSuffixes[suffix] {
+		panic(fmt.Sprintf("messaging: command type suffix %q is reserved", suffix))
+	}
+
+	translatorsMu.Lock()
+	defer translatorsMu.Unlock()
+	if _, exists := translators[suffix]; exists {
+		panic(fmt.Sprintf("messaging: command type %q registered twice", suffix))
+	}
+	translators[suffix] = translator
+}
+
+// lookupCommandTranslator 명령 타입 번역기 검색
+func lookupCommandTranslator(suffix string) (CommandTranslator, bool) {
+	translatorsMu.RLock()
+	defer translatorsMu.RUnlock()
+	translator, exists := translators[suffix]
+	return translator, exists
+}
+
+// CommandTypes 등록된 명령 타입 목록 (정렬, 오류 메시지용)
+func CommandTypes() []string {
+	translatorsMu.RLock()
+	defer translatorsMu.RUnlock()
+
+	suffixes := make([]string, 0, len(translators))
+	for suffix := range translators {
+		suffixes = append(suffixes, suffix)
+	}
+	sort.Strings(suffixes)
+	return suffixes
+}
+
+// inferenceTranslator 추론 명령 (BASE:I)
+type inferenceTranslator struct{}
+
+func (inferenceTranslator) Translate(base, arm string) (string, []types.ActionParameter, error) {
+	return "Roboligent Robin - Inference", []types.ActionParameter{
+		{Key: "inference_name", Value: base},
+	}, nil
+}
+
+func (inferenceTranslator) AcceptsArm() bool { return false }
+
+// trajectoryTranslator 궤적 명령 (BASE:T[:ARM])
+type trajectoryTranslator struct{}
+
+func (trajectoryTranslator) Translate(base, arm string) (string, []types.ActionParameter, error) {
+	value, err := parseArm(arm)
+	if err != nil {
+		return "", nil, err
+	}
+	return "Roboligent Robin - Follow Trajectory", []types.ActionParameter{
+		{Key: "trajectory_name", Value: base},
+		{Key: "arm", Value: value},
+	}, nil
+}
+
+func (trajectoryTranslator) AcceptsArm() bool { return true }
@@ -0,0 +1,108 @@
+// internal/messaging/trigger.go - waitForTrigger Steps and PLC Release
+package messaging
+
+import (
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+)
+
+// triggerAction waitForTrigger 단계 액션 생성
+// 기본 HARD 블로킹 (해제 전까지 다음 액션이 시작되지 않음), 단계에 blockingType이 있으면 그 값
+func (h *DirectActionHandler) triggerAction(step config.MappedStep) OrderAction {
+	blockingType := types.BlockingTypeHard
+	if step.BlockingType != "" {
+		blockingType = step.BlockingType
+	}
+
+	var parameters []types.ActionParameter
+	if step.Name != "" {
+		parameters = append(parameters, types.ActionParameter{Key: "triggerName", Value: step.Name})
+	}
+	return OrderAction{
+		ActionType:   h.config.TriggerActionType,
+		Parameters:   parameters,
+		BlockingType: blockingType,
+		Trigger:      true,
+	}
+}
+
+// handleReleaseCommand 활성 오더에서 대기 중인 첫 waitForTrigger 액션 해제
+// 성공 시 별도 응답 없이 오더 상태(W -> R)로 진행을 알림
+func (h *DirectActionHandler) handleReleaseCommand(commandStr string) (string, error) {
+	baseCommand := h.extractBaseCommand(commandStr)
+
+	var targetOrder *OrderInfo
+	for _, order := range h.activeOrders {
+		if h.extractBaseCommand(order.Command) == baseCommand {
+			targetOrder = order
+			break
+		}
+	}
+	if targetOrder == nil {
+		utils.Logger.Warnf("⚠️ No active order found for release: %s", baseCommand)
+		err := fmt.Errorf("no active order found for command %s", baseCommand)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+
+	actionID := pendingTrigger(targetOrder)
+	if actionID == "" {
+		err := fmt.Errorf("order %s has no pending trigger", targetOrder.OrderID)
+		utils.Logger.Warnf("⚠️ Release rejected: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+
+	if err := h.sendTriggerRelease(targetOrder.OrderID, actionID); err != nil {
+		utils.Logger.Errorf("❌ Failed to send trigger release: %v", err)
+		h.sendPLCFailure(commandStr, err)
+		return "", err
+	}
+
+	h.orderLog(targetOrder).WithField("actionId", actionID).Info("Trigger released")
+	return targetOrder.OrderID, nil
+}
+
+// sendTriggerRelease 해제 InstantAction 전송 (대상 액션 ID를 파라미터로 전달)
+func (h *DirectActionHandler) sendTriggerRelease(orderID, actionID string) error {
+	if send, err := h.allowInstantAction(h.config.TriggerReleaseAction, orderID); !send {
+		return err
+	}
+
+	message, err := h.protocol.BuildInstantAction(InstantActionRequest{
+		ActionType:   h.config.TriggerReleaseAction,
+		BlockingType: types.BlockingTypeNone,
+		Parameters: []types.ActionParameter{
+			{Key: "orderId", Value: orderID},
+			{Key: "actionId", Value: actionID},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return h.sendToRobot(message)
+}
+
+// pendingTrigger 아직 끝나지 않은 첫 waitForTrigger 액션 ID (없으면 "")
+func pendingTrigger(order *OrderInfo) string {
+	for _, actionID := range order.triggerActionIDs {
+		switch order.actionStatuses[actionID] {
+		case "FINISHED", "FAILED":
+			continue
+		}
+		return actionID
+	}
+	return ""
+}
+
+// awaitingTrigger 로봇이 waitForTrigger 액션을 실행 중인지 (PLC 해제 대기)
+func awaitingTrigger(order *OrderInfo) bool {
+	for _, actionID := range order.triggerActionIDs {
+		if order.actionStatuses[actionID] == "RUNNING" {
+			return true
+		}
+	}
+	return false
+}
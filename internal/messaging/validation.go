@@ -0,0 +1,169 @@
+// internal/messaging/validation.go - PLC Command Validation
+package messaging
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"mqtt-bridge/internal/types"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError 명령 구성 요소별 검증 오류 (통합 담당자가 설정을 고칠 수 있도록 위치 포함)
+type ValidationError struct {
+	Field    string `json:"field"`    // base, type, arm, params, path, target, priority, command
+	Position int    `json:"position"` // ':'로 구분된 명령 세그먼트 인덱스 (경로/목적지/우선순위는 -1)
+	Value    string `json:"value"`
+	Reason   string `json:"reason"`
+}
+
+// ValidationErrors 명령 검증 오류 목록
+type ValidationErrors []ValidationError
+
+// Error error 인터페이스 구현
+func (e ValidationErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, validationErr := range e {
+		if validationErr.Position == noSegment {
+			messages = append(messages, fmt.Sprintf("%s (%q): %s", validationErr.Field, validationErr.Value, validationErr.Reason))
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s (segment %d, %q): %s", validationErr.Field, validationErr.Position, validationErr.Value, validationErr.Reason))
+	}
+	return "invalid command: " + strings.Join(messages, "; ")
+}
+
+// directCommand 검증된 Direct Action 명령 (BASE:TYPE[:ARM][:key=value,...])
+type directCommand struct {
+	Base   string
+	Type   string // 등록된 명령 타입 (translator.go)
+	Arm    string
+	Params []types.ActionParameter // PLC가 덧붙인 추가 액션 파라미터
+	Delay  time.Duration           // 발행 지연 (delay 파라미터, delay.go)
+}
+
+// reservedParamKeys 브릿지가 채우는 파라미터 (PLC 파라미터로 덮어쓸 수 없음)
+var reservedParamKeys = map[string]bool{
+	"inference_name":  true,
+	"trajectory_name": true,
+	"arm":             true,
+}
+
+// parseDirectCommand Direct Action 명령 파싱 및 검증 (문법 오류와 타입/팔 오류를 모아서 반환)
+func parseDirectCommand(commandStr string) (*directCommand, error) {
+	parsed, errs := parseCommand(commandStr)
+	errs = append(errs, parsed.validateDirect()...)
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	return &directCommand{Base: parsed.Base, Type: parsed.Type, Arm: parsed.Arm, Params: parsed.Params, Delay: parsed.Delay}, nil
+}
+
+// validateDirect 등록된 명령 타입과 팔 코드 확인
+func (p *ParsedCommand) validateDirect() ValidationErrors {
+	var errs ValidationErrors
+	if p.Kind != CommandKindAction {
+		return ValidationErrors{{Field: "command", Position: 1, Value: string(p.Kind), Reason: "control command is not a direct action"}}
+	}
+
+	var translator CommandTranslator
+	if p.Type == "" {
+		errs = append(errs, ValidationError{Field: "type", Position: 1, Reason: "command type is required (" + strings.Join(CommandTypes(), ", ") + ")"})
+	} else {
+		var exists bool
+		if translator, exists = lookupCommandTranslator(p.Type); !exists {
+			errs = append(errs, ValidationError{Field: "type", Position: 1, Value: p.Type, Reason: "unknown command type, expected one of " + strings.Join(CommandTypes(), ", ")})
+		}
+	}
+
+	if p.Arm != "" {
+		switch {
+		case translator != nil && !translator.AcceptsArm():
+			errs = append(errs, ValidationError{Field: "arm", Position: 2, Value: p.Arm, Reason: "arm is not valid for " + p.Type + " commands"})
+		case p.Arm != "R" && p.Arm != "L" && p.Arm != "B":
+			errs = append(errs, ValidationError{Field: "arm", Position: 2, Value: p.Arm, Reason: "unknown arm, expected R, L or B"})
+		}
+	}
+	return errs
+}
+
+// extractDelayParam 파라미터 목록에서 delay 분리 (로봇으로 전달하지 않음)
+func extractDelayParam(params []types.ActionParameter, position int) ([]types.ActionParameter, time.Duration, ValidationErrors) {
+	for i, param := range params {
+		if param.Key != delayParamKey {
+			continue
+		}
+		rest := append(params[:i:i], params[i+1:]...)
+		delay, err := parseDelayParam(param.Value)
+		if err != nil {
+			return rest, 0, ValidationErrors{{Field: "params", Position: position, Value: fmt.Sprint(param.Value), Reason: err.Error()}}
+		}
+		return rest, delay, nil
+	}
+	return params, 0, nil
+}
+
+// parseCommandParams key=value 목록 해석 (예: speed=0.4,retries=2)
+// 10진수는 number (선행 0, 2^53-1 초과 정수는 문자열 유지), true/false는 boolean, 나머지는 문자열 값
+// NaN, Inf, 범위를 넘는 수는 오더 JSON으로 표현할 수 없으므로 오류
+func parseCommandParams(segment string, position int) ([]types.ActionParameter, ValidationErrors) {
+	var params []types.ActionParameter
+	var errs ValidationErrors
+	seen := make(map[string]bool)
+
+	for _, pair := range strings.Split(segment, ",") {
+		key, value, found := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		switch {
+		case !found || key == "":
+			errs = append(errs, ValidationError{Field: "params", Position: position, Value: pair, Reason: "expected key=value"})
+			continue
+		case reservedParamKeys[key]:
+			errs = append(errs, ValidationError{Field: "params", Position: position, Value: key, Reason: "parameter is set by the bridge and cannot be overridden"})
+			continue
+		case seen[key]:
+			errs = append(errs, ValidationError{Field: "params", Position: position, Value: key, Reason: "duplicate parameter"})
+			continue
+		}
+		seen[key] = true
+		value = strings.TrimSpace(value)
+		typed, err := paramValue(value)
+		if err != nil {
+			errs = append(errs, ValidationError{Field: "params", Position: position, Value: key + "=" + value, Reason: err.Error()})
+			continue
+		}
+		params = append(params, types.ActionParameter{Key: key, Value: typed})
+	}
+	return params, errs
+}
+
+// numberPattern number로 해석하는 10진수 (007, 0x10, +5, .5 등은 문자열 유지)
+var numberPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// maxSafeInteger 이웃 정수와 구분되어 float64로 정확히 표현되는 최대 정수 (2^53-1)
+const maxSafeInteger = 1<<53 - 1
+
+// errNonFiniteParam NaN/Inf 파라미터 값
+var errNonFiniteParam = errors.New("must be a finite number (NaN and Inf cannot be sent to the robot)")
+
+// paramValue 파라미터 값 타입 추론 (NaN, Inf, 범위 초과는 오류)
+func paramValue(value string) (interface{}, error) {
+	number, err := strconv.ParseFloat(value, 64)
+	if (err == nil || errors.Is(err, strconv.ErrRange)) && (math.IsNaN(number) || math.IsInf(number, 0)) {
+		return nil, errNonFiniteParam
+	}
+	if err == nil && numberPattern.MatchString(value) {
+		// 정수 리터럴이 float64로 정확히 표현되지 않으면 원문 유지 (ID, 코드 값)
+		if !strings.ContainsAny(value, ".eE") && math.Abs(number) > maxSafeInteger {
+			return value, nil
+		}
+		return number, nil
+	}
+	if value == "true" || value == "false" {
+		return value == "true", nil
+	}
+	return value, nil
+}
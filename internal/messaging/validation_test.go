@@ -0,0 +1,70 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestParamValue(t *testing.T) {
+	tests := []struct {
+		value string
+		want  interface{}
+		err   error
+	}{
+		{value: "0.4", want: 0.4},
+		{value: "2", want: float64(2)},
+		{value: "-3", want: float64(-3)},
+		{value: "0", want: float64(0)},
+		{value: "1.5e3", want: 1500.0},
+		{value: "9007199254740991", want: float64(9007199254740991)},
+		{value: "9007199254740992", want: "9007199254740992"},
+		{value: "true", want: true},
+		{value: "false", want: false},
+		{value: "007", want: "007"},
+		{value: "12345678901234567890", want: "12345678901234567890"},
+		{value: "-9007199254740993", want: "-9007199254740993"},
+		{value: "0x10", want: "0x10"},
+		{value: "+5", want: "+5"},
+		{value: ".5", want: ".5"},
+		{value: "TRUE", want: "TRUE"},
+		{value: "station_3", want: "station_3"},
+		{value: "", want: ""},
+		{value: "NaN", err: errNonFiniteParam},
+		{value: "nan", err: errNonFiniteParam},
+		{value: "Inf", err: errNonFiniteParam},
+		{value: "-inf", err: errNonFiniteParam},
+		{value: "infinity", err: errNonFiniteParam},
+		{value: "1e999", err: errNonFiniteParam},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := paramValue(tt.value)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("paramValue(%q) error = %v, want %v", tt.value, err, tt.err)
+			}
+			if err == nil && got != tt.want {
+				t.Errorf("paramValue(%q) = %#v, want %#v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCommandParamsMarshal(t *testing.T) {
+	params, errs := parseCommandParams("id=007,code=12345678901234567890,speed=0.5", 3)
+	if len(errs) > 0 {
+		t.Fatalf("parseCommandParams: %v", errs)
+	}
+	data, err := json.Marshal(params)
+	if err != nil {
+		t.Fatalf("json.Marshal: %v", err)
+	}
+	want := `[{"key":"id","value":"007"},{"key":"code","value":"12345678901234567890"},{"key":"speed","value":0.5}]`
+	if string(data) != want {
+		t.Errorf("marshaled params = %s, want %s", data, want)
+	}
+
+	if _, errs := parseCommandParams("speed=NaN,retries=2", 3); len(errs) != 1 || errs[0].Field != "params" || errs[0].Value != "speed=NaN" {
+		t.Errorf("parseCommandParams with NaN errors = %v, want one params error for speed=NaN", errs)
+	}
+}
@@ -0,0 +1,302 @@
+// internal/messaging/vda5050.go - VDA5050 Robot Protocol (default)
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// vda5050Protocol VDA5050 MQTT 오더/InstantActions/State 변환
+type vda5050Protocol struct {
+	config    *config.Config
+	profile   VersionProfile // 로봇에 적용된 프로토콜 버전 프로필
+	headerIDs *headerIDStore // 토픽별 headerId (HEADER_ID_FILE)
+	ids       utils.IDGenerator
+}
+
+// newVDA5050Protocol 새 VDA5050 프로토콜 생성
+func newVDA5050Protocol(cfg *config.Config, ids utils.IDGenerator) (*vda5050Protocol, error) {
+	headerIDs, err := openHeaderIDStore(cfg.HeaderIDFile)
+	if err != nil {
+		return nil, err
+	}
+	return &vda5050Protocol{
+		config:    cfg,
+		profile:   defaultVersionProfile(cfg.RobotMajorVersion, cfg.RobotMessageVersion),
+		headerIDs: headerIDs,
+		ids:       ids,
+	}, nil
+}
+
+// Name 프로토콜 이름
+func (p *vda5050Protocol) Name() string {
+	return "vda5050"
+}
+
+// BuildOrder 오더 생성
+// 경로(Path)가 있으면 웨이포인트마다 노드를 만들어 엣지로 연결하고 마지막 웨이포인트에서 액션 실행
+// 액션이 하나면 단일 노드/단일 액션, 여러 개면 한 노드에 HARD 블로킹으로 나열하거나
+// (Sequential) 액션마다 같은 위치의 노드를 만들고 엣지로 연결
+func (p *vda5050Protocol) BuildOrder(req OrderRequest) (*OutboundMessage, error) {
+	if len(req.Actions) == 0 {
+		return nil, fmt.Errorf("order %s has no actions", req.OrderID)
+	}
+	actionID := p.ids.NewID()
+
+	// 오더 생성
+	order := types.NewOrderMessage(
+		p.nextHeaderID("order"),
+		p.config.RobotManufacturer,
+		p.config.RobotSerialNumber,
+		req.OrderID,
+		0,
+	)
+	order.Version = p.profile.MessageVersion
+
+	blockingType := defaultBlockingType(len(req.Actions))
+
+	builder := newPathBuilder(order, p.ids.NewID(), req.BaseCommand, p.config.RobotMapID(), p.config.NavEdgeTrajectory)
+	for _, waypoint := range req.Path[:max(len(req.Path)-1, 0)] {
+		builder.addNode(waypointPosition(waypoint))
+	}
+	actionPosition := defaultNodePosition()
+	if len(req.Path) > 0 {
+		actionPosition = waypointPosition(req.Path[len(req.Path)-1])
+	}
+
+	var triggerActionIDs []string
+	node := builder.addNode(actionPosition)
+	for i, request := range req.Actions {
+		// 액션 생성 및 설정
+		id := actionID
+		if len(req.Actions) > 1 {
+			id = fmt.Sprintf("%s-%d", actionID, i+1)
+		}
+		if request.Trigger {
+			triggerActionIDs = append(triggerActionIDs, id)
+		}
+		actionBlocking := blockingType
+		if request.BlockingType != "" {
+			actionBlocking = request.BlockingType
+		}
+		action := types.NewAction(request.ActionType, id, actionBlocking)
+		actionDescription := fmt.Sprintf("Execute %s for %s", request.ActionType, req.BaseCommand)
+		action.ActionDescription = &actionDescription
+		action.ActionParameters = request.Parameters
+
+		// 순차 배치: 같은 위치에 엣지로 연결된 다음 노드 생성
+		if req.Sequential && i > 0 {
+			node = builder.addNode(actionPosition)
+		}
+		node.AddAction(action)
+	}
+
+	msgData, err := json.Marshal(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal order: %v", err)
+	}
+	return &OutboundMessage{Topic: p.topic("order"), Payload: msgData, ActionID: actionID, TriggerActionIDs: triggerActionIDs}, nil
+}
+
+// BuildTemplateOrder 템플릿으로 만든 오더에 헤더를 채워 메시지 생성
+func (p *vda5050Protocol) BuildTemplateOrder(order *types.OrderMessage) (*OutboundMessage, error) {
+	order.HeaderID = p.nextHeaderID("order")
+	order.Timestamp = time.Now()
+	order.Version = p.profile.MessageVersion
+	order.Manufacturer = p.config.RobotManufacturer
+	order.SerialNumber = p.config.RobotSerialNumber
+	if order.Edges == nil {
+		order.Edges = make([]types.Edge, 0)
+	}
+
+	actionID := ""
+	for _, node := range order.Nodes {
+		if len(node.Actions) > 0 {
+			actionID = node.Actions[0].ActionID
+			break
+		}
+	}
+
+	msgData, err := json.Marshal(order)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal order: %v", err)
+	}
+	return &OutboundMessage{Topic: p.topic("order"), Payload: msgData, ActionID: actionID}, nil
+}
+
+// BuildCancel cancelOrder InstantAction 생성
+func (p *vda5050Protocol) BuildCancel(orderID string) (*OutboundMessage, error) {
+	return p.BuildInstantAction(InstantActionRequest{
+		ActionType:   "cancelOrder",
+		BlockingType: types.BlockingTypeHard,
+	})
+}
+
+// BuildInstantAction InstantActions 메시지 생성
+func (p *vda5050Protocol) BuildInstantAction(req InstantActionRequest) (*OutboundMessage, error) {
+	instantActions := types.NewInstantActionsMessage(
+		p.nextHeaderID("instantActions"),
+		p.config.RobotManufacturer,
+		p.config.RobotSerialNumber,
+	)
+	instantActions.Version = p.profile.MessageVersion
+
+	actionID := p.ids.NewID()
+	action := types.NewInstantAction(req.ActionType, actionID, req.BlockingType)
+	for _, parameter := range req.Parameters {
+		action.AddParameter(parameter.Key, parameter.Value)
+	}
+	instantActions.AddAction(action)
+
+	var payload interface{} = instantActions
+	if p.profile.LegacyInstantActions {
+		payload = instantActions.ToV1()
+	}
+	msgData, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s instant actions: %v", req.ActionType, err)
+	}
+	return &OutboundMessage{Topic: p.topic("instantActions"), Payload: msgData, ActionID: actionID}, nil
+}
+
+// vda5050State state 메시지 중 브릿지가 사용하는 필드
+type vda5050State struct {
+	OrderID      string `json:"orderId"`
+	Timestamp    string `json:"timestamp"`
+	ActionStates []struct {
+		ActionID          string `json:"actionId"`
+		ActionType        string `json:"actionType"`
+		ActionStatus      string `json:"actionStatus"`
+		ResultDescription string `json:"resultDescription"`
+	} `json:"actionStates"`
+	Errors []struct {
+		ErrorType        string `json:"errorType"`
+		ErrorLevel       string `json:"errorLevel"`
+		ErrorDescription string `json:"errorDescription"`
+		ErrorReferences  []struct {
+			ReferenceKey   string `json:"referenceKey"`
+			ReferenceValue string `json:"referenceValue"`
+		} `json:"errorReferences"`
+	} `json:"errors"`
+	AgvPosition *struct {
+		X                   float64 `json:"x"`
+		Y                   float64 `json:"y"`
+		Theta               float64 `json:"theta"`
+		MapID               string  `json:"mapId"`
+		PositionInitialized *bool   `json:"positionInitialized"`
+	} `json:"agvPosition"`
+	BatteryState *struct {
+		BatteryCharge *float64 `json:"batteryCharge"`
+	} `json:"batteryState"`
+	SafetyState *struct {
+		EStop          string `json:"eStop"`
+		FieldViolation bool   `json:"fieldViolation"`
+	} `json:"safetyState"`
+	OperatingMode string `json:"operatingMode"`
+	Paused        *bool  `json:"paused"`
+	Driving       *bool  `json:"driving"`
+	NodeStates    *[]struct {
+		NodeID string `json:"nodeId"`
+	} `json:"nodeStates"`
+}
+
+// ParseState state 메시지 해석
+func (p *vda5050Protocol) ParseState(payload []byte) (*RobotState, error) {
+	var msg vda5050State
+	if err := json.Unmarshal(payload, &msg); err != nil {
+		return nil, err
+	}
+
+	state := &RobotState{
+		OrderID:       msg.OrderID,
+		OperatingMode: msg.OperatingMode,
+		Paused:        msg.Paused,
+		Driving:       msg.Driving,
+		ActionStates:  make([]ActionState, 0, len(msg.ActionStates)),
+		Errors:        make([]RobotError, 0, len(msg.Errors)),
+	}
+	for _, actionState := range msg.ActionStates {
+		if actionState.ActionStatus == "" {
+			continue
+		}
+		state.ActionStates = append(state.ActionStates, ActionState{
+			ActionID:          actionState.ActionID,
+			ActionType:        actionState.ActionType,
+			ActionStatus:      actionState.ActionStatus,
+			ResultDescription: actionState.ResultDescription,
+		})
+	}
+	for _, robotError := range msg.Errors {
+		parsed := RobotError{
+			ErrorType:        robotError.ErrorType,
+			ErrorLevel:       robotError.ErrorLevel,
+			ErrorDescription: robotError.ErrorDescription,
+		}
+		for _, reference := range robotError.ErrorReferences {
+			if reference.ReferenceKey == orderIDReferenceKey {
+				parsed.OrderID = reference.ReferenceValue
+			}
+		}
+		state.Errors = append(state.Errors, parsed)
+	}
+	if msg.AgvPosition != nil {
+		state.PositionInitialized = msg.AgvPosition.PositionInitialized
+		state.Position = &Position{
+			X:     msg.AgvPosition.X,
+			Y:     msg.AgvPosition.Y,
+			Theta: msg.AgvPosition.Theta,
+			MapID: msg.AgvPosition.MapID,
+		}
+	}
+	if msg.BatteryState != nil {
+		state.BatteryCharge = msg.BatteryState.BatteryCharge
+	}
+	if msg.SafetyState != nil {
+		state.SafetyState = &SafetyState{
+			EStop:          msg.SafetyState.EStop,
+			FieldViolation: msg.SafetyState.FieldViolation,
+		}
+	}
+	if msg.NodeStates != nil {
+		nodes := len(*msg.NodeStates)
+		state.NodeStates = &nodes
+	}
+	// 형식이 잘못된 timestamp는 보고하지 않은 것으로 취급 (재생 검사 생략)
+	if timestamp, err := time.Parse(time.RFC3339Nano, msg.Timestamp); err == nil {
+		state.Timestamp = timestamp
+	}
+	return state, nil
+}
+
+// topic 협상된 버전을 반영한 로봇 토픽 생성
+func (p *vda5050Protocol) topic(name string) string {
+	return p.config.RobotInterfaceName + "/" + p.profile.MajorVersion + "/" +
+		p.config.RobotManufacturer + "/" + p.config.RobotSerialNumber + "/" + name
+}
+
+// nextHeaderID 토픽의 다음 메시지 헤더 ID (VDA5050은 토픽마다 별도로 증가)
+func (p *vda5050Protocol) nextHeaderID(name string) int64 {
+	return p.headerIDs.Next(p.topic(name))
+}
+
+// defaultNodePosition 기본 노드 위치 생성
+func defaultNodePosition() *types.NodePosition {
+	theta := 0.0
+	allowedDeviationXY := 0.0
+	allowedDeviationTheta := 0.0
+	mapDescription := ""
+
+	return &types.NodePosition{
+		X:                     0.0,
+		Y:                     0.0,
+		Theta:                 &theta,
+		AllowedDeviationXY:    &allowedDeviationXY,
+		AllowedDeviationTheta: &allowedDeviationTheta,
+		MapID:                 "",
+		MapDescription:        &mapDescription,
+	}
+}
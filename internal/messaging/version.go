@@ -0,0 +1,99 @@
+// internal/messaging/version.go - VDA5050 Protocol Version Negotiation
+package messaging
+
+import (
+	"encoding/json"
+	"mqtt-bridge/internal/schema"
+	"mqtt-bridge/internal/utils"
+	"strings"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// VersionProfile VDA5050 프로토콜 버전별 토픽/메시지 설정
+type VersionProfile struct {
+	MajorVersion   string // 토픽 경로의 버전 (예: v2)
+	MessageVersion string // 메시지 헤더의 version 필드 (예: 2.0.0)
+
+	// VDA5050 1.x 호환: InstantActions 액션 배열 키가 actions가 아닌 instantActions
+	LegacyInstantActions bool
+}
+
+// versionProfiles 지원하는 프로토콜 버전 (major version -> profile)
+var versionProfiles = map[string]VersionProfile{
+	"1": {MajorVersion: "v1", MessageVersion: "1.1.0", LegacyInstantActions: true},
+	"2": {MajorVersion: "v2", MessageVersion: "2.0.0"},
+}
+
+// profileForVersion 메시지 version 필드로 프로필 검색 (예: "2.0.0" -> v2)
+func profileForVersion(version string) (VersionProfile, bool) {
+	major := strings.TrimPrefix(strings.SplitN(version, ".", 2)[0], "v")
+	profile, exists := versionProfiles[major]
+	return profile, exists
+}
+
+// defaultVersionProfile 설정된 major version의 기본 프로필 반환 (messageVersion이 있으면 헤더 version 대체)
+func defaultVersionProfile(majorVersion, messageVersion string) VersionProfile {
+	profile, exists := profileForVersion(majorVersion)
+	if !exists {
+		profile = VersionProfile{MessageVersion: "2.0.0"}
+	}
+	profile.MajorVersion = majorVersion
+	if messageVersion != "" {
+		profile.MessageVersion = messageVersion
+	}
+	return profile
+}
+
+// HandleFactsheet 로봇 factsheet 메시지 처리 (프로토콜 버전 협상, 동시 오더 한도, 액션 이름 목록, 액션 검증)
+func (h *DirectActionHandler) HandleFactsheet(client mqtt.Client, msg mqtt.Message) {
+	utils.Logger.Debugf("📄 Processing robot factsheet message")
+	if !h.acceptRobotMessage(schema.MessageFactsheet, msg.Topic(), msg.Payload()) {
+		return
+	}
+
+	var factsheetMsg map[string]interface{}
+	if err := json.Unmarshal(msg.Payload(), &factsheetMsg); err != nil {
+		utils.Logger.Errorf("❌ Failed to parse robot factsheet: %v", err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.negotiateVersion(factsheetMsg)
+	h.applyFactsheetOrderLimit(factsheetMsg)
+	h.applyFactsheetAllowlist(factsheetMsg)
+	h.applyFactsheetActions(factsheetMsg)
+	h.dispatchQueued()
+}
+
+// negotiateVersion 로봇이 보고한 버전으로 해당 로봇의 프로필 선택
+func (h *DirectActionHandler) negotiateVersion(robotMsg map[string]interface{}) {
+	// 버전 협상은 VDA5050 전용
+	vda5050, ok := h.protocol.(*vda5050Protocol)
+	if !h.config.ProtocolAutoNegotiate || !ok {
+		return
+	}
+
+	manufacturer, _ := robotMsg["manufacturer"].(string)
+	serialNumber, _ := robotMsg["serialNumber"].(string)
+	version, _ := robotMsg["version"].(string)
+	if manufacturer != h.config.RobotManufacturer || serialNumber != h.config.RobotSerialNumber || version == "" {
+		return
+	}
+
+	profile, supported := profileForVersion(version)
+	if !supported {
+		utils.Logger.Warnf("⚠️ Robot %s/%s reported unsupported protocol version: %s", manufacturer, serialNumber, version)
+		return
+	}
+
+	if vda5050.profile == profile {
+		return
+	}
+
+	utils.Logger.Infof("🔀 Protocol version negotiated for %s/%s: %s (topic %s/%s)",
+		manufacturer, serialNumber, profile.MessageVersion, h.config.RobotInterfaceName, profile.MajorVersion)
+	vda5050.profile = profile
+}
@@ -0,0 +1,111 @@
+// internal/messaging/visualization.go - Downsampled Robot Visualization Forwarding
+package messaging
+
+import (
+	"encoding/json"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// vda5050Visualization 로봇 visualization 메시지 중 위치/속도
+type vda5050Visualization struct {
+	AgvPosition *struct {
+		X     float64 `json:"x"`
+		Y     float64 `json:"y"`
+		Theta float64 `json:"theta"`
+		MapID string  `json:"mapId"`
+	} `json:"agvPosition"`
+	Velocity *struct {
+		Vx    float64 `json:"vx"`
+		Vy    float64 `json:"vy"`
+		Omega float64 `json:"omega"`
+	} `json:"velocity"`
+}
+
+// visualizationSampler 로봇별 다운샘플링 (고주기 메시지이므로 핸들러 잠금과 분리)
+type visualizationSampler struct {
+	mu   sync.Mutex
+	rate float64 // 초당 최대 전달 수 (VISUALIZATION_RATE, 0 이하면 모두 전달)
+	last time.Time
+}
+
+// setRate 전달 빈도 변경 (설정 리로드)
+func (s *visualizationSampler) setRate(rate float64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rate = rate
+}
+
+// take 마지막 전달 이후 간격이 지났으면 true
+func (s *visualizationSampler) take(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rate > 0 && now.Sub(s.last) < time.Duration(float64(time.Second)/s.rate) {
+		return false
+	}
+	s.last = now
+	return true
+}
+
+// HandleVisualization 로봇 visualization 메시지를 다운샘플링해 경량 토픽/이벤트 스트림으로 전달
+// VISUALIZATION_TOPIC이 있으면 <토픽>/<로봇>으로, VISUALIZATION_EVENTS면 robot.pose 이벤트(WebSocket)로 전달
+func (h *DirectActionHandler) HandleVisualization(client mqtt.Client, msg mqtt.Message) {
+	if !strings.HasSuffix(msg.Topic(), "/"+h.config.RobotManufacturer+"/"+h.config.RobotSerialNumber+"/visualization") {
+		return
+	}
+	now := time.Now()
+	if !h.visualization.take(now) {
+		return
+	}
+
+	var visualization vda5050Visualization
+	if err := json.Unmarshal(msg.Payload(), &visualization); err != nil {
+		utils.Logger.Debugf("Ignoring malformed visualization message: %v", err)
+		return
+	}
+	if visualization.AgvPosition == nil {
+		return
+	}
+
+	pose := &types.PoseUpdate{
+		Robot:     h.config.RobotSerialNumber,
+		X:         visualization.AgvPosition.X,
+		Y:         visualization.AgvPosition.Y,
+		Theta:     visualization.AgvPosition.Theta,
+		MapID:     visualization.AgvPosition.MapID,
+		UpdatedAt: now,
+	}
+	if visualization.Velocity != nil {
+		pose.Velocity = &types.Velocity{
+			Vx:    visualization.Velocity.Vx,
+			Vy:    visualization.Velocity.Vy,
+			Omega: visualization.Velocity.Omega,
+		}
+	}
+
+	if h.config.VisualizationTopic != "" {
+		msgData, err := json.Marshal(pose)
+		if err != nil {
+			utils.Logger.Errorf("❌ Failed to marshal robot pose: %v", err)
+			return
+		}
+		topic := fmt.Sprintf("%s/%s", h.config.VisualizationTopic, h.config.RobotSerialNumber)
+		if err := h.mqttClient.Publish(topic, 0, false, msgData); err != nil {
+			utils.Logger.Errorf("❌ Failed to publish robot pose: %v", err)
+		}
+	}
+	if h.config.VisualizationEvents {
+		h.eventBus.Publish(events.Event{
+			Type:  events.TypeRobotPose,
+			Robot: h.config.RobotSerialNumber,
+			Data:  pose,
+		})
+	}
+}
@@ -0,0 +1,195 @@
+// internal/metrics/bridge.go - Bridge Metrics (command/robot labels)
+package metrics
+
+import (
+	"context"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/types"
+	"strings"
+	"time"
+)
+
+// BridgeMetrics 명령 이름과 로봇 시리얼 라벨을 가진 브릿지 메트릭
+type BridgeMetrics struct {
+	Registry *Registry
+
+	commandsReceived *CounterVec
+	commandsRejected *CounterVec
+	ordersCompleted  *CounterVec
+	orderDuration    *HistogramVec
+
+	orderTimeToRunning  *HistogramVec
+	orderTimeToTerminal *HistogramVec
+	orderOutcomes       *CounterVec
+
+	instantActionsSuppressed *CounterVec
+	robotSchemaViolations    *CounterVec
+	robotStatesDropped       *CounterVec
+	handlerPanics            *CounterVec
+
+	commandLabels    *LabelLimiter
+	actionTypeLabels *LabelLimiter
+	orderStarts      map[string]time.Time    // orderID -> 오더 발행 시간
+	commandReceipts  map[string]time.Time    // correlationID -> PLC 명령 수신 시간
+	orderTimings     map[string]*orderTiming // orderID -> 명령 수신 기준 지연 시간 추적
+}
+
+// orderTiming PLC 명령 수신부터 첫 RUNNING/종료 상태까지의 추적 정보
+type orderTiming struct {
+	receivedAt time.Time
+	actionType string
+	running    bool
+}
+
+// commandReceiptMaxAge 오더로 이어지지 않은 명령 수신 기록 보관 시간
+const commandReceiptMaxAge = 10 * time.Minute
+
+// NewBridgeMetrics 새 브릿지 메트릭 생성 (maxCommandLabels: command 라벨 카디널리티 상한)
+func NewBridgeMetrics(maxCommandLabels int) *BridgeMetrics {
+	registry := NewRegistry()
+
+	return &BridgeMetrics{
+		Registry: registry,
+		commandsReceived: registry.NewCounterVec("bridge_commands_received_total",
+			"PLC commands received", "command", "robot"),
+		commandsRejected: registry.NewCounterVec("bridge_commands_rejected_total",
+			"PLC commands rejected before an order was published", "command", "robot"),
+		ordersCompleted: registry.NewCounterVec("bridge_orders_completed_total",
+			"Orders reaching a terminal PLC status", "command", "robot", "status"),
+		orderDuration: registry.NewHistogramVec("bridge_order_duration_seconds",
+			"Time from order publication to terminal PLC status", DefaultLatencyBuckets, "command", "robot"),
+		orderTimeToRunning: registry.NewHistogramVec("bridge_order_time_to_running_seconds",
+			"Time from PLC command receipt to first RUNNING status", DefaultLatencyBuckets, "action_type"),
+		orderTimeToTerminal: registry.NewHistogramVec("bridge_order_time_to_terminal_seconds",
+			"Time from PLC command receipt to terminal PLC status", DefaultLatencyBuckets, "action_type"),
+		orderOutcomes: registry.NewCounterVec("bridge_order_outcomes_total",
+			"Orders reaching a terminal PLC status by action type (success, failure)", "action_type", "outcome"),
+		instantActionsSuppressed: registry.NewCounterVec("bridge_instant_actions_suppressed_total",
+			"InstantActions not sent due to per-robot rate limiting or coalescing", "robot", "action", "reason"),
+		robotSchemaViolations: registry.NewCounterVec("bridge_robot_schema_violations_total",
+			"Robot messages quarantined for failing schema validation", "robot", "message"),
+		robotStatesDropped: registry.NewCounterVec("bridge_robot_states_dropped_total",
+			"Robot state messages dropped because the state queue was full", "robot"),
+		handlerPanics: registry.NewCounterVec("bridge_handler_panics_total",
+			"Panics recovered in message handlers", "source"),
+		commandLabels:    NewLabelLimiter(maxCommandLabels),
+		actionTypeLabels: NewLabelLimiter(maxCommandLabels),
+		orderStarts:      make(map[string]time.Time),
+		commandReceipts:  make(map[string]time.Time),
+		orderTimings:     make(map[string]*orderTiming),
+	}
+}
+
+// Run 이벤트 버스를 구독하여 메트릭 갱신 (ctx 종료 시 반환)
+func (m *BridgeMetrics) Run(ctx context.Context, bus *events.Bus) {
+	eventCh, unsubscribe := bus.Subscribe(1024)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			m.observe(event)
+		}
+	}
+}
+
+// observe 이벤트별 메트릭 갱신
+func (m *BridgeMetrics) observe(event events.Event) {
+	command := m.commandLabels.Limit(baseCommand(event.Command))
+
+	switch event.Type {
+	case events.TypeCommandReceived:
+		m.commandsReceived.Inc(command, event.Robot)
+		m.recordReceipt(event)
+	case events.TypeCommandRejected:
+		m.commandsRejected.Inc(command, event.Robot)
+		delete(m.commandReceipts, event.CorrelationID)
+	case events.TypeOrderPublished:
+		m.orderStarts[event.OrderID] = event.Time
+		m.startOrderTiming(event)
+	case events.TypeInstantActionSuppressed:
+		m.instantActionsSuppressed.Inc(event.Robot, event.Message, event.Status)
+	case events.TypeRobotMessageInvalid:
+		m.robotSchemaViolations.Inc(event.Robot, event.Status)
+	case events.TypeRobotStateDropped:
+		m.robotStatesDropped.Inc(event.Robot)
+	case events.TypeHandlerPanic:
+		m.handlerPanics.Inc(event.Status)
+	case events.TypeOrderStatus:
+		m.observeOrderTiming(event)
+		if !types.IsTerminalStatus(event.Status) {
+			return
+		}
+		m.ordersCompleted.Inc(command, event.Robot, event.Status)
+		if startedAt, exists := m.orderStarts[event.OrderID]; exists {
+			m.orderDuration.Observe(event.Time.Sub(startedAt).Seconds(), command, event.Robot)
+			delete(m.orderStarts, event.OrderID)
+		}
+	}
+}
+
+// recordReceipt PLC 명령 수신 시간 기록 (오래된 기록 정리)
+func (m *BridgeMetrics) recordReceipt(event events.Event) {
+	if event.CorrelationID == "" {
+		return
+	}
+	for correlationID, receivedAt := range m.commandReceipts {
+		if event.Time.Sub(receivedAt) > commandReceiptMaxAge {
+			delete(m.commandReceipts, correlationID)
+		}
+	}
+	m.commandReceipts[event.CorrelationID] = event.Time
+}
+
+// startOrderTiming 발행된 오더의 지연 시간 추적 시작 (수신 기록이 없으면 발행 시간 기준)
+func (m *BridgeMetrics) startOrderTiming(event events.Event) {
+	receivedAt, exists := m.commandReceipts[event.CorrelationID]
+	if !exists {
+		receivedAt = event.Time
+	}
+	delete(m.commandReceipts, event.CorrelationID)
+
+	actionType := event.ActionType
+	if actionType == "" {
+		actionType = "unknown"
+	}
+	m.orderTimings[event.OrderID] = &orderTiming{
+		receivedAt: receivedAt,
+		actionType: m.actionTypeLabels.Limit(actionType),
+	}
+}
+
+// observeOrderTiming 첫 RUNNING 및 종료 상태까지의 지연 시간과 결과 기록
+func (m *BridgeMetrics) observeOrderTiming(event events.Event) {
+	timing, exists := m.orderTimings[event.OrderID]
+	if !exists {
+		return
+	}
+
+	elapsed := event.Time.Sub(timing.receivedAt).Seconds()
+	if event.Status == types.PLCStatusRunning && !timing.running {
+		timing.running = true
+		m.orderTimeToRunning.Observe(elapsed, timing.actionType)
+	}
+	if !types.IsTerminalStatus(event.Status) {
+		return
+	}
+
+	outcome := "failure"
+	if event.Status == types.PLCStatusSuccess {
+		outcome = "success"
+	}
+	m.orderTimeToTerminal.Observe(elapsed, timing.actionType)
+	m.orderOutcomes.Inc(timing.actionType, outcome)
+	delete(m.orderTimings, event.OrderID)
+}
+
+// baseCommand 명령에서 기본 명령 이름 추출 (라벨용)
+func baseCommand(command string) string {
+	return strings.SplitN(command, ":", 2)[0]
+}
@@ -0,0 +1,33 @@
+// internal/metrics/exporter.go - Push-based Metric Exporters
+package metrics
+
+import (
+	"context"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// Exporter push 방식 메트릭 백엔드 (StatsD, OTLP 등)
+type Exporter interface {
+	Name() string
+	Export(ctx context.Context, families []Family) error
+}
+
+// RunExporter interval마다 레지스트리를 수집하여 exporter로 전송 (ctx 종료 시 반환)
+func RunExporter(ctx context.Context, registry *Registry, exporter Exporter, interval time.Duration) {
+	utils.Logger.Infof("📈 Metrics exporter started: %s (every %s)", exporter.Name(), interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := exporter.Export(ctx, registry.Gather()); err != nil {
+				utils.Logger.Errorf("❌ Metrics export failed (%s): %v", exporter.Name(), err)
+			}
+		}
+	}
+}
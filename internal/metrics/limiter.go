@@ -0,0 +1,44 @@
+// internal/metrics/limiter.go - Label Cardinality Cap
+package metrics
+
+import (
+	"sync"
+)
+
+// OverflowLabel 카디널리티 상한 초과 시 사용하는 라벨 값
+const OverflowLabel = "__other__"
+
+// LabelLimiter 라벨 값 종류 수 제한 (상한 초과 값은 OverflowLabel로 합침)
+type LabelLimiter struct {
+	max int
+
+	mu   sync.Mutex
+	seen map[string]struct{}
+}
+
+// NewLabelLimiter 새 라벨 제한기 생성 (max <= 0이면 무제한)
+func NewLabelLimiter(max int) *LabelLimiter {
+	return &LabelLimiter{
+		max:  max,
+		seen: make(map[string]struct{}),
+	}
+}
+
+// Limit 허용된 라벨 값 반환
+func (l *LabelLimiter) Limit(value string) string {
+	if l.max <= 0 {
+		return value
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, exists := l.seen[value]; exists {
+		return value
+	}
+	if len(l.seen) >= l.max {
+		return OverflowLabel
+	}
+	l.seen[value] = struct{}{}
+	return value
+}
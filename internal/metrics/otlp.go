@@ -0,0 +1,210 @@
+// internal/metrics/otlp.go - OTLP/HTTP (JSON) Metrics Exporter
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OTLPExporter OTLP/HTTP JSON exporter ({endpoint}/v1/metrics)
+type OTLPExporter struct {
+	url         string
+	serviceName string
+	startTime   time.Time
+	client      *http.Client
+}
+
+// NewOTLPExporter 새 OTLP exporter 생성
+func NewOTLPExporter(endpoint, serviceName string) *OTLPExporter {
+	return &OTLPExporter{
+		url:         strings.TrimSuffix(endpoint, "/") + "/v1/metrics",
+		serviceName: serviceName,
+		startTime:   time.Now(),
+		client:      &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Name exporter 이름
+func (e *OTLPExporter) Name() string {
+	return "otlp"
+}
+
+// Export OTLP ExportMetricsServiceRequest 전송 (누적 temporality)
+func (e *OTLPExporter) Export(ctx context.Context, families []Family) error {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	start := strconv.FormatInt(e.startTime.UnixNano(), 10)
+
+	otlpMetrics := make([]map[string]interface{}, 0, len(families))
+	for _, family := range families {
+		if len(family.Samples) == 0 {
+			continue
+		}
+
+		metric := map[string]interface{}{
+			"name":        family.Name,
+			"description": family.Help,
+		}
+
+		switch family.Type {
+		case "histogram":
+			metric["histogram"] = map[string]interface{}{
+				"aggregationTemporality": 2,
+				"dataPoints":             otlpHistogramPoints(family, start, now),
+			}
+		case "gauge":
+			metric["gauge"] = map[string]interface{}{
+				"dataPoints": otlpNumberPoints(family, start, now),
+			}
+		default:
+			metric["sum"] = map[string]interface{}{
+				"aggregationTemporality": 2,
+				"isMonotonic":            true,
+				"dataPoints":             otlpNumberPoints(family, start, now),
+			}
+		}
+		otlpMetrics = append(otlpMetrics, metric)
+	}
+
+	request := map[string]interface{}{
+		"resourceMetrics": []interface{}{
+			map[string]interface{}{
+				"resource": map[string]interface{}{
+					"attributes": otlpAttributes(map[string]string{"service.name": e.serviceName}),
+				},
+				"scopeMetrics": []interface{}{
+					map[string]interface{}{
+						"scope":   map[string]interface{}{"name": "mqtt-bridge"},
+						"metrics": otlpMetrics,
+					},
+				},
+			},
+		},
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal OTLP request: %v", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send OTLP request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("OTLP collector returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// otlpNumberPoints 카운터/게이지 데이터 포인트
+func otlpNumberPoints(family Family, start, now string) []interface{} {
+	points := make([]interface{}, 0, len(family.Samples))
+	for _, sample := range family.Samples {
+		points = append(points, map[string]interface{}{
+			"attributes":        otlpAttributes(sample.Labels),
+			"startTimeUnixNano": start,
+			"timeUnixNano":      now,
+			"asDouble":          sample.Value,
+		})
+	}
+	return points
+}
+
+// otlpHistogramPoints 누적 버킷 시계열을 OTLP 히스토그램 포인트로 재구성
+func otlpHistogramPoints(family Family, start, now string) []interface{} {
+	type histogramPoint struct {
+		labels  map[string]string
+		bounds  []float64
+		buckets []uint64 // 누적값
+		sum     float64
+		count   uint64
+	}
+
+	points := make(map[string]*histogramPoint)
+	order := make([]string, 0)
+	pointFor := func(labels map[string]string) *histogramPoint {
+		withoutLE := make(map[string]string, len(labels))
+		for key, value := range labels {
+			if key != "le" {
+				withoutLE[key] = value
+			}
+		}
+		key := formatLabels(withoutLE)
+		point, exists := points[key]
+		if !exists {
+			point = &histogramPoint{labels: withoutLE}
+			points[key] = point
+			order = append(order, key)
+		}
+		return point
+	}
+
+	for _, sample := range family.Samples {
+		point := pointFor(sample.Labels)
+		switch {
+		case strings.HasSuffix(sample.Name, "_bucket"):
+			bound, err := strconv.ParseFloat(sample.Labels["le"], 64)
+			if err != nil || math.IsInf(bound, 1) {
+				continue
+			}
+			point.bounds = append(point.bounds, bound)
+			point.buckets = append(point.buckets, uint64(sample.Value))
+		case strings.HasSuffix(sample.Name, "_sum"):
+			point.sum = sample.Value
+		case strings.HasSuffix(sample.Name, "_count"):
+			point.count = uint64(sample.Value)
+		}
+	}
+
+	result := make([]interface{}, 0, len(order))
+	for _, key := range order {
+		point := points[key]
+
+		// OTLP bucketCounts는 비누적이며 +Inf 버킷 포함
+		bucketCounts := make([]string, 0, len(point.buckets)+1)
+		var previous uint64
+		for _, cumulative := range point.buckets {
+			bucketCounts = append(bucketCounts, strconv.FormatUint(cumulative-previous, 10))
+			previous = cumulative
+		}
+		bucketCounts = append(bucketCounts, strconv.FormatUint(point.count-previous, 10))
+
+		result = append(result, map[string]interface{}{
+			"attributes":        otlpAttributes(point.labels),
+			"startTimeUnixNano": start,
+			"timeUnixNano":      now,
+			"count":             strconv.FormatUint(point.count, 10),
+			"sum":               point.sum,
+			"bucketCounts":      bucketCounts,
+			"explicitBounds":    point.bounds,
+		})
+	}
+	return result
+}
+
+// otlpAttributes 라벨을 OTLP KeyValue 목록으로 변환
+func otlpAttributes(labels map[string]string) []interface{} {
+	attributes := make([]interface{}, 0, len(labels))
+	for _, key := range sortedKeys(labels) {
+		attributes = append(attributes, map[string]interface{}{
+			"key":   key,
+			"value": map[string]interface{}{"stringValue": labels[key]},
+		})
+	}
+	return attributes
+}
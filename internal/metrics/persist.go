@@ -0,0 +1,93 @@
+// internal/metrics/persist.go - Counter Persistence across Restarts
+package metrics
+
+import (
+	"context"
+	"mqtt-bridge/internal/utils"
+	"strings"
+	"time"
+)
+
+// PersistedCounter 영속 저장되는 카운터 시계열 값
+type PersistedCounter struct {
+	Name        string
+	LabelValues []string
+	Value       float64
+}
+
+// CounterStore 누적 카운터 영속 저장소 (history.Store 구현)
+type CounterStore interface {
+	LoadCounters() ([]PersistedCounter, error)
+	SaveCounters(counters []PersistedCounter) error
+}
+
+// counters 등록된 카운터 목록
+func (r *Registry) counters() map[string]*CounterVec {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	counters := make(map[string]*CounterVec)
+	for _, c := range r.collectors {
+		if counter, ok := c.(*CounterVec); ok {
+			counters[counter.name] = counter
+		}
+	}
+	return counters
+}
+
+// RestoreCounters 저장된 카운터 값을 더함 (시작 시 이벤트 처리 전에 호출)
+// 라벨 구성이 바뀐 시계열이나 더 이상 없는 카운터는 무시
+func (r *Registry) RestoreCounters(store CounterStore) error {
+	persisted, err := store.LoadCounters()
+	if err != nil {
+		return err
+	}
+
+	counters := r.counters()
+	restored := 0
+	for _, value := range persisted {
+		counter, exists := counters[value.Name]
+		if !exists || len(value.LabelValues) != len(counter.labelNames) {
+			continue
+		}
+		counter.Add(value.Value, value.LabelValues...)
+		restored++
+	}
+
+	utils.Logger.Infof("📈 Restored %d persisted counter series", restored)
+	return nil
+}
+
+// SaveCounters 모든 카운터 현재 값 저장
+func (r *Registry) SaveCounters(store CounterStore) error {
+	var snapshot []PersistedCounter
+	for _, counter := range r.counters() {
+		counter.mu.Lock()
+		for key, value := range counter.values {
+			snapshot = append(snapshot, PersistedCounter{
+				Name:        counter.name,
+				LabelValues: strings.Split(key, labelSeparator),
+				Value:       value,
+			})
+		}
+		counter.mu.Unlock()
+	}
+	return store.SaveCounters(snapshot)
+}
+
+// RunCounterPersistence 주기적으로 카운터 저장 (ctx 종료 시 반환, 종료 시 저장은 호출자 담당)
+func RunCounterPersistence(ctx context.Context, registry *Registry, store CounterStore, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := registry.SaveCounters(store); err != nil {
+				utils.Logger.Errorf("❌ Failed to persist counters: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,142 @@
+// internal/metrics/registry.go - Minimal Prometheus-compatible Metrics Registry
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// labelSeparator 라벨 값 결합용 구분자
+const labelSeparator = "\xff"
+
+// Sample 단일 시계열 값
+type Sample struct {
+	Name   string            // 시계열 이름 (히스토그램은 _bucket/_sum/_count 접미사 포함)
+	Labels map[string]string // 라벨
+	Value  float64
+}
+
+// Family 메트릭 패밀리 (동일 이름의 시계열 묶음)
+type Family struct {
+	Name    string
+	Help    string
+	Type    string // counter, gauge, histogram
+	Samples []Sample
+}
+
+// collector 레지스트리에 등록되는 메트릭
+type collector interface {
+	family() Family
+}
+
+// Registry 메트릭 레지스트리
+type Registry struct {
+	mu         sync.Mutex
+	collectors []collector
+}
+
+// NewRegistry 새 레지스트리 생성
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// register 메트릭 등록
+func (r *Registry) register(c collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// Gather 등록된 모든 메트릭 패밀리 수집
+func (r *Registry) Gather() []Family {
+	r.mu.Lock()
+	collectors := append([]collector(nil), r.collectors...)
+	r.mu.Unlock()
+
+	families := make([]Family, 0, len(collectors))
+	for _, c := range collectors {
+		families = append(families, c.family())
+	}
+	return families
+}
+
+// WriteText Prometheus text exposition 형식으로 출력
+func (r *Registry) WriteText(w io.Writer) error {
+	for _, family := range r.Gather() {
+		if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", family.Name, family.Help, family.Name, family.Type); err != nil {
+			return err
+		}
+		for _, sample := range family.Samples {
+			if _, err := fmt.Fprintf(w, "%s%s %s\n", sample.Name, formatLabels(sample.Labels), formatValue(sample.Value)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Handler /metrics HTTP 핸들러
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		r.WriteText(w)
+	})
+}
+
+// formatLabels 라벨을 {k="v",...} 형식으로 변환 (키 정렬)
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", key, labels[key]))
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// formatValue 값 포맷 (Inf 처리)
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	default:
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+}
+
+// labelMap 라벨 이름/값 쌍을 맵으로 변환
+func labelMap(names, values []string) map[string]string {
+	labels := make(map[string]string, len(names))
+	for i, name := range names {
+		if i < len(values) {
+			labels[name] = values[i]
+		}
+	}
+	return labels
+}
+
+// sortedKeys 시계열 키 정렬 (출력 순서 고정)
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,127 @@
+// internal/metrics/statsd.go - StatsD / DogStatsD Exporter
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+)
+
+// statsdMaxPacketSize UDP 패킷 최대 크기 (MTU 고려)
+const statsdMaxPacketSize = 1400
+
+// StatsDExporter StatsD UDP exporter (누적 카운터는 증분으로 변환하여 전송)
+type StatsDExporter struct {
+	conn        net.Conn
+	prefix      string
+	datadogTags bool // DogStatsD 태그 형식(|#k:v) 사용 여부
+
+	lastValues map[string]float64 // 시계열 키 -> 마지막 전송 누적값
+}
+
+// NewStatsDExporter 새 StatsD exporter 생성
+func NewStatsDExporter(addr, prefix string, datadogTags bool) (*StatsDExporter, error) {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open statsd connection: %v", err)
+	}
+
+	return &StatsDExporter{
+		conn:        conn,
+		prefix:      prefix,
+		datadogTags: datadogTags,
+		lastValues:  make(map[string]float64),
+	}, nil
+}
+
+// Name exporter 이름
+func (e *StatsDExporter) Name() string {
+	return "statsd"
+}
+
+// Export 메트릭 전송 (카운터/히스토그램 sum,count는 증분 |c, 게이지는 |g)
+func (e *StatsDExporter) Export(ctx context.Context, families []Family) error {
+	var packet bytes.Buffer
+
+	for _, family := range families {
+		for _, sample := range family.Samples {
+			// 버킷 시계열은 StatsD에서 의미가 없으므로 제외
+			if strings.HasSuffix(sample.Name, "_bucket") {
+				continue
+			}
+
+			var line string
+			if family.Type == "gauge" {
+				line = fmt.Sprintf("%s:%s|g%s", e.metricName(sample), formatValue(sample.Value), e.tags(sample))
+			} else {
+				key := sample.Name + formatLabels(sample.Labels)
+				delta := sample.Value - e.lastValues[key]
+				e.lastValues[key] = sample.Value
+				if delta <= 0 {
+					continue
+				}
+				line = fmt.Sprintf("%s:%s|c%s", e.metricName(sample), formatValue(delta), e.tags(sample))
+			}
+
+			if packet.Len()+len(line)+1 > statsdMaxPacketSize {
+				if err := e.flush(&packet); err != nil {
+					return err
+				}
+			}
+			if packet.Len() > 0 {
+				packet.WriteByte('\n')
+			}
+			packet.WriteString(line)
+		}
+	}
+
+	return e.flush(&packet)
+}
+
+// metricName StatsD 메트릭 이름 (태그 미사용 시 라벨 값을 이름에 포함)
+func (e *StatsDExporter) metricName(sample Sample) string {
+	name := e.prefix + sample.Name
+	if e.datadogTags || len(sample.Labels) == 0 {
+		return name
+	}
+
+	for _, key := range sortedKeys(sample.Labels) {
+		name += "." + sanitizeStatsD(sample.Labels[key])
+	}
+	return name
+}
+
+// tags DogStatsD 태그 문자열
+func (e *StatsDExporter) tags(sample Sample) string {
+	if !e.datadogTags || len(sample.Labels) == 0 {
+		return ""
+	}
+
+	tags := make([]string, 0, len(sample.Labels))
+	for key, value := range sample.Labels {
+		tags = append(tags, key+":"+sanitizeStatsD(value))
+	}
+	sort.Strings(tags)
+	return "|#" + strings.Join(tags, ",")
+}
+
+// flush 버퍼된 패킷 전송
+func (e *StatsDExporter) flush(packet *bytes.Buffer) error {
+	if packet.Len() == 0 {
+		return nil
+	}
+	defer packet.Reset()
+
+	if _, err := e.conn.Write(packet.Bytes()); err != nil {
+		return fmt.Errorf("failed to send statsd packet: %v", err)
+	}
+	return nil
+}
+
+// sanitizeStatsD StatsD 프로토콜 예약 문자 치환
+func sanitizeStatsD(value string) string {
+	return strings.NewReplacer(":", "_", "|", "_", "@", "_", "#", "_", ",", "_", " ", "_").Replace(value)
+}
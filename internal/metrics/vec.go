@@ -0,0 +1,177 @@
+// internal/metrics/vec.go - Counter/Histogram Vectors and Gauges
+package metrics
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// DefaultLatencyBuckets 기본 지연 시간 버킷 (초)
+var DefaultLatencyBuckets = []float64{0.5, 1, 2.5, 5, 10, 30, 60, 120, 300, 600}
+
+// CounterVec 라벨별 카운터
+type CounterVec struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+// NewCounterVec 카운터 생성 및 등록
+func (r *Registry) NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	counter := &CounterVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     make(map[string]float64),
+	}
+	r.register(counter)
+	return counter
+}
+
+// Inc 1 증가
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add 값 증가
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	key := strings.Join(labelValues, labelSeparator)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.values[key] += value
+}
+
+func (c *CounterVec) family() Family {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	family := Family{Name: c.name, Help: c.help, Type: "counter"}
+	for _, key := range sortedKeys(c.values) {
+		family.Samples = append(family.Samples, Sample{
+			Name:   c.name,
+			Labels: labelMap(c.labelNames, strings.Split(key, labelSeparator)),
+			Value:  c.values[key],
+		})
+	}
+	return family
+}
+
+// HistogramVec 라벨별 히스토그램
+type HistogramVec struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+
+	mu     sync.Mutex
+	values map[string]*histogramValue
+}
+
+type histogramValue struct {
+	counts []uint64 // 버킷별 누적 전 개수
+	sum    float64
+	count  uint64
+}
+
+// NewHistogramVec 히스토그램 생성 및 등록
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	histogram := &HistogramVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		buckets:    buckets,
+		values:     make(map[string]*histogramValue),
+	}
+	r.register(histogram)
+	return histogram
+}
+
+// Observe 값 기록
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := strings.Join(labelValues, labelSeparator)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	hv, exists := h.values[key]
+	if !exists {
+		hv = &histogramValue{counts: make([]uint64, len(h.buckets))}
+		h.values[key] = hv
+	}
+
+	for i, bound := range h.buckets {
+		if value <= bound {
+			hv.counts[i]++
+			break
+		}
+	}
+	hv.sum += value
+	hv.count++
+}
+
+func (h *HistogramVec) family() Family {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	family := Family{Name: h.name, Help: h.help, Type: "histogram"}
+	for _, key := range sortedKeys(h.values) {
+		hv := h.values[key]
+		labelValues := strings.Split(key, labelSeparator)
+
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += hv.counts[i]
+			labels := labelMap(h.labelNames, labelValues)
+			labels["le"] = formatValue(bound)
+			family.Samples = append(family.Samples, Sample{Name: h.name + "_bucket", Labels: labels, Value: float64(cumulative)})
+		}
+
+		labels := labelMap(h.labelNames, labelValues)
+		labels["le"] = formatValue(math.Inf(1))
+		family.Samples = append(family.Samples,
+			Sample{Name: h.name + "_bucket", Labels: labels, Value: float64(hv.count)},
+			Sample{Name: h.name + "_sum", Labels: labelMap(h.labelNames, labelValues), Value: hv.sum},
+			Sample{Name: h.name + "_count", Labels: labelMap(h.labelNames, labelValues), Value: float64(hv.count)},
+		)
+	}
+	return family
+}
+
+// GaugeFunc 수집 시점에 값을 읽는 게이지 (라벨 하나, 예: 로봇별 대기열 길이)
+type GaugeFunc struct {
+	name      string
+	help      string
+	labelName string
+	collect   func() map[string]float64 // 라벨 값 -> 현재 값
+}
+
+// NewGaugeFunc 게이지 생성 및 등록
+func (r *Registry) NewGaugeFunc(name, help, labelName string, collect func() map[string]float64) *GaugeFunc {
+	gauge := &GaugeFunc{
+		name:      name,
+		help:      help,
+		labelName: labelName,
+		collect:   collect,
+	}
+	r.register(gauge)
+	return gauge
+}
+
+func (g *GaugeFunc) family() Family {
+	values := g.collect()
+
+	family := Family{Name: g.name, Help: g.help, Type: "gauge"}
+	for _, key := range sortedKeys(values) {
+		family.Samples = append(family.Samples, Sample{
+			Name:   g.name,
+			Labels: map[string]string{g.labelName: key},
+			Value:  values[key],
+		})
+	}
+	return family
+}
@@ -0,0 +1,129 @@
+// internal/modbus/registers.go - Holding Register Map (per unit/robot)
+package modbus
+
+import (
+	"mqtt-bridge/internal/types"
+	"strings"
+	"sync"
+)
+
+// 홀딩 레지스터 맵 (0 기준 주소, 레지스터당 ASCII 2문자, 상위 바이트 먼저)
+//
+//	0-31   명령 문자열 (PLC 쓰기, 예: "PICK01:T:L", 남는 영역은 0)
+//	32     트리거 (PLC가 0이 아닌 새 시퀀스 값을 쓰면 명령 실행)
+//	33     확인 시퀀스 (실행한 트리거 값)
+//	34     수락 결과 (0 없음, 1 수락, 2 거부)
+//	35     마지막 응답 상태 (0 없음, types.PLCStatusCode)
+//	36     응답 카운터 (응답마다 1 증가, PLC 변경 감지용)
+//	40-71  마지막 응답 명령 (ASCII)
+const (
+	regCommand       = 0
+	regCommandCount  = 32
+	regTrigger       = 32
+	regAck           = 33
+	regAccepted      = 34
+	regStatus        = 35
+	regResponseSeq   = 36
+	regResponse      = 40
+	regResponseCount = 32
+	registerCount    = 72
+)
+
+// 수락 결과 값
+const (
+	acceptedNone     = 0
+	acceptedOK       = 1
+	acceptedRejected = 2
+)
+
+// registerBank 유닛 하나의 레지스터 (동시 접근 보호)
+type registerBank struct {
+	mu     sync.Mutex
+	values [registerCount]uint16
+}
+
+// read 레지스터 범위 읽기 (범위 밖이면 false)
+func (b *registerBank) read(address, count int) ([]uint16, bool) {
+	if address < 0 || count < 1 || address+count > registerCount {
+		return nil, false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return append([]uint16(nil), b.values[address:address+count]...), true
+}
+
+// write 레지스터 범위 쓰기 (PLC 쓰기 가능 영역만 허용: 명령, 트리거)
+func (b *registerBank) write(address int, values []uint16) bool {
+	if address < 0 || address+len(values) > regTrigger+1 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	copy(b.values[address:], values)
+	return true
+}
+
+// command 명령 문자열 영역 해석
+func (b *registerBank) command() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return decodeString(b.values[regCommand : regCommand+regCommandCount])
+}
+
+// trigger 현재 트리거/확인 시퀀스
+func (b *registerBank) trigger() (trigger, ack uint16) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.values[regTrigger], b.values[regAck]
+}
+
+// acknowledge 트리거 실행 결과 기록
+func (b *registerBank) acknowledge(sequence uint16, accepted bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.values[regAck] = sequence
+	b.values[regAccepted] = acceptedRejected
+	if accepted {
+		b.values[regAccepted] = acceptedOK
+	}
+}
+
+// setResponse 마지막 PLC 응답 기록
+func (b *registerBank) setResponse(command, status string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.values[regStatus] = types.PLCStatusCode(status)
+	b.values[regResponseSeq]++
+	copy(b.values[regResponse:regResponse+regResponseCount], encodeString(command, regResponseCount))
+}
+
+// encodeString 문자열을 레지스터로 변환 (넘치는 부분은 잘림)
+func encodeString(text string, count int) []uint16 {
+	registers := make([]uint16, count)
+	for i := 0; i < len(text) && i < count*2; i++ {
+		if i%2 == 0 {
+			registers[i/2] = uint16(text[i]) << 8
+		} else {
+			registers[i/2] |= uint16(text[i])
+		}
+	}
+	return registers
+}
+
+// decodeString 레지스터를 문자열로 변환 (첫 0 바이트에서 종료)
+func decodeString(registers []uint16) string {
+	var sb strings.Builder
+	for _, register := range registers {
+		for _, b := range []byte{byte(register >> 8), byte(register)} {
+			if b == 0 {
+				return strings.TrimSpace(sb.String())
+			}
+			sb.WriteByte(b)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
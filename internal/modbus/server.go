@@ -0,0 +1,289 @@
+// internal/modbus/server.go - Modbus TCP Server PLC Frontend
+package modbus
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"net"
+	"sync"
+	"time"
+)
+
+// Modbus 함수 코드
+const (
+	funcReadHoldingRegisters   = 0x03
+	funcWriteSingleRegister    = 0x06
+	funcWriteMultipleRegisters = 0x10
+)
+
+// Modbus 예외 코드
+const (
+	exceptionIllegalFunction = 0x01
+	exceptionIllegalAddress  = 0x02
+	exceptionIllegalValue    = 0x03
+)
+
+// maxReadRegisters 한 번에 읽을 수 있는 최대 레지스터 수 (Modbus 규격)
+const maxReadRegisters = 125
+
+// idleTimeout 요청이 없는 연결 종료 시간
+const idleTimeout = 5 * time.Minute
+
+// unit 유닛 ID 하나 (로봇 하나의 핸들러와 레지스터)
+type unit struct {
+	handler   *messaging.DirectActionHandler
+	registers *registerBank
+}
+
+// Server Modbus TCP 서버 (PLC가 MQTT 대신 레지스터로 명령/상태 교환)
+// 유닛 ID 1..N은 명령 경로 순서의 핸들러, 0과 255는 첫 번째 핸들러
+type Server struct {
+	addr     string
+	units    []*unit
+	eventBus *events.Bus
+
+	mu       sync.Mutex
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+}
+
+// NewServer 새 Modbus 서버 생성
+func NewServer(addr string, handlers []*messaging.DirectActionHandler, eventBus *events.Bus) *Server {
+	server := &Server{
+		addr:     addr,
+		eventBus: eventBus,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	for _, handler := range handlers {
+		server.units = append(server.units, &unit{handler: handler, registers: &registerBank{}})
+	}
+	return server
+}
+
+// Start 리스너 열기 및 연결 수락 시작 (백그라운드)
+func (s *Server) Start(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.listener = listener
+	s.mu.Unlock()
+
+	utils.Logger.Infof("🔌 Modbus TCP server listening on %s (%d unit(s))", s.addr, len(s.units))
+	go s.trackResponses(ctx)
+	go s.accept(listener)
+	return nil
+}
+
+// Stop 리스너와 모든 연결 종료
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.listener != nil {
+		s.listener.Close()
+	}
+	for conn := range s.conns {
+		conn.Close()
+	}
+	utils.Logger.Info("Modbus TCP server stopped")
+}
+
+// trackResponses PLC 응답 이벤트를 로봇별 상태 레지스터에 반영
+func (s *Server) trackResponses(ctx context.Context) {
+	eventCh, unsubscribe := s.eventBus.Subscribe(256)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if event.Type != events.TypePLCResponse {
+				continue
+			}
+			for _, u := range s.units {
+				if u.handler.RobotSerialNumber() == event.Robot {
+					u.registers.setResponse(event.Command, event.Status)
+				}
+			}
+		}
+	}
+}
+
+// accept 연결 수락 루프 (리스너가 닫히면 반환)
+func (s *Server) accept(listener net.Listener) {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				utils.Logger.Errorf("❌ Modbus accept failed: %v", err)
+			}
+			return
+		}
+
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+
+		go s.serve(conn)
+	}
+}
+
+// serve 연결 하나의 요청 처리 (MBAP 헤더 + PDU)
+func (s *Server) serve(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+	}()
+
+	utils.Logger.Infof("🔌 Modbus client connected: %s", conn.RemoteAddr())
+
+	header := make([]byte, 7)
+	for {
+		conn.SetReadDeadline(time.Now().Add(idleTimeout))
+		if _, err := io.ReadFull(conn, header); err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				utils.Logger.Warnf("⚠️ Modbus connection closed: %s - %v", conn.RemoteAddr(), err)
+			}
+			return
+		}
+
+		transactionID := binary.BigEndian.Uint16(header[0:2])
+		protocolID := binary.BigEndian.Uint16(header[2:4])
+		length := int(binary.BigEndian.Uint16(header[4:6]))
+		unitID := header[6]
+		if protocolID != 0 || length < 2 || length > 254 {
+			utils.Logger.Warnf("⚠️ Invalid Modbus frame from %s, closing connection", conn.RemoteAddr())
+			return
+		}
+
+		pdu := make([]byte, length-1)
+		if _, err := io.ReadFull(conn, pdu); err != nil {
+			return
+		}
+
+		response := s.handle(unitID, pdu)
+
+		frame := make([]byte, 7+len(response))
+		binary.BigEndian.PutUint16(frame[0:2], transactionID)
+		binary.BigEndian.PutUint16(frame[4:6], uint16(len(response)+1))
+		frame[6] = unitID
+		copy(frame[7:], response)
+		if _, err := conn.Write(frame); err != nil {
+			return
+		}
+	}
+}
+
+// handle PDU 처리 후 응답 PDU 반환
+func (s *Server) handle(unitID byte, pdu []byte) []byte {
+	function := pdu[0]
+
+	u := s.unit(unitID)
+	if u == nil {
+		return exception(function, exceptionIllegalAddress)
+	}
+
+	switch function {
+	case funcReadHoldingRegisters:
+		if len(pdu) != 5 {
+			return exception(function, exceptionIllegalValue)
+		}
+		address := int(binary.BigEndian.Uint16(pdu[1:3]))
+		count := int(binary.BigEndian.Uint16(pdu[3:5]))
+		if count > maxReadRegisters {
+			return exception(function, exceptionIllegalValue)
+		}
+		values, ok := u.registers.read(address, count)
+		if !ok {
+			return exception(function, exceptionIllegalAddress)
+		}
+
+		response := []byte{function, byte(count * 2)}
+		for _, value := range values {
+			response = binary.BigEndian.AppendUint16(response, value)
+		}
+		return response
+
+	case funcWriteSingleRegister:
+		if len(pdu) != 5 {
+			return exception(function, exceptionIllegalValue)
+		}
+		address := int(binary.BigEndian.Uint16(pdu[1:3]))
+		value := binary.BigEndian.Uint16(pdu[3:5])
+		if !u.registers.write(address, []uint16{value}) {
+			return exception(function, exceptionIllegalAddress)
+		}
+		s.checkTrigger(u, address, 1)
+		return pdu
+
+	case funcWriteMultipleRegisters:
+		if len(pdu) < 6 {
+			return exception(function, exceptionIllegalValue)
+		}
+		address := int(binary.BigEndian.Uint16(pdu[1:3]))
+		count := int(binary.BigEndian.Uint16(pdu[3:5]))
+		if int(pdu[5]) != count*2 || len(pdu) != 6+count*2 {
+			return exception(function, exceptionIllegalValue)
+		}
+		values := make([]uint16, count)
+		for i := range values {
+			values[i] = binary.BigEndian.Uint16(pdu[6+i*2:])
+		}
+		if !u.registers.write(address, values) {
+			return exception(function, exceptionIllegalAddress)
+		}
+		s.checkTrigger(u, address, count)
+		return pdu[:5]
+
+	default:
+		return exception(function, exceptionIllegalFunction)
+	}
+}
+
+// checkTrigger 트리거 레지스터에 새 시퀀스가 쓰이면 명령 실행 (같은 값 재기록은 무시)
+func (s *Server) checkTrigger(u *unit, address, count int) {
+	if address > regTrigger || address+count <= regTrigger {
+		return
+	}
+
+	sequence, ack := u.registers.trigger()
+	if sequence == 0 || sequence == ack {
+		return
+	}
+
+	command := u.registers.command()
+	utils.Logger.Infof("📨 Modbus command (sequence %d): '%s'", sequence, command)
+
+	result := u.handler.ProcessCommand(command)
+	u.registers.acknowledge(sequence, result.Accepted)
+}
+
+// unit 유닛 ID에 해당하는 유닛 (0, 255는 첫 번째)
+func (s *Server) unit(unitID byte) *unit {
+	if unitID == 0 || unitID == 255 {
+		return s.units[0]
+	}
+	if int(unitID) > len(s.units) {
+		return nil
+	}
+	return s.units[unitID-1]
+}
+
+// exception 예외 응답 PDU
+func exception(function, code byte) []byte {
+	return []byte{function | 0x80, code}
+}
@@ -0,0 +1,299 @@
+// internal/opcua/server.go - OPC UA Server PLC Frontend
+package opcua
+
+import (
+	"context"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/gopcua/opcua/id"
+	"github.com/gopcua/opcua/server"
+	"github.com/gopcua/opcua/server/attrs"
+	"github.com/gopcua/opcua/ua"
+)
+
+// NamespaceURI 브릿지 노드 네임스페이스
+const NamespaceURI = "urn:mqtt-bridge"
+
+// writeBacklog 처리 대기 중인 Command 쓰기 최대 수 (초과 시 쓰기 값만 저장되고 실행되지 않음)
+const writeBacklog = 64
+
+// Server OPC UA 서버 (PLC/SCADA가 MQTT 대신 노드 읽기/쓰기로 명령/상태 교환)
+// 보안 정책 None + 익명 인증, 로봇마다 Objects 아래 폴더 하나
+//
+// 노드 (ns=<NamespaceURI 인덱스>;s=<robot>.<name>)
+//
+//	Command          String    쓰기 시 PLC 명령 실행 (예: "PICK01:T:L")
+//	CommandResult    String    마지막 쓰기 결과 ("accepted <orderId>" 또는 "rejected: <사유>")
+//	Status           String    마지막 PLC 응답 상태 (W, I, R, S, F)
+//	LastResponse     String    마지막 PLC 응답 ("PICK01:S")
+//	ResponseCounter  UInt32    응답마다 1 증가 (데이터 변경 감지용)
+//	ActiveOrders     String[]  활성 오더 ("<orderId>|<command>|<status>")
+type Server struct {
+	addr     string
+	robots   []*robotNodes
+	eventBus *events.Bus
+
+	mu        sync.Mutex
+	srv       *server.Server
+	ns        *server.NodeNameSpace
+	byCommand map[string]*robotNodes // Command 노드 ID → 로봇
+}
+
+// robotNodes 로봇 하나의 노드 값
+type robotNodes struct {
+	handler *messaging.DirectActionHandler
+
+	mu              sync.Mutex
+	commandResult   string
+	status          string
+	lastResponse    string
+	responseCounter uint32
+}
+
+// NewServer 새 OPC UA 서버 생성 (핸들러 하나당 로봇 폴더 하나)
+func NewServer(addr string, handlers []*messaging.DirectActionHandler, eventBus *events.Bus) *Server {
+	s := &Server{
+		addr:      addr,
+		eventBus:  eventBus,
+		byCommand: make(map[string]*robotNodes, len(handlers)),
+	}
+	for _, handler := range handlers {
+		s.robots = append(s.robots, &robotNodes{handler: handler})
+	}
+	return s
+}
+
+// Start 주소 공간 구성 후 리스너 열기 (요청 처리는 백그라운드)
+func (s *Server) Start(ctx context.Context) error {
+	host, portText, err := net.SplitHostPort(s.addr)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portText)
+	if err != nil {
+		return fmt.Errorf("invalid port %q: %w", portText, err)
+	}
+
+	// 첫 번째 엔드포인트가 리스너 주소, 모든 인터페이스면 호스트 이름도 광고
+	options := []server.Option{
+		server.EnableSecurity("None", ua.MessageSecurityModeNone),
+		server.EnableAuthMode(ua.UserTokenTypeAnonymous),
+		server.ServerName("MQTT Bridge"),
+		server.ProductName("mqtt-bridge"),
+		server.SetLogger(logger{}),
+	}
+	if host == "" || host == "0.0.0.0" {
+		options = append(options, server.EndPoint("0.0.0.0", port))
+		if hostname, err := os.Hostname(); err == nil {
+			options = append(options, server.EndPoint(hostname, port))
+		}
+	} else {
+		options = append(options, server.EndPoint(host, port))
+	}
+
+	srv := server.New(options...)
+	ns := server.NewNodeNameSpace(srv, NamespaceURI)
+	ns.ExternalNotification = make(chan *ua.NodeID, writeBacklog)
+
+	s.ns = ns
+	s.buildAddressSpace(srv)
+
+	if err := srv.Start(ctx); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.srv = srv
+	s.mu.Unlock()
+
+	utils.Logger.Infof("🏭 OPC UA server listening on %s (%d robot(s))", s.addr, len(s.robots))
+	go s.serveWrites(ctx)
+	go s.trackEvents(ctx)
+	return nil
+}
+
+// Stop 리스너와 모든 세션 종료
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.srv != nil {
+		if err := s.srv.Close(); err != nil {
+			utils.Logger.Warnf("⚠️ OPC UA server close: %v", err)
+		}
+	}
+	utils.Logger.Info("OPC UA server stopped")
+}
+
+// buildAddressSpace 로봇별 폴더와 변수 노드 등록
+func (s *Server) buildAddressSpace(srv *server.Server) {
+	root, _ := srv.Namespace(0)
+	root.Objects().AddRef(s.ns.Objects(), server.RefTypeIDHasComponent, true)
+
+	for _, robot := range s.robots {
+		name := robot.handler.RobotSerialNumber()
+		folder := server.NewFolderNode(ua.NewStringNodeID(s.ns.ID(), name), name)
+		s.ns.AddNode(folder)
+		s.ns.Objects().AddRef(folder, server.RefTypeIDHasComponent, true)
+
+		command := s.variable(folder, name, "Command", id.String, true, nil)
+		s.byCommand[command.ID().String()] = robot
+
+		s.variable(folder, name, "CommandResult", id.String, false, func() *ua.DataValue {
+			robot.mu.Lock()
+			defer robot.mu.Unlock()
+			return server.DataValueFromValue(robot.commandResult)
+		})
+		s.variable(folder, name, "Status", id.String, false, func() *ua.DataValue {
+			robot.mu.Lock()
+			defer robot.mu.Unlock()
+			return server.DataValueFromValue(robot.status)
+		})
+		s.variable(folder, name, "LastResponse", id.String, false, func() *ua.DataValue {
+			robot.mu.Lock()
+			defer robot.mu.Unlock()
+			return server.DataValueFromValue(robot.lastResponse)
+		})
+		s.variable(folder, name, "ResponseCounter", id.UInt32, false, func() *ua.DataValue {
+			robot.mu.Lock()
+			defer robot.mu.Unlock()
+			return server.DataValueFromValue(robot.responseCounter)
+		})
+		s.variable(folder, name, "ActiveOrders", id.String, false, func() *ua.DataValue {
+			return server.DataValueFromValue(activeOrders(robot.handler))
+		})
+	}
+}
+
+// variable 로봇 폴더 아래 변수 노드 추가 (value가 nil이면 빈 문자열로 시작)
+func (s *Server) variable(folder *server.Node, robot, name string, dataType uint32, writable bool, value server.ValueFunc) *server.Node {
+	access := byte(ua.AccessLevelTypeCurrentRead)
+	if writable {
+		access |= byte(ua.AccessLevelTypeCurrentWrite)
+	}
+	if value == nil {
+		value = func() *ua.DataValue { return server.DataValueFromValue("") }
+	}
+
+	node := server.NewNode(
+		nodeID(s.ns, robot, name),
+		map[ua.AttributeID]*ua.DataValue{
+			ua.AttributeIDNodeClass:       server.DataValueFromValue(uint32(ua.NodeClassVariable)),
+			ua.AttributeIDBrowseName:      server.DataValueFromValue(attrs.BrowseName(name)),
+			ua.AttributeIDDisplayName:     server.DataValueFromValue(attrs.DisplayName(name, name)),
+			ua.AttributeIDDataType:        server.DataValueFromValue(ua.NewNumericNodeID(0, dataType)),
+			ua.AttributeIDAccessLevel:     server.DataValueFromValue(access),
+			ua.AttributeIDUserAccessLevel: server.DataValueFromValue(access),
+		},
+		nil,
+		value,
+	)
+	s.ns.AddNode(node)
+	folder.AddRef(node, server.RefTypeIDHasComponent, true)
+	return node
+}
+
+// nodeID 로봇 변수 노드 ID (ns=<ns>;s=<robot>.<name>)
+func nodeID(ns *server.NodeNameSpace, robot, name string) *ua.NodeID {
+	return ua.NewStringNodeID(ns.ID(), robot+"."+name)
+}
+
+// activeOrders 활성 오더 목록 ("<orderId>|<command>|<status>")
+func activeOrders(handler *messaging.DirectActionHandler) []string {
+	orders := handler.GetOrders()
+	values := make([]string, 0, len(orders))
+	for _, order := range orders {
+		values = append(values, order.OrderID+"|"+order.Command+"|"+order.Status)
+	}
+	return values
+}
+
+// serveWrites Command 노드 쓰기를 순서대로 PLC 명령으로 실행
+// 쓰기 서비스는 값을 저장한 뒤 네임스페이스 알림 채널로 노드 ID만 전달
+func (s *Server) serveWrites(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case written := <-s.ns.ExternalNotification:
+			robot, exists := s.byCommand[written.String()]
+			if !exists {
+				continue
+			}
+			s.execute(robot, s.ns.Node(written).Value())
+		}
+	}
+}
+
+// execute Command 쓰기 값 하나 실행 후 CommandResult 갱신
+func (s *Server) execute(robot *robotNodes, value *ua.DataValue) {
+	outcome := "rejected: Command must be a String"
+	if value != nil && value.Value != nil {
+		if command, ok := value.Value.Value().(string); ok {
+			result := robot.handler.ProcessCommand(command)
+			if result.Accepted {
+				outcome = "accepted " + result.OrderID
+			} else {
+				outcome = "rejected: " + result.Reason
+			}
+			utils.Logger.Infof("🏭 OPC UA command %s for %s: %s", command, robot.handler.RobotSerialNumber(), outcome)
+		}
+	}
+
+	robot.mu.Lock()
+	robot.commandResult = outcome
+	robot.mu.Unlock()
+	s.srv.ChangeNotification(nodeID(s.ns, robot.handler.RobotSerialNumber(), "CommandResult"))
+}
+
+// trackEvents PLC 응답을 상태 노드에 반영하고 오더 이벤트마다 구독자에게 변경 알림
+func (s *Server) trackEvents(ctx context.Context) {
+	eventCh, unsubscribe := s.eventBus.Subscribe(256)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if event.OrderID == "" && event.Type != events.TypePLCResponse {
+				continue
+			}
+			for _, robot := range s.robots {
+				name := robot.handler.RobotSerialNumber()
+				if name != event.Robot {
+					continue
+				}
+				if event.Type == events.TypePLCResponse {
+					robot.mu.Lock()
+					robot.status = event.Status
+					robot.lastResponse = event.Message
+					robot.responseCounter++
+					robot.mu.Unlock()
+					for _, node := range []string{"Status", "LastResponse", "ResponseCounter"} {
+						s.srv.ChangeNotification(nodeID(s.ns, name, node))
+					}
+				}
+				s.srv.ChangeNotification(nodeID(s.ns, name, "ActiveOrders"))
+			}
+		}
+	}
+}
+
+// logger gopcua 서버 로그를 브릿지 로거로 전달 (연결/요청 로그는 디버그 레벨)
+type logger struct{}
+
+func (logger) Debug(msg string, args ...any) { utils.Logger.Debugf(msg, args...) }
+func (logger) Info(msg string, args ...any)  { utils.Logger.Debugf(msg, args...) }
+func (logger) Warn(msg string, args ...any)  { utils.Logger.Warnf(msg, args...) }
+func (logger) Error(msg string, args ...any) { utils.Logger.Errorf(msg, args...) }
@@ -0,0 +1,162 @@
+package opcua
+
+import (
+	"context"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/harness"
+	"mqtt-bridge/internal/messaging"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gopcua/opcua"
+	"github.com/gopcua/opcua/ua"
+)
+
+// freeAddr 사용 가능한 루프백 주소 (gopcua 서버는 포트 0의 실제 포트를 노출하지 않음)
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+// newTestClient 하네스 핸들러에 연결된 서버와 익명 클라이언트
+func newTestClient(t *testing.T) (*harness.Harness, *Server, *opcua.Client) {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config: %v", err)
+	}
+	h, err := harness.New(cfg, time.Millisecond)
+	if err != nil {
+		t.Fatalf("harness: %v", err)
+	}
+	t.Cleanup(h.Close)
+	h.Robot.SetOutcome(harness.OutcomeHold)
+
+	addr := freeAddr(t)
+	server := NewServer(addr, []*messaging.DirectActionHandler{h.Handler}, h.Events)
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := server.Start(ctx); err != nil {
+		cancel()
+		t.Fatalf("start: %v", err)
+	}
+	t.Cleanup(func() {
+		server.Stop()
+		cancel()
+	})
+
+	client, err := opcua.NewClient("opc.tcp://"+addr,
+		opcua.SecurityMode(ua.MessageSecurityModeNone), opcua.AuthAnonymous())
+	if err != nil {
+		t.Fatalf("client: %v", err)
+	}
+	connectCtx, connectCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer connectCancel()
+	if err := client.Connect(connectCtx); err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { client.Close(context.Background()) })
+	return h, server, client
+}
+
+// read 노드 값 하나 읽기
+func read(t *testing.T, server *Server, client *opcua.Client, robot, name string) interface{} {
+	t.Helper()
+	resp, err := client.Read(context.Background(), &ua.ReadRequest{
+		NodesToRead: []*ua.ReadValueID{{NodeID: nodeID(server.ns, robot, name), AttributeID: ua.AttributeIDValue}},
+	})
+	if err != nil {
+		t.Fatalf("read %s: %v", name, err)
+	}
+	if status := resp.Results[0].Status; status != ua.StatusOK {
+		t.Fatalf("read %s: %v", name, status)
+	}
+	return resp.Results[0].Value.Value()
+}
+
+// write 노드 값 하나 쓰기 후 상태 코드
+func write(t *testing.T, server *Server, client *opcua.Client, robot, name string, value interface{}) ua.StatusCode {
+	t.Helper()
+	resp, err := client.Write(context.Background(), &ua.WriteRequest{
+		NodesToWrite: []*ua.WriteValue{{
+			NodeID:      nodeID(server.ns, robot, name),
+			AttributeID: ua.AttributeIDValue,
+			Value:       &ua.DataValue{EncodingMask: ua.DataValueValue, Value: ua.MustVariant(value)},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+	return resp.Results[0]
+}
+
+// waitFor 조건이 참이 될 때까지 대기
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCommandWriteRunsOrder(t *testing.T) {
+	h, server, client := newTestClient(t)
+	robot := h.Handler.RobotSerialNumber()
+
+	if status := write(t, server, client, robot, "Command", "CAL:I"); status != ua.StatusOK {
+		t.Fatalf("write Command: %v", status)
+	}
+	waitFor(t, "CommandResult", func() bool {
+		result, _ := read(t, server, client, robot, "CommandResult").(string)
+		return strings.HasPrefix(result, "accepted ")
+	})
+	result := read(t, server, client, robot, "CommandResult").(string)
+	orderID := strings.TrimPrefix(result, "accepted ")
+	if _, exists := h.Handler.GetOrder(orderID); !exists {
+		t.Fatalf("order %q from CommandResult not on the handler", orderID)
+	}
+
+	waitFor(t, "running status", func() bool {
+		return read(t, server, client, robot, "Status") == "R"
+	})
+	if counter, _ := read(t, server, client, robot, "ResponseCounter").(uint32); counter == 0 {
+		t.Error("ResponseCounter not incremented")
+	}
+	orders, _ := read(t, server, client, robot, "ActiveOrders").([]string)
+	if len(orders) != 1 || !strings.HasPrefix(orders[0], orderID+"|CAL:I|") {
+		t.Errorf("ActiveOrders %v, want the CAL:I order", orders)
+	}
+}
+
+func TestCommandWriteRejections(t *testing.T) {
+	h, server, client := newTestClient(t)
+	robot := h.Handler.RobotSerialNumber()
+
+	if status := write(t, server, client, robot, "Command", "NOPE"); status != ua.StatusOK {
+		t.Fatalf("write Command: %v", status)
+	}
+	waitFor(t, "rejection", func() bool {
+		result, _ := read(t, server, client, robot, "CommandResult").(string)
+		return strings.HasPrefix(result, "rejected: ")
+	})
+
+	if status := write(t, server, client, robot, "Command", int32(7)); status != ua.StatusOK {
+		t.Fatalf("write Command: %v", status)
+	}
+	waitFor(t, "type rejection", func() bool {
+		return read(t, server, client, robot, "CommandResult") == "rejected: Command must be a String"
+	})
+
+	if status := write(t, server, client, robot, "Status", "S"); status == ua.StatusOK {
+		t.Error("Status accepted a write")
+	}
+}
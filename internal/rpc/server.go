@@ -0,0 +1,297 @@
+// internal/rpc/server.go - BridgeService gRPC Server (HTTP/2 cleartext)
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+	"io"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// gRPC 상태 코드 (google.golang.org/grpc/codes)
+const (
+	codeOK                 = 0
+	codeInvalidArgument    = 3
+	codeNotFound           = 5
+	codeFailedPrecondition = 9
+	codeUnimplemented      = 12
+	codeInternal           = 13
+	codeUnauthenticated    = 16
+)
+
+// servicePath BridgeService 메서드 경로 접두사 (api/proto/bridge/v1/bridge.proto)
+const servicePath = "/bridge.v1.BridgeService/"
+
+// maxMessageSize 요청 메시지 최대 크기
+const maxMessageSize = 4 * 1024
+
+// rpcError gRPC 상태 코드를 가진 오류
+type rpcError struct {
+	code    int
+	message string
+}
+
+func (e *rpcError) Error() string {
+	return e.message
+}
+
+// Server BridgeService gRPC 서버 (h2c, 생성된 스텁 없이 bridge.proto 메시지를 직접 인코딩)
+// 명령 제출과 취소는 HTTP API와 같은 Bearer 토큰(HTTP_AUTH_TOKEN)이 필요
+type Server struct {
+	config     *config.Config
+	service    *CommandService
+	httpServer *http.Server
+}
+
+// NewServer 새 gRPC 서버 생성 (handlers는 전체 명령 경로)
+func NewServer(cfg *config.Config, handlers []*messaging.DirectActionHandler) *Server {
+	server := &Server{
+		config:  cfg,
+		service: NewCommandService(handlers),
+	}
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	server.httpServer = &http.Server{
+		Addr:              cfg.GRPCAddr,
+		Handler:           http.HandlerFunc(server.serveRPC),
+		Protocols:         protocols,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	return server
+}
+
+// Start 리스너 열기 및 요청 처리 시작 (백그라운드)
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.config.GRPCAddr)
+	if err != nil {
+		return err
+	}
+	utils.Logger.Infof("🌐 gRPC server listening on %s", listener.Addr())
+
+	go func() {
+		if err := s.httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			utils.Logger.Errorf("❌ gRPC server failed: %v", err)
+		}
+	}()
+	return nil
+}
+
+// Stop gRPC 서버 중지 (진행 중인 WatchOrder 스트림은 종료)
+func (s *Server) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := s.httpServer.Shutdown(ctx); err != nil {
+		s.httpServer.Close()
+	}
+	utils.Logger.Info("gRPC server stopped")
+}
+
+// serveRPC gRPC 요청 하나 처리 (요청 메시지 1개, 응답 메시지 0..N개 + 상태 trailer)
+func (s *Server) serveRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ProtoMajor != 2 || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		http.Error(w, "gRPC requests only", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/grpc+proto")
+	w.WriteHeader(http.StatusOK)
+
+	ctx := r.Context()
+	if timeout, ok := parseTimeout(r.Header.Get("Grpc-Timeout")); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	err := s.dispatch(ctx, w, r)
+	writeStatus(w, err)
+}
+
+// dispatch 메서드별 처리
+func (s *Server) dispatch(ctx context.Context, w http.ResponseWriter, r *http.Request) error {
+	method, _ := strings.CutPrefix(r.URL.Path, servicePath)
+
+	request, err := readMessage(r.Body)
+	if err != nil {
+		return err
+	}
+
+	switch method {
+	case "SubmitDirectAction":
+		if err := s.authorize(r); err != nil {
+			return err
+		}
+		command, err := decodeStringField(request, 1)
+		if err != nil {
+			return &rpcError{codeInvalidArgument, err.Error()}
+		}
+		robot, err := decodeStringField(request, 2)
+		if err != nil {
+			return &rpcError{codeInvalidArgument, err.Error()}
+		}
+		utils.Logger.Infof("🌐 gRPC SubmitDirectAction from %s (robot %q): '%s'", r.RemoteAddr, robot, command)
+
+		result, err := s.service.SubmitDirectAction(ctx, command, robot)
+		if err != nil {
+			return err
+		}
+		var response []byte
+		response = appendString(response, 1, result.Command)
+		response = appendString(response, 2, result.OrderID)
+		response = appendBool(response, 3, result.Accepted)
+		response = appendString(response, 4, result.Reason)
+		return writeMessage(w, response)
+
+	case "CancelOrder":
+		if err := s.authorize(r); err != nil {
+			return err
+		}
+		orderID, err := decodeStringField(request, 1)
+		if err != nil {
+			return &rpcError{codeInvalidArgument, err.Error()}
+		}
+		utils.Logger.Infof("🌐 gRPC CancelOrder from %s for OrderID: %s", r.RemoteAddr, orderID)
+
+		order, err := s.service.CancelOrder(ctx, orderID)
+		if err != nil {
+			return err
+		}
+		return writeMessage(w, encodeOrder(order))
+
+	case "WatchOrder":
+		orderID, err := decodeStringField(request, 1)
+		if err != nil {
+			return &rpcError{codeInvalidArgument, err.Error()}
+		}
+		return s.service.WatchOrder(ctx, orderID, func(event events.Event) error {
+			return writeMessage(w, encodeOrderEvent(event))
+		})
+	}
+	return &rpcError{codeUnimplemented, "unknown method " + r.URL.Path}
+}
+
+// authorize Bearer 토큰 확인 (authorization 메타데이터)
+func (s *Server) authorize(r *http.Request) error {
+	if s.config.HTTPAuthToken == "" {
+		return &rpcError{codeUnauthenticated, "mutating RPCs are disabled (HTTP_AUTH_TOKEN is not set)"}
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.HTTPAuthToken)) != 1 {
+		return &rpcError{codeUnauthenticated, "missing or invalid bearer token"}
+	}
+	return nil
+}
+
+// encodeOrder bridge.v1.Order
+func encodeOrder(order *messaging.OrderInfo) []byte {
+	var b []byte
+	b = appendString(b, 1, order.OrderID)
+	b = appendString(b, 2, order.Command)
+	b = appendString(b, 3, order.CancelCommand)
+	b = appendString(b, 4, order.Status)
+	b = appendBool(b, 5, order.Canceled)
+	b = appendTimestamp(b, 6, order.CreatedAt)
+	b = appendTimestamp(b, 7, order.UpdatedAt)
+	return b
+}
+
+// encodeOrderEvent bridge.v1.OrderEvent
+func encodeOrderEvent(event events.Event) []byte {
+	var b []byte
+	b = appendString(b, 1, event.Type)
+	b = appendTimestamp(b, 2, event.Time)
+	b = appendString(b, 3, event.Command)
+	b = appendString(b, 4, event.OrderID)
+	b = appendString(b, 5, event.Status)
+	return b
+}
+
+// readMessage 길이 접두 메시지 하나 읽기 (압축 플래그 1바이트 + 길이 4바이트)
+func readMessage(body io.Reader) ([]byte, error) {
+	var prefix [5]byte
+	if _, err := io.ReadFull(body, prefix[:]); err != nil {
+		return nil, &rpcError{codeInvalidArgument, "missing request message"}
+	}
+	if prefix[0] != 0 {
+		return nil, &rpcError{codeUnimplemented, "compressed messages are not supported"}
+	}
+	length := binary.BigEndian.Uint32(prefix[1:])
+	if length > maxMessageSize {
+		return nil, &rpcError{codeInvalidArgument, "request message too large"}
+	}
+	message := make([]byte, length)
+	if _, err := io.ReadFull(body, message); err != nil {
+		return nil, &rpcError{codeInvalidArgument, "truncated request message"}
+	}
+	return message, nil
+}
+
+// writeMessage 길이 접두 메시지 하나 쓰기 (스트리밍 응답을 위해 즉시 flush)
+func writeMessage(w http.ResponseWriter, message []byte) error {
+	frame := make([]byte, 5, 5+len(message))
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(message)))
+	if _, err := w.Write(append(frame, message...)); err != nil {
+		return err
+	}
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// writeStatus 상태 trailer 작성 (grpc-status, grpc-message)
+func writeStatus(w http.ResponseWriter, err error) {
+	code, message := codeOK, ""
+	var rpcErr *rpcError
+	switch {
+	case err == nil:
+	case errors.As(err, &rpcErr):
+		code, message = rpcErr.code, rpcErr.message
+	case errors.Is(err, ErrInvalidArgument):
+		code, message = codeInvalidArgument, err.Error()
+	case errors.Is(err, messaging.ErrOrderNotFound), errors.Is(err, messaging.ErrUnknownRobot):
+		code, message = codeNotFound, err.Error()
+	case errors.Is(err, ErrFailedPrecondition):
+		code, message = codeFailedPrecondition, err.Error()
+	default:
+		code, message = codeInternal, err.Error()
+	}
+
+	w.Header().Set(http.TrailerPrefix+"Grpc-Status", strconv.Itoa(code))
+	if message != "" {
+		w.Header().Set(http.TrailerPrefix+"Grpc-Message", url.PathEscape(message))
+	}
+}
+
+// parseTimeout grpc-timeout 헤더 해석 (예: 10S, 500m)
+func parseTimeout(value string) (time.Duration, bool) {
+	if len(value) < 2 {
+		return 0, false
+	}
+	units := map[byte]time.Duration{
+		'H': time.Hour, 'M': time.Minute, 'S': time.Second,
+		'm': time.Millisecond, 'u': time.Microsecond, 'n': time.Nanosecond,
+	}
+	unit, exists := units[value[len(value)-1]]
+	if !exists {
+		return 0, false
+	}
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+	return time.Duration(amount) * unit, true
+}
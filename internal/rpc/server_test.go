@@ -0,0 +1,197 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/harness"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testToken = "rpc-test-token"
+
+// newTestServer 하네스 핸들러에 연결된 h2c gRPC 서버와 클라이언트
+func newTestServer(t *testing.T) (*harness.Harness, *httptest.Server, *http.Client) {
+	t.Helper()
+	harnesses, ts, client := newFleetServer(t, "")
+	return harnesses[0], ts, client
+}
+
+// newFleetServer 로봇마다 하네스를 만들어 하나의 gRPC 서버에 연결 (빈 시리얼은 기본 설정 유지)
+func newFleetServer(t *testing.T, serials ...string) ([]*harness.Harness, *httptest.Server, *http.Client) {
+	t.Helper()
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config: %v", err)
+	}
+	cfg.HTTPAuthToken = testToken
+
+	harnesses := make([]*harness.Harness, 0, len(serials))
+	handlers := make([]*messaging.DirectActionHandler, 0, len(serials))
+	for _, serial := range serials {
+		robotCfg := *cfg
+		if serial != "" {
+			robotCfg.RobotSerialNumber = serial
+		}
+		h, err := harness.New(&robotCfg, time.Millisecond)
+		if err != nil {
+			t.Fatalf("harness: %v", err)
+		}
+		t.Cleanup(h.Close)
+		harnesses = append(harnesses, h)
+		handlers = append(handlers, h.Handler)
+	}
+
+	server := NewServer(cfg, handlers)
+	ts := httptest.NewUnstartedServer(server.httpServer.Handler)
+	ts.Config.Protocols = server.httpServer.Protocols
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	protocols := new(http.Protocols)
+	protocols.SetUnencryptedHTTP2(true)
+	return harnesses, ts, &http.Client{Transport: &http.Transport{Protocols: protocols}}
+}
+
+// call RPC 호출 후 응답 메시지와 grpc-status
+func call(t *testing.T, ts *httptest.Server, client *http.Client, method, token string, request []byte) ([][]byte, int) {
+	t.Helper()
+	frame := make([]byte, 5)
+	binary.BigEndian.PutUint32(frame[1:], uint32(len(request)))
+
+	req, _ := http.NewRequest(http.MethodPost, ts.URL+servicePath+method, bytes.NewReader(append(frame, request...)))
+	req.Header.Set("Content-Type", "application/grpc")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("%s: %v", method, err)
+	}
+	defer resp.Body.Close()
+
+	var messages [][]byte
+	for {
+		message, err := readMessage(resp.Body)
+		if err != nil {
+			break
+		}
+		messages = append(messages, message)
+	}
+	io.Copy(io.Discard, resp.Body)
+
+	code, err := strconv.Atoi(resp.Trailer.Get("Grpc-Status"))
+	if err != nil {
+		t.Fatalf("%s: missing grpc-status trailer (%v)", method, resp.Trailer)
+	}
+	return messages, code
+}
+
+func TestUnaryStatusCodes(t *testing.T) {
+	_, ts, client := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		method  string
+		token   string
+		request []byte
+		code    int
+	}{
+		{"submit without token", "SubmitDirectAction", "", appendString(nil, 1, "CAL:I"), codeUnauthenticated},
+		{"submit with wrong token", "SubmitDirectAction", "wrong", appendString(nil, 1, "CAL:I"), codeUnauthenticated},
+		{"submit empty command", "SubmitDirectAction", testToken, nil, codeInvalidArgument},
+		{"cancel unknown order", "CancelOrder", testToken, appendString(nil, 1, "missing"), codeNotFound},
+		{"watch unknown order", "WatchOrder", "", appendString(nil, 1, "missing"), codeNotFound},
+		{"unknown method", "Reboot", testToken, nil, codeUnimplemented},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, code := call(t, ts, client, tt.method, tt.token, tt.request); code != tt.code {
+				t.Errorf("grpc-status = %d, want %d", code, tt.code)
+			}
+		})
+	}
+}
+
+func TestSubmitAndWatchOrder(t *testing.T) {
+	h, ts, client := newTestServer(t)
+	h.Robot.SetOutcome(harness.OutcomeSucceed)
+
+	messages, code := call(t, ts, client, "SubmitDirectAction", testToken, appendString(nil, 1, "CAL:I"))
+	if code != codeOK || len(messages) != 1 {
+		t.Fatalf("SubmitDirectAction: status %d, %d message(s)", code, len(messages))
+	}
+	orderID, err := decodeStringField(messages[0], 2)
+	if err != nil || orderID == "" {
+		t.Fatalf("SubmitDirectAction: no order_id in response (%v)", err)
+	}
+
+	events, code := call(t, ts, client, "WatchOrder", "", appendString(nil, 1, orderID))
+	if code != codeOK || len(events) == 0 {
+		t.Fatalf("WatchOrder: status %d, %d event(s)", code, len(events))
+	}
+	last := events[len(events)-1]
+	if status, _ := decodeStringField(last, 5); status != types.PLCStatusSuccess {
+		t.Errorf("WatchOrder: last status %q, want %q", status, types.PLCStatusSuccess)
+	}
+	if id, _ := decodeStringField(last, 4); id != orderID {
+		t.Errorf("WatchOrder: order_id %q, want %q", id, orderID)
+	}
+}
+
+func TestRoutesAcrossRobots(t *testing.T) {
+	harnesses, ts, client := newFleetServer(t, "ROBOT1", "ROBOT2")
+	for _, h := range harnesses {
+		h.Robot.SetOutcome(harness.OutcomeHold)
+	}
+
+	request := appendString(appendString(nil, 1, "CAL:I"), 2, "ROBOT2")
+	messages, code := call(t, ts, client, "SubmitDirectAction", testToken, request)
+	if code != codeOK || len(messages) != 1 {
+		t.Fatalf("SubmitDirectAction: status %d, %d message(s)", code, len(messages))
+	}
+	orderID, _ := decodeStringField(messages[0], 2)
+	if _, exists := harnesses[1].Handler.GetOrder(orderID); !exists {
+		t.Fatalf("order %s not created on ROBOT2", orderID)
+	}
+	if _, exists := harnesses[0].Handler.GetOrder(orderID); exists {
+		t.Fatalf("order %s created on ROBOT1", orderID)
+	}
+
+	if _, code := call(t, ts, client, "CancelOrder", testToken, appendString(nil, 1, orderID)); code != codeOK {
+		t.Errorf("CancelOrder on second route: grpc-status %d", code)
+	}
+
+	unknown := appendString(appendString(nil, 1, "CAL:I"), 2, "ROBOT9")
+	if _, code := call(t, ts, client, "SubmitDirectAction", testToken, unknown); code != codeNotFound {
+		t.Errorf("SubmitDirectAction unknown robot: grpc-status %d, want %d", code, codeNotFound)
+	}
+}
+
+func TestParseTimeout(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"10S", 10 * time.Second, true},
+		{"500m", 500 * time.Millisecond, true},
+		{"2H", 2 * time.Hour, true},
+		{"S", 0, false},
+		{"10x", 0, false},
+		{"-1S", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseTimeout(tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseTimeout(%q) = %s, %v; want %s, %v", tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
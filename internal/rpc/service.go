@@ -0,0 +1,70 @@
+// internal/rpc/service.go - BridgeService (gRPC) Implementation
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+)
+
+// CommandService api/proto/bridge/v1/bridge.proto BridgeService 구현체
+// 전송 계층(server.go)은 메시지 인코딩과 상태 코드 변환만 담당하고 이 메서드들에 위임한다.
+// 오더는 ID로 모든 명령 경로에서 찾고, 명령 제출은 robot으로 대상 로봇을 고른다.
+type CommandService struct {
+	handlers []*messaging.DirectActionHandler
+}
+
+// NewCommandService 새 명령 서비스 생성 (handlers는 전체 명령 경로, 첫 번째가 robot 생략 시 제출 대상)
+func NewCommandService(handlers []*messaging.DirectActionHandler) *CommandService {
+	return &CommandService{
+		handlers: handlers,
+	}
+}
+
+// SubmitDirectAction PLC 명령 문법으로 Direct Action 제출 (robot이 비어있으면 첫 번째 명령 경로)
+func (s *CommandService) SubmitDirectAction(ctx context.Context, command, robot string) (*messaging.CommandResult, error) {
+	if command == "" {
+		return nil, fmt.Errorf("%w: command is required", ErrInvalidArgument)
+	}
+	handler, err := messaging.HandlerForRobot(s.handlers, robot)
+	if err != nil {
+		return nil, err
+	}
+	return handler.ProcessCommand(command), nil
+}
+
+// CancelOrder 활성 오더 취소
+func (s *CommandService) CancelOrder(ctx context.Context, orderID string) (*messaging.OrderInfo, error) {
+	handler, err := messaging.HandlerForOrder(s.handlers, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := handler.CancelOrder(orderID); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFailedPrecondition, err)
+	}
+
+	order, _ := handler.GetOrder(orderID)
+	return &order, nil
+}
+
+// WatchOrder 오더 상태 전이를 최종 상태까지 스트리밍 (server-streaming RPC)
+func (s *CommandService) WatchOrder(ctx context.Context, orderID string, send func(events.Event) error) error {
+	handler, err := messaging.HandlerForOrder(s.handlers, orderID)
+	if err != nil {
+		return err
+	}
+	err = handler.WatchOrder(ctx, orderID, send)
+	if errors.Is(err, context.Canceled) {
+		return nil
+	}
+	return err
+}
+
+// gRPC 상태 코드로 매핑되는 오류
+var (
+	ErrInvalidArgument    = errors.New("invalid argument")    // codes.InvalidArgument
+	ErrFailedPrecondition = errors.New("failed precondition") // codes.FailedPrecondition
+)
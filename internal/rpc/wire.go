@@ -0,0 +1,111 @@
+// internal/rpc/wire.go - Protobuf Wire Format (bridge.v1 messages)
+package rpc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// protobuf wire type
+const (
+	wireVarint = 0
+	wireI64    = 1
+	wireBytes  = 2
+	wireI32    = 5
+)
+
+// errMalformed 요청 메시지 해석 실패
+var errMalformed = errors.New("malformed protobuf message")
+
+// appendTag 필드 번호 + wire type
+func appendTag(b []byte, field int, wireType int) []byte {
+	return binary.AppendUvarint(b, uint64(field)<<3|uint64(wireType))
+}
+
+// appendString string 필드 (proto3 기본값은 생략)
+func appendString(b []byte, field int, value string) []byte {
+	if value == "" {
+		return b
+	}
+	b = appendTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(value)))
+	return append(b, value...)
+}
+
+// appendBool bool 필드 (proto3 기본값은 생략)
+func appendBool(b []byte, field int, value bool) []byte {
+	if !value {
+		return b
+	}
+	b = appendTag(b, field, wireVarint)
+	return append(b, 1)
+}
+
+// appendMessage 하위 메시지 필드
+func appendMessage(b []byte, field int, message []byte) []byte {
+	b = appendTag(b, field, wireBytes)
+	b = binary.AppendUvarint(b, uint64(len(message)))
+	return append(b, message...)
+}
+
+// appendTimestamp google.protobuf.Timestamp 필드 (seconds = 1, nanos = 2)
+func appendTimestamp(b []byte, field int, t time.Time) []byte {
+	if t.IsZero() {
+		return b
+	}
+	var timestamp []byte
+	if seconds := t.Unix(); seconds != 0 {
+		timestamp = appendTag(timestamp, 1, wireVarint)
+		timestamp = binary.AppendUvarint(timestamp, uint64(seconds))
+	}
+	if nanos := t.Nanosecond(); nanos != 0 {
+		timestamp = appendTag(timestamp, 2, wireVarint)
+		timestamp = binary.AppendUvarint(timestamp, uint64(nanos))
+	}
+	return appendMessage(b, field, timestamp)
+}
+
+// decodeStringField 메시지에서 string 필드 하나 추출 (알 수 없는 필드는 건너뜀)
+func decodeStringField(data []byte, field int) (string, error) {
+	var value string
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return "", errMalformed
+		}
+		data = data[n:]
+		number, wireType := int(tag>>3), int(tag&7)
+
+		switch wireType {
+		case wireVarint:
+			if _, n = binary.Uvarint(data); n <= 0 {
+				return "", errMalformed
+			}
+			data = data[n:]
+		case wireI64:
+			if len(data) < 8 {
+				return "", errMalformed
+			}
+			data = data[8:]
+		case wireI32:
+			if len(data) < 4 {
+				return "", errMalformed
+			}
+			data = data[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return "", errMalformed
+			}
+			if number == field {
+				value = string(data[n : n+int(length)])
+			}
+			data = data[n+int(length):]
+		default:
+			return "", fmt.Errorf("%w: unsupported wire type %d", errMalformed, wireType)
+		}
+	}
+	return value, nil
+}
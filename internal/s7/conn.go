@@ -0,0 +1,247 @@
+// internal/s7/conn.go - S7comm Client over ISO-on-TCP (RFC 1006)
+package s7
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// S7 프로토콜 상수
+const (
+	tpktVersion       = 0x03
+	cotpConnectReq    = 0xE0
+	cotpConnectConf   = 0xD0
+	cotpData          = 0xF0
+	s7ProtocolID      = 0x32
+	s7RoleJob         = 0x01
+	s7RoleAckData     = 0x03
+	s7FuncSetupComm   = 0xF0
+	s7FuncReadVar     = 0x04
+	s7FuncWriteVar    = 0x05
+	s7AreaDB          = 0x84
+	s7TransportByte   = 0x02
+	s7DataBitLength   = 0x04
+	s7ReturnSuccess   = 0xFF
+	requestedPDUSize  = 480
+	readWriteOverhead = 18 // 헤더 + 파라미터 (읽기/쓰기 요청 하나당)
+)
+
+// ErrDataTooLarge 협상된 PDU에 들어가지 않는 읽기/쓰기
+var ErrDataTooLarge = errors.New("data exceeds negotiated PDU size")
+
+// conn S7 PLC 연결 하나 (요청-응답은 순차 처리)
+type conn struct {
+	mu      sync.Mutex
+	netConn net.Conn
+	timeout time.Duration
+	pduSize int
+	pduRef  uint16
+}
+
+// dial PLC 연결 (TCP -> COTP 연결 -> S7 통신 설정)
+// rack/slot은 원격 TSAP (PG 연결, 0x01 | rack<<5 | slot)으로 변환
+func dial(addr string, rack, slot int, timeout time.Duration) (*conn, error) {
+	netConn, err := net.DialTimeout("tcp", addr, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &conn{netConn: netConn, timeout: timeout}
+	if err := c.connectCOTP(rack, slot); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("COTP connect failed: %v", err)
+	}
+	if err := c.setupCommunication(); err != nil {
+		netConn.Close()
+		return nil, fmt.Errorf("S7 setup communication failed: %v", err)
+	}
+	return c, nil
+}
+
+// close 연결 종료
+func (c *conn) close() error {
+	return c.netConn.Close()
+}
+
+// connectCOTP COTP 연결 요청 (TPDU 크기 1024, 로컬 TSAP 0x0100)
+func (c *conn) connectCOTP(rack, slot int) error {
+	request := []byte{
+		0x11, cotpConnectReq,
+		0x00, 0x00, // 목적지 참조
+		0x00, 0x01, // 출발지 참조
+		0x00,             // 클래스 0
+		0xC0, 0x01, 0x0A, // TPDU 크기
+		0xC1, 0x02, 0x01, 0x00, // 로컬 TSAP
+		0xC2, 0x02, 0x01, byte(rack<<5 | slot), // 원격 TSAP
+	}
+	if err := c.writeTPKT(request); err != nil {
+		return err
+	}
+
+	response, err := c.readTPKT()
+	if err != nil {
+		return err
+	}
+	if len(response) < 2 || response[1] != cotpConnectConf {
+		return fmt.Errorf("unexpected COTP response")
+	}
+	return nil
+}
+
+// setupCommunication PDU 크기 협상
+func (c *conn) setupCommunication() error {
+	c.pduSize = requestedPDUSize
+	params := []byte{s7FuncSetupComm, 0x00, 0x00, 0x01, 0x00, 0x01}
+	params = binary.BigEndian.AppendUint16(params, requestedPDUSize)
+
+	responseParams, _, err := c.exchange(params, nil)
+	if err != nil {
+		return err
+	}
+	if len(responseParams) < 8 {
+		return fmt.Errorf("short setup communication response")
+	}
+	c.pduSize = int(binary.BigEndian.Uint16(responseParams[6:8]))
+	return nil
+}
+
+// readDB DB 바이트 범위 읽기
+func (c *conn) readDB(db, start, size int) ([]byte, error) {
+	if size+readWriteOverhead > c.pduSize {
+		return nil, ErrDataTooLarge
+	}
+
+	_, data, err := c.exchange(append([]byte{s7FuncReadVar, 0x01}, dbItem(db, start, size)...), nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 4 || data[0] != s7ReturnSuccess {
+		return nil, returnCodeError(data)
+	}
+
+	length := int(binary.BigEndian.Uint16(data[2:4]))
+	if data[1] == s7DataBitLength {
+		length /= 8
+	}
+	if length != size || len(data) < 4+size {
+		return nil, fmt.Errorf("short read: got %d bytes, want %d", length, size)
+	}
+	return data[4 : 4+size], nil
+}
+
+// writeDB DB 바이트 범위 쓰기
+func (c *conn) writeDB(db, start int, values []byte) error {
+	if len(values)+readWriteOverhead+4 > c.pduSize {
+		return ErrDataTooLarge
+	}
+
+	data := []byte{0x00, s7DataBitLength}
+	data = binary.BigEndian.AppendUint16(data, uint16(len(values)*8))
+	data = append(data, values...)
+
+	_, responseData, err := c.exchange(append([]byte{s7FuncWriteVar, 0x01}, dbItem(db, start, len(values))...), data)
+	if err != nil {
+		return err
+	}
+	if len(responseData) < 1 || responseData[0] != s7ReturnSuccess {
+		return returnCodeError(responseData)
+	}
+	return nil
+}
+
+// exchange S7 job 전송 후 ack_data 응답의 파라미터/데이터 반환
+func (c *conn) exchange(params, data []byte) ([]byte, []byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.pduRef++
+	frame := []byte{0x02, cotpData, 0x80, s7ProtocolID, s7RoleJob, 0x00, 0x00}
+	frame = binary.BigEndian.AppendUint16(frame, c.pduRef)
+	frame = binary.BigEndian.AppendUint16(frame, uint16(len(params)))
+	frame = binary.BigEndian.AppendUint16(frame, uint16(len(data)))
+	frame = append(frame, params...)
+	frame = append(frame, data...)
+	if err := c.writeTPKT(frame); err != nil {
+		return nil, nil, err
+	}
+
+	response, err := c.readTPKT()
+	if err != nil {
+		return nil, nil, err
+	}
+	// COTP DT(3) + S7 ack_data 헤더(12)
+	if len(response) < 15 || response[3] != s7ProtocolID || response[4] != s7RoleAckData {
+		return nil, nil, fmt.Errorf("unexpected S7 response")
+	}
+	if errorClass, errorCode := response[13], response[14]; errorClass != 0 || errorCode != 0 {
+		return nil, nil, fmt.Errorf("S7 error class 0x%02X code 0x%02X", errorClass, errorCode)
+	}
+
+	paramLength := int(binary.BigEndian.Uint16(response[9:11]))
+	dataLength := int(binary.BigEndian.Uint16(response[11:13]))
+	body := response[15:]
+	if len(body) < paramLength+dataLength {
+		return nil, nil, fmt.Errorf("truncated S7 response")
+	}
+	return body[:paramLength], body[paramLength : paramLength+dataLength], nil
+}
+
+// writeTPKT TPKT 헤더를 붙여 전송
+func (c *conn) writeTPKT(payload []byte) error {
+	frame := []byte{tpktVersion, 0x00}
+	frame = binary.BigEndian.AppendUint16(frame, uint16(len(payload)+4))
+	frame = append(frame, payload...)
+
+	c.netConn.SetWriteDeadline(time.Now().Add(c.timeout))
+	_, err := c.netConn.Write(frame)
+	return err
+}
+
+// readTPKT TPKT 프레임 하나 수신 (헤더 제외)
+func (c *conn) readTPKT() ([]byte, error) {
+	c.netConn.SetReadDeadline(time.Now().Add(c.timeout))
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(c.netConn, header); err != nil {
+		return nil, err
+	}
+	length := int(binary.BigEndian.Uint16(header[2:4]))
+	if header[0] != tpktVersion || length < 4 {
+		return nil, fmt.Errorf("invalid TPKT header")
+	}
+
+	payload := make([]byte, length-4)
+	if _, err := io.ReadFull(c.netConn, payload); err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// dbItem DB 영역 바이트 범위 지정 (S7ANY 주소)
+func dbItem(db, start, size int) []byte {
+	item := []byte{0x12, 0x0A, 0x10, s7TransportByte}
+	item = binary.BigEndian.AppendUint16(item, uint16(size))
+	item = binary.BigEndian.AppendUint16(item, uint16(db))
+	address := start * 8
+	return append(item, s7AreaDB, byte(address>>16), byte(address>>8), byte(address))
+}
+
+// returnCodeError 항목 반환 코드 오류 변환
+func returnCodeError(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("empty S7 item response")
+	}
+	switch data[0] {
+	case 0x05:
+		return fmt.Errorf("address out of range (check DB number/offset)")
+	case 0x0A:
+		return fmt.Errorf("object does not exist (check DB number)")
+	default:
+		return fmt.Errorf("S7 item return code 0x%02X", data[0])
+	}
+}
@@ -0,0 +1,236 @@
+// internal/s7/poller.go - S7 DB Polling PLC Transport
+package s7
+
+import (
+	"context"
+	"encoding/binary"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/types"
+	"mqtt-bridge/internal/utils"
+	"sync"
+	"time"
+)
+
+// 명령 블록 (S7_COMMAND_DB, S7_COMMAND_OFFSET 기준, PLC 쓰기)
+//
+//	+0  INT                          트리거 (0이 아닌 새 시퀀스 값을 쓰면 명령 실행)
+//	+2  STRING[S7_COMMAND_LENGTH]    명령 (예: "PICK01:T:L")
+//
+// 상태 블록 (S7_STATUS_DB, S7_STATUS_OFFSET 기준, 브릿지 쓰기)
+//
+//	+0  INT                          확인 시퀀스 (실행한 트리거 값)
+//	+2  INT                          수락 결과 (0 없음, 1 수락, 2 거부)
+//	+4  INT                          마지막 응답 상태 (0 없음, types.PLCStatusCode)
+//	+6  INT                          응답 카운터 (응답마다 1 증가)
+//	+8  STRING[S7_COMMAND_LENGTH]    마지막 응답 명령
+const (
+	commandHeaderSize = 2
+	statusHeaderSize  = 8
+)
+
+// 수락 결과 값
+const (
+	acceptedOK       = 1
+	acceptedRejected = 2
+)
+
+// connectTimeout 연결/요청 시간 제한
+const connectTimeout = 5 * time.Second
+
+// maxReconnectDelay 재연결 대기 최대 시간
+const maxReconnectDelay = 30 * time.Second
+
+// Poller S7 PLC의 DB를 주기적으로 읽어 명령 실행, 상태를 DB에 기록 (bridge/command 대체 전송 계층)
+type Poller struct {
+	config   *config.Config
+	handler  *messaging.DirectActionHandler
+	eventBus *events.Bus
+
+	mu     sync.Mutex
+	conn   *conn
+	status status
+}
+
+// status 상태 블록 값 (재연결 시 다시 기록)
+type status struct {
+	ack         uint16
+	accepted    uint16
+	code        uint16
+	responseSeq uint16
+	response    string
+}
+
+// NewPoller 새 S7 폴러 생성
+func NewPoller(cfg *config.Config, handler *messaging.DirectActionHandler, eventBus *events.Bus) *Poller {
+	return &Poller{
+		config:   cfg,
+		handler:  handler,
+		eventBus: eventBus,
+	}
+}
+
+// Run 연결 유지 및 폴링 (ctx 종료 시 반환, 연결 실패 시 백오프 재연결)
+func (p *Poller) Run(ctx context.Context) {
+	go p.trackResponses(ctx)
+
+	delay := time.Second
+	for {
+		err := p.session(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		utils.Logger.Warnf("⚠️ S7 connection to %s lost: %v (retrying in %s)", p.config.S7Addr, err, delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// session 연결 하나의 수명 (오류 발생 시 반환)
+func (p *Poller) session(ctx context.Context) error {
+	c, err := dial(p.config.S7Addr, p.config.S7Rack, p.config.S7Slot, connectTimeout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		p.mu.Lock()
+		p.conn = nil
+		p.mu.Unlock()
+		c.close()
+	}()
+
+	// 이미 확인한 트리거는 재연결 후 다시 실행하지 않음
+	block, err := c.readDB(p.config.S7StatusDB, p.config.S7StatusOffset, statusHeaderSize)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	p.conn = c
+	if p.status.ack == 0 {
+		p.status.ack = binary.BigEndian.Uint16(block[0:2])
+	}
+	err = p.writeStatus()
+	p.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	utils.Logger.Infof("🔌 S7 connected to %s (rack %d, slot %d, PDU %d bytes)", p.config.S7Addr, p.config.S7Rack, p.config.S7Slot, c.pduSize)
+
+	ticker := time.NewTicker(p.config.S7PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.poll(c); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// poll 명령 블록 읽기 후 새 트리거면 명령 실행
+func (p *Poller) poll(c *conn) error {
+	block, err := c.readDB(p.config.S7CommandDB, p.config.S7CommandOffset, commandHeaderSize+2+p.config.S7CommandLength)
+	if err != nil {
+		return err
+	}
+
+	sequence := binary.BigEndian.Uint16(block[0:2])
+	p.mu.Lock()
+	ack := p.status.ack
+	p.mu.Unlock()
+	if sequence == 0 || sequence == ack {
+		return nil
+	}
+
+	command := decodeString(block[commandHeaderSize:])
+	utils.Logger.Infof("📨 S7 command (sequence %d): '%s'", sequence, command)
+
+	result := p.handler.ProcessCommand(command)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.status.ack = sequence
+	p.status.accepted = acceptedRejected
+	if result.Accepted {
+		p.status.accepted = acceptedOK
+	}
+	return p.writeStatus()
+}
+
+// trackResponses PLC 응답 이벤트를 상태 블록에 반영
+func (p *Poller) trackResponses(ctx context.Context) {
+	eventCh, unsubscribe := p.eventBus.Subscribe(256)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if event.Type != events.TypePLCResponse || event.Robot != p.handler.RobotSerialNumber() {
+				continue
+			}
+
+			p.mu.Lock()
+			p.status.code = types.PLCStatusCode(event.Status)
+			p.status.responseSeq++
+			p.status.response = event.Command
+			// 연결이 끊긴 동안의 응답은 재연결 시 기록 (마지막 값만 유지)
+			if err := p.writeStatus(); err != nil {
+				utils.Logger.Errorf("❌ Failed to write S7 status: %v", err)
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// writeStatus 상태 블록 전체 기록 (잠금 보유 상태에서 호출, 미연결이면 생략)
+func (p *Poller) writeStatus() error {
+	if p.conn == nil {
+		return nil
+	}
+
+	block := make([]byte, 0, statusHeaderSize+2+p.config.S7CommandLength)
+	block = binary.BigEndian.AppendUint16(block, p.status.ack)
+	block = binary.BigEndian.AppendUint16(block, p.status.accepted)
+	block = binary.BigEndian.AppendUint16(block, p.status.code)
+	block = binary.BigEndian.AppendUint16(block, p.status.responseSeq)
+	block = append(block, encodeString(p.status.response, p.config.S7CommandLength)...)
+	return p.conn.writeDB(p.config.S7StatusDB, p.config.S7StatusOffset, block)
+}
+
+// encodeString S7 STRING 형식 (최대 길이, 실제 길이, 문자) 변환 (넘치는 부분은 잘림)
+func encodeString(text string, maxLength int) []byte {
+	if len(text) > maxLength {
+		text = text[:maxLength]
+	}
+	data := make([]byte, 2+maxLength)
+	data[0] = byte(maxLength)
+	data[1] = byte(len(text))
+	copy(data[2:], text)
+	return data
+}
+
+// decodeString S7 STRING 해석 (실제 길이가 영역을 넘으면 영역까지)
+func decodeString(data []byte) string {
+	if len(data) < 2 {
+		return ""
+	}
+	length := min(int(data[1]), len(data)-2)
+	return string(data[2 : 2+length])
+}
@@ -0,0 +1,108 @@
+// internal/scheduler/scheduler.go - Scheduled and Recurring Commands
+package scheduler
+
+import (
+	"context"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"time"
+)
+
+// log 스케줄러 컴포넌트 로거
+var log = utils.Component("scheduler")
+
+// Runner 설정된 시각에 명령을 PLC 명령과 같은 파이프라인으로 주입
+// 응답/이벤트/메트릭은 일반 PLC 명령과 동일하게 발행됨
+type Runner struct {
+	config   *config.Config
+	handlers []*messaging.DirectActionHandler
+	commands []config.ScheduledCommand
+}
+
+// NewRunner 새 스케줄러 생성 (명령은 모든 경로의 로봇에 각각 실행)
+func NewRunner(cfg *config.Config, handlers []*messaging.DirectActionHandler) (*Runner, error) {
+	commands, err := cfg.ParseScheduledCommands()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Runner{
+		config:   cfg,
+		handlers: handlers,
+		commands: commands,
+	}, nil
+}
+
+// Run 매 분 경계마다 일정 확인 (ctx 종료 시 반환)
+func (r *Runner) Run(ctx context.Context) {
+	for _, command := range r.commands {
+		log.Infof("Scheduled %s at '%s'", command.Command, command.Spec)
+	}
+
+	for {
+		now := time.Now()
+		next := now.Truncate(time.Minute).Add(time.Minute)
+		timer := time.NewTimer(next.Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			r.runDue(ctx, next)
+		}
+	}
+}
+
+// runDue 해당 분에 예정된 명령 실행
+func (r *Runner) runDue(ctx context.Context, minute time.Time) {
+	for _, command := range r.commands {
+		if !command.Schedule.Matches(minute) {
+			continue
+		}
+		for _, handler := range r.handlers {
+			// HA 대기 인스턴스는 건너뜀 (리더만 실행)
+			if handler.IsStandby() {
+				continue
+			}
+			r.execute(ctx, handler, command)
+		}
+	}
+}
+
+// execute 명령 1회 주입 후 최종 상태를 비동기로 기록
+func (r *Runner) execute(ctx context.Context, handler *messaging.DirectActionHandler, command config.ScheduledCommand) {
+	entry := log.WithField("command", command.Command).WithField("schedule", command.Spec)
+
+	result := handler.ProcessCommand(command.Command)
+	entry = entry.WithField("correlationId", result.CorrelationID)
+	if !result.Accepted {
+		entry.Warnf("Scheduled command rejected: %s", result.Reason)
+		return
+	}
+	if result.OrderID == "" {
+		entry.Info("Scheduled command executed")
+		return
+	}
+
+	entry = entry.WithField("orderId", result.OrderID)
+	entry.Info("Scheduled command accepted")
+
+	go func() {
+		watchCtx, cancel := context.WithTimeout(ctx, r.config.ScheduledCommandTimeout)
+		defer cancel()
+
+		var finalStatus string
+		err := handler.WatchOrder(watchCtx, result.OrderID, func(event events.Event) error {
+			finalStatus = event.Status
+			return nil
+		})
+		if err != nil {
+			entry.Warnf("Scheduled command did not finish (last status %q): %v", finalStatus, err)
+			return
+		}
+		entry.WithField("status", finalStatus).Info("Scheduled command finished")
+	}()
+}
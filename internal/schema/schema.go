@@ -0,0 +1,191 @@
+// internal/schema/schema.go - Robot Message JSON Schema Validation
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// schemaFiles VDA5050 2.0 메시지 스키마 (브릿지가 사용하는 필드 위주로 축약)
+//
+//go:embed schemas/*.json
+var schemaFiles embed.FS
+
+// 검증 대상 메시지 (스키마 파일 이름)
+const (
+	MessageState      = "state"
+	MessageConnection = "connection"
+	MessageFactsheet  = "factsheet"
+)
+
+// Schema JSON Schema 하위 집합 (type, required, properties, items, enum, minimum, maximum)
+type Schema struct {
+	Type       string             `json:"type,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Enum       []string           `json:"enum,omitempty"`
+	Minimum    *float64           `json:"minimum,omitempty"`
+	Maximum    *float64           `json:"maximum,omitempty"`
+}
+
+// Violation 스키마 위반 (JSON 경로와 사유)
+type Violation struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// String 위반 문자열 (예: $.actionStates[0].actionStatus: value "DONE" not in enum)
+func (v Violation) String() string {
+	return v.Path + ": " + v.Reason
+}
+
+// Violations 여러 위반 (error 구현)
+type Violations []Violation
+
+// Error 위반 목록 문자열
+func (v Violations) Error() string {
+	parts := make([]string, 0, len(v))
+	for _, violation := range v {
+		parts = append(parts, violation.String())
+	}
+	return strings.Join(parts, "; ")
+}
+
+// Validator 메시지별 스키마 검증기
+type Validator struct {
+	schemas map[string]*Schema
+}
+
+// NewValidator 내장 스키마로 검증기 생성
+func NewValidator() (*Validator, error) {
+	validator := &Validator{schemas: make(map[string]*Schema)}
+	for _, message := range []string{MessageState, MessageConnection, MessageFactsheet} {
+		data, err := schemaFiles.ReadFile("schemas/" + message + ".json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s schema: %v", message, err)
+		}
+		var schema Schema
+		if err := json.Unmarshal(data, &schema); err != nil {
+			return nil, fmt.Errorf("failed to parse %s schema: %v", message, err)
+		}
+		validator.schemas[message] = &schema
+	}
+	return validator, nil
+}
+
+// Validate 메시지 페이로드 검증 (JSON이 아니거나 위반이 있으면 Violations)
+func (v *Validator) Validate(message string, payload []byte) error {
+	schema, exists := v.schemas[message]
+	if !exists {
+		return fmt.Errorf("unknown message type %q", message)
+	}
+
+	var document interface{}
+	if err := json.Unmarshal(payload, &document); err != nil {
+		return Violations{{Path: "$", Reason: "invalid JSON: " + err.Error()}}
+	}
+
+	var violations Violations
+	schema.validate("$", document, &violations)
+	if len(violations) > 0 {
+		return violations
+	}
+	return nil
+}
+
+// validate 값 검증 후 위반 추가
+func (s *Schema) validate(path string, value interface{}, violations *Violations) {
+	add := func(format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Path: path, Reason: fmt.Sprintf(format, args...)})
+	}
+
+	if s.Type != "" && !matchesType(s.Type, value) {
+		add("expected %s, got %s", s.Type, typeName(value))
+		return
+	}
+
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, exists := typed[name]; !exists {
+				add("missing required property %q", name)
+			}
+		}
+		names := make([]string, 0, len(s.Properties))
+		for name := range s.Properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if property, exists := typed[name]; exists && property != nil {
+				s.Properties[name].validate(path+"."+name, property, violations)
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range typed {
+				s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item, violations)
+			}
+		}
+	case string:
+		if len(s.Enum) > 0 && !contains(s.Enum, typed) {
+			add("value %q not in enum (%s)", typed, strings.Join(s.Enum, ", "))
+		}
+	case float64:
+		if s.Minimum != nil && typed < *s.Minimum {
+			add("value %v below minimum %v", typed, *s.Minimum)
+		}
+		if s.Maximum != nil && typed > *s.Maximum {
+			add("value %v above maximum %v", typed, *s.Maximum)
+		}
+	}
+}
+
+// matchesType JSON 값이 스키마 type과 일치하는지 확인
+func matchesType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "integer":
+		number, ok := value.(float64)
+		return ok && number == math.Trunc(number)
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return typeName(value) == schemaType
+	}
+}
+
+// typeName JSON 값의 스키마 type 이름
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// contains 문자열 목록 포함 여부
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+// internal/secrets/source.go - Secret Sources (literal, file, Vault)
+package secrets
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// vaultPrefix Vault 참조 값 접두어 (vault:<path>#<key>)
+const vaultPrefix = "vault:"
+
+// Source 비밀 값 원천 (조회할 때마다 최신 값을 읽음)
+type Source interface {
+	Load() (string, error)
+	String() string // 로그용 설명 (값은 포함하지 않음)
+}
+
+// NewSource 설정 값과 *_FILE 경로로 원천 결정
+//   - file이 있으면 파일 (Kubernetes secret 볼륨, Vault Agent 렌더링 파일 포함)
+//   - value가 "vault:<path>#<key>"이면 Vault KV
+//   - 그 외에는 값 그대로
+func NewSource(value, file string, vault *Vault) (Source, error) {
+	if file != "" {
+		return fileSource{path: file}, nil
+	}
+	if !strings.HasPrefix(value, vaultPrefix) {
+		return literalSource(value), nil
+	}
+
+	path, key, found := strings.Cut(strings.TrimPrefix(value, vaultPrefix), "#")
+	if !found || path == "" || key == "" {
+		return nil, fmt.Errorf("invalid vault reference %q (expected vault:<path>#<key>)", value)
+	}
+	if vault == nil {
+		return nil, fmt.Errorf("vault reference %q requires VAULT_ADDR", value)
+	}
+	return vaultSource{vault: vault, path: path, key: key}, nil
+}
+
+// literalSource 환경 변수에 직접 지정된 값
+type literalSource string
+
+func (s literalSource) Load() (string, error) { return string(s), nil }
+func (s literalSource) String() string        { return "env" }
+
+// fileSource 파일 내용 (끝의 개행 제거)
+type fileSource struct {
+	path string
+}
+
+func (s fileSource) Load() (string, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %v", err)
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+func (s fileSource) String() string { return "file " + s.path }
+
+// vaultSource Vault KV 시크릿의 한 키
+type vaultSource struct {
+	vault *Vault
+	path  string
+	key   string
+}
+
+func (s vaultSource) Load() (string, error) {
+	return s.vault.Read(s.path, s.key)
+}
+
+func (s vaultSource) String() string { return "vault " + s.path + "#" + s.key }
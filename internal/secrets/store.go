@@ -0,0 +1,105 @@
+// internal/secrets/store.go - Secret Store with Rotation
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"mqtt-bridge/internal/utils"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Store 이름별 비밀 값 보관 (주기적으로 원천을 다시 읽어 로테이션 반영)
+type Store struct {
+	mu      sync.RWMutex
+	entries map[string]*entry
+}
+
+// entry 비밀 값 하나
+type entry struct {
+	source Source
+	value  string
+}
+
+// NewStore 새 비밀 값 저장소 생성
+func NewStore() *Store {
+	return &Store{entries: make(map[string]*entry)}
+}
+
+// Add 비밀 값 등록 (즉시 한 번 읽음, 실패 시 오류)
+func (s *Store) Add(name string, source Source) error {
+	value, err := source.Load()
+	if err != nil {
+		return fmt.Errorf("%s (%s): %v", name, source, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[name] = &entry{source: source, value: value}
+	return nil
+}
+
+// Get 현재 값 반환 (등록되지 않은 이름은 빈 값)
+func (s *Store) Get(name string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if e, exists := s.entries[name]; exists {
+		return e.value
+	}
+	return ""
+}
+
+// Refresh 모든 원천을 다시 읽고 값이 바뀐 이름 목록 반환
+// 읽기 실패 시 기존 값을 유지 (로테이션 도중의 일시적 실패 대비)
+func (s *Store) Refresh() []string {
+	s.mu.RLock()
+	names := make([]string, 0, len(s.entries))
+	for name := range s.entries {
+		names = append(names, name)
+	}
+	s.mu.RUnlock()
+	sort.Strings(names)
+
+	var changed []string
+	for _, name := range names {
+		s.mu.RLock()
+		e := s.entries[name]
+		s.mu.RUnlock()
+
+		value, err := e.source.Load()
+		if err != nil {
+			utils.Logger.Warnf("⚠️ Failed to refresh secret %s (%s), keeping previous value: %v", name, e.source, err)
+			continue
+		}
+
+		s.mu.Lock()
+		if value != e.value {
+			e.value = value
+			changed = append(changed, name)
+		}
+		s.mu.Unlock()
+	}
+	return changed
+}
+
+// Run 주기적으로 비밀 값 재조회 (interval이 0이면 즉시 반환, ctx 종료 시 반환)
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, name := range s.Refresh() {
+				utils.Logger.Infof("🔑 Secret rotated: %s", name)
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// internal/secrets/vault.go - HashiCorp Vault KV Client
+package secrets
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// kubernetesTokenPath Kubernetes 서비스 계정 토큰 경로
+const kubernetesTokenPath = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+
+// Vault KV v1/v2 시크릿 조회 클라이언트
+// 인증 우선순위: 토큰 파일(매번 재조회) > 토큰 > Kubernetes auth 로그인
+type Vault struct {
+	addr           string
+	token          string
+	tokenFile      string
+	kubernetesRole string
+	client         *http.Client
+
+	mu           sync.Mutex
+	loginToken   string    // Kubernetes auth로 발급받은 토큰
+	loginExpires time.Time // 발급 토큰 만료 시간
+}
+
+// NewVault 새 Vault 클라이언트 생성 (addr가 비어있으면 nil 반환)
+func NewVault(addr, token, tokenFile, kubernetesRole string) *Vault {
+	if addr == "" {
+		return nil
+	}
+	return &Vault{
+		addr:           strings.TrimRight(addr, "/"),
+		token:          token,
+		tokenFile:      tokenFile,
+		kubernetesRole: kubernetesRole,
+		client:         &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Read 시크릿 키 조회 (KV v2 응답은 data.data에서 조회)
+func (v *Vault) Read(path, key string) (string, error) {
+	token, err := v.authToken()
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, v.addr+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := v.do(req, &body); err != nil {
+		return "", fmt.Errorf("failed to read vault secret %s: %v", path, err)
+	}
+
+	data := body.Data
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+
+	value, exists := data[key]
+	if !exists {
+		return "", fmt.Errorf("vault secret %s has no key %q", path, key)
+	}
+	if text, ok := value.(string); ok {
+		return text, nil
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// authToken 요청에 사용할 토큰 결정
+func (v *Vault) authToken() (string, error) {
+	if v.tokenFile != "" {
+		data, err := os.ReadFile(v.tokenFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read vault token file: %v", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+	if v.token != "" {
+		return v.token, nil
+	}
+	if v.kubernetesRole != "" {
+		return v.kubernetesLogin()
+	}
+	return "", fmt.Errorf("no vault credentials (set VAULT_TOKEN, VAULT_TOKEN_FILE or VAULT_KUBERNETES_ROLE)")
+}
+
+// kubernetesLogin 서비스 계정 토큰으로 Kubernetes auth 로그인 (만료 전까지 재사용)
+func (v *Vault) kubernetesLogin() (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.loginToken != "" && time.Now().Before(v.loginExpires) {
+		return v.loginToken, nil
+	}
+
+	jwt, err := os.ReadFile(kubernetesTokenPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read kubernetes service account token: %v", err)
+	}
+	payload, err := json.Marshal(map[string]string{"role": v.kubernetesRole, "jwt": strings.TrimSpace(string(jwt))})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, v.addr+"/v1/auth/kubernetes/login", bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var body struct {
+		Auth struct {
+			ClientToken   string `json:"client_token"`
+			LeaseDuration int    `json:"lease_duration"`
+		} `json:"auth"`
+	}
+	if err := v.do(req, &body); err != nil {
+		return "", fmt.Errorf("vault kubernetes login failed: %v", err)
+	}
+
+	// 만료 직전 재로그인을 위해 임대 시간의 절반만 사용
+	v.loginToken = body.Auth.ClientToken
+	v.loginExpires = time.Now().Add(time.Duration(body.Auth.LeaseDuration) * time.Second / 2)
+	return v.loginToken, nil
+}
+
+// do 요청 실행 및 JSON 응답 해석 (2xx 이외 응답은 오류)
+func (v *Vault) do(req *http.Request, out interface{}) error {
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("vault returned %s: %s", resp.Status, strings.TrimSpace(string(detail)))
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
@@ -0,0 +1,231 @@
+// internal/sparkplug/node.go - Sparkplug B Edge Node (PLC command/status metrics)
+package sparkplug
+
+import (
+	"context"
+	"mqtt-bridge/internal/config"
+	"mqtt-bridge/internal/events"
+	"mqtt-bridge/internal/messaging"
+	"mqtt-bridge/internal/utils"
+	"strings"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// 메트릭 이름 (로봇별 메트릭은 "<robot>/<name>")
+const (
+	metricBdSeq         = "bdSeq"
+	metricRebirth       = "Node Control/Rebirth"
+	metricCommand       = "Command"       // NCMD로 쓰기 시 PLC 명령 실행
+	metricCommandResult = "CommandResult" // 마지막 명령 수락 결과 ("accepted" 또는 거부 사유)
+	metricStatus        = "Status"        // 마지막 PLC 응답 상태 (W, I, R, S, F)
+	metricLastResponse  = "LastResponse"  // 마지막 PLC 응답 ("PICK01:S")
+)
+
+// Node Sparkplug B Edge Node (브릿지 하나 = 노드 하나, 로봇별 메트릭)
+// PLC(호스트 애플리케이션)는 NCMD로 명령을 쓰고 NDATA로 상태를 받음
+//
+// bdSeq는 프로세스 시작 시 한 번 정해짐: 유언(NDEATH)은 연결 옵션이라 자동 재연결 시 바꿀 수 없으므로
+// 재연결 후 NBIRTH도 같은 bdSeq로 발행
+type Node struct {
+	client   *messaging.MQTTClient
+	config   *config.Config
+	handlers map[string]*messaging.DirectActionHandler // 로봇 시리얼 -> 핸들러
+	robots   []string                                  // 명령 경로 순서
+	eventBus *events.Bus
+	bdSeq    uint64
+
+	mu        sync.Mutex
+	seq       uint64                  // 0-255 순환 (NBIRTH는 0)
+	responses map[string]lastResponse // 로봇 시리얼 -> 마지막 PLC 응답 (NBIRTH 재발행용)
+}
+
+// lastResponse 로봇의 마지막 PLC 응답
+type lastResponse struct {
+	status  string
+	message string
+}
+
+// NewBdSeq 프로세스 수명 동안 사용할 bdSeq (0-255)
+func NewBdSeq() uint64 {
+	return uint64(time.Now().Unix() % 256)
+}
+
+// WillOption NDEATH 유언 설정 (MQTT 연결 전에 적용)
+func WillOption(cfg *config.Config, bdSeq uint64) messaging.ClientOption {
+	return func(opts *mqtt.ClientOptions) {
+		death := &Payload{
+			Timestamp: nowMillis(),
+			Metrics:   []Metric{UInt64Metric(metricBdSeq, bdSeq, nowMillis())},
+		}
+		opts.SetBinaryWill(topic(cfg, "NDEATH"), death.Marshal(), 0, false)
+	}
+}
+
+// NewNode 새 Sparkplug 노드 생성
+func NewNode(client *messaging.MQTTClient, cfg *config.Config, handlers []*messaging.DirectActionHandler, eventBus *events.Bus, bdSeq uint64) *Node {
+	node := &Node{
+		client:    client,
+		config:    cfg,
+		handlers:  make(map[string]*messaging.DirectActionHandler, len(handlers)),
+		eventBus:  eventBus,
+		bdSeq:     bdSeq,
+		responses: make(map[string]lastResponse, len(handlers)),
+	}
+	for _, handler := range handlers {
+		node.handlers[handler.RobotSerialNumber()] = handler
+		node.robots = append(node.robots, handler.RobotSerialNumber())
+	}
+	return node
+}
+
+// Start NCMD 구독, NBIRTH 발행, 재연결 시 NBIRTH 재발행 등록
+func (n *Node) Start(ctx context.Context) error {
+	if err := n.client.Subscribe(topic(n.config, "NCMD"), 0, n.handleCommand); err != nil {
+		return err
+	}
+	n.client.OnConnect(func() {
+		// 재연결 후에도 NCMD 구독이 유지되도록 다시 구독
+		if err := n.client.Subscribe(topic(n.config, "NCMD"), 0, n.handleCommand); err != nil {
+			utils.Logger.Errorf("❌ Sparkplug NCMD resubscription failed: %v", err)
+		}
+		n.publishBirth()
+	})
+	n.publishBirth()
+
+	go n.trackResponses(ctx)
+	return nil
+}
+
+// Stop NDEATH 발행 (정상 종료 시 유언 대신 직접 발행)
+func (n *Node) Stop() {
+	death := &Payload{
+		Timestamp: nowMillis(),
+		Metrics:   []Metric{UInt64Metric(metricBdSeq, n.bdSeq, nowMillis())},
+	}
+	if err := n.client.Publish(topic(n.config, "NDEATH"), 0, false, death.Marshal()); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish Sparkplug NDEATH: %v", err)
+	}
+}
+
+// publishBirth NBIRTH 발행 (seq 0부터 다시 시작, 모든 메트릭 포함)
+func (n *Node) publishBirth() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	now := nowMillis()
+	metrics := []Metric{
+		UInt64Metric(metricBdSeq, n.bdSeq, now),
+		BooleanMetric(metricRebirth, false, now),
+	}
+	for _, robot := range n.robots {
+		response := n.responses[robot]
+		metrics = append(metrics,
+			StringMetric(robot+"/"+metricCommand, "", now),
+			StringMetric(robot+"/"+metricCommandResult, "", now),
+			StringMetric(robot+"/"+metricStatus, response.status, now),
+			StringMetric(robot+"/"+metricLastResponse, response.message, now),
+		)
+	}
+
+	n.seq = 0
+	n.publish("NBIRTH", metrics)
+	utils.Logger.Infof("🐣 Sparkplug NBIRTH published (bdSeq %d, %d robot(s))", n.bdSeq, len(n.robots))
+}
+
+// publishData NDATA 발행
+func (n *Node) publishData(metrics []Metric) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.seq = (n.seq + 1) % 256
+	n.publish("NDATA", metrics)
+}
+
+// publish 페이로드 발행 (잠금 보유 상태에서 호출)
+func (n *Node) publish(messageType string, metrics []Metric) {
+	seq := n.seq
+	payload := &Payload{Timestamp: nowMillis(), Metrics: metrics, Seq: &seq}
+	if err := n.client.Publish(topic(n.config, messageType), 0, false, payload.Marshal()); err != nil {
+		utils.Logger.Errorf("❌ Failed to publish Sparkplug %s: %v", messageType, err)
+	}
+}
+
+// handleCommand NCMD 처리 (Rebirth 요청, 로봇별 Command 쓰기)
+func (n *Node) handleCommand(client mqtt.Client, msg mqtt.Message) {
+	payload, err := Unmarshal(msg.Payload())
+	if err != nil {
+		utils.Logger.Errorf("❌ Failed to decode Sparkplug NCMD: %v", err)
+		return
+	}
+
+	for _, metric := range payload.Metrics {
+		if metric.Name == metricRebirth {
+			if metric.BooleanValue {
+				utils.Logger.Infof("🔄 Sparkplug rebirth requested")
+				n.publishBirth()
+			}
+			continue
+		}
+
+		robot, name, found := strings.Cut(metric.Name, "/")
+		handler, exists := n.handlers[robot]
+		if !found || !exists || name != metricCommand || metric.DataType != DataTypeString {
+			utils.Logger.Warnf("⚠️ Ignoring unknown Sparkplug NCMD metric: %s", metric.Name)
+			continue
+		}
+
+		utils.Logger.Infof("📨 Sparkplug command for %s: '%s'", robot, metric.StringValue)
+		result := handler.ProcessCommand(metric.StringValue)
+		commandResult := "accepted"
+		if !result.Accepted {
+			commandResult = result.Reason
+		}
+		n.publishData([]Metric{StringMetric(robot+"/"+metricCommandResult, commandResult, nowMillis())})
+	}
+}
+
+// trackResponses PLC 응답 이벤트를 NDATA로 발행
+func (n *Node) trackResponses(ctx context.Context) {
+	eventCh, unsubscribe := n.eventBus.Subscribe(256)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-eventCh:
+			if !ok {
+				return
+			}
+			if event.Type != events.TypePLCResponse {
+				continue
+			}
+			if _, exists := n.handlers[event.Robot]; !exists {
+				continue
+			}
+
+			n.mu.Lock()
+			n.responses[event.Robot] = lastResponse{status: event.Status, message: event.Message}
+			n.mu.Unlock()
+
+			now := nowMillis()
+			n.publishData([]Metric{
+				StringMetric(event.Robot+"/"+metricStatus, event.Status, now),
+				StringMetric(event.Robot+"/"+metricLastResponse, event.Message, now),
+			})
+		}
+	}
+}
+
+// topic spBv1.0/{group_id}/{message_type}/{edge_node_id}
+func topic(cfg *config.Config, messageType string) string {
+	return "spBv1.0/" + cfg.SparkplugGroupID + "/" + messageType + "/" + cfg.SparkplugEdgeNodeID
+}
+
+// nowMillis 현재 시간 (epoch ms)
+func nowMillis() uint64 {
+	return uint64(time.Now().UnixMilli())
+}
@@ -0,0 +1,238 @@
+// internal/sparkplug/payload.go - Sparkplug B Payload (protobuf wire format)
+package sparkplug
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Sparkplug B 데이터 타입 (사용하는 것만)
+const (
+	DataTypeInt64   = 4
+	DataTypeUInt64  = 8
+	DataTypeDouble  = 10
+	DataTypeBoolean = 11
+	DataTypeString  = 12
+)
+
+// protobuf 필드 번호 (sparkplug_b.proto)
+const (
+	fieldPayloadTimestamp = 1
+	fieldPayloadMetrics   = 2
+	fieldPayloadSeq       = 3
+
+	fieldMetricName      = 1
+	fieldMetricTimestamp = 3
+	fieldMetricDatatype  = 4
+	fieldMetricIsNull    = 7
+	fieldMetricLong      = 11
+	fieldMetricDouble    = 13
+	fieldMetricBoolean   = 14
+	fieldMetricString    = 15
+)
+
+// protobuf wire type
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// ErrMalformedPayload protobuf 해석 실패
+var ErrMalformedPayload = errors.New("malformed Sparkplug B payload")
+
+// Payload Sparkplug B 페이로드 (timestamp, metrics, seq)
+type Payload struct {
+	Timestamp uint64 // epoch ms
+	Metrics   []Metric
+	Seq       *uint64 // NDEATH/STATE에는 없음
+}
+
+// Metric Sparkplug B 메트릭 (값은 DataType에 맞는 필드 하나만 사용)
+type Metric struct {
+	Name      string
+	Timestamp uint64
+	DataType  uint32
+	IsNull    bool
+
+	LongValue    uint64
+	DoubleValue  float64
+	BooleanValue bool
+	StringValue  string
+}
+
+// StringMetric 문자열 메트릭
+func StringMetric(name, value string, timestamp uint64) Metric {
+	return Metric{Name: name, Timestamp: timestamp, DataType: DataTypeString, StringValue: value}
+}
+
+// UInt64Metric 정수 메트릭
+func UInt64Metric(name string, value, timestamp uint64) Metric {
+	return Metric{Name: name, Timestamp: timestamp, DataType: DataTypeUInt64, LongValue: value}
+}
+
+// BooleanMetric 불리언 메트릭
+func BooleanMetric(name string, value bool, timestamp uint64) Metric {
+	return Metric{Name: name, Timestamp: timestamp, DataType: DataTypeBoolean, BooleanValue: value}
+}
+
+// Marshal protobuf 인코딩
+func (p *Payload) Marshal() []byte {
+	var data []byte
+	data = appendVarintField(data, fieldPayloadTimestamp, p.Timestamp)
+	for _, metric := range p.Metrics {
+		data = appendBytesField(data, fieldPayloadMetrics, metric.marshal())
+	}
+	if p.Seq != nil {
+		data = appendVarintField(data, fieldPayloadSeq, *p.Seq)
+	}
+	return data
+}
+
+// marshal 메트릭 인코딩
+func (m *Metric) marshal() []byte {
+	var data []byte
+	data = appendBytesField(data, fieldMetricName, []byte(m.Name))
+	data = appendVarintField(data, fieldMetricTimestamp, m.Timestamp)
+	data = appendVarintField(data, fieldMetricDatatype, uint64(m.DataType))
+	if m.IsNull {
+		return appendVarintField(data, fieldMetricIsNull, 1)
+	}
+
+	switch m.DataType {
+	case DataTypeString:
+		data = appendBytesField(data, fieldMetricString, []byte(m.StringValue))
+	case DataTypeBoolean:
+		value := uint64(0)
+		if m.BooleanValue {
+			value = 1
+		}
+		data = appendVarintField(data, fieldMetricBoolean, value)
+	case DataTypeDouble:
+		data = binary.AppendUvarint(data, fieldMetricDouble<<3|wireFixed64)
+		data = binary.LittleEndian.AppendUint64(data, math.Float64bits(m.DoubleValue))
+	default:
+		data = appendVarintField(data, fieldMetricLong, m.LongValue)
+	}
+	return data
+}
+
+// Unmarshal protobuf 해석 (알 수 없는 필드는 건너뜀)
+func Unmarshal(data []byte) (*Payload, error) {
+	payload := &Payload{}
+	err := walkFields(data, func(field int, varint uint64, bytes []byte) error {
+		switch field {
+		case fieldPayloadTimestamp:
+			payload.Timestamp = varint
+		case fieldPayloadSeq:
+			seq := varint
+			payload.Seq = &seq
+		case fieldPayloadMetrics:
+			metric, err := unmarshalMetric(bytes)
+			if err != nil {
+				return err
+			}
+			payload.Metrics = append(payload.Metrics, *metric)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return payload, nil
+}
+
+// unmarshalMetric 메트릭 해석
+func unmarshalMetric(data []byte) (*Metric, error) {
+	metric := &Metric{}
+	err := walkFields(data, func(field int, varint uint64, bytes []byte) error {
+		switch field {
+		case fieldMetricName:
+			metric.Name = string(bytes)
+		case fieldMetricTimestamp:
+			metric.Timestamp = varint
+		case fieldMetricDatatype:
+			metric.DataType = uint32(varint)
+		case fieldMetricIsNull:
+			metric.IsNull = varint != 0
+		case fieldMetricLong:
+			metric.LongValue = varint
+		case fieldMetricDouble:
+			metric.DoubleValue = math.Float64frombits(varint)
+		case fieldMetricBoolean:
+			metric.BooleanValue = varint != 0
+		case fieldMetricString:
+			metric.StringValue = string(bytes)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metric, nil
+}
+
+// walkFields protobuf 필드 순회 (varint/fixed 값은 varint, length-delimited는 bytes로 전달)
+func walkFields(data []byte, visit func(field int, varint uint64, bytes []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrMalformedPayload
+		}
+		data = data[n:]
+		field := int(key >> 3)
+
+		var varint uint64
+		var bytes []byte
+		switch key & 0x7 {
+		case wireVarint:
+			varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return ErrMalformedPayload
+			}
+			data = data[n:]
+		case wireFixed64:
+			if len(data) < 8 {
+				return ErrMalformedPayload
+			}
+			varint = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case wireFixed32:
+			if len(data) < 4 {
+				return ErrMalformedPayload
+			}
+			varint = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < length {
+				return ErrMalformedPayload
+			}
+			bytes = data[n : n+int(length)]
+			data = data[n+int(length):]
+		default:
+			return fmt.Errorf("%w: unsupported wire type %d", ErrMalformedPayload, key&0x7)
+		}
+
+		if err := visit(field, varint, bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendVarintField varint 필드 추가
+func appendVarintField(data []byte, field int, value uint64) []byte {
+	data = binary.AppendUvarint(data, uint64(field)<<3|wireVarint)
+	return binary.AppendUvarint(data, value)
+}
+
+// appendBytesField length-delimited 필드 추가
+func appendBytesField(data []byte, field int, value []byte) []byte {
+	data = binary.AppendUvarint(data, uint64(field)<<3|wireBytes)
+	data = binary.AppendUvarint(data, uint64(len(value)))
+	return append(data, value...)
+}
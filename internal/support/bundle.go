@@ -0,0 +1,95 @@
+// internal/support/bundle.go - Support Bundle Archive Writer
+package support
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Writer 지원 번들 tar.gz 작성기
+type Writer struct {
+	gz      *gzip.Writer
+	tw      *tar.Writer
+	created time.Time
+}
+
+// NewWriter 새 번들 작성기 생성 (Close 필수)
+func NewWriter(w io.Writer) *Writer {
+	gz := gzip.NewWriter(w)
+	return &Writer{
+		gz:      gz,
+		tw:      tar.NewWriter(gz),
+		created: time.Now(),
+	}
+}
+
+// AddJSON 값을 들여쓰기된 JSON 파일로 추가
+func (b *Writer) AddJSON(name string, value interface{}) error {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %v", name, err)
+	}
+	return b.AddBytes(name, data)
+}
+
+// AddBytes 바이트 내용을 파일로 추가
+func (b *Writer) AddBytes(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: b.created,
+	}
+	if err := b.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := b.tw.Write(data)
+	return err
+}
+
+// AddFile 디스크 파일을 추가 (maxBytes 초과 시 마지막 maxBytes만 포함)
+func (b *Writer) AddFile(name, path string, maxBytes int64) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	size := info.Size()
+	if maxBytes > 0 && size > maxBytes {
+		if _, err := file.Seek(size-maxBytes, io.SeekStart); err != nil {
+			return err
+		}
+		size = maxBytes
+	}
+
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    size,
+		ModTime: info.ModTime(),
+	}
+	if err := b.tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.CopyN(b.tw, file, size)
+	return err
+}
+
+// Close tar/gzip 스트림 종료
+func (b *Writer) Close() error {
+	if err := b.tw.Close(); err != nil {
+		return err
+	}
+	return b.gz.Close()
+}
@@ -0,0 +1,112 @@
+// internal/support/info.go - Version Info and Config Redaction
+package support
+
+import (
+	"net/url"
+	"os"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
+
+// redactedValue 비밀 값 대체 문자열
+const redactedValue = "[REDACTED]"
+
+// secretFieldMarkers 이름에 포함되면 값을 가리는 설정 필드
+var secretFieldMarkers = []string{"Password", "Secret", "Token", "TLSKey", "WebhookURL"}
+
+// VersionInfo 빌드/런타임 정보
+type VersionInfo struct {
+	Module      string    `json:"module"`
+	Version     string    `json:"version"`
+	Revision    string    `json:"revision,omitempty"`
+	BuildTime   string    `json:"buildTime,omitempty"`
+	GoVersion   string    `json:"goVersion"`
+	OS          string    `json:"os"`
+	Arch        string    `json:"arch"`
+	Hostname    string    `json:"hostname"`
+	GeneratedAt time.Time `json:"generatedAt"`
+}
+
+// CurrentVersion 현재 바이너리 버전 정보
+func CurrentVersion() VersionInfo {
+	info := VersionInfo{
+		Module:      "mqtt-bridge",
+		Version:     "(devel)",
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		GeneratedAt: time.Now(),
+	}
+	info.Hostname, _ = os.Hostname()
+
+	if build, ok := debug.ReadBuildInfo(); ok {
+		info.Module = build.Main.Path
+		if build.Main.Version != "" {
+			info.Version = build.Main.Version
+		}
+		for _, setting := range build.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				info.Revision = setting.Value
+			case "vcs.time":
+				info.BuildTime = setting.Value
+			}
+		}
+	}
+	return info
+}
+
+// RedactConfig 설정 구조체를 비밀 값이 가려진 맵으로 변환
+// 비밀 필드는 값이 있을 때만 가리고, URL의 사용자 정보 비밀번호도 제거
+func RedactConfig(cfg interface{}) map[string]interface{} {
+	value := reflect.Indirect(reflect.ValueOf(cfg))
+	result := make(map[string]interface{}, value.NumField())
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldValue := value.Field(i).Interface()
+		if isSecretField(field.Name) {
+			if !value.Field(i).IsZero() {
+				fieldValue = redactedValue
+			}
+		} else if text, ok := fieldValue.(string); ok {
+			fieldValue = redactURL(text)
+		} else if duration, ok := fieldValue.(time.Duration); ok {
+			fieldValue = duration.String()
+		}
+		result[field.Name] = fieldValue
+	}
+	return result
+}
+
+// isSecretField 비밀 필드 여부
+func isSecretField(name string) bool {
+	for _, marker := range secretFieldMarkers {
+		if strings.Contains(name, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL URL에 포함된 비밀번호 제거 (URL이 아니면 그대로)
+func redactURL(text string) string {
+	if !strings.Contains(text, "://") {
+		return text
+	}
+	u, err := url.Parse(text)
+	if err != nil || u.User == nil {
+		return text
+	}
+	if _, hasPassword := u.User.Password(); hasPassword {
+		u.User = url.UserPassword(u.User.Username(), "REDACTED")
+	}
+	return u.String()
+}
@@ -0,0 +1,31 @@
+// internal/types/fault.go
+package types
+
+import (
+	"time"
+)
+
+// FaultEvent 운영자 확인이 필요한 래치된 오류 구조체
+type FaultEvent struct {
+	Command          string    `json:"command"`
+	OrderID          string    `json:"orderId"`
+	ErrorType        string    `json:"errorType,omitempty"`
+	ErrorLevel       string    `json:"errorLevel,omitempty"`
+	ErrorDescription string    `json:"errorDescription,omitempty"`
+	LatchedAt        time.Time `json:"latchedAt"`
+}
+
+// ErrorLevel 열거형 (VDA5050 errors[].errorLevel)
+const (
+	ErrorLevelWarning = "WARNING"
+	ErrorLevelFatal   = "FATAL"
+)
+
+// NewFaultEvent 새 래치 오류 생성
+func NewFaultEvent(command, orderID string) *FaultEvent {
+	return &FaultEvent{
+		Command:   extractBaseCommand(command),
+		OrderID:   orderID,
+		LatchedAt: time.Now(),
+	}
+}
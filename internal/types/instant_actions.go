@@ -15,6 +15,28 @@ type InstantActionsMessage struct {
 	Actions      []InstantAction `json:"actions"`
 }
 
+// InstantActionsMessageV1 VDA5050 1.1 InstantActions 메시지 (액션 배열 키가 instantActions)
+type InstantActionsMessageV1 struct {
+	HeaderID       int64           `json:"headerId"`
+	Timestamp      time.Time       `json:"timestamp"`
+	Version        string          `json:"version"`
+	Manufacturer   string          `json:"manufacturer"`
+	SerialNumber   string          `json:"serialNumber"`
+	InstantActions []InstantAction `json:"instantActions"`
+}
+
+// ToV1 VDA5050 1.1 형식으로 변환
+func (i *InstantActionsMessage) ToV1() *InstantActionsMessageV1 {
+	return &InstantActionsMessageV1{
+		HeaderID:       i.HeaderID,
+		Timestamp:      i.Timestamp,
+		Version:        i.Version,
+		Manufacturer:   i.Manufacturer,
+		SerialNumber:   i.SerialNumber,
+		InstantActions: i.Actions,
+	}
+}
+
 // InstantAction InstantAction 구조체
 type InstantAction struct {
 	ActionType        string                   `json:"actionType"`
@@ -2,14 +2,30 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+)
+
+// PLC 응답 형식
+const (
+	PLCResponseFormatString = "string" // "COMMAND:STATUS" (기본값)
+	PLCResponseFormatJSON   = "json"
 )
 
 // PLCResponse PLC 응답 구조체
 type PLCResponse struct {
-	Command string `json:"command"`
-	Status  string `json:"status"`
+	Command      string    `json:"command"`
+	Status       string    `json:"status"`
+	OrderID      string    `json:"orderId,omitempty"`
+	Progress     *int      `json:"progress,omitempty"` // R 응답의 진행률 (0-100)
+	Timestamp    time.Time `json:"timestamp"`
+	ErrorMessage string    `json:"errorMessage,omitempty"`
+
+	CorrelationID string `json:"correlationId,omitempty"` // 응답을 만든 PLC 명령의 상관관계 ID
+	ReplyChannel  string `json:"-"`                       // 응답 채널 (비어있지 않으면 <응답 토픽>/<채널>로 발행)
 }
 
 // PLCResponseStatus PLC 응답 상태 열거형
@@ -19,21 +35,109 @@ const (
 	PLCStatusRunning      = "R" // Action is running
 	PLCStatusSuccess      = "S" // Action completed successfully
 	PLCStatusFailed       = "F" // Action failed
+	PLCStatusBusy         = "B" // Robot busy (concurrent order limit reached, command not executed)
+	PLCStatusSafetyStop   = "X" // Robot e-stop active (command not executed)
+	PLCStatusRateLimited  = "L" // Command rate limit exceeded (command not executed)
+	PLCStatusNotFound     = "N" // Status query (BASE:Q) found no order for the base command
+	PLCStatusNotAllowed   = "U" // Inference/trajectory name not in the allowlist (command not executed)
+	PLCStatusManualMode   = "M" // Robot not in AUTOMATIC mode (MANUAL/SERVICE/TEACHIN, command not executed)
+	PLCStatusPaused       = "P" // Robot paused (command not executed)
 )
 
+// plcStatusCodes PLC 응답 상태 -> 숫자 코드 (Modbus 레지스터, S7 상태 블록, EtherNet/IP 입력 어셈블리 공용)
+var plcStatusCodes = map[string]uint16{
+	PLCStatusWaiting:      1,
+	PLCStatusInitializing: 2,
+	PLCStatusRunning:      3,
+	PLCStatusSuccess:      4,
+	PLCStatusFailed:       5,
+	PLCStatusBusy:         6,
+	PLCStatusSafetyStop:   7,
+	PLCStatusRateLimited:  8,
+	PLCStatusNotFound:     9,
+	PLCStatusNotAllowed:   10,
+	PLCStatusManualMode:   11,
+	PLCStatusPaused:       12,
+}
+
+// PLCStatusCode 레지스터 기반 PLC 전송 계층의 상태 코드 (0은 응답 없음/알 수 없는 상태)
+func PLCStatusCode(status string) uint16 {
+	return plcStatusCodes[status]
+}
+
+// IsTerminalStatus 최종 상태인지 확인 (성공/실패/사용 중/비상 정지/한도 초과/조회 결과 없음/허용되지 않은 이름/수동 모드/일시 정지)
+func IsTerminalStatus(status string) bool {
+	switch status {
+	case PLCStatusSuccess, PLCStatusFailed, PLCStatusBusy, PLCStatusSafetyStop, PLCStatusRateLimited, PLCStatusNotFound, PLCStatusNotAllowed, PLCStatusManualMode, PLCStatusPaused:
+		return true
+	}
+	return false
+}
+
 // NewPLCResponse 새 PLC 응답 생성
 func NewPLCResponse(command, status, message string) *PLCResponse {
 	return &PLCResponse{
-		Command: extractBaseCommand(command),
-		Status:  status,
+		Command:      extractBaseCommand(command),
+		Status:       status,
+		Timestamp:    time.Now().UTC(),
+		ErrorMessage: message,
 	}
 }
 
-// ToResponseString PLC 응답을 문자열로 변환 (기존 형식: "COMMAND:STATUS")
+// ToResponseString PLC 응답을 문자열로 변환 (기존 형식: "COMMAND:STATUS", 진행률이 있으면 "COMMAND:R:nn")
 func (r *PLCResponse) ToResponseString() string {
+	if r.Progress != nil {
+		return fmt.Sprintf("%s:%s:%d", r.Command, r.Status, *r.Progress)
+	}
 	return fmt.Sprintf("%s:%s", r.Command, r.Status)
 }
 
+// ToJSON PLC 응답을 JSON 문자열로 변환
+func (r *PLCResponse) ToJSON() string {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return r.ToResponseString()
+	}
+	return string(data)
+}
+
+// Format 설정된 형식으로 PLC 응답 변환 (알 수 없는 형식은 기존 문자열)
+func (r *PLCResponse) Format(format string) string {
+	if format == PLCResponseFormatJSON {
+		return r.ToJSON()
+	}
+	return r.ToResponseString()
+}
+
+// ParsePLCResponse 발행된 응답 해석 (JSON 또는 "COMMAND:STATUS" 형식 모두 지원)
+func ParsePLCResponse(payload string) (*PLCResponse, error) {
+	payload = strings.TrimSpace(payload)
+	if strings.HasPrefix(payload, "{") {
+		var response PLCResponse
+		if err := json.Unmarshal([]byte(payload), &response); err != nil {
+			return nil, fmt.Errorf("invalid JSON response: %v", err)
+		}
+		return &response, nil
+	}
+
+	parts := strings.Split(payload, ":")
+	if len(parts) < 2 || parts[0] == "" {
+		return nil, fmt.Errorf("invalid response %q (expected COMMAND:STATUS)", payload)
+	}
+
+	// 진행률 형식 "COMMAND:R:nn"
+	if len(parts) >= 3 && parts[len(parts)-2] == PLCStatusRunning {
+		if progress, err := strconv.Atoi(parts[len(parts)-1]); err == nil {
+			return &PLCResponse{
+				Command:  strings.Join(parts[:len(parts)-2], ":"),
+				Status:   PLCStatusRunning,
+				Progress: &progress,
+			}, nil
+		}
+	}
+	return &PLCResponse{Command: strings.Join(parts[:len(parts)-1], ":"), Status: parts[len(parts)-1]}, nil
+}
+
 // extractBaseCommand 기본 명령 추출 (내부 함수)
 func extractBaseCommand(command string) string {
 	parts := strings.Split(command, ":")
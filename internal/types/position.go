@@ -0,0 +1,39 @@
+// internal/types/position.go - Robot Position Update
+package types
+
+import (
+	"time"
+)
+
+// PositionUpdate 모니터링용 로봇 위치 (VDA5050 state.agvPosition 요약)
+type PositionUpdate struct {
+	Robot     string    `json:"robot"`
+	X         float64   `json:"x"`
+	Y         float64   `json:"y"`
+	Theta     float64   `json:"theta"`
+	MapID     string    `json:"mapId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// SamePose 위치/방향/맵이 같은지 확인 (갱신 시각 제외)
+func (p *PositionUpdate) SamePose(other *PositionUpdate) bool {
+	return p != nil && other != nil && p.X == other.X && p.Y == other.Y && p.Theta == other.Theta && p.MapID == other.MapID
+}
+
+// PoseUpdate 실시간 표시용 로봇 위치/속도 (VDA5050 visualization 요약, 다운샘플링)
+type PoseUpdate struct {
+	Robot     string    `json:"robot"`
+	X         float64   `json:"x"`
+	Y         float64   `json:"y"`
+	Theta     float64   `json:"theta"`
+	MapID     string    `json:"mapId,omitempty"`
+	Velocity  *Velocity `json:"velocity,omitempty"` // 로봇이 보고하지 않으면 생략
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// Velocity 로봇 속도 (VDA5050 velocity)
+type Velocity struct {
+	Vx    float64 `json:"vx"`
+	Vy    float64 `json:"vy"`
+	Omega float64 `json:"omega"`
+}
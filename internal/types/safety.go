@@ -0,0 +1,27 @@
+// internal/types/safety.go
+package types
+
+import (
+	"time"
+)
+
+// SafetyEvent 로봇 안전 상태 구조체 (VDA5050 state.safetyState)
+type SafetyEvent struct {
+	Robot          string    `json:"robot"`
+	EStop          string    `json:"eStop"` // AUTOACK, MANUAL, REMOTE, NONE
+	FieldViolation bool      `json:"fieldViolation"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// EStop 열거형 (VDA5050 safetyState.eStop)
+const (
+	EStopAutoAck = "AUTOACK"
+	EStopManual  = "MANUAL"
+	EStopRemote  = "REMOTE"
+	EStopNone    = "NONE"
+)
+
+// EStopActive 비상 정지가 활성화되어 있는지 확인 (보고하지 않으면 비활성)
+func (s *SafetyEvent) EStopActive() bool {
+	return s != nil && s.EStop != "" && s.EStop != EStopNone
+}
@@ -0,0 +1,58 @@
+// internal/utils/idgen.go - Order/Node/Action ID Generators
+package utils
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// ID 형식 (ID_FORMAT)
+const (
+	IDFormatULID = "ulid" // 26자, 시간 정렬 가능 (기본값)
+	IDFormatUUID = "uuid" // UUIDv4 (36자, 무작위)
+)
+
+// IDGenerator 오더/노드/액션 ID 생성기 (동시 호출 안전, 호출마다 고유한 값)
+type IDGenerator interface {
+	NewID() string
+}
+
+// ULIDGenerator ULID 기반 ID 생성기
+type ULIDGenerator struct{}
+
+// NewID 새 ULID
+func (ULIDGenerator) NewID() string {
+	return NewULID()
+}
+
+// UUIDGenerator UUIDv4 기반 ID 생성기
+type UUIDGenerator struct{}
+
+// NewID 새 UUIDv4
+func (UUIDGenerator) NewID() string {
+	return NewUUID()
+}
+
+// NewIDGenerator 형식에 맞는 ID 생성기 (빈 값이면 ULID)
+func NewIDGenerator(format string) (IDGenerator, error) {
+	switch format {
+	case "", IDFormatULID:
+		return ULIDGenerator{}, nil
+	case IDFormatUUID:
+		return UUIDGenerator{}, nil
+	default:
+		return nil, fmt.Errorf("unknown ID format: %s", format)
+	}
+}
+
+// NewUUID 새 UUIDv4 생성 (RFC 4122, crypto/rand 실패 시 ULID 난수로 대체)
+func NewUUID() string {
+	var data [16]byte
+	if _, err := rand.Read(data[:]); err != nil {
+		copy(data[:], NewULID())
+	}
+	data[6] = (data[6] & 0x0f) | 0x40 // version 4
+	data[8] = (data[8] & 0x3f) | 0x80 // variant RFC 4122
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", data[0:4], data[4:6], data[6:8], data[8:10], data[10:16])
+}
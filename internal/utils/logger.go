@@ -2,17 +2,34 @@
 package utils
 
 import (
+	"io"
+	"os"
+	"time"
+
 	"github.com/sirupsen/logrus"
 )
 
 var Logger *logrus.Logger
 
+// LogOptions 로그 출력 형식 및 파일 회전 설정
+type LogOptions struct {
+	Format     string        // text, json
+	File       string        // 로그 파일 경로 (비어있으면 stdout만)
+	MaxSizeMB  int           // 크기 기준 회전 (MB)
+	MaxAge     time.Duration // 시간 기준 회전 주기
+	MaxBackups int           // 보관할 회전 파일 수
+
+	PayloadLimit   int      // 페이로드 최대 로그 길이 (0이면 무제한)
+	SuppressTopics []string // 페이로드 로그 제외 토픽 필터
+}
+
 func init() {
 	Logger = logrus.New()
 	Logger.SetFormatter(&logrus.TextFormatter{
 		FullTimestamp: true,
 		ForceColors:   true,
 	})
+	Logger.AddHook(redactHook{})
 }
 
 func SetupLogger(level string) {
@@ -29,3 +46,39 @@ func SetupLogger(level string) {
 		Logger.SetLevel(logrus.InfoLevel)
 	}
 }
+
+// ConfigureOutput 로그 형식(JSON/text)과 파일 출력(회전) 설정
+func ConfigureOutput(opts LogOptions) error {
+	SetPayloadLogging(opts.PayloadLimit, opts.SuppressTopics)
+
+	if opts.Format == "json" {
+		Logger.SetFormatter(&logrus.JSONFormatter{
+			TimestampFormat: time.RFC3339Nano,
+		})
+	}
+
+	if opts.File == "" {
+		return nil
+	}
+
+	file, err := NewRotatingFile(opts.File, opts.MaxSizeMB, opts.MaxAge, opts.MaxBackups)
+	if err != nil {
+		return err
+	}
+
+	// 파일 출력 시 색상 코드가 섞이지 않도록 text 형식은 색상 비활성화
+	if opts.Format != "json" {
+		Logger.SetFormatter(&logrus.TextFormatter{
+			FullTimestamp: true,
+			DisableColors: true,
+		})
+	}
+
+	Logger.SetOutput(io.MultiWriter(os.Stdout, file))
+	return nil
+}
+
+// Component 컴포넌트 필드가 포함된 로그 엔트리 반환
+func Component(name string) *logrus.Entry {
+	return Logger.WithField("component", name)
+}
@@ -0,0 +1,64 @@
+// internal/utils/payloadlog.go - MQTT 페이로드 로그 제한
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	payloadLogMu       sync.RWMutex
+	payloadLogLimit    int
+	payloadLogSuppress []string
+)
+
+// SetPayloadLogging 페이로드 최대 로그 길이(0이면 무제한)와 로그 제외 토픽 필터 설정
+func SetPayloadLogging(limit int, suppressTopics []string) {
+	payloadLogMu.Lock()
+	defer payloadLogMu.Unlock()
+	payloadLogLimit = limit
+	payloadLogSuppress = append([]string(nil), suppressTopics...)
+}
+
+// PayloadLogSuppressed 토픽이 로그 제외 필터(+, # 와일드카드)에 해당하는지 여부
+func PayloadLogSuppressed(topic string) bool {
+	payloadLogMu.RLock()
+	defer payloadLogMu.RUnlock()
+	for _, filter := range payloadLogSuppress {
+		if TopicMatches(filter, topic) {
+			return true
+		}
+	}
+	return false
+}
+
+// TruncatePayload 설정된 길이를 넘는 페이로드를 잘라 원래 크기 표시
+func TruncatePayload(payload string) string {
+	payloadLogMu.RLock()
+	limit := payloadLogLimit
+	payloadLogMu.RUnlock()
+
+	if limit <= 0 || len(payload) <= limit {
+		return payload
+	}
+	return fmt.Sprintf("%s... (truncated, %d bytes)", payload[:limit], len(payload))
+}
+
+// TopicMatches MQTT 토픽 필터 매칭
+func TopicMatches(filter, topic string) bool {
+	filterLevels := strings.Split(filter, "/")
+	topicLevels := strings.Split(topic, "/")
+	for i, level := range filterLevels {
+		if level == "#" {
+			return true
+		}
+		if i >= len(topicLevels) {
+			return false
+		}
+		if level != "+" && level != topicLevels[i] {
+			return false
+		}
+	}
+	return len(filterLevels) == len(topicLevels)
+}
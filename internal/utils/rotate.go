@@ -0,0 +1,128 @@
+// internal/utils/rotate.go - Size/Time-based Rotating Log File
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// rotateTimeFormat 회전된 파일 이름의 시간 접미사 형식
+const rotateTimeFormat = "20060102T150405"
+
+// RotatingFile 크기/시간 기준으로 회전하는 로그 파일 writer
+type RotatingFile struct {
+	path       string
+	maxSize    int64         // 바이트 (0이면 크기 회전 없음)
+	maxAge     time.Duration // 파일 유지 시간 (0이면 시간 회전 없음)
+	maxBackups int           // 보관할 회전 파일 수 (0이면 무제한)
+
+	mu       sync.Mutex
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+// NewRotatingFile 새 회전 로그 파일 생성
+func NewRotatingFile(path string, maxSizeMB int, maxAge time.Duration, maxBackups int) (*RotatingFile, error) {
+	r := &RotatingFile{
+		path:       path,
+		maxSize:    int64(maxSizeMB) * 1024 * 1024,
+		maxAge:     maxAge,
+		maxBackups: maxBackups,
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write 로그 기록 (필요 시 회전)
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.shouldRotate(int64(len(p))) {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close 파일 닫기
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
+
+// shouldRotate 회전 필요 여부 확인
+func (r *RotatingFile) shouldRotate(writeSize int64) bool {
+	if r.maxSize > 0 && r.size+writeSize > r.maxSize {
+		return true
+	}
+	return r.maxAge > 0 && time.Since(r.openedAt) >= r.maxAge
+}
+
+// open 로그 파일 열기 (append)
+func (r *RotatingFile) open() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create log directory: %v", err)
+	}
+
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %v", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %v", err)
+	}
+
+	r.file = file
+	r.size = info.Size()
+	r.openedAt = time.Now()
+	return nil
+}
+
+// rotate 현재 파일을 시간 접미사로 이름 변경 후 새 파일 열기
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	backupPath := fmt.Sprintf("%s.%s", r.path, time.Now().Format(rotateTimeFormat))
+	if err := os.Rename(r.path, backupPath); err != nil {
+		return fmt.Errorf("failed to rotate log file: %v", err)
+	}
+
+	r.pruneBackups()
+	return r.open()
+}
+
+// pruneBackups 보관 개수를 초과한 오래된 회전 파일 삭제
+func (r *RotatingFile) pruneBackups() {
+	if r.maxBackups <= 0 {
+		return
+	}
+
+	backups, err := filepath.Glob(r.path + ".*")
+	if err != nil || len(backups) <= r.maxBackups {
+		return
+	}
+
+	// 시간 접미사이므로 이름 순 정렬 = 시간 순 정렬
+	sort.Strings(backups)
+	for _, backup := range backups[:len(backups)-r.maxBackups] {
+		os.Remove(backup)
+	}
+}
@@ -0,0 +1,79 @@
+// internal/utils/ulid.go - ULID Generator
+package utils
+
+import (
+	"crypto/rand"
+	"sync"
+	"time"
+)
+
+// crockfordAlphabet ULID Crockford Base32 문자 집합
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+var (
+	ulidMu      sync.Mutex
+	ulidLastMs  uint64
+	ulidLastRnd [10]byte
+)
+
+// NewULID 새 ULID 생성 (26자, 시간 정렬 가능, 같은 밀리초 내에서는 단조 증가)
+func NewULID() string {
+	return newULIDAt(time.Now())
+}
+
+// newULIDAt 지정 시간 기준 ULID 생성
+func newULIDAt(now time.Time) string {
+	ulidMu.Lock()
+	defer ulidMu.Unlock()
+
+	ms := uint64(now.UnixMilli())
+	if ms == ulidLastMs {
+		// 같은 밀리초: 이전 난수부 +1 (정렬 순서 보장)
+		for i := len(ulidLastRnd) - 1; i >= 0; i-- {
+			ulidLastRnd[i]++
+			if ulidLastRnd[i] != 0 {
+				break
+			}
+		}
+	} else {
+		if _, err := rand.Read(ulidLastRnd[:]); err != nil {
+			// crypto/rand 실패 시 시간 기반 값으로 대체
+			nanos := uint64(now.UnixNano())
+			for i := range ulidLastRnd {
+				ulidLastRnd[i] = byte(nanos >> (8 * (i % 8)))
+			}
+		}
+		ulidLastMs = ms
+	}
+
+	var data [16]byte
+	data[0] = byte(ms >> 40)
+	data[1] = byte(ms >> 32)
+	data[2] = byte(ms >> 24)
+	data[3] = byte(ms >> 16)
+	data[4] = byte(ms >> 8)
+	data[5] = byte(ms)
+	copy(data[6:], ulidLastRnd[:])
+
+	return encodeCrockford(data)
+}
+
+// encodeCrockford 128비트 값을 26자 Crockford Base32로 인코딩
+func encodeCrockford(data [16]byte) string {
+	var out [26]byte
+
+	// 130비트(상위 2비트 0 패딩)를 5비트씩 인코딩
+	var bitBuffer uint64
+	bits := 2 // 선행 패딩 비트
+	pos := 0
+	for _, b := range data {
+		bitBuffer = bitBuffer<<8 | uint64(b)
+		bits += 8
+		for bits >= 5 {
+			bits -= 5
+			out[pos] = crockfordAlphabet[(bitBuffer>>uint(bits))&0x1F]
+			pos++
+		}
+	}
+	return string(out[:pos])
+}